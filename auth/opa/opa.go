@@ -21,11 +21,17 @@ package opa
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/go-logr/logr"
 	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/loader"
 	"github.com/open-policy-agent/opa/rego"
+	"github.com/open-policy-agent/opa/storage/inmem"
 	"github.com/open-policy-agent/opa/topdown"
 )
 
@@ -36,6 +42,15 @@ const (
 
 	// DefaultAuthzQuery is the default query used for policy evaluation.
 	DefaultAuthzQuery = "data.sansshell.authz.allow"
+
+	// DefaultDenialHintsQuery is the default query used to explain why
+	// input was denied. Policies don't have to define it.
+	DefaultDenialHintsQuery = "data.sansshell.authz.denial_hints"
+
+	// DefaultRequiresApprovalQuery is the default query used to decide if
+	// input needs further approval before it proceeds. Policies don't have
+	// to define it.
+	DefaultRequiresApprovalQuery = "data.sansshell.authz.requires_approval"
 )
 
 var (
@@ -49,12 +64,19 @@ var (
 // An AuthzPolicy performs policy checking by evaluating input against
 // a sansshell rego policy file.
 type AuthzPolicy struct {
-	query rego.PreparedEvalQuery
-	b     *bytes.Buffer
+	query         rego.PreparedEvalQuery
+	hintsQuery    rego.PreparedEvalQuery
+	approvalQuery rego.PreparedEvalQuery
+	b             *bytes.Buffer
+	hash          string
 }
 
 type policyOptions struct {
-	query string
+	query         string
+	hintsQuery    string
+	approvalQuery string
+	modules       map[string]string
+	data          map[string]interface{}
 }
 
 // An Option controls the behavior of an AuthzPolicy
@@ -78,45 +100,184 @@ func WithAllowQuery(query string) Option {
 	})
 }
 
+// WithDenialHintsQuery returns an option to use `query` to explain denials
+// in DenialHints, instead of DefaultDenialHintsQuery. The query should
+// evaluate to a set (or array) of strings.
+func WithDenialHintsQuery(query string) Option {
+	return optionFunc(func(o *policyOptions) {
+		o.hintsQuery = query
+	})
+}
+
+// WithRequiresApprovalQuery returns an option to use `query` in
+// RequiresApproval, instead of DefaultRequiresApprovalQuery. Like the allow
+// query it should evaluate to true iff approval is still required.
+func WithRequiresApprovalQuery(query string) Option {
+	return optionFunc(func(o *policyOptions) {
+		o.approvalQuery = query
+	})
+}
+
+// WithModule returns an option which adds the rego module `src` to the
+// policy. Unlike the main policy the module may use any package, so
+// shared rules can live in their own packages and be imported. `name`
+// is used in error messages and must be unique.
+func WithModule(name string, src string) Option {
+	return optionFunc(func(o *policyOptions) {
+		if o.modules == nil {
+			o.modules = make(map[string]string)
+		}
+		o.modules[name] = src
+	})
+}
+
+// WithData returns an option which makes `data` available to the policy
+// as the base document (i.e. data.teams for a top level "teams" key).
+// If given more than once only the last one is used.
+func WithData(data map[string]interface{}) Option {
+	return optionFunc(func(o *policyOptions) {
+		o.data = data
+	})
+}
+
+// LoadBundle reads an OPA bundle from path, which is either a directory or
+// a gzipped tarball, and returns options adding all of its rego modules and
+// data documents (data.json or data.yaml files, rooted at the directory
+// they're in) to a policy. As the bundle generally contains the sansshell
+// policy itself these are normally passed to NewAuthzPolicy with an empty
+// policy string.
+func LoadBundle(path string) ([]Option, error) {
+	b, err := loader.NewFileLoader().AsBundle(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't load policy bundle %s: %w", path, err)
+	}
+	var opts []Option
+	for _, m := range b.Modules {
+		opts = append(opts, WithModule(m.Path, string(m.Raw)))
+	}
+	if len(b.Data) > 0 {
+		opts = append(opts, WithData(b.Data))
+	}
+	return opts, nil
+}
+
 // NewAuthzPolicy creates a new AuthzPolicy by parsing the policy given
-// in the string `policy`.
-// It returns an error if the policy cannot be parsed, or does not use
-// SansshellRegoPackage in its package declaration.
+// in the string `policy` along with any modules added by WithModule.
+// It returns an error if any module cannot be parsed, or if `policy`
+// does not use SansshellRegoPackage in its package declaration. `policy`
+// may be empty if one of the additional modules uses SansshellRegoPackage
+// instead.
 func NewAuthzPolicy(ctx context.Context, policy string, opts ...Option) (*AuthzPolicy, error) {
 	options := &policyOptions{
-		query: DefaultAuthzQuery,
+		query:         DefaultAuthzQuery,
+		hintsQuery:    DefaultDenialHintsQuery,
+		approvalQuery: DefaultRequiresApprovalQuery,
 	}
 	for _, opt := range opts {
 		opt.apply(options)
 	}
 	parserOpts := ast.ParserOptions{FutureKeywords: []string{"in"}}
-	module, err := ast.ParseModuleWithOpts("sanshell-authz-policy.rego", policy, parserOpts)
-	if err != nil {
-		return nil, fmt.Errorf("policy parse error: %w", err)
+	var regoOpts []func(*rego.Rego)
+	hasPackage := false
+	if policy != "" || len(options.modules) == 0 {
+		module, err := ast.ParseModuleWithOpts("sanshell-authz-policy.rego", policy, parserOpts)
+		if err != nil {
+			return nil, fmt.Errorf("policy parse error: %w", err)
+		}
+
+		if !module.Package.Equal(sansshellPackage) {
+			return nil, fmt.Errorf("policy has invalid package '%s' (must be '%s')", module.Package, sansshellPackage)
+		}
+		hasPackage = true
+		regoOpts = append(regoOpts, rego.ParsedModule(module))
+	}
+	for _, name := range sortedKeys(options.modules) {
+		module, err := ast.ParseModuleWithOpts(name, options.modules[name], parserOpts)
+		if err != nil {
+			return nil, fmt.Errorf("policy module %s parse error: %w", name, err)
+		}
+		if module.Package.Equal(sansshellPackage) {
+			hasPackage = true
+		}
+		regoOpts = append(regoOpts, rego.ParsedModule(module))
+	}
+	if !hasPackage {
+		return nil, fmt.Errorf("no policy module uses package '%s'", sansshellPackage)
+	}
+	if options.data != nil {
+		regoOpts = append(regoOpts, rego.Store(inmem.NewFromObject(options.data)))
 	}
 
-	if !module.Package.Equal(sansshellPackage) {
-		return nil, fmt.Errorf("policy has invalid package '%s' (must be '%s')", module.Package, sansshellPackage)
+	hash, err := policyHash(policy, options)
+	if err != nil {
+		return nil, err
 	}
 
 	b := &bytes.Buffer{}
-	r := rego.New(
-		rego.Query(options.query),
-		rego.ParsedModule(module),
+	regoOpts = append(regoOpts,
 		rego.EnablePrintStatements(true),
 		rego.PrintHook(topdown.NewPrintHook(b)),
 	)
-
+	r := rego.New(append(regoOpts, rego.Query(options.query))...)
 	prepared, err := r.PrepareForEval(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("rego: PrepareForEval() error: %w", err)
 	}
+	r = rego.New(append(regoOpts, rego.Query(options.hintsQuery))...)
+	hintsPrepared, err := r.PrepareForEval(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("rego: PrepareForEval() for denial hints error: %w", err)
+	}
+	r = rego.New(append(regoOpts, rego.Query(options.approvalQuery))...)
+	approvalPrepared, err := r.PrepareForEval(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("rego: PrepareForEval() for requires approval error: %w", err)
+	}
 	return &AuthzPolicy{
-		query: prepared,
-		b:     b,
+		query:         prepared,
+		hintsQuery:    hintsPrepared,
+		approvalQuery: approvalPrepared,
+		b:             b,
+		hash:          hash,
 	}, nil
 }
 
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// policyHash computes the value returned by Hash. For a lone policy string
+// it's simply the SHA256 of the policy.
+func policyHash(policy string, options *policyOptions) (string, error) {
+	h := sha256.New()
+	h.Write([]byte(policy))
+	for _, name := range sortedKeys(options.modules) {
+		fmt.Fprintf(h, "\x00%s\x00%s", name, options.modules[name])
+	}
+	if options.data != nil {
+		// Maps are marshaled with sorted keys so this is stable.
+		d, err := json.Marshal(options.data)
+		if err != nil {
+			return "", fmt.Errorf("can't marshal policy data: %w", err)
+		}
+		h.Write([]byte{0})
+		h.Write(d)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Hash returns the hex encoded SHA256 identifying this policy, including any
+// modules and data added to it. For a policy without either it's the SHA256
+// of the policy string.
+func (q *AuthzPolicy) Hash() string {
+	return q.hash
+}
+
 // Eval evaluates this policy using the provided input, returning 'true'
 // iff the evaulation was successful, and the operation represented by
 // `input` is permitted by the policy.
@@ -131,3 +292,45 @@ func (q *AuthzPolicy) Eval(ctx context.Context, input interface{}) (bool, error)
 	}
 	return results.Allowed(), nil
 }
+
+// RequiresApproval evaluates the requires approval query using the provided
+// input, returning 'true' iff the operation represented by `input` may only
+// proceed once (further) approved. It's intended to be called after Eval
+// has allowed `input`. If the policy doesn't define the query no approval
+// is required.
+func (q *AuthzPolicy) RequiresApproval(ctx context.Context, input interface{}) (bool, error) {
+	results, err := q.approvalQuery.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return false, fmt.Errorf("requires approval evaluation error: %w", err)
+	}
+	return results.Allowed(), nil
+}
+
+// DenialHints evaluates the denial hints query using the provided input and
+// returns the resulting strings in sorted order. It's intended to be called
+// after Eval has denied `input` to explain why to the caller. If the policy
+// doesn't define the query there are no hints.
+func (q *AuthzPolicy) DenialHints(ctx context.Context, input interface{}) ([]string, error) {
+	results, err := q.hintsQuery.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return nil, fmt.Errorf("denial hints evaluation error: %w", err)
+	}
+	var hints []string
+	for _, r := range results {
+		for _, e := range r.Expressions {
+			values, ok := e.Value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("denial hints must be a set of strings, got %T", e.Value)
+			}
+			for _, v := range values {
+				h, ok := v.(string)
+				if !ok {
+					return nil, fmt.Errorf("denial hints must be a set of strings, got element %T", v)
+				}
+				hints = append(hints, h)
+			}
+		}
+	}
+	sort.Strings(hints)
+	return hints, nil
+}
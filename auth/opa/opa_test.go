@@ -17,7 +17,13 @@
 package opa
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -38,6 +44,7 @@ func TestNewAuthzPolicy(t *testing.T) {
 		name    string
 		policy  string
 		query   string
+		modules map[string]string
 		errFunc func(*testing.T, error)
 	}{
 		{
@@ -71,6 +78,32 @@ func TestNewAuthzPolicy(t *testing.T) {
 			policy:  "",
 			errFunc: expectParseError,
 		},
+		{
+			name:    "empty policy with module",
+			modules: map[string]string{"authz.rego": "package sansshell.authz"},
+			errFunc: expectNoError,
+		},
+		{
+			name:    "policy with library module",
+			policy:  "package sansshell.authz\nimport data.lib\nallow { lib.ok }",
+			modules: map[string]string{"lib.rego": "package lib\nok = true"},
+			errFunc: expectNoError,
+		},
+		{
+			name:    "invalid module",
+			policy:  "package sansshell.authz",
+			modules: map[string]string{"lib.rego": "foo := bar"},
+			errFunc: expectParseError,
+		},
+		{
+			name:    "no sansshell module",
+			modules: map[string]string{"lib.rego": "package lib"},
+			errFunc: func(t *testing.T, err error) {
+				if err == nil || !strings.Contains(err.Error(), "no policy module") {
+					t.Errorf("%s got error %v, want error with 'no policy module'", t.Name(), err)
+				}
+			},
+		},
 		{
 			name:   "non-sansshell package",
 			policy: "package another.name",
@@ -87,6 +120,9 @@ func TestNewAuthzPolicy(t *testing.T) {
 			if tc.query != "" {
 				opts = append(opts, WithAllowQuery(tc.query))
 			}
+			for name, src := range tc.modules {
+				opts = append(opts, WithModule(name, src))
+			}
 			_, err := NewAuthzPolicy(context.Background(), tc.policy, opts...)
 			tc.errFunc(t, err)
 		})
@@ -190,3 +226,216 @@ allow {
 		})
 	}
 }
+
+func TestHash(t *testing.T) {
+	ctx := context.Background()
+	policy := "package sansshell.authz"
+	p, err := NewAuthzPolicy(ctx, policy)
+	testutil.FatalOnErr("NewAuthzPolicy", err, t)
+	sum := sha256.Sum256([]byte(policy))
+	if got, want := p.Hash(), hex.EncodeToString(sum[:]); got != want {
+		t.Fatalf("Hash() = %s, want %s", got, want)
+	}
+
+	withData, err := NewAuthzPolicy(ctx, policy, WithData(map[string]interface{}{"a": "b"}))
+	testutil.FatalOnErr("NewAuthzPolicy with data", err, t)
+	otherData, err := NewAuthzPolicy(ctx, policy, WithData(map[string]interface{}{"a": "c"}))
+	testutil.FatalOnErr("NewAuthzPolicy with other data", err, t)
+	if withData.Hash() == p.Hash() || withData.Hash() == otherData.Hash() {
+		t.Fatalf("Hash() doesn't reflect data: %s %s %s", p.Hash(), withData.Hash(), otherData.Hash())
+	}
+}
+
+func TestLoadBundle(t *testing.T) {
+	ctx := context.Background()
+	files := map[string]string{
+		"sansshell/authz.rego": `
+package sansshell.authz
+
+import data.lib.teams
+
+allow {
+  teams.member[input.peer.principal.id][_] = input.host.name
+}
+`,
+		"lib/teams.rego": `
+package lib.teams
+
+member[user] = hosts {
+  some team
+  user := data.teams[team].users[_]
+  hosts := data.teams[team].hosts
+}
+`,
+		"teams/data.yaml": `
+db:
+  users: [alice]
+  hosts: [db1, db2]
+`,
+		"teams/web/data.json": `{"users": ["bob"], "hosts": ["web1"]}`,
+	}
+
+	dir := t.TempDir()
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		testutil.FatalOnErr("mkdir", os.MkdirAll(filepath.Dir(path), 0755), t)
+		testutil.FatalOnErr("write "+name, os.WriteFile(path, []byte(contents), 0644), t)
+	}
+
+	tarball := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	f, err := os.Create(tarball)
+	testutil.FatalOnErr("create tarball", err, t)
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for name, contents := range files {
+		testutil.FatalOnErr("tar header", tw.WriteHeader(&tar.Header{Name: "/" + name, Mode: 0644, Size: int64(len(contents))}), t)
+		_, err := tw.Write([]byte(contents))
+		testutil.FatalOnErr("tar write", err, t)
+	}
+	testutil.FatalOnErr("tar close", tw.Close(), t)
+	testutil.FatalOnErr("gzip close", gz.Close(), t)
+	testutil.FatalOnErr("file close", f.Close(), t)
+
+	input := func(user, host string) interface{} {
+		return map[string]interface{}{
+			"peer": map[string]interface{}{"principal": map[string]interface{}{"id": user}},
+			"host": map[string]interface{}{"name": host},
+		}
+	}
+	for _, path := range []string{dir, tarball} {
+		opts, err := LoadBundle(path)
+		testutil.FatalOnErr("LoadBundle("+path+")", err, t)
+		policy, err := NewAuthzPolicy(ctx, "", opts...)
+		testutil.FatalOnErr("NewAuthzPolicy", err, t)
+		for _, tc := range []struct {
+			user, host string
+			want       bool
+		}{
+			{"alice", "db2", true},
+			{"bob", "web1", true},
+			{"alice", "web1", false},
+			{"mallory", "db1", false},
+		} {
+			got, err := policy.Eval(ctx, input(tc.user, tc.host))
+			testutil.FatalOnErr("Eval", err, t)
+			if got != tc.want {
+				t.Errorf("%s: Eval(%s on %s) = %v, want %v", path, tc.user, tc.host, got, tc.want)
+			}
+		}
+	}
+
+	_, err = LoadBundle(filepath.Join(dir, "missing"))
+	testutil.FatalOnNoErr("missing bundle", err, t)
+}
+
+func TestDenialHints(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		name    string
+		policy  string
+		opts    []Option
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "no hints defined",
+			policy: "package sansshell.authz",
+		},
+		{
+			name: "set of hints",
+			policy: `
+package sansshell.authz
+
+denial_hints[msg] {
+  msg := sprintf("%s isn't allowed", [input.foo])
+}
+
+denial_hints["ask for access"] {
+  true
+}
+`,
+			want: []string{"ask for access", "bar isn't allowed"},
+		},
+		{
+			name:   "alternate query",
+			policy: "package sansshell.authz\nwhy = [\"because\"]",
+			opts:   []Option{WithDenialHintsQuery("data.sansshell.authz.why")},
+			want:   []string{"because"},
+		},
+		{
+			name:    "not strings",
+			policy:  "package sansshell.authz\ndenial_hints[1] { true }",
+			wantErr: true,
+		},
+		{
+			name:    "not a set",
+			policy:  "package sansshell.authz\ndenial_hints = \"nope\"",
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := NewAuthzPolicy(ctx, tc.policy, tc.opts...)
+			testutil.FatalOnErr("NewAuthzPolicy", err, t)
+			got, err := policy.DenialHints(ctx, map[string]string{"foo": "bar"})
+			testutil.WantErr(tc.name, err, tc.wantErr, t)
+			testutil.DiffErr(tc.name, got, tc.want, t)
+		})
+	}
+}
+
+func TestRequiresApproval(t *testing.T) {
+	ctx := context.Background()
+	policyString := `
+package sansshell.authz
+
+allow = true
+
+requires_approval {
+  input.method = "/Rm"
+  count(input.approvers) == 0
+}
+`
+	policy, err := NewAuthzPolicy(ctx, policyString)
+	testutil.FatalOnErr("NewAuthzPolicy", err, t)
+	undefined, err := NewAuthzPolicy(ctx, "package sansshell.authz")
+	testutil.FatalOnErr("NewAuthzPolicy", err, t)
+
+	for _, tc := range []struct {
+		name   string
+		policy *AuthzPolicy
+		input  interface{}
+		want   bool
+	}{
+		{
+			name:   "approval required",
+			policy: policy,
+			input:  map[string]interface{}{"method": "/Rm", "approvers": []string{}},
+			want:   true,
+		},
+		{
+			name:   "approved",
+			policy: policy,
+			input:  map[string]interface{}{"method": "/Rm", "approvers": []string{"bob"}},
+		},
+		{
+			name:   "other method",
+			policy: policy,
+			input:  map[string]interface{}{"method": "/Ls", "approvers": []string{}},
+		},
+		{
+			name:   "undefined",
+			policy: undefined,
+			input:  map[string]interface{}{"method": "/Rm", "approvers": []string{}},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.policy.RequiresApproval(ctx, tc.input)
+			testutil.FatalOnErr(tc.name, err, t)
+			if got != tc.want {
+				t.Errorf("RequiresApproval() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
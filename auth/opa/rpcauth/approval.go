@@ -0,0 +1,217 @@
+/* Copyright (c) 2019 Snowflake Inc. All rights reserved.
+
+   Licensed under the Apache License, Version 2.0 (the
+   "License"); you may not use this file except in compliance
+   with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing,
+   software distributed under the License is distributed on an
+   "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+   KIND, either express or implied.  See the License for the
+   specific language governing permissions and limitations
+   under the License.
+*/
+
+package rpcauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// An ApprovalStore tracks requests which policy says need approval from
+// other principals (via requires_approval) along with who has approved them.
+// Requests are identified by RequestHash.
+type ApprovalStore interface {
+	// Approvers returns the principals who have approved the request.
+	Approvers(ctx context.Context, hash string) []*PrincipalAuthInput
+	// AddPending records that the request in input is waiting for approval.
+	// It returns an error (i.e. ResourceExhausted) if the request can't be
+	// held, in which case it's returned to the caller.
+	AddPending(ctx context.Context, hash string, input *RPCAuthInput) error
+	// Consume forgets the request and its approvals once they've been used.
+	// It returns false if the request was already gone (i.e. a concurrent
+	// request used the approvals first).
+	Consume(ctx context.Context, hash string) bool
+}
+
+// ApprovalScope controls which requests one approval covers.
+type ApprovalScope int
+
+const (
+	// ApprovalPerTarget approvals cover a single request to a single target
+	// and are consumed by it.
+	ApprovalPerTarget ApprovalScope = iota
+	// ApprovalFleetWide approvals cover the same request sent to any
+	// number of targets, any number of times, until the store forgets them.
+	ApprovalFleetWide
+)
+
+// ParseApprovalScope returns the ApprovalScope named by s, which is either
+// "target" or "fleet".
+func ParseApprovalScope(s string) (ApprovalScope, error) {
+	switch s {
+	case "target":
+		return ApprovalPerTarget, nil
+	case "fleet":
+		return ApprovalFleetWide, nil
+	}
+	return ApprovalPerTarget, fmt.Errorf("invalid approval scope %q, must be target or fleet", s)
+}
+
+// SetApprovalStore enables multi-party approval. Once set every evaluation
+// fills in input.approvers from s and, if the policy allows the request,
+// evaluates requires_approval. If that's true the request is added to s as
+// pending and fails with an error for which ApprovalRequired returns the
+// request hash. Once approved the same request from the same principal
+// proceeds (subject to policy). With ApprovalPerTarget that's once, to the
+// same target, after which the approval is consumed. Passing nil disables
+// approvals.
+func (g *Authorizer) SetApprovalStore(s ApprovalStore, scope ApprovalScope) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.approvals = s
+	g.approvalScope = scope
+}
+
+// Requester returns the identity of peer used for approvals. This is the
+// principal ID if one is set, otherwise the SPIFFE ID or certificate subject.
+// If peer can't be identified it's empty.
+func Requester(peer *PeerAuthInput) string {
+	switch {
+	case peer == nil:
+		return ""
+	case peer.Principal != nil && peer.Principal.ID != "":
+		return peer.Principal.ID
+	case peer.Cert == nil:
+		return ""
+	case peer.Cert.SPIFFEID != "":
+		return peer.Cert.SPIFFEID
+	case peer.Cert.Subject.String() != "":
+		return peer.Cert.Subject.String()
+	}
+	return ""
+}
+
+// RequestTarget returns the host:port the request in input is for, or an
+// empty string if it's unknown.
+func RequestTarget(input *RPCAuthInput) string {
+	if input.Host == nil || input.Host.Net == nil {
+		return ""
+	}
+	return net.JoinHostPort(input.Host.Net.Address, input.Host.Net.Port)
+}
+
+// RequestHash returns the hex encoded SHA256 identifying a request for
+// approvals. It covers the requester, method and message along with the
+// target for ApprovalPerTarget.
+func RequestHash(input *RPCAuthInput, scope ApprovalScope) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00", Requester(input.Peer), input.Method)
+	if scope == ApprovalPerTarget {
+		fmt.Fprintf(h, "%s\x00", RequestTarget(input))
+	}
+	// protojson output isn't stable so normalize it.
+	var msg interface{}
+	if err := json.Unmarshal(input.Message, &msg); err == nil {
+		b, _ := json.Marshal(msg)
+		h.Write(b)
+	} else {
+		h.Write(input.Message)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+const (
+	// ApprovalRequiredReason is the ErrorInfo reason attached to errors for
+	// requests held for approval.
+	ApprovalRequiredReason = "APPROVAL_REQUIRED"
+	// approvalDomain is the ErrorInfo domain for approvals.
+	approvalDomain = "sansshell"
+	// approvalHashKey is the ErrorInfo metadata key holding the request hash.
+	approvalHashKey = "request_hash"
+)
+
+// approvalError returns the error for a request held pending approval.
+func approvalError(hash string) error {
+	st := status.Newf(codes.FailedPrecondition, "request requires approval, request hash %s", hash)
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   ApprovalRequiredReason,
+		Domain:   approvalDomain,
+		Metadata: map[string]string{approvalHashKey: hash},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// holdForApproval adds input to approvals as pending and returns the error
+// telling the caller it needs approval. Requests from peers Requester can't
+// identify are refused instead, as they'd all share approvals and limits.
+func holdForApproval(ctx context.Context, approvals ApprovalStore, hash string, input *RPCAuthInput) error {
+	if Requester(input.Peer) == "" {
+		return status.Error(codes.PermissionDenied, "request requires approval but the requester can't be identified")
+	}
+	if err := approvals.AddPending(ctx, hash, input); err != nil {
+		return err
+	}
+	return approvalError(hash)
+}
+
+// Approval is a per target approval which allowed a request evaluated by
+// EvalDeferConsume but which hasn't been used up yet.
+type Approval struct {
+	approvals ApprovalStore
+	hash      string
+	input     *RPCAuthInput
+}
+
+// Consume uses up the approval. If another request consumed it first the
+// request is held for approval again and the error for that is returned.
+// Consuming a nil Approval does nothing.
+func (a *Approval) Consume(ctx context.Context) error {
+	if a == nil || a.approvals.Consume(ctx, a.hash) {
+		return nil
+	}
+	logr.FromContextOrDiscard(ctx).V(1).Info("approval already used", "hash", a.hash)
+	a.input.Approvers = nil
+	return holdForApproval(ctx, a.approvals, a.hash, a.input)
+}
+
+// ApprovalRequired returns the request hash if err is from Eval holding a
+// request for approval.
+func ApprovalRequired(err error) (string, bool) {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.FailedPrecondition {
+		return "", false
+	}
+	for _, d := range st.Details() {
+		info, ok := d.(*errdetails.ErrorInfo)
+		if ok && info.Reason == ApprovalRequiredReason && info.Domain == approvalDomain {
+			return info.Metadata[approvalHashKey], true
+		}
+	}
+	return "", false
+}
+
+type inputKey struct{}
+
+// InputFromContext returns the RPCAuthInput, as modified by any hooks, which
+// Authorize evaluated before calling the handler with ctx. It's nil for
+// streaming RPCs or if the handler wasn't invoked via Authorize.
+func InputFromContext(ctx context.Context) *RPCAuthInput {
+	input, _ := ctx.Value(inputKey{}).(*RPCAuthInput)
+	return input
+}
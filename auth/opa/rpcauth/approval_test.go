@@ -0,0 +1,247 @@
+/* Copyright (c) 2019 Snowflake Inc. All rights reserved.
+
+   Licensed under the Apache License, Version 2.0 (the
+   "License"); you may not use this file except in compliance
+   with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing,
+   software distributed under the License is distributed on an
+   "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+   KIND, either express or implied.  See the License for the
+   specific language governing permissions and limitations
+   under the License.
+*/
+
+package rpcauth
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/Snowflake-Labs/sansshell/testing/testutil"
+)
+
+var approvalPolicy = `
+package sansshell.authz
+
+allow {
+  input.method = "/Foo/Rm"
+}
+
+allow {
+  input.method = "/Foo/Ls"
+}
+
+requires_approval {
+  input.method = "/Foo/Rm"
+  not approved
+}
+
+approved {
+  input.approvers[_].groups[_] = "security"
+}
+`
+
+type fakeApprovalStore struct {
+	approvers map[string][]*PrincipalAuthInput
+	pending   map[string]*RPCAuthInput
+	addErr    error
+}
+
+func (f *fakeApprovalStore) Approvers(ctx context.Context, hash string) []*PrincipalAuthInput {
+	return f.approvers[hash]
+}
+
+func (f *fakeApprovalStore) AddPending(ctx context.Context, hash string, input *RPCAuthInput) error {
+	if f.addErr != nil {
+		return f.addErr
+	}
+	if _, ok := f.pending[hash]; !ok {
+		f.pending[hash] = input
+	}
+	return nil
+}
+
+func (f *fakeApprovalStore) Consume(ctx context.Context, hash string) bool {
+	_, ok := f.pending[hash]
+	delete(f.pending, hash)
+	delete(f.approvers, hash)
+	return ok
+}
+
+func TestApproval(t *testing.T) {
+	ctx := context.Background()
+	authz, err := NewWithPolicy(ctx, approvalPolicy)
+	testutil.FatalOnErr("NewWithPolicy", err, t)
+
+	peer := &PeerAuthInput{Principal: &PrincipalAuthInput{ID: "alice"}}
+	rmTo := func(address string) *RPCAuthInput {
+		return &RPCAuthInput{
+			Method:  "/Foo/Rm",
+			Message: json.RawMessage(`{"b": 1, "a": 2}`),
+			Peer:    peer,
+			Host:    &HostAuthInput{Net: &NetAuthInput{Address: address, Port: "50042"}},
+		}
+	}
+	rm := func() *RPCAuthInput { return rmTo("10.0.0.1") }
+
+	// Without a store requires_approval is ignored.
+	testutil.FatalOnErr("no store", authz.Eval(ctx, rm()), t)
+
+	store := &fakeApprovalStore{
+		approvers: map[string][]*PrincipalAuthInput{},
+		pending:   map[string]*RPCAuthInput{},
+	}
+	authz.SetApprovalStore(store, ApprovalPerTarget)
+	testutil.FatalOnErr("no approval needed", authz.Eval(ctx, &RPCAuthInput{Method: "/Foo/Ls", Peer: peer}), t)
+	if len(store.pending) != 0 {
+		t.Fatalf("unexpected pending requests: %v", store.pending)
+	}
+
+	err = authz.Eval(ctx, rm())
+	if got, want := status.Code(err), codes.FailedPrecondition; got != want {
+		t.Fatalf("unapproved: got %v want %v", got, want)
+	}
+	hash, ok := ApprovalRequired(err)
+	if !ok {
+		t.Fatalf("ApprovalRequired(%v) = false", err)
+	}
+	if hash != RequestHash(rm(), ApprovalPerTarget) || store.pending[hash] == nil {
+		t.Fatalf("hash %s not pending in %v", hash, store.pending)
+	}
+
+	// Field order in the message doesn't matter.
+	reordered := rm()
+	reordered.Message = json.RawMessage(`{"a":2,"b":1}`)
+	if got := RequestHash(reordered, ApprovalPerTarget); got != hash {
+		t.Fatalf("RequestHash(reordered) = %s, want %s", got, hash)
+	}
+	// But the requester and target do, unless approvals are fleet wide.
+	other := rm()
+	other.Peer = &PeerAuthInput{Principal: &PrincipalAuthInput{ID: "mallory"}}
+	if RequestHash(other, ApprovalPerTarget) == hash {
+		t.Fatal("RequestHash ignores the requester")
+	}
+	if RequestHash(rmTo("10.0.0.2"), ApprovalPerTarget) == hash {
+		t.Fatal("RequestHash ignores the target")
+	}
+	if RequestHash(rmTo("10.0.0.2"), ApprovalFleetWide) != RequestHash(rm(), ApprovalFleetWide) {
+		t.Fatal("fleet wide RequestHash depends on the target")
+	}
+
+	store.approvers[hash] = []*PrincipalAuthInput{{ID: "bob"}}
+	if _, ok := ApprovalRequired(authz.Eval(ctx, rm())); !ok {
+		t.Fatal("approval by a non-security principal was accepted")
+	}
+	store.approvers[hash] = append(store.approvers[hash], &PrincipalAuthInput{ID: "carol", Groups: []string{"security"}})
+	// The approval only covers its target.
+	if _, ok := ApprovalRequired(authz.Eval(ctx, rmTo("10.0.0.2"))); !ok {
+		t.Fatal("approval applied to another target")
+	}
+	testutil.FatalOnErr("approved", authz.Eval(ctx, rm()), t)
+	// And only once.
+	if _, ok := ApprovalRequired(authz.Eval(ctx, rm())); !ok {
+		t.Fatal("approval was used twice")
+	}
+
+	// If another request consumed the approval first this one is held again.
+	store.approvers[hash] = []*PrincipalAuthInput{{ID: "carol", Groups: []string{"security"}}}
+	delete(store.pending, hash)
+	if _, ok := ApprovalRequired(authz.Eval(ctx, rm())); !ok {
+		t.Fatal("request allowed with an approval already used")
+	}
+	if store.pending[hash] == nil {
+		t.Fatal("request isn't pending again after losing its approval")
+	}
+
+	// EvalDeferConsume leaves consuming the approval to the caller.
+	store.approvers[hash] = []*PrincipalAuthInput{{ID: "carol", Groups: []string{"security"}}}
+	approval, err := authz.EvalDeferConsume(ctx, rm())
+	testutil.FatalOnErr("EvalDeferConsume", err, t)
+	if approval == nil || store.pending[hash] == nil {
+		t.Fatalf("EvalDeferConsume returned %v with pending %v, want an unconsumed approval", approval, store.pending)
+	}
+	testutil.FatalOnErr("Consume", approval.Consume(ctx), t)
+	if store.pending[hash] != nil {
+		t.Fatal("approval not consumed")
+	}
+	if _, ok := ApprovalRequired(approval.Consume(ctx)); !ok {
+		t.Fatal("approval consumed twice")
+	}
+	none, err := authz.EvalDeferConsume(ctx, &RPCAuthInput{Method: "/Foo/Ls", Peer: peer})
+	testutil.FatalOnErr("EvalDeferConsume without approval", err, t)
+	testutil.FatalOnErr("Consume without approval", none.Consume(ctx), t)
+
+	// Fleet wide approvals cover every target and aren't consumed.
+	authz.SetApprovalStore(store, ApprovalFleetWide)
+	fleetHash := RequestHash(rm(), ApprovalFleetWide)
+	store.approvers[fleetHash] = []*PrincipalAuthInput{{ID: "carol", Groups: []string{"security"}}}
+	for _, address := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.1"} {
+		testutil.FatalOnErr("fleet wide approval to "+address, authz.Eval(ctx, rmTo(address)), t)
+	}
+
+	// Requests from unidentified peers aren't held.
+	anonymous := rm()
+	anonymous.Peer = &PeerAuthInput{}
+	if got, want := status.Code(authz.Eval(ctx, anonymous)), codes.PermissionDenied; got != want {
+		t.Fatalf("anonymous: got %v want %v", got, want)
+	}
+	if store.pending[RequestHash(anonymous, ApprovalFleetWide)] != nil {
+		t.Fatal("anonymous request held for approval")
+	}
+
+	// Errors from the store are returned as is.
+	store.addErr = status.Error(codes.ResourceExhausted, "full")
+	if got, want := status.Code(authz.Eval(ctx, other)), codes.ResourceExhausted; got != want {
+		t.Fatalf("full store: got %v want %v", got, want)
+	}
+
+	if _, ok := ApprovalRequired(status.Error(codes.FailedPrecondition, "other")); ok {
+		t.Fatal("ApprovalRequired true for an unrelated error")
+	}
+}
+
+func TestParseApprovalScope(t *testing.T) {
+	for _, tc := range []struct {
+		in      string
+		want    ApprovalScope
+		wantErr bool
+	}{
+		{in: "target", want: ApprovalPerTarget},
+		{in: "fleet", want: ApprovalFleetWide},
+		{in: "", wantErr: true},
+	} {
+		got, err := ParseApprovalScope(tc.in)
+		testutil.WantErr(tc.in, err, tc.wantErr, t)
+		if got != tc.want {
+			t.Errorf("ParseApprovalScope(%q) = %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestInputFromContext(t *testing.T) {
+	ctx := context.Background()
+	if InputFromContext(ctx) != nil {
+		t.Fatal("got input from an empty context")
+	}
+	authorizer, err := NewWithPolicy(ctx, policyString)
+	testutil.FatalOnErr("NewWithPolicy", err, t)
+	var got *RPCAuthInput
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got = InputFromContext(ctx)
+		return nil, nil
+	}
+	_, err = authorizer.Authorize(ctx, &emptypb.Empty{}, &grpc.UnaryServerInfo{FullMethod: "/Foo/Bar"}, handler)
+	testutil.FatalOnErr("Authorize", err, t)
+	if got == nil || got.Method != "/Foo/Bar" {
+		t.Fatalf("InputFromContext() = %+v, want input for /Foo/Bar", got)
+	}
+}
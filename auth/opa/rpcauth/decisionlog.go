@@ -0,0 +1,244 @@
+/* Copyright (c) 2019 Snowflake Inc. All rights reserved.
+
+   Licensed under the Apache License, Version 2.0 (the
+   "License"); you may not use this file except in compliance
+   with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing,
+   software distributed under the License is distributed on an
+   "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+   KIND, either express or implied.  See the License for the
+   specific language governing permissions and limitations
+   under the License.
+*/
+
+package rpcauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/status"
+
+	"github.com/Snowflake-Labs/sansshell/auth/opa"
+)
+
+// A Decision records the outcome of a single policy evaluation by an
+// Authorizer.
+type Decision struct {
+	// When evaluation started.
+	Time time.Time `json:"time"`
+
+	// The GRPC method name, as '/Package.Service/Method'
+	Method string `json:"method"`
+
+	// The request protocol buffer, serialized as JSON.
+	Message json.RawMessage `json:"message,omitempty"`
+
+	// Information about the calling peer, if available.
+	Peer *PeerAuthInput `json:"peer,omitempty"`
+
+	// The justification passed in the request metadata, if any.
+	Justification string `json:"justification,omitempty"`
+
+	// True iff the request was permitted.
+	Allowed bool `json:"allowed"`
+
+	// The status code returned, i.e. PermissionDenied for a denial or
+	// Internal if the policy couldn't be evaluated.
+	Code string `json:"code"`
+
+	// The error returned for requests which weren't allowed.
+	Error string `json:"error,omitempty"`
+
+	// Any hints the policy gave for a denial.
+	Hints []string `json:"hints,omitempty"`
+
+	// Who approved the request, if approvals are in use.
+	Approvers []*PrincipalAuthInput `json:"approvers,omitempty"`
+
+	// The request hash if it was held for approval.
+	ApprovalHash string `json:"approval_hash,omitempty"`
+
+	// The hex encoded SHA256 of the policy used (see opa.AuthzPolicy.Hash).
+	PolicySHA256 string `json:"policy_sha256"`
+
+	// How long the evaluation (including hooks) took.
+	Latency time.Duration `json:"latency_ns"`
+}
+
+func newDecision(start time.Time, input *RPCAuthInput, policy *opa.AuthzPolicy, err error) *Decision {
+	d := &Decision{
+		Time:         start,
+		Allowed:      err == nil,
+		Code:         status.Code(err).String(),
+		Hints:        DenialHints(err),
+		PolicySHA256: policy.Hash(),
+		Latency:      time.Since(start),
+	}
+	if err != nil {
+		d.Error = err.Error()
+	}
+	d.ApprovalHash, _ = ApprovalRequired(err)
+	if input != nil {
+		d.Method = input.Method
+		d.Approvers = input.Approvers
+		d.Message = input.Message
+		d.Peer = input.Peer
+		if j := input.Metadata.Get(ReqJustKey); len(j) > 0 {
+			d.Justification = j[0]
+		}
+	}
+	return d
+}
+
+// A DecisionLogger records authorization decisions, i.e. for auditing.
+// LogDecision is called synchronously for every evaluation so
+// implementations which can block for long should buffer.
+type DecisionLogger interface {
+	LogDecision(ctx context.Context, d *Decision) error
+}
+
+// A DecisionLoggerFunc adapts a function to a DecisionLogger.
+type DecisionLoggerFunc func(ctx context.Context, d *Decision) error
+
+// LogDecision implements DecisionLogger.
+func (f DecisionLoggerFunc) LogDecision(ctx context.Context, d *Decision) error {
+	return f(ctx, d)
+}
+
+// NewWriterDecisionLogger returns a DecisionLogger which writes each Decision
+// to w as a line of JSON.
+func NewWriterDecisionLogger(w io.Writer) DecisionLogger {
+	return &writerDecisionLogger{w: w}
+}
+
+type writerDecisionLogger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *writerDecisionLogger) LogDecision(ctx context.Context, d *Decision) error {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.w.Write(append(b, '\n'))
+	return err
+}
+
+// FileDecisionLogger is a DecisionLogger writing JSON lines to a file which
+// is rotated when it reaches a given size.
+type FileDecisionLogger struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	f          *os.File
+	size       int64
+}
+
+// NewFileDecisionLogger returns a FileDecisionLogger appending to the file
+// at path. Once the file would grow past maxSize bytes it's renamed to
+// path.1 (with any existing path.1 moving to path.2 and so on) and a new
+// file started. Only maxBackups old files are kept. If maxSize is 0 the
+// file is never rotated.
+func NewFileDecisionLogger(path string, maxSize int64, maxBackups int) (*FileDecisionLogger, error) {
+	l := &FileDecisionLogger{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *FileDecisionLogger) open() error {
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("can't open decision log: %w", err)
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("can't stat decision log: %w", err)
+	}
+	l.f = f
+	l.size = fi.Size()
+	return nil
+}
+
+func (l *FileDecisionLogger) backup(n int) string {
+	return fmt.Sprintf("%s.%d", l.path, n)
+}
+
+// rotate moves the current file to path.1 (shifting existing backups
+// along) and opens a new one.
+func (l *FileDecisionLogger) rotate() error {
+	if err := l.f.Close(); err != nil {
+		return fmt.Errorf("can't close decision log: %w", err)
+	}
+	l.f = nil
+	if l.maxBackups > 0 {
+		for i := l.maxBackups - 1; i > 0; i-- {
+			if err := os.Rename(l.backup(i), l.backup(i+1)); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("can't rotate decision log: %w", err)
+			}
+		}
+		if err := os.Rename(l.path, l.backup(1)); err != nil {
+			return fmt.Errorf("can't rotate decision log: %w", err)
+		}
+	} else if err := os.Remove(l.path); err != nil {
+		return fmt.Errorf("can't rotate decision log: %w", err)
+	}
+	return l.open()
+}
+
+// LogDecision implements DecisionLogger.
+func (l *FileDecisionLogger) LogDecision(ctx context.Context, d *Decision) error {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		// A previous rotation failed part way so try again.
+		if err := l.open(); err != nil {
+			return err
+		}
+	}
+	if l.maxSize > 0 && l.size > 0 && l.size+int64(len(b)) > l.maxSize {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := l.f.Write(b)
+	l.size += int64(n)
+	return err
+}
+
+// Close closes the underlying file.
+func (l *FileDecisionLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return nil
+	}
+	err := l.f.Close()
+	l.f = nil
+	return err
+}
@@ -0,0 +1,35 @@
+//go:build !(windows || plan9)
+// +build !windows,!plan9
+
+/* Copyright (c) 2019 Snowflake Inc. All rights reserved.
+
+   Licensed under the Apache License, Version 2.0 (the
+   "License"); you may not use this file except in compliance
+   with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing,
+   software distributed under the License is distributed on an
+   "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+   KIND, either express or implied.  See the License for the
+   specific language governing permissions and limitations
+   under the License.
+*/
+
+package rpcauth
+
+import (
+	"fmt"
+	"log/syslog"
+)
+
+// NewSyslogDecisionLogger returns a DecisionLogger sending each Decision as
+// JSON to the local syslog daemon with the auth facility and given tag.
+func NewSyslogDecisionLogger(tag string) (DecisionLogger, error) {
+	w, err := syslog.New(syslog.LOG_INFO|syslog.LOG_AUTH, tag)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to syslog: %w", err)
+	}
+	return NewWriterDecisionLogger(w), nil
+}
@@ -0,0 +1,136 @@
+/* Copyright (c) 2019 Snowflake Inc. All rights reserved.
+
+   Licensed under the Apache License, Version 2.0 (the
+   "License"); you may not use this file except in compliance
+   with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing,
+   software distributed under the License is distributed on an
+   "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+   KIND, either express or implied.  See the License for the
+   specific language governing permissions and limitations
+   under the License.
+*/
+
+package rpcauth
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/Snowflake-Labs/sansshell/testing/testutil"
+)
+
+func TestDecisionLogger(t *testing.T) {
+	ctx := context.Background()
+	authz, err := NewWithPolicy(ctx, policyString)
+	testutil.FatalOnErr("NewWithPolicy", err, t)
+
+	var got []*Decision
+	authz.SetDecisionLogger(DecisionLoggerFunc(func(ctx context.Context, d *Decision) error {
+		got = append(got, d)
+		return nil
+	}))
+
+	testutil.FatalOnErr("allowed", authz.Eval(ctx, &RPCAuthInput{
+		Method:   "/Foo/Bar",
+		Metadata: metadata.Pairs(ReqJustKey, "ticket-1"),
+		Peer:     &PeerAuthInput{Principal: &PrincipalAuthInput{ID: "someone"}},
+	}), t)
+	testutil.FatalOnNoErr("denied", authz.Eval(ctx, &RPCAuthInput{Method: "/Foo/Baz"}), t)
+	testutil.FatalOnNoErr("nil input", authz.Eval(ctx, nil), t)
+
+	if len(got) != 3 {
+		t.Fatalf("got %d decisions, want 3", len(got))
+	}
+	for _, d := range got {
+		if d.Time.IsZero() || d.Latency <= 0 {
+			t.Errorf("missing time or latency: %+v", d)
+		}
+		if d.PolicySHA256 != authz.PolicyHash() {
+			t.Errorf("policy hash %s, want %s", d.PolicySHA256, authz.PolicyHash())
+		}
+	}
+	allowed, denied, invalid := got[0], got[1], got[2]
+	if !allowed.Allowed || allowed.Code != "OK" || allowed.Error != "" || allowed.Method != "/Foo/Bar" || allowed.Justification != "ticket-1" || allowed.Peer.Principal.ID != "someone" {
+		t.Errorf("unexpected allowed decision %+v", allowed)
+	}
+	if denied.Allowed || denied.Code != "PermissionDenied" || denied.Error == "" || denied.Method != "/Foo/Baz" {
+		t.Errorf("unexpected denied decision %+v", denied)
+	}
+	if invalid.Allowed || invalid.Code != "InvalidArgument" {
+		t.Errorf("unexpected decision for nil input %+v", invalid)
+	}
+
+	authz.SetDecisionLogger(nil)
+	testutil.FatalOnErr("allowed", authz.Eval(ctx, &RPCAuthInput{Method: "/Foo/Bar"}), t)
+	if len(got) != 3 {
+		t.Fatalf("decision logged after SetDecisionLogger(nil)")
+	}
+}
+
+func TestFileDecisionLogger(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "decisions.log")
+	d := &Decision{Time: time.Unix(1, 0), Method: "/Foo/Bar", Allowed: true, Code: "OK"}
+	b, err := json.Marshal(d)
+	testutil.FatalOnErr("Marshal", err, t)
+	line := int64(len(b) + 1)
+
+	// Room for 2 lines per file and keep 2 backups.
+	l, err := NewFileDecisionLogger(path, 2*line, 2)
+	testutil.FatalOnErr("NewFileDecisionLogger", err, t)
+	for i := 0; i < 7; i++ {
+		testutil.FatalOnErr("LogDecision", l.LogDecision(ctx, d), t)
+	}
+	testutil.FatalOnErr("Close", l.Close(), t)
+
+	for _, tc := range []struct {
+		file  string
+		lines int
+	}{
+		{path, 1},
+		{path + ".1", 2},
+		{path + ".2", 2},
+	} {
+		f, err := os.Open(tc.file)
+		testutil.FatalOnErr("Open", err, t)
+		defer f.Close()
+		n := 0
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			got := &Decision{}
+			testutil.FatalOnErr("Unmarshal", json.Unmarshal(scanner.Bytes(), got), t)
+			if got.Method != d.Method || !got.Allowed || !got.Time.Equal(d.Time) {
+				t.Errorf("%s: got %+v want %+v", tc.file, got, d)
+			}
+			n++
+		}
+		if n != tc.lines {
+			t.Errorf("%s has %d lines, want %d", tc.file, n, tc.lines)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("too many backups kept: %v", err)
+	}
+
+	// Reopening appends rather than truncating.
+	l, err = NewFileDecisionLogger(path, 0, 0)
+	testutil.FatalOnErr("NewFileDecisionLogger", err, t)
+	testutil.FatalOnErr("LogDecision", l.LogDecision(ctx, d), t)
+	testutil.FatalOnErr("Close", l.Close(), t)
+	fi, err := os.Stat(path)
+	testutil.FatalOnErr("Stat", err, t)
+	if fi.Size() != 2*line {
+		t.Errorf("size after reopening %d, want %d", fi.Size(), 2*line)
+	}
+}
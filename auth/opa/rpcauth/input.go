@@ -53,6 +53,10 @@ type RPCAuthInput struct {
 
 	// Implementation specific extensions.
 	Extensions json.RawMessage `json:"extensions"`
+
+	// The principals who have approved this request, if an ApprovalStore
+	// is in use (see Authorizer.SetApprovalStore).
+	Approvers []*PrincipalAuthInput `json:"approvers"`
 }
 
 // PeerAuthInput contains policy-relevant information about an RPC peer.
@@ -105,6 +109,9 @@ type CertAuthInput struct {
 
 	// The raw SPIFFE identifier, if present
 	SPIFFEID string `json:"spiffeid"`
+
+	// URIs, from SubjectAlternativeName
+	URIs []string `json:"uris"`
 }
 
 // PrincipalAuthInput contains policy-relevant information about the principal
@@ -190,6 +197,9 @@ func CertInputFrom(authInfo credentials.AuthInfo) *CertAuthInput {
 		out.Subject = cert.Subject
 		out.Issuer = cert.Issuer
 		out.DNSNames = cert.DNSNames
+		for _, u := range cert.URIs {
+			out.URIs = append(out.URIs, u.String())
+		}
 	}
 	return out
 }
@@ -0,0 +1,354 @@
+/* Copyright (c) 2019 Snowflake Inc. All rights reserved.
+
+   Licensed under the Apache License, Version 2.0 (the
+   "License"); you may not use this file except in compliance
+   with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing,
+   software distributed under the License is distributed on an
+   "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+   KIND, either express or implied.  See the License for the
+   specific language governing permissions and limitations
+   under the License.
+*/
+
+package rpcauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/user"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v2"
+)
+
+// A PrincipalFunc derives a principal ID from a peer certificate, returning
+// an empty string if the certificate doesn't identify one.
+type PrincipalFunc func(cert *CertAuthInput) string
+
+// PrincipalFromCN returns a PrincipalFunc using the certificate subject's
+// common name as the principal ID.
+func PrincipalFromCN() PrincipalFunc {
+	return func(cert *CertAuthInput) string {
+		return cert.Subject.CommonName
+	}
+}
+
+// PrincipalFromSPIFFEPath returns a PrincipalFunc using the path of the
+// certificate's SPIFFE ID (without the leading slash) as the principal ID,
+// i.e. "ns/prod/sa/deployer" for spiffe://example.org/ns/prod/sa/deployer.
+func PrincipalFromSPIFFEPath() PrincipalFunc {
+	return func(cert *CertAuthInput) string {
+		u, err := url.Parse(cert.SPIFFEID)
+		if err != nil || u.Scheme != "spiffe" {
+			return ""
+		}
+		return strings.TrimPrefix(u.Path, "/")
+	}
+}
+
+// PrincipalFromURI returns a PrincipalFunc which uses the first URI
+// SubjectAlternativeName matching re. If re has a capture group the first
+// group is the principal ID, otherwise the whole URI is.
+func PrincipalFromURI(re *regexp.Regexp) PrincipalFunc {
+	return func(cert *CertAuthInput) string {
+		for _, u := range cert.URIs {
+			m := re.FindStringSubmatch(u)
+			switch {
+			case m == nil:
+				continue
+			case len(m) > 1:
+				return m[1]
+			default:
+				return u
+			}
+		}
+		return ""
+	}
+}
+
+// ParsePrincipalFunc returns the PrincipalFunc described by spec, which is
+// one of "cn", "spiffe-path" or "uri:<regexp>" (see PrincipalFromURI).
+func ParsePrincipalFunc(spec string) (PrincipalFunc, error) {
+	switch {
+	case spec == "cn":
+		return PrincipalFromCN(), nil
+	case spec == "spiffe-path":
+		return PrincipalFromSPIFFEPath(), nil
+	case strings.HasPrefix(spec, "uri:"):
+		re, err := regexp.Compile(strings.TrimPrefix(spec, "uri:"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid principal URI pattern: %w", err)
+		}
+		return PrincipalFromURI(re), nil
+	default:
+		return nil, fmt.Errorf("unknown principal source %q (must be cn, spiffe-path or uri:<regexp>)", spec)
+	}
+}
+
+// A GroupSource resolves the groups a principal belongs to.
+type GroupSource interface {
+	Groups(ctx context.Context, principal string) ([]string, error)
+}
+
+// GroupSourceFunc implements GroupSource for a simple function.
+type GroupSourceFunc func(ctx context.Context, principal string) ([]string, error)
+
+// Groups runs the function for the given principal.
+func (f GroupSourceFunc) Groups(ctx context.Context, principal string) ([]string, error) {
+	return f(ctx, principal)
+}
+
+// StaticGroups returns a GroupSource from a map of group names to their
+// members.
+func StaticGroups(members map[string][]string) GroupSource {
+	byPrincipal := make(map[string][]string)
+	for group, principals := range members {
+		for _, p := range principals {
+			byPrincipal[p] = append(byPrincipal[p], group)
+		}
+	}
+	for _, groups := range byPrincipal {
+		sort.Strings(groups)
+	}
+	return GroupSourceFunc(func(ctx context.Context, principal string) ([]string, error) {
+		return byPrincipal[principal], nil
+	})
+}
+
+// LoadGroupsFile returns a StaticGroups source from a YAML (or JSON) file
+// mapping group names to lists of members:
+//
+//	admins:
+//	  - alice
+//	oncall: [alice, bob]
+func LoadGroupsFile(path string) (GroupSource, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't read groups file: %w", err)
+	}
+	members := make(map[string][]string)
+	if err := yaml.Unmarshal(b, &members); err != nil {
+		return nil, fmt.Errorf("can't parse groups file %s: %w", path, err)
+	}
+	return StaticGroups(members), nil
+}
+
+// UnixGroups returns a GroupSource which treats the principal as a local
+// user and returns the names of the unix groups they belong to. Unknown
+// users have no groups.
+func UnixGroups() GroupSource {
+	return GroupSourceFunc(func(ctx context.Context, principal string) ([]string, error) {
+		u, err := user.Lookup(principal)
+		if err != nil {
+			if _, ok := err.(user.UnknownUserError); ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+		gids, err := u.GroupIds()
+		if err != nil {
+			return nil, err
+		}
+		var groups []string
+		for _, gid := range gids {
+			g, err := user.LookupGroupId(gid)
+			if err != nil {
+				// Groups without names can't be referenced by policy anyways.
+				continue
+			}
+			groups = append(groups, g.Name)
+		}
+		sort.Strings(groups)
+		return groups, nil
+	})
+}
+
+const (
+	// DefaultHTTPGroupsTimeout bounds each request HTTPGroups makes when
+	// not given a client.
+	DefaultHTTPGroupsTimeout = 5 * time.Second
+	// DefaultGroupsCacheTTL is how long ParseGroupSource caches the groups
+	// fetched from an HTTP endpoint.
+	DefaultGroupsCacheTTL = time.Minute
+	// DefaultGroupsMaxStale is how long after they expire ParseGroupSource
+	// keeps serving cached groups while an HTTP endpoint is failing.
+	DefaultGroupsMaxStale = 10 * time.Minute
+)
+
+// HTTPGroups returns a GroupSource which fetches groups from endpoint with
+// a GET request, passing the principal as the "principal" query parameter.
+// The endpoint must reply with a JSON object such as {"groups": ["a", "b"]}.
+// If client is nil one with DefaultHTTPGroupsTimeout is used. Every call
+// makes a request so it's normally wrapped with CachedGroups.
+func HTTPGroups(endpoint string, client *http.Client) GroupSource {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultHTTPGroupsTimeout}
+	}
+	return GroupSourceFunc(func(ctx context.Context, principal string) ([]string, error) {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		q := u.Query()
+		q.Set("principal", principal)
+		u.RawQuery = q.Encode()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("groups endpoint returned %s", resp.Status)
+		}
+		var reply struct {
+			Groups []string `json:"groups"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+			return nil, fmt.Errorf("can't decode groups reply: %w", err)
+		}
+		return reply.Groups, nil
+	})
+}
+
+// groupsEntry is the result of a lookup cached by groupsCache.
+type groupsEntry struct {
+	groups  []string
+	fetched time.Time
+}
+
+// groupsCache implements CachedGroups.
+type groupsCache struct {
+	src      GroupSource
+	ttl      time.Duration
+	maxStale time.Duration
+	// now is a field so tests can control time.
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]groupsEntry
+
+	// lookups collapses concurrent lookups of the same principal.
+	lookups singleflight.Group
+}
+
+// CachedGroups returns a GroupSource which caches the groups src returns
+// for each principal for ttl. If src fails once the groups have expired
+// the previous groups are returned for up to maxStale longer (logging the
+// error) so an outage of src doesn't fail every request. Concurrent misses
+// for the same principal share a single call to src.
+func CachedGroups(src GroupSource, ttl time.Duration, maxStale time.Duration) GroupSource {
+	return &groupsCache{
+		src:      src,
+		ttl:      ttl,
+		maxStale: maxStale,
+		now:      time.Now,
+		entries:  make(map[string]groupsEntry),
+	}
+}
+
+// Groups implements GroupSource.
+func (c *groupsCache) Groups(ctx context.Context, principal string) ([]string, error) {
+	c.mu.Lock()
+	e, ok := c.entries[principal]
+	c.mu.Unlock()
+	if ok && c.now().Sub(e.fetched) < c.ttl {
+		return e.groups, nil
+	}
+
+	// Lookups can be slow so don't hold the lock for them, and only make one
+	// at a time for each principal so a fan out doesn't flood src.
+	groups, err, _ := c.lookups.Do(principal, func() (interface{}, error) {
+		return c.fetch(ctx, principal)
+	})
+	if err != nil {
+		if ok && c.now().Sub(e.fetched) < c.ttl+c.maxStale {
+			logr.FromContextOrDiscard(ctx).Error(err, "using cached groups", "principal", principal)
+			return e.groups, nil
+		}
+		return nil, err
+	}
+	return groups.([]string), nil
+}
+
+// fetch looks up the groups for principal from src and caches them.
+func (c *groupsCache) fetch(ctx context.Context, principal string) ([]string, error) {
+	groups, err := c.src.Groups(ctx, principal)
+	if err != nil {
+		return nil, err
+	}
+	now := c.now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Drop anything which can't be used any more so the cache only holds
+	// principals seen recently.
+	for p, e := range c.entries {
+		if now.Sub(e.fetched) >= c.ttl+c.maxStale {
+			delete(c.entries, p)
+		}
+	}
+	c.entries[principal] = groupsEntry{groups: groups, fetched: now}
+	return groups, nil
+}
+
+// ParseGroupSource returns the GroupSource described by spec, which is
+// "unix" for UnixGroups, an http(s) URL for HTTPGroups (cached with
+// CachedGroups using DefaultGroupsCacheTTL and DefaultGroupsMaxStale) or
+// otherwise the path of a file for LoadGroupsFile.
+func ParseGroupSource(spec string) (GroupSource, error) {
+	switch {
+	case spec == "unix":
+		return UnixGroups(), nil
+	case strings.HasPrefix(spec, "http://") || strings.HasPrefix(spec, "https://"):
+		return CachedGroups(HTTPGroups(spec, nil), DefaultGroupsCacheTTL, DefaultGroupsMaxStale), nil
+	default:
+		return LoadGroupsFile(spec)
+	}
+}
+
+// PrincipalHook returns an RPCAuthzHook that sets the peer principal from
+// the peer certificate using principal, with groups resolved from groups
+// (which may be nil for none). Peers which already have a principal, have
+// no certificate or for which principal returns an empty ID are left alone.
+// If groups can't be resolved the request fails rather than being
+// evaluated without them.
+func PrincipalHook(principal PrincipalFunc, groups GroupSource) RPCAuthzHook {
+	return RPCAuthzHookFunc(func(ctx context.Context, input *RPCAuthInput) error {
+		if input.Peer == nil || input.Peer.Cert == nil || input.Peer.Principal != nil {
+			return nil
+		}
+		id := principal(input.Peer.Cert)
+		if id == "" {
+			return nil
+		}
+		p := &PrincipalAuthInput{ID: id}
+		if groups != nil {
+			g, err := groups.Groups(ctx, id)
+			if err != nil {
+				return status.Errorf(codes.Unavailable, "can't resolve groups for %s: %v", id, err)
+			}
+			p.Groups = g
+		}
+		input.Peer.Principal = p
+		return nil
+	})
+}
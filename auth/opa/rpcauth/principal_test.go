@@ -0,0 +1,321 @@
+/* Copyright (c) 2019 Snowflake Inc. All rights reserved.
+
+   Licensed under the Apache License, Version 2.0 (the
+   "License"); you may not use this file except in compliance
+   with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing,
+   software distributed under the License is distributed on an
+   "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+   KIND, either express or implied.  See the License for the
+   specific language governing permissions and limitations
+   under the License.
+*/
+
+package rpcauth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"github.com/Snowflake-Labs/sansshell/testing/testutil"
+)
+
+func TestPrincipalFuncs(t *testing.T) {
+	cert := &CertAuthInput{
+		Subject:  pkix.Name{CommonName: "alice"},
+		SPIFFEID: "spiffe://example.org/ns/prod/sa/deployer",
+		URIs:     []string{"https://example.org/other", "urn:example:user:bob"},
+	}
+	for _, tc := range []struct {
+		spec    string
+		want    string
+		wantErr bool
+	}{
+		{spec: "cn", want: "alice"},
+		{spec: "spiffe-path", want: "ns/prod/sa/deployer"},
+		{spec: "uri:^urn:example:user:(.+)$", want: "bob"},
+		{spec: "uri:^urn:example:", want: "urn:example:user:bob"},
+		{spec: "uri:^nomatch:", want: ""},
+		{spec: "uri:(", wantErr: true},
+		{spec: "email", wantErr: true},
+	} {
+		f, err := ParsePrincipalFunc(tc.spec)
+		testutil.WantErr(tc.spec, err, tc.wantErr, t)
+		if err != nil {
+			continue
+		}
+		if got := f(cert); got != tc.want {
+			t.Errorf("%s: got %q want %q", tc.spec, got, tc.want)
+		}
+	}
+
+	if got := PrincipalFromSPIFFEPath()(&CertAuthInput{}); got != "" {
+		t.Errorf("SPIFFE path without a SPIFFE ID: got %q", got)
+	}
+}
+
+func TestCertInputURIs(t *testing.T) {
+	u, err := url.Parse("urn:example:user:bob")
+	testutil.FatalOnErr("url.Parse", err, t)
+	info := credentials.TLSInfo{State: tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{{URIs: []*url.URL{u}}},
+	}}
+	testutil.DiffErr("URIs", CertInputFrom(info).URIs, []string{"urn:example:user:bob"}, t)
+}
+
+func TestGroupSources(t *testing.T) {
+	ctx := context.Background()
+
+	file := filepath.Join(t.TempDir(), "groups.yaml")
+	testutil.FatalOnErr("WriteFile", os.WriteFile(file, []byte(`
+oncall: [bob, alice]
+admins:
+  - alice
+`), 0644), t)
+	fromFile, err := ParseGroupSource(file)
+	testutil.FatalOnErr("ParseGroupSource(file)", err, t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("principal") {
+		case "alice":
+			json.NewEncoder(w).Encode(map[string][]string{"groups": {"admins", "oncall"}})
+		case "broken":
+			http.Error(w, "broken", http.StatusInternalServerError)
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	fromHTTP, err := ParseGroupSource(srv.URL)
+	testutil.FatalOnErr("ParseGroupSource(url)", err, t)
+
+	for _, tc := range []struct {
+		name      string
+		source    GroupSource
+		principal string
+		want      []string
+		wantErr   bool
+	}{
+		{name: "file", source: fromFile, principal: "alice", want: []string{"admins", "oncall"}},
+		{name: "file other", source: fromFile, principal: "bob", want: []string{"oncall"}},
+		{name: "file unknown", source: fromFile, principal: "mallory"},
+		{name: "http", source: fromHTTP, principal: "alice", want: []string{"admins", "oncall"}},
+		{name: "http unknown", source: fromHTTP, principal: "mallory"},
+		{name: "http error", source: fromHTTP, principal: "broken", wantErr: true},
+		{name: "unix unknown", source: UnixGroups(), principal: "no-such-user-sansshell"},
+	} {
+		got, err := tc.source.Groups(ctx, tc.principal)
+		testutil.WantErr(tc.name, err, tc.wantErr, t)
+		testutil.DiffErr(tc.name, got, tc.want, t)
+	}
+
+	_, err = ParseGroupSource(filepath.Join(t.TempDir(), "missing.yaml"))
+	testutil.FatalOnNoErr("missing groups file", err, t)
+
+	// Whatever groups the current user has the primary one should be there.
+	u, err := user.Current()
+	testutil.FatalOnErr("user.Current", err, t)
+	g, err := user.LookupGroupId(u.Gid)
+	if err != nil {
+		t.Skipf("primary group %s has no name", u.Gid)
+	}
+	unix, err := ParseGroupSource("unix")
+	testutil.FatalOnErr("ParseGroupSource(unix)", err, t)
+	groups, err := unix.Groups(ctx, u.Username)
+	testutil.FatalOnErr("unix groups", err, t)
+	found := false
+	for _, name := range groups {
+		found = found || name == g.Name
+	}
+	if !found {
+		t.Errorf("unix groups for %s are %v, missing %s", u.Username, groups, g.Name)
+	}
+}
+
+func TestCachedGroups(t *testing.T) {
+	ctx := context.Background()
+	var calls int
+	var failing bool
+	src := GroupSourceFunc(func(ctx context.Context, principal string) ([]string, error) {
+		calls++
+		if failing {
+			return nil, errors.New("endpoint down")
+		}
+		return []string{fmt.Sprintf("%s-%d", principal, calls)}, nil
+	})
+	cached := CachedGroups(src, time.Minute, 5*time.Minute)
+	now := time.Unix(1000, 0)
+	cached.(*groupsCache).now = func() time.Time { return now }
+
+	for _, tc := range []struct {
+		name      string
+		advance   time.Duration
+		failing   bool
+		principal string
+		want      []string
+		wantErr   bool
+		wantCalls int
+	}{
+		{name: "first lookup", principal: "alice", want: []string{"alice-1"}, wantCalls: 1},
+		{name: "cached", advance: 30 * time.Second, principal: "alice", want: []string{"alice-1"}, wantCalls: 1},
+		{name: "other principal", principal: "bob", want: []string{"bob-2"}, wantCalls: 2},
+		{name: "expired", advance: 31 * time.Second, principal: "alice", want: []string{"alice-3"}, wantCalls: 3},
+		{name: "stale while failing", advance: 2 * time.Minute, failing: true, principal: "alice", want: []string{"alice-3"}, wantCalls: 4},
+		{name: "failing without cache", failing: true, principal: "carol", wantErr: true, wantCalls: 5},
+		{name: "too stale", advance: 5 * time.Minute, failing: true, principal: "alice", wantErr: true, wantCalls: 6},
+		{name: "recovered", principal: "alice", want: []string{"alice-7"}, wantCalls: 7},
+	} {
+		now = now.Add(tc.advance)
+		failing = tc.failing
+		got, err := cached.Groups(ctx, tc.principal)
+		testutil.WantErr(tc.name, err, tc.wantErr, t)
+		testutil.DiffErr(tc.name, got, tc.want, t)
+		if calls != tc.wantCalls {
+			t.Fatalf("%s: source called %d times, want %d", tc.name, calls, tc.wantCalls)
+		}
+	}
+	// bob's entry is long gone so it was dropped.
+	if _, ok := cached.(*groupsCache).entries["bob"]; ok {
+		t.Fatal("expired entry for bob still cached")
+	}
+}
+
+func TestCachedGroupsConcurrent(t *testing.T) {
+	ctx := context.Background()
+	var calls int32
+	started, release := make(chan struct{}), make(chan struct{})
+	src := GroupSourceFunc(func(ctx context.Context, principal string) ([]string, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			close(started)
+		}
+		<-release
+		return []string{"admins"}, nil
+	})
+	cached := CachedGroups(src, time.Minute, time.Minute)
+
+	// Every lookup while the first is in flight shares it.
+	var wg sync.WaitGroup
+	lookup := func() {
+		defer wg.Done()
+		got, err := cached.Groups(ctx, "alice")
+		if err != nil || len(got) != 1 || got[0] != "admins" {
+			t.Errorf("Groups(alice) = %v, %v, want [admins]", got, err)
+		}
+	}
+	wg.Add(1)
+	go lookup()
+	<-started
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go lookup()
+	}
+	// Give the others time to start waiting. Any which don't are served
+	// from the cache so this can't cause false failures.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("source called %d times, want 1", got)
+	}
+}
+
+func TestPrincipalHook(t *testing.T) {
+	ctx := context.Background()
+	groups := StaticGroups(map[string][]string{"admins": {"alice"}})
+	failing := GroupSourceFunc(func(context.Context, string) ([]string, error) {
+		return nil, errors.New("no groups today")
+	})
+	peer := func() *PeerAuthInput {
+		return &PeerAuthInput{Cert: &CertAuthInput{Subject: pkix.Name{CommonName: "alice"}}}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		groups   GroupSource
+		input    *RPCAuthInput
+		want     *PrincipalAuthInput
+		wantCode codes.Code
+	}{
+		{
+			name:   "principal and groups",
+			groups: groups,
+			input:  &RPCAuthInput{Peer: peer()},
+			want:   &PrincipalAuthInput{ID: "alice", Groups: []string{"admins"}},
+		},
+		{
+			name:  "no group source",
+			input: &RPCAuthInput{Peer: peer()},
+			want:  &PrincipalAuthInput{ID: "alice"},
+		},
+		{
+			name:   "no cert",
+			groups: groups,
+			input:  &RPCAuthInput{Peer: &PeerAuthInput{}},
+		},
+		{
+			name:   "no principal in cert",
+			groups: groups,
+			input:  &RPCAuthInput{Peer: &PeerAuthInput{Cert: &CertAuthInput{}}},
+		},
+		{
+			name:   "existing principal kept",
+			groups: groups,
+			input: &RPCAuthInput{Peer: &PeerAuthInput{
+				Cert:      &CertAuthInput{Subject: pkix.Name{CommonName: "alice"}},
+				Principal: &PrincipalAuthInput{ID: "set-elsewhere"},
+			}},
+			want: &PrincipalAuthInput{ID: "set-elsewhere"},
+		},
+		{
+			name:     "groups fail",
+			groups:   failing,
+			input:    &RPCAuthInput{Peer: peer()},
+			wantCode: codes.Unavailable,
+		},
+	} {
+		err := PrincipalHook(PrincipalFromCN(), tc.groups).Hook(ctx, tc.input)
+		if got := status.Code(err); got != tc.wantCode {
+			t.Errorf("%s: got code %v want %v (%v)", tc.name, got, tc.wantCode, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		testutil.DiffErr(tc.name, tc.input.Peer.Principal, tc.want, t)
+	}
+
+	// The principal is usable from policy.
+	authz, err := NewWithPolicy(ctx, `
+package sansshell.authz
+
+allow {
+  "admins" in input.peer.principal.groups
+}
+`, PrincipalHook(PrincipalFromCN(), groups))
+	testutil.FatalOnErr("NewWithPolicy", err, t)
+	testutil.FatalOnErr("admin allowed", authz.Eval(ctx, &RPCAuthInput{Peer: peer()}), t)
+	bob := &RPCAuthInput{Peer: &PeerAuthInput{Cert: &CertAuthInput{Subject: pkix.Name{CommonName: "bob"}}}}
+	testutil.FatalOnNoErr("non admin denied", authz.Eval(ctx, bob), t)
+}
@@ -21,8 +21,12 @@ package rpcauth
 import (
 	"context"
 	"encoding/json"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -37,11 +41,20 @@ import (
 // It can be used as both a unary and stream interceptor, or manually
 // invoked to perform policy checks using `Eval`
 type Authorizer struct {
+	// mu protects policy so it can be swapped by Reload while evaluations are in flight.
+	mu sync.RWMutex
 	// The AuthzPolicy used to perform authorization checks.
 	policy *opa.AuthzPolicy
 
 	// Additional authorization hooks invoked before policy evaluation.
 	hooks []RPCAuthzHook
+
+	// If set every evaluation is recorded here. Protected by mu.
+	decisions DecisionLogger
+
+	// If set requests may need approval. Protected by mu.
+	approvals     ApprovalStore
+	approvalScope ApprovalScope
 }
 
 // A RPCAuthzHook is invoked on populated RpcAuthInput prior to policy
@@ -72,12 +85,79 @@ func NewWithPolicy(ctx context.Context, policy string, authzHooks ...RPCAuthzHoo
 	return New(p, authzHooks...), nil
 }
 
+// Reload compiles policy (along with any modules or data in opts) and, if
+// it's valid, atomically replaces the policy used for all subsequent
+// evaluations. On error the current policy remains in effect. Evaluations
+// already in progress finish with the policy they started with.
+func (g *Authorizer) Reload(ctx context.Context, policy string, opts ...opa.Option) error {
+	p, err := opa.NewAuthzPolicy(ctx, policy, opts...)
+	if err != nil {
+		return err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.policy = p
+	return nil
+}
+
+// PolicyHash returns the Hash of the policy currently in effect.
+func (g *Authorizer) PolicyHash() string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.policy.Hash()
+}
+
+// SetDecisionLogger arranges for the outcome of every subsequent Eval to be
+// recorded with l. Passing nil disables decision logging.
+func (g *Authorizer) SetDecisionLogger(l DecisionLogger) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.decisions = l
+}
+
 // Eval will evalulate the supplied input against the authorization policy, returning
 // nil iff policy evaulation was successful, and the request is permitted, or
 // an appropriate status.Error otherwise. Any input hooks will be executed
 // prior to policy evaluation, and may mutate `input`, regardless of the
-// the success or failure of policy.
+// the success or failure of policy. If a DecisionLogger is set the outcome
+// is recorded there.
 func (g *Authorizer) Eval(ctx context.Context, input *RPCAuthInput) error {
+	_, err := g.evalAndLog(ctx, input, true)
+	return err
+}
+
+// EvalDeferConsume is like Eval except that a per target approval the
+// request relies on isn't consumed. Instead it's returned (nil if there's
+// none) for the caller to Consume once it's sure the request will be sent.
+func (g *Authorizer) EvalDeferConsume(ctx context.Context, input *RPCAuthInput) (*Approval, error) {
+	return g.evalAndLog(ctx, input, false)
+}
+
+// evalAndLog implements Eval and EvalDeferConsume, consuming any approval
+// first if consume is true.
+func (g *Authorizer) evalAndLog(ctx context.Context, input *RPCAuthInput, consume bool) (*Approval, error) {
+	start := time.Now()
+	g.mu.RLock()
+	policy, decisions, approvals, scope := g.policy, g.decisions, g.approvals, g.approvalScope
+	g.mu.RUnlock()
+	approval, err := g.eval(ctx, policy, approvals, scope, input)
+	if err == nil && consume {
+		err = approval.Consume(ctx)
+		approval = nil
+	}
+	if decisions != nil {
+		d := newDecision(start, input, policy, err)
+		if logErr := decisions.LogDecision(ctx, d); logErr != nil {
+			logr.FromContextOrDiscard(ctx).Error(logErr, "decision log")
+		}
+	}
+	return approval, err
+}
+
+// eval implements Eval using policy and approvals, which are passed in so
+// the decision log records the policy that was actually used. Any per target
+// approval the request needs is returned unconsumed.
+func (g *Authorizer) eval(ctx context.Context, policy *opa.AuthzPolicy, approvals ApprovalStore, scope ApprovalScope, input *RPCAuthInput) (*Approval, error) {
 	logger := logr.FromContextOrDiscard(ctx)
 	if input != nil {
 		if logger.V(2).Enabled() {
@@ -90,17 +170,22 @@ func (g *Authorizer) Eval(ctx context.Context, input *RPCAuthInput) error {
 		}
 	}
 	if input == nil {
-		return status.Error(codes.InvalidArgument, "policy input cannot be nil")
+		return nil, status.Error(codes.InvalidArgument, "policy input cannot be nil")
 	}
 	for _, hook := range g.hooks {
 		if err := hook.Hook(ctx, input); err != nil {
 			if _, ok := status.FromError(err); ok {
 				// error is already an appropriate status.Status
-				return err
+				return nil, err
 			}
-			return status.Errorf(codes.Internal, "authz hook error: %v", err)
+			return nil, status.Errorf(codes.Internal, "authz hook error: %v", err)
 		}
 	}
+	var hash string
+	if approvals != nil {
+		hash = RequestHash(input, scope)
+		input.Approvers = approvals.Approvers(ctx, hash)
+	}
 	if logger.V(1).Enabled() {
 		b, err := json.Marshal(input)
 		if err != nil {
@@ -109,15 +194,83 @@ func (g *Authorizer) Eval(ctx context.Context, input *RPCAuthInput) error {
 			logger.V(1).Info("evaluating authz policy post hooks", "input", string(b))
 		}
 	}
-	allowed, err := g.policy.Eval(ctx, input)
+	allowed, err := policy.Eval(ctx, input)
 	if err != nil {
-		return status.Errorf(codes.Internal, "authz policy evaluation error: %v", err)
+		return nil, status.Errorf(codes.Internal, "authz policy evaluation error: %v", err)
 	}
 	if !allowed {
-		logger.V(1).Info("permission denied")
-		return status.Errorf(codes.PermissionDenied, "OPA policy does not permit this request")
+		hints, err := policy.DenialHints(ctx, input)
+		if err != nil {
+			// The request is denied regardless so just note the broken hints.
+			logger.Error(err, "denial hints")
+		}
+		logger.V(1).Info("permission denied", "hints", hints)
+		return nil, denialError(hints)
 	}
-	return nil
+	if approvals != nil {
+		required, err := policy.RequiresApproval(ctx, input)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "authz policy evaluation error: %v", err)
+		}
+		if required {
+			logger.V(1).Info("approval required", "hash", hash)
+			return nil, holdForApproval(ctx, approvals, hash, input)
+		}
+		// Per target approvals are single use.
+		if len(input.Approvers) > 0 && scope == ApprovalPerTarget {
+			return &Approval{approvals: approvals, hash: hash, input: input}, nil
+		}
+	}
+	return nil, nil
+}
+
+// DenialHintType is the type of the PreconditionFailure violations attached
+// to PermissionDenied errors for each hint from the policy.
+const DenialHintType = "OPA_DENIAL_HINT"
+
+// denialError returns the PermissionDenied error for a request the policy
+// denied. Any hints are appended to the message, so they're shown to users
+// without further work, and also attached as details for DenialHints.
+func denialError(hints []string) error {
+	msg := "OPA policy does not permit this request"
+	if len(hints) == 0 {
+		return status.Error(codes.PermissionDenied, msg)
+	}
+	st := status.New(codes.PermissionDenied, msg+": "+strings.Join(hints, "; "))
+	pf := &errdetails.PreconditionFailure{}
+	for _, h := range hints {
+		pf.Violations = append(pf.Violations, &errdetails.PreconditionFailure_Violation{
+			Type:        DenialHintType,
+			Description: h,
+		})
+	}
+	withDetails, err := st.WithDetails(pf)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// DenialHints returns the hints attached to err if it's a PermissionDenied
+// error from Eval and the policy explained the denial.
+func DenialHints(err error) []string {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.PermissionDenied {
+		return nil
+	}
+	var hints []string
+	for _, d := range st.Details() {
+		pf, ok := d.(*errdetails.PreconditionFailure)
+		if !ok {
+			continue
+		}
+		for _, v := range pf.Violations {
+			if v.Type == DenialHintType {
+				hints = append(hints, v.Description)
+			}
+		}
+	}
+	return hints
 }
 
 // Authorize implements grpc.UnaryServerInterceptor
@@ -133,7 +286,7 @@ func (g *Authorizer) Authorize(ctx context.Context, req interface{}, info *grpc.
 	if err := g.Eval(ctx, authInput); err != nil {
 		return nil, err
 	}
-	return handler(ctx, req)
+	return handler(context.WithValue(ctx, inputKey{}, authInput), req)
 }
 
 // AuthorizeClient implements grpc.UnaryClientInterceptor
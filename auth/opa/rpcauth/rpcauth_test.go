@@ -26,6 +26,7 @@ import (
 	"net"
 	"net/url"
 	"os"
+	"strings"
 	"testing"
 
 	"google.golang.org/grpc"
@@ -371,6 +372,32 @@ func TestNewWithPolicy(t *testing.T) {
 	}
 }
 
+func TestReload(t *testing.T) {
+	ctx := context.Background()
+	authz, err := NewWithPolicy(ctx, policyString)
+	testutil.FatalOnErr("NewWithPolicy", err, t)
+
+	input := &RPCAuthInput{Method: "/Other/Method"}
+	if got, want := status.Code(authz.Eval(ctx, input)), codes.PermissionDenied; got != want {
+		t.Fatalf("before reload: got %v want %v", got, want)
+	}
+
+	testutil.FatalOnErr("Reload", authz.Reload(ctx, `
+package sansshell.authz
+
+default allow = false
+
+allow {
+  input.method = "/Other/Method"
+}
+`), t)
+	testutil.FatalOnErr("after reload", authz.Eval(ctx, input), t)
+
+	// A bad policy is rejected and the last good one stays in effect.
+	testutil.FatalOnNoErr("Reload with an invalid policy", authz.Reload(ctx, "package other"), t)
+	testutil.FatalOnErr("after invalid reload", authz.Eval(ctx, input), t)
+}
+
 type testAuthInfo struct {
 	credentials.CommonAuthInfo
 }
@@ -379,6 +406,53 @@ func (testAuthInfo) AuthType() string {
 	return "testAuthInfo"
 }
 
+func TestDenialHints(t *testing.T) {
+	ctx := context.Background()
+	authz, err := NewWithPolicy(ctx, `
+package sansshell.authz
+
+allow {
+  input.method = "/Foo/Bar"
+}
+
+denial_hints[msg] {
+  not allow
+  msg := sprintf("%s is not allowed", [input.method])
+}
+
+denial_hints["try /Foo/Bar"] {
+  not allow
+}
+`)
+	testutil.FatalOnErr("NewWithPolicy", err, t)
+
+	testutil.FatalOnErr("allowed", authz.Eval(ctx, &RPCAuthInput{Method: "/Foo/Bar"}), t)
+	if hints := DenialHints(nil); hints != nil {
+		t.Fatalf("got hints %v for a nil error", hints)
+	}
+
+	err = authz.Eval(ctx, &RPCAuthInput{Method: "/Foo/Baz"})
+	if got, want := status.Code(err), codes.PermissionDenied; got != want {
+		t.Fatalf("denied: got %v want %v", got, want)
+	}
+	want := []string{"/Foo/Baz is not allowed", "try /Foo/Bar"}
+	testutil.DiffErr("hints", DenialHints(err), want, t)
+	if msg := status.Convert(err).Message(); !strings.Contains(msg, "/Foo/Baz is not allowed; try /Foo/Bar") {
+		t.Fatalf("hints missing from message: %s", msg)
+	}
+
+	// Policies without hints keep the plain message.
+	authz, err = NewWithPolicy(ctx, policyString)
+	testutil.FatalOnErr("NewWithPolicy", err, t)
+	err = authz.Eval(ctx, &RPCAuthInput{Method: "/Foo/Baz"})
+	if got, want := status.Convert(err).Message(), "OPA policy does not permit this request"; got != want {
+		t.Fatalf("message without hints: got %q want %q", got, want)
+	}
+	if hints := DenialHints(err); hints != nil {
+		t.Fatalf("got hints %v from a policy without any", hints)
+	}
+}
+
 func TestRpcAuthInput(t *testing.T) {
 	md := metadata.New(map[string]string{
 		"foo": "foo",
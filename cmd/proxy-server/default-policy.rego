@@ -13,6 +13,19 @@ allow {
 	input.method = "/Proxy.Proxy/Proxy"
 }
 
+# Allow anyone to list requests held for approval, but only callers in
+# the 'approvers' group to approve them. The Approval service won't let a
+# principal approve its own requests.
+allow {
+	input.method = "/Approval.Approval/List"
+}
+
+allow {
+	input.method = "/Approval.Approval/Approve"
+	some i
+	input.peer.principal.groups[i] = "approvers"
+}
+
 # Allow people to run reflection against the proxy
 allow {
 	input.method = "/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo"
@@ -25,6 +38,10 @@ allow {
 	input.method = "/HealthCheck.HealthCheck/Ok"
 }
 
+allow {
+	input.method = "/HealthCheck.HealthCheck/Detailed"
+}
+
 # Allow anyone to read /etc/hosts on any host
 allow {
 	input.method = "/LocalFile.LocalFile/Read"
@@ -48,3 +65,32 @@ allow {
 #  some i
 #  input.peer.principal.groups[i] = "admin"
 # }
+
+# Multi-party approval example (needs --approval-ttl): once allowed,
+# removing files, packages or stopping services is held on the proxy until
+# someone in the 'security' group approves it with
+# `sanssh approval approve <hash>`. Each approval covers one retry of the
+# request to one target.
+#
+# requires_approval {
+#  destructive
+#  not security_approved
+# }
+#
+# destructive {
+#  input.method = "/LocalFile.LocalFile/Rm"
+# }
+#
+# destructive {
+#  input.method = "/Packages.Packages/Remove"
+# }
+#
+# destructive {
+#  input.method = "/Service.Service/Action"
+#  input.message.action = "ACTION_STOP"
+# }
+#
+# security_approved {
+#  some i
+#  input.approvers[i].groups[_] = "security"
+# }
@@ -24,6 +24,7 @@ import (
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/Snowflake-Labs/sansshell/auth/mtls"
 	mtlsFlags "github.com/Snowflake-Labs/sansshell/auth/mtls/flags"
@@ -31,6 +32,7 @@ import (
 	"github.com/Snowflake-Labs/sansshell/auth/opa/rpcauth"
 	"github.com/Snowflake-Labs/sansshell/cmd/proxy-server/server"
 	"github.com/Snowflake-Labs/sansshell/cmd/util"
+	approvalserver "github.com/Snowflake-Labs/sansshell/services/approval/server"
 	"github.com/go-logr/logr"
 	"github.com/go-logr/stdr"
 
@@ -49,15 +51,25 @@ var (
 	//go:embed default-policy.rego
 	defaultPolicy string
 
-	policyFlag       = flag.String("policy", defaultPolicy, "Local OPA policy governing access.  If empty, use builtin policy.")
-	policyFile       = flag.String("policy-file", "", "Path to a file with an OPA policy.  If empty, uses --policy.")
-	clientPolicyFlag = flag.String("client-policy", "", "OPA policy for outbound client actions (i.e. connecting to sansshell servers). If empty no policy is applied.")
-	clientPolicyFile = flag.String("client-policy-file", "", "Path to a file with a client OPA.  If empty uses --client-policy")
-	hostport         = flag.String("hostport", "localhost:50043", "Where to listen for connections.")
-	credSource       = flag.String("credential-source", mtlsFlags.Name(), fmt.Sprintf("Method used to obtain mTLS creds (one of [%s])", strings.Join(mtls.Loaders(), ",")))
-	verbosity        = flag.Int("v", 0, "Verbosity level. > 0 indicates more extensive logging")
-	validate         = flag.Bool("validate", false, "If true will evaluate the policy and then exit (non-zero on error)")
-	justification    = flag.Bool("justification", false, "If true then justification (which is logged and possibly validated) must be passed along in the client context Metadata with the key '"+rpcauth.ReqJustKey+"'")
+	policyFlag         = flag.String("policy", defaultPolicy, "Local OPA policy governing access.  If empty, use builtin policy.")
+	policyFile         = flag.String("policy-file", "", "Path to a file with an OPA policy, or a directory or .tar.gz OPA bundle with rego modules and data documents.  If empty, uses --policy.")
+	policyReload       = flag.Duration("policy-reload-interval", 30*time.Second, "How often --policy-file is checked for changes which are then loaded without a restart. The policy is also reloaded on SIGHUP. If 0 only SIGHUP reloads.")
+	clientPolicyFlag   = flag.String("client-policy", "", "OPA policy for outbound client actions (i.e. connecting to sansshell servers). If empty no policy is applied.")
+	clientPolicyFile   = flag.String("client-policy-file", "", "Path to a file with a client OPA, or a directory or .tar.gz OPA bundle.  If empty uses --client-policy")
+	hostport           = flag.String("hostport", "localhost:50043", "Where to listen for connections.")
+	credSource         = flag.String("credential-source", mtlsFlags.Name(), fmt.Sprintf("Method used to obtain mTLS creds (one of [%s])", strings.Join(mtls.Loaders(), ",")))
+	verbosity          = flag.Int("v", 0, "Verbosity level. > 0 indicates more extensive logging")
+	validate           = flag.Bool("validate", false, "If true will evaluate the policy and then exit (non-zero on error)")
+	justification      = flag.Bool("justification", false, "If true then justification (which is logged and possibly validated) must be passed along in the client context Metadata with the key '"+rpcauth.ReqJustKey+"'")
+	decisionLog        = flag.String("decision-log", "", "If set every authorization decision is logged as JSON to this file (rotated by size) or to the local syslog if set to \"syslog\".")
+	decisionLogMaxSize = flag.Int64("decision-log-max-size", 100, "Size in megabytes at which the --decision-log file is rotated. 0 disables rotation.")
+	decisionLogBackups = flag.Int("decision-log-max-backups", 5, "How many rotated --decision-log files to keep.")
+	principalFrom      = flag.String("principal-from", "", "How to derive input.peer.principal.id from the peer certificate for policy evaluation. One of cn, spiffe-path or uri:<regexp> (using the first capture group if any). If empty no principal is set.")
+	groupsSource       = flag.String("groups-source", "", "Where to find input.peer.principal.groups for the principal from --principal-from. Either unix (local groups), an http(s) URL returning {\"groups\": [...]} for ?principal=<id>, or a YAML file mapping group names to members.")
+	approvalTTL        = flag.Duration("approval-ttl", 0, "If non-zero requests policy says need approval (requires_approval) are held on the proxy for this long for another principal to approve with the Approval service. If 0 approvals are disabled and requires_approval is ignored.")
+	approvalScope      = flag.String("approval-scope", "target", "What an approval covers. Either target (one request to one target, consumed once used) or fleet (the same request to any target until --approval-ttl passes).")
+	approvalMaxPending = flag.Int("approval-max-pending", approvalserver.DefaultMaxPending, "Most requests held for approval at once. Further ones fail with ResourceExhausted.")
+	approvalMaxPerPeer = flag.Int("approval-max-pending-per-requester", approvalserver.DefaultMaxPendingPerRequester, "Most requests held for approval at once for a single requester.")
 )
 
 func main() {
@@ -68,11 +80,13 @@ func main() {
 	stdr.SetVerbosity(*verbosity)
 
 	policy := util.ChoosePolicy(logger, defaultPolicy, *policyFlag, *policyFile)
+	policyOpts := util.PolicyFileOptions(logger, *policyFile)
 	clientPolicy := util.ChoosePolicy(logger, "", *clientPolicyFlag, *clientPolicyFile)
+	clientPolicyOpts := util.PolicyFileOptions(logger, *clientPolicyFile)
 	ctx := logr.NewContext(context.Background(), logger)
 
 	if *validate {
-		_, err := opa.NewAuthzPolicy(ctx, policy)
+		_, err := opa.NewAuthzPolicy(ctx, policy, policyOpts...)
 		if err != nil {
 			log.Fatalf("Invalid policy: %v\n", err)
 		}
@@ -80,13 +94,30 @@ func main() {
 		os.Exit(0)
 	}
 
+	scope, err := rpcauth.ParseApprovalScope(*approvalScope)
+	if err != nil {
+		logger.Error(err, "--approval-scope")
+		os.Exit(1)
+	}
+
+	decisionLogger := util.ChooseDecisionLogger(logger, *decisionLog, *decisionLogMaxSize, *decisionLogBackups)
+
 	rs := server.RunState{
-		Logger:        logger,
-		Policy:        policy,
-		ClientPolicy:  clientPolicy,
-		CredSource:    *credSource,
-		Hostport:      *hostport,
-		Justification: *justification,
+		Logger:               logger,
+		Policy:               policy,
+		PolicyOptions:        policyOpts,
+		PolicyFile:           *policyFile,
+		PolicyReloadInterval: *policyReload,
+		ClientPolicy:         clientPolicy,
+		ClientPolicyOptions:  clientPolicyOpts,
+		CredSource:           *credSource,
+		Hostport:             *hostport,
+		DecisionLogger:       decisionLogger,
+		ApprovalTTL:          *approvalTTL,
+		ApprovalScope:        scope,
+		ApprovalMaxPending:   *approvalMaxPending,
+		ApprovalPerRequester: *approvalMaxPerPeer,
+		Justification:        *justification,
 	}
-	server.Run(ctx, rs)
+	server.Run(ctx, rs, util.ChoosePrincipalHooks(logger, *principalFrom, *groupsSource)...)
 }
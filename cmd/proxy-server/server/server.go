@@ -24,10 +24,15 @@ import (
 	"context"
 	"net"
 	"os"
+	"time"
 
 	"github.com/Snowflake-Labs/sansshell/auth/mtls"
+	"github.com/Snowflake-Labs/sansshell/auth/opa"
 	"github.com/Snowflake-Labs/sansshell/auth/opa/rpcauth"
+	"github.com/Snowflake-Labs/sansshell/cmd/util"
 	"github.com/Snowflake-Labs/sansshell/proxy/server"
+	"github.com/Snowflake-Labs/sansshell/services"
+	approval "github.com/Snowflake-Labs/sansshell/services/approval/server"
 	ss "github.com/Snowflake-Labs/sansshell/services/sansshell/server"
 	"github.com/Snowflake-Labs/sansshell/telemetry"
 	"github.com/go-logr/logr"
@@ -42,12 +47,38 @@ type RunState struct {
 	Logger logr.Logger
 	// Policy is an OPA policy for determining authz decisions.
 	Policy string
+	// PolicyOptions are used along with Policy when compiling it, i.e. to
+	// add modules and data from an OPA bundle.
+	PolicyOptions []opa.Option
+	// PolicyFile if set is the file (or OPA bundle) Policy and PolicyOptions
+	// were read from. It's watched and the policy is reloaded when it changes
+	// or on SIGHUP.
+	PolicyFile string
+	// PolicyReloadInterval is how often PolicyFile is checked for changes. If
+	// zero it's only reloaded on SIGHUP.
+	PolicyReloadInterval time.Duration
 	// ClientPolicy is an optional OPA policy for determining outbound decisions.
 	ClientPolicy string
+	// ClientPolicyOptions are used along with ClientPolicy like PolicyOptions.
+	ClientPolicyOptions []opa.Option
 	// CredSource is a registered credential source with the mtls package.
 	CredSource string
 	// Hostport is the host:port to run the server.
 	Hostport string
+	// DecisionLogger if set records every authorization decision.
+	DecisionLogger rpcauth.DecisionLogger
+	// ApprovalTTL if non-zero enables multi-party approval. Requests policy
+	// says need approval are held for this long for other principals to
+	// approve via the Approval service.
+	ApprovalTTL time.Duration
+	// ApprovalScope controls whether an approval covers one request to one
+	// target or the same request to any target.
+	ApprovalScope rpcauth.ApprovalScope
+	// ApprovalMaxPending and ApprovalPerRequester limit how many
+	// requests are held for approval in total and for each requester. If
+	// zero the approval package defaults are used.
+	ApprovalMaxPending   int
+	ApprovalPerRequester int
 	// Justification if true requires justification to be set in the
 	// incoming RPC context Metadata (to the key defined in the telemetry package).
 	Justification bool
@@ -88,17 +119,23 @@ func Run(ctx context.Context, rs RunState, hooks ...rpcauth.RPCAuthzHook) {
 
 	h := []rpcauth.RPCAuthzHook{addressHook, justificationHook}
 	h = append(h, hooks...)
-	authz, err := rpcauth.NewWithPolicy(ctx, rs.Policy, h...)
+	policy, err := opa.NewAuthzPolicy(ctx, rs.Policy, rs.PolicyOptions...)
 	if err != nil {
-		rs.Logger.Error(err, "rpcauth.NewWithPolicy")
+		rs.Logger.Error(err, "opa.NewAuthzPolicy")
 		os.Exit(1)
 	}
+	authz := rpcauth.New(policy, h...)
+	if rs.DecisionLogger != nil {
+		authz.SetDecisionLogger(rs.DecisionLogger)
+	}
 
 	var clientAuthz *rpcauth.Authorizer
-	if rs.ClientPolicy != "" {
-		clientAuthz, err = rpcauth.NewWithPolicy(ctx, rs.ClientPolicy)
+	if rs.ClientPolicy != "" || len(rs.ClientPolicyOptions) > 0 {
+		clientPolicy, err := opa.NewAuthzPolicy(ctx, rs.ClientPolicy, rs.ClientPolicyOptions...)
 		if err != nil {
-			rs.Logger.Error(err, "client rpcauth.NewWithPolicy")
+			rs.Logger.Error(err, "client opa.NewAuthzPolicy")
+		} else {
+			clientAuthz = rpcauth.New(clientPolicy)
 		}
 	}
 
@@ -129,6 +166,22 @@ func Run(ctx context.Context, rs RunState, hooks ...rpcauth.RPCAuthzHook) {
 
 	server.Register(g)
 	reflection.Register(g)
+	services.SetPolicyHash(authz.PolicyHash())
+	rs.Logger.Info("loaded policy", "sha256", services.PolicyHash())
+	if rs.PolicyFile != "" {
+		go util.WatchPolicyFile(ctx, rs.Logger, rs.PolicyFile, rs.PolicyReloadInterval, func(ctx context.Context, policy string, opts ...opa.Option) error {
+			if err := authz.Reload(ctx, policy, opts...); err != nil {
+				return err
+			}
+			services.SetPolicyHash(authz.PolicyHash())
+			return nil
+		})
+	}
+	if rs.ApprovalTTL > 0 {
+		store := approval.NewStore(rs.ApprovalTTL, rs.ApprovalMaxPending, rs.ApprovalPerRequester)
+		authz.SetApprovalStore(store, rs.ApprovalScope)
+		approval.NewServer(store).Register(g)
+	}
 	// Create a an instance of logging for the proxy server itself.
 	s := &ss.Server{}
 	s.Register(g)
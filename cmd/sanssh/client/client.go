@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/Snowflake-Labs/sansshell/auth/mtls"
+	"github.com/Snowflake-Labs/sansshell/auth/opa"
 	"github.com/Snowflake-Labs/sansshell/auth/opa/rpcauth"
 	"github.com/Snowflake-Labs/sansshell/proxy/proxy"
 	"github.com/google/subcommands"
@@ -63,6 +64,9 @@ type RunState struct {
 	Timeout time.Duration
 	// ClientPolicy is an optional OPA policy for determining outbound decisions.
 	ClientPolicy string
+	// ClientPolicyOptions are used along with ClientPolicy when compiling it,
+	// i.e. to add modules and data from an OPA bundle.
+	ClientPolicyOptions []opa.Option
 }
 
 const (
@@ -141,10 +145,12 @@ func Run(ctx context.Context, rs RunState) {
 	}
 
 	var clientAuthz *rpcauth.Authorizer
-	if rs.ClientPolicy != "" {
-		clientAuthz, err = rpcauth.NewWithPolicy(ctx, rs.ClientPolicy)
+	if rs.ClientPolicy != "" || len(rs.ClientPolicyOptions) > 0 {
+		policy, err := opa.NewAuthzPolicy(ctx, rs.ClientPolicy, rs.ClientPolicyOptions...)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Could not load policy: %v\n", err)
+		} else {
+			clientAuthz = rpcauth.New(policy)
 		}
 	}
 
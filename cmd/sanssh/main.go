@@ -40,6 +40,7 @@ import (
 
 	// Import services here to make them accessible for CLI
 	_ "github.com/Snowflake-Labs/sansshell/services/ansible/client"
+	_ "github.com/Snowflake-Labs/sansshell/services/approval/client"
 	_ "github.com/Snowflake-Labs/sansshell/services/exec/client"
 	_ "github.com/Snowflake-Labs/sansshell/services/healthcheck/client"
 	_ "github.com/Snowflake-Labs/sansshell/services/localfile/client"
@@ -69,7 +70,7 @@ If port is blank the default of %d will be used`, proxyEnv, defaultProxyPort))
 	justification    = flag.String("justification", "", "If non-empty will add the key '"+rpcauth.ReqJustKey+"' to the outgoing context Metadata to be passed along to the server for possible validation and logging.")
 	targetsFile      = flag.String("targets-file", "", "If set read the targets list line by line (as host[:port]) from the indicated file instead of using --targets (error if both flags are used). A blank port acts the same as --targets")
 	clientPolicyFlag = flag.String("client-policy", "", "OPA policy for outbound client actions.  If empty no policy is applied.")
-	clientPolicyFile = flag.String("client-policy-file", "", "Path to a file with a client OPA.  If empty uses --client-policy")
+	clientPolicyFile = flag.String("client-policy-file", "", "Path to a file with a client OPA, or a directory or .tar.gz OPA bundle.  If empty uses --client-policy")
 	verbosity        = flag.Int("v", 0, "Verbosity level. > 0 indicates more extensive logging")
 
 	// targets will be bound to --targets for sending a single request to N nodes.
@@ -144,15 +145,17 @@ func main() {
 	logOpts := log.Ldate | log.Ltime | log.Lshortfile
 	logger := stdr.New(log.New(os.Stderr, "", logOpts)).WithName("sanssh")
 	stdr.SetVerbosity(*verbosity)
+	clientPolicyOpts := cmdUtil.PolicyFileOptions(logger, *clientPolicyFile)
 
 	rs := client.RunState{
-		Proxy:        *proxyAddr,
-		Targets:      *targetsFlag.Target,
-		Outputs:      *outputsFlag.Target,
-		OutputsDir:   *outputsDir,
-		CredSource:   *credSource,
-		Timeout:      *timeout,
-		ClientPolicy: clientPolicy,
+		Proxy:               *proxyAddr,
+		Targets:             *targetsFlag.Target,
+		Outputs:             *outputsFlag.Target,
+		OutputsDir:          *outputsDir,
+		CredSource:          *credSource,
+		Timeout:             *timeout,
+		ClientPolicy:        clientPolicy,
+		ClientPolicyOptions: clientPolicyOpts,
 	}
 	ctx := logr.NewContext(context.Background(), logger)
 
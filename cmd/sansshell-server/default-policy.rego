@@ -6,6 +6,23 @@ allow {
 	input.method = "/HealthCheck.HealthCheck/Ok"
 }
 
+allow {
+	input.method = "/HealthCheck.HealthCheck/Detailed"
+}
+
+allow {
+	input.method = "/Sansshell.Admin/Info"
+}
+
+# Allow the standard gRPC health protocol used by load balancers and orchestrators
+allow {
+	input.method = "/grpc.health.v1.Health/Check"
+}
+
+allow {
+	input.method = "/grpc.health.v1.Health/Watch"
+}
+
 # Allow people to run reflection against the server
 allow {
 	input.method = "/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo"
@@ -40,6 +57,10 @@ allow {
 	input.type = "Process.GetStacksRequest"
 }
 
+allow {
+	input.type = "Process.MonitorRequest"
+}
+
 allow {
 	input.type = "Packages.ListInstalledRequest"
 }
@@ -48,6 +69,26 @@ allow {
 	input.type = "Packages.RepoListRequest"
 }
 
+allow {
+	input.type = "Packages.SearchRequest"
+}
+
+allow {
+	input.type = "Packages.InfoRequest"
+}
+
+allow {
+	input.type = "Packages.FilesRequest"
+}
+
+allow {
+	input.type = "Packages.VerifyRequest"
+}
+
+allow {
+	input.type = "Packages.HistoryRequest"
+}
+
 allow {
 	input.type = "Process.GetJavaStacksRequest"
 }
@@ -59,3 +100,27 @@ allow {
 allow {
 	input.type = "Service.StatusRequest"
 }
+
+# Journal output often contains secrets and user data so reading service
+# logs isn't allowed by default. To opt in allow specific units for a
+# group, e.g.:
+#
+# allow {
+#  input.type = "Service.LogsRequest"
+#  input.message.service_name = ["sshd.service", "crond.service"][_]
+#  some i
+#  input.peer.principal.groups[i] = "oncall"
+# }
+
+allow {
+	input.type = "Service.ListTimersRequest"
+}
+
+# Transient timers run arbitrary commands so (as with Exec) only allow
+# specific commands to be scheduled.
+allow {
+	input.type = "Service.CreateTransientTimerRequest"
+	input.message.command = "/bin/echo"
+	input.message.args = ["hello", "world"]
+	not input.message.user
+}
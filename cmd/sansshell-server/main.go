@@ -28,6 +28,7 @@ import (
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/stdr"
@@ -54,13 +55,19 @@ var (
 	//go:embed default-policy.rego
 	defaultPolicy string
 
-	policyFlag    = flag.String("policy", defaultPolicy, "Local OPA policy governing access.  If empty, use builtin policy.")
-	policyFile    = flag.String("policy-file", "", "Path to a file with an OPA policy.  If empty, uses --policy.")
-	hostport      = flag.String("hostport", "localhost:50042", "Where to listen for connections.")
-	credSource    = flag.String("credential-source", mtlsFlags.Name(), fmt.Sprintf("Method used to obtain mTLS credentials (one of [%s])", strings.Join(mtls.Loaders(), ",")))
-	verbosity     = flag.Int("v", 0, "Verbosity level. > 0 indicates more extensive logging")
-	validate      = flag.Bool("validate", false, "If true will evaluate the policy and then exit (non-zero on error)")
-	justification = flag.Bool("justification", false, "If true then justification (which is logged and possibly validated) must be passed along in the client context Metadata with the key '"+rpcauth.ReqJustKey+"'")
+	policyFlag         = flag.String("policy", defaultPolicy, "Local OPA policy governing access.  If empty, use builtin policy.")
+	policyFile         = flag.String("policy-file", "", "Path to a file with an OPA policy, or a directory or .tar.gz OPA bundle with rego modules and data documents.  If empty, uses --policy.")
+	policyReload       = flag.Duration("policy-reload-interval", 30*time.Second, "How often --policy-file is checked for changes which are then loaded without a restart. The policy is also reloaded on SIGHUP. If 0 only SIGHUP reloads.")
+	hostport           = flag.String("hostport", "localhost:50042", "Where to listen for connections.")
+	credSource         = flag.String("credential-source", mtlsFlags.Name(), fmt.Sprintf("Method used to obtain mTLS credentials (one of [%s])", strings.Join(mtls.Loaders(), ",")))
+	verbosity          = flag.Int("v", 0, "Verbosity level. > 0 indicates more extensive logging")
+	validate           = flag.Bool("validate", false, "If true will evaluate the policy and then exit (non-zero on error)")
+	justification      = flag.Bool("justification", false, "If true then justification (which is logged and possibly validated) must be passed along in the client context Metadata with the key '"+rpcauth.ReqJustKey+"'")
+	decisionLog        = flag.String("decision-log", "", "If set every authorization decision is logged as JSON to this file (rotated by size) or to the local syslog if set to \"syslog\".")
+	decisionLogMaxSize = flag.Int64("decision-log-max-size", 100, "Size in megabytes at which the --decision-log file is rotated. 0 disables rotation.")
+	decisionLogBackups = flag.Int("decision-log-max-backups", 5, "How many rotated --decision-log files to keep.")
+	principalFrom      = flag.String("principal-from", "", "How to derive input.peer.principal.id from the peer certificate for policy evaluation. One of cn, spiffe-path or uri:<regexp> (using the first capture group if any). If empty no principal is set.")
+	groupsSource       = flag.String("groups-source", "", "Where to find input.peer.principal.groups for the principal from --principal-from. Either unix (local groups), an http(s) URL returning {\"groups\": [...]} for ?principal=<id>, or a YAML file mapping group names to members.")
 )
 
 func main() {
@@ -71,10 +78,11 @@ func main() {
 	stdr.SetVerbosity(*verbosity)
 
 	policy := util.ChoosePolicy(logger, defaultPolicy, *policyFlag, *policyFile)
+	policyOpts := util.PolicyFileOptions(logger, *policyFile)
 	ctx := logr.NewContext(context.Background(), logger)
 
 	if *validate {
-		_, err := opa.NewAuthzPolicy(ctx, policy)
+		_, err := opa.NewAuthzPolicy(ctx, policy, policyOpts...)
 		if err != nil {
 			log.Fatalf("Invalid policy: %v\n", err)
 		}
@@ -82,12 +90,18 @@ func main() {
 		os.Exit(0)
 	}
 
+	decisionLogger := util.ChooseDecisionLogger(logger, *decisionLog, *decisionLogMaxSize, *decisionLogBackups)
+
 	rs := server.RunState{
-		Logger:        logger,
-		CredSource:    *credSource,
-		Hostport:      *hostport,
-		Policy:        policy,
-		Justification: *justification,
+		Logger:               logger,
+		CredSource:           *credSource,
+		Hostport:             *hostport,
+		Policy:               policy,
+		PolicyOptions:        policyOpts,
+		PolicyFile:           *policyFile,
+		PolicyReloadInterval: *policyReload,
+		DecisionLogger:       decisionLogger,
+		Justification:        *justification,
 	}
-	server.Run(ctx, rs)
+	server.Run(ctx, rs, util.ChoosePrincipalHooks(logger, *principalFrom, *groupsSource)...)
 }
@@ -22,9 +22,12 @@ package server
 import (
 	"context"
 	"os"
+	"time"
 
 	"github.com/Snowflake-Labs/sansshell/auth/mtls"
+	"github.com/Snowflake-Labs/sansshell/auth/opa"
 	"github.com/Snowflake-Labs/sansshell/auth/opa/rpcauth"
+	"github.com/Snowflake-Labs/sansshell/cmd/util"
 	"github.com/Snowflake-Labs/sansshell/server"
 	"github.com/go-logr/logr"
 )
@@ -38,6 +41,18 @@ type RunState struct {
 	Hostport string
 	// Policy is an OPA policy for determining authz decisions.
 	Policy string
+	// PolicyOptions are used along with Policy when compiling it, i.e. to
+	// add modules and data from an OPA bundle.
+	PolicyOptions []opa.Option
+	// PolicyFile if set is the file (or OPA bundle) Policy and PolicyOptions
+	// were read from. It's watched and the policy is reloaded when it changes
+	// or on SIGHUP.
+	PolicyFile string
+	// PolicyReloadInterval is how often PolicyFile is checked for changes. If
+	// zero it's only reloaded on SIGHUP.
+	PolicyReloadInterval time.Duration
+	// DecisionLogger if set records every authorization decision.
+	DecisionLogger rpcauth.DecisionLogger
 	// Justification if true requires justification to be set in the
 	// incoming RPC context Metadata (to the key defined in the telemetry package).
 	Justification bool
@@ -47,9 +62,9 @@ type RunState struct {
 	JustificationFunc func(string) error
 }
 
-// Run takes the given context and RunState and starts up a sansshell server.
+// Run takes the given context and RunState along with any authz hooks and starts up a sansshell server.
 // As this is intended to be called from main() it doesn't return errors and will instead exit on any errors.
-func Run(ctx context.Context, rs RunState) {
+func Run(ctx context.Context, rs RunState, hooks ...rpcauth.RPCAuthzHook) {
 	creds, err := mtls.LoadServerCredentials(ctx, rs.CredSource)
 	if err != nil {
 		rs.Logger.Error(err, "mtls.LoadServerCredentials", "credsource", rs.CredSource)
@@ -59,8 +74,14 @@ func Run(ctx context.Context, rs RunState) {
 	justificationHook := rpcauth.HookIf(rpcauth.JustificationHook(rs.JustificationFunc), func(input *rpcauth.RPCAuthInput) bool {
 		return rs.Justification
 	})
-	if err := server.Serve(rs.Hostport, creds, rs.Policy, rs.Logger, justificationHook); err != nil {
-		rs.Logger.Error(err, "server.Serve", "hostport", rs.Hostport)
+	if rs.DecisionLogger != nil {
+		server.SetDecisionLogger(rs.DecisionLogger)
+	}
+	if rs.PolicyFile != "" {
+		go util.WatchPolicyFile(ctx, rs.Logger, rs.PolicyFile, rs.PolicyReloadInterval, server.ReloadPolicy)
+	}
+	if err := server.ServeWithPolicyOptions(rs.Hostport, creds, rs.Policy, rs.PolicyOptions, rs.Logger, append([]rpcauth.RPCAuthzHook{justificationHook}, hooks...)...); err != nil {
+		rs.Logger.Error(err, "server.ServeWithPolicyOptions", "hostport", rs.Hostport)
 		os.Exit(1)
 	}
 }
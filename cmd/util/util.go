@@ -18,10 +18,23 @@
 package util
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"io/fs"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/go-logr/logr"
+
+	"github.com/Snowflake-Labs/sansshell/auth/opa"
+	"github.com/Snowflake-Labs/sansshell/auth/opa/rpcauth"
 )
 
 // ChoosePolicy selects an OPA policy based on the flags, or calls log.Fatal if
@@ -33,7 +46,10 @@ func ChoosePolicy(logger logr.Logger, defaultPolicy string, policyFlag string, p
 	}
 
 	var policy string
-	if policyFile != "" {
+	if policyFile != "" && IsPolicyBundle(policyFile) {
+		// Everything comes from PolicyFileOptions instead.
+		logger.Info("using policy bundle from --policy-file", "file", policyFile)
+	} else if policyFile != "" {
 		pff, err := os.ReadFile(policyFile)
 		if err != nil {
 			logger.Error(err, "os.ReadFile(policyFile)", "file", policyFile)
@@ -51,3 +67,193 @@ func ChoosePolicy(logger logr.Logger, defaultPolicy string, policyFlag string, p
 	}
 	return policy
 }
+
+// IsPolicyBundle returns true if path names a directory or gzipped tarball
+// which should be loaded as an OPA bundle (with opa.LoadBundle) rather
+// than as a single rego file.
+func IsPolicyBundle(path string) bool {
+	if strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz") {
+		return true
+	}
+	fi, err := os.Stat(path)
+	return err == nil && fi.IsDir()
+}
+
+// PolicyFileOptions returns the options to pass along with the policy from
+// ChoosePolicy. If policyFile is an OPA bundle (see IsPolicyBundle) these
+// add all of its modules and data, otherwise there are none. It calls
+// os.Exit if the bundle can't be loaded.
+func PolicyFileOptions(logger logr.Logger, policyFile string) []opa.Option {
+	if policyFile == "" || !IsPolicyBundle(policyFile) {
+		return nil
+	}
+	opts, err := opa.LoadBundle(policyFile)
+	if err != nil {
+		logger.Error(err, "opa.LoadBundle(policyFile)", "file", policyFile)
+		os.Exit(1)
+	}
+	return opts
+}
+
+// ChooseDecisionLogger returns the rpcauth.DecisionLogger named by dest (from
+// a --decision-log flag). This is "syslog" to send decisions to the local
+// syslog daemon, any other value is a file path written to by an
+// rpcauth.FileDecisionLogger rotated at maxSizeMB megabytes and keeping
+// maxBackups old files. If dest is empty decisions aren't logged and nil is
+// returned. It calls os.Exit if the logger can't be created.
+func ChooseDecisionLogger(logger logr.Logger, dest string, maxSizeMB int64, maxBackups int) rpcauth.DecisionLogger {
+	var l rpcauth.DecisionLogger
+	var err error
+	switch dest {
+	case "":
+		return nil
+	case "syslog":
+		l, err = rpcauth.NewSyslogDecisionLogger("sansshell-authz")
+	default:
+		l, err = rpcauth.NewFileDecisionLogger(dest, maxSizeMB*1024*1024, maxBackups)
+	}
+	if err != nil {
+		logger.Error(err, "can't create decision logger", "decision-log", dest)
+		os.Exit(1)
+	}
+	logger.Info("logging authorization decisions", "decision-log", dest)
+	return l
+}
+
+// ChoosePrincipalHooks returns the hooks which populate the peer principal
+// as described by --principal-from (see rpcauth.ParsePrincipalFunc) and
+// --groups-source (see rpcauth.ParseGroupSource) flags. If principalFrom is
+// empty no hooks are returned. It calls os.Exit on invalid flags.
+func ChoosePrincipalHooks(logger logr.Logger, principalFrom string, groupsSource string) []rpcauth.RPCAuthzHook {
+	if principalFrom == "" {
+		if groupsSource != "" {
+			logger.Error(errors.New("invalid principal flags"), "--groups-source requires --principal-from")
+			os.Exit(1)
+		}
+		return nil
+	}
+	principal, err := rpcauth.ParsePrincipalFunc(principalFrom)
+	if err != nil {
+		logger.Error(err, "rpcauth.ParsePrincipalFunc", "principal-from", principalFrom)
+		os.Exit(1)
+	}
+	var groups rpcauth.GroupSource
+	if groupsSource != "" {
+		groups, err = rpcauth.ParseGroupSource(groupsSource)
+		if err != nil {
+			logger.Error(err, "rpcauth.ParseGroupSource", "groups-source", groupsSource)
+			os.Exit(1)
+		}
+	}
+	logger.Info("deriving peer principals from certificates", "principal-from", principalFrom, "groups-source", groupsSource)
+	return []rpcauth.RPCAuthzHook{rpcauth.PrincipalHook(principal, groups)}
+}
+
+// ReloadFunc is called by WatchPolicyFile to replace the policy in effect
+// with policy and opts, which together are the same as ChoosePolicy and
+// PolicyFileOptions return for the file.
+type ReloadFunc func(ctx context.Context, policy string, opts ...opa.Option) error
+
+// WatchPolicyFile reloads the OPA policy in file (a single rego file or an OPA
+// bundle) whenever its contents change or the process receives SIGHUP. The
+// file is checked every interval (or only on SIGHUP if interval is 0) and
+// reload is called with each new policy. If reload returns an error it's
+// logged and the caller is expected to keep the previous policy. This blocks
+// until ctx is done so should be run in its own goroutine.
+func WatchPolicyFile(ctx context.Context, logger logr.Logger, file string, interval time.Duration, reload ReloadFunc) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	watchPolicyFile(ctx, logger, file, interval, hup, reload)
+}
+
+// contentHash returns the hex encoded SHA256 of the contents of path. For a
+// directory this covers the names and contents of every file under it.
+func contentHash(path string) (string, error) {
+	h := sha256.New()
+	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		if p != path {
+			rel, err := filepath.Rel(path, p)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(h, "%s\x00%d\x00", rel, len(b))
+		}
+		h.Write(b)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// loadPolicyFile reads the policy in file the same way ChoosePolicy and
+// PolicyFileOptions do.
+func loadPolicyFile(file string) (string, []opa.Option, error) {
+	if IsPolicyBundle(file) {
+		opts, err := opa.LoadBundle(file)
+		return "", opts, err
+	}
+	policy, err := os.ReadFile(file)
+	return string(policy), nil, err
+}
+
+// watchPolicyFile implements WatchPolicyFile with the signal channel passed in for testing.
+func watchPolicyFile(ctx context.Context, logger logr.Logger, file string, interval time.Duration, hup <-chan os.Signal, reload ReloadFunc) {
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	// The hash of the last contents a reload was attempted with so a bad
+	// policy is only reported once rather than on every check. This starts
+	// as whatever is there now which is what the caller already loaded.
+	last, err := contentHash(file)
+	if err != nil {
+		logger.Error(err, "can't read policy file", "file", file)
+	}
+	for {
+		force := false
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			logger.Info("SIGHUP received, reloading policy", "file", file)
+			force = true
+		case <-tick:
+		}
+
+		hash, err := contentHash(file)
+		if err != nil {
+			logger.Error(err, "can't read policy file, keeping previous policy", "file", file)
+			continue
+		}
+		if hash == last && !force {
+			continue
+		}
+		last = hash
+		policy, opts, err := loadPolicyFile(file)
+		if err != nil {
+			logger.Error(err, "can't load policy file, keeping previous policy", "file", file, "sha256", hash)
+			continue
+		}
+		if err := reload(ctx, policy, opts...); err != nil {
+			logger.Error(err, "policy reload failed, keeping previous policy", "file", file, "sha256", hash)
+			continue
+		}
+		logger.Info("reloaded policy", "file", file, "sha256", hash)
+	}
+}
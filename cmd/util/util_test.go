@@ -0,0 +1,147 @@
+/* Copyright (c) 2019 Snowflake Inc. All rights reserved.
+
+   Licensed under the Apache License, Version 2.0 (the
+   "License"); you may not use this file except in compliance
+   with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing,
+   software distributed under the License is distributed on an
+   "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+   KIND, either express or implied.  See the License for the
+   specific language governing permissions and limitations
+   under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/Snowflake-Labs/sansshell/auth/opa"
+	"github.com/Snowflake-Labs/sansshell/testing/testutil"
+)
+
+func TestWatchPolicyFile(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	file := filepath.Join(t.TempDir(), "policy.rego")
+	testutil.FatalOnErr("write policy", os.WriteFile(file, []byte("initial"), 0644), t)
+
+	reloads := make(chan string, 10)
+	reload := func(ctx context.Context, policy string, opts ...opa.Option) error {
+		reloads <- policy
+		if policy == "bad" {
+			return errors.New("bad policy")
+		}
+		return nil
+	}
+	hup := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	go func() {
+		watchPolicyFile(ctx, logr.Discard(), file, 10*time.Millisecond, hup, reload)
+		close(done)
+	}()
+
+	expect := func(want string) {
+		t.Helper()
+		select {
+		case got := <-reloads:
+			if got != want {
+				t.Fatalf("reloaded with %q want %q", got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no reload with %q", want)
+		}
+	}
+	expectNone := func() {
+		t.Helper()
+		select {
+		case got := <-reloads:
+			t.Fatalf("unexpected reload with %q", got)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	// Nothing has changed yet.
+	expectNone()
+
+	testutil.FatalOnErr("write policy", os.WriteFile(file, []byte("bad"), 0644), t)
+	expect("bad")
+	// A failed reload isn't retried until the file changes again.
+	expectNone()
+
+	testutil.FatalOnErr("write policy", os.WriteFile(file, []byte("good"), 0644), t)
+	expect("good")
+
+	// SIGHUP always reloads.
+	hup <- syscall.SIGHUP
+	expect("good")
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("watcher didn't exit when the context was cancelled")
+	}
+}
+
+func TestWatchPolicyBundle(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	write := func(name, contents string) {
+		t.Helper()
+		testutil.FatalOnErr("write "+name, os.WriteFile(filepath.Join(dir, name), []byte(contents), 0644), t)
+	}
+	write("policy.rego", "package sansshell.authz\n\nallow {\n  input.method = data.allowed\n}\n")
+	write("data.json", `{"allowed": "/Foo/Bar"}`)
+	if !IsPolicyBundle(dir) {
+		t.Fatalf("%s isn't treated as a bundle", dir)
+	}
+
+	hashes := make(chan string, 10)
+	reload := func(ctx context.Context, policy string, opts ...opa.Option) error {
+		if policy != "" {
+			t.Errorf("got policy %q for a bundle, want empty", policy)
+		}
+		p, err := opa.NewAuthzPolicy(ctx, policy, opts...)
+		if err != nil {
+			return err
+		}
+		allowed, err := p.Eval(ctx, map[string]string{"method": "/Foo/Baz"})
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return errors.New("data.json change not picked up")
+		}
+		hashes <- p.Hash()
+		return nil
+	}
+	go watchPolicyFile(ctx, logr.Discard(), dir, 10*time.Millisecond, make(chan os.Signal), reload)
+
+	// Give the watcher time to hash the initial contents.
+	time.Sleep(100 * time.Millisecond)
+	// Changing only the data is enough to reload.
+	write("data.json", `{"allowed": "/Foo/Baz"}`)
+	select {
+	case h := <-hashes:
+		if h == "" {
+			t.Fatal("empty policy hash")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("bundle wasn't reloaded")
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"io"
 	"log"
 
+	spb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -295,9 +296,6 @@ func (p *proxyStream) RecvMsg(m interface{}) error {
 			*manyRet = append(*manyRet, p.ids[id])
 		}
 	case cl != nil:
-		code := codes.Code(cl.GetStatus().GetCode())
-		msg := cl.GetStatus().GetMessage()
-
 		// Do a one time check all the returned ids are ones we know.
 		for _, id := range cl.StreamIds {
 			if _, ok := p.ids[id]; !ok {
@@ -310,7 +308,7 @@ func (p *proxyStream) RecvMsg(m interface{}) error {
 
 		// A normal close actually returns this as an error so map it so clients know the stream closed.
 		closedErr := io.EOF
-		streamStatus := status.New(code, msg)
+		streamStatus := convertStatus(cl.GetStatus())
 
 		if streamStatus.Code() != codes.OK {
 			closedErr = streamStatus.Err()
@@ -327,6 +325,16 @@ func (p *proxyStream) RecvMsg(m interface{}) error {
 	return nil
 }
 
+// convertStatus turns a Status from the proxy back into a grpc status
+// keeping any details the target attached.
+func convertStatus(s *proxypb.Status) *status.Status {
+	return status.FromProto(&spb.Status{
+		Code:    s.GetCode(),
+		Message: s.GetMessage(),
+		Details: s.GetDetails(),
+	})
+}
+
 // createStreams is a helper which does the heavy lifting of creating N tracked streams to the proxy
 // for later RPCs to flow across. It returns a proxy stream object (for clients), and a map of stream ids to prefilled ProxyRet
 // objects. If any of the targets had an error connecting these will be collected and returned as a slice. This way later calls
@@ -491,7 +499,7 @@ func (p *Conn) InvokeOneMany(ctx context.Context, method string, args interface{
 							break processing
 						}
 
-						s.ids[id].Error = status.New(code, msg).Err()
+						s.ids[id].Error = convertStatus(cl.GetStatus()).Err()
 						retChan <- s.ids[id]
 					}
 				}
@@ -26,6 +26,7 @@ import (
 	"net"
 	"testing"
 
+	"github.com/Snowflake-Labs/sansshell/auth/opa/rpcauth"
 	proxypb "github.com/Snowflake-Labs/sansshell/proxy"
 	"github.com/Snowflake-Labs/sansshell/proxy/proxy"
 	"github.com/Snowflake-Labs/sansshell/proxy/server"
@@ -216,6 +217,42 @@ func TestUnary(t *testing.T) {
 	}
 }
 
+func TestDenialHints(t *testing.T) {
+	ctx := context.Background()
+	authz := testutil.NewRPCAuthorizer(ctx, t, `
+package sansshell.authz
+
+denial_hints["nothing is allowed here"] {
+  true
+}
+`)
+	lis := bufconn.Listen(testutil.BufSize)
+	rpcServer := grpc.NewServer(grpc.UnaryInterceptor(authz.Authorize))
+	tdpb.RegisterTestServiceServer(rpcServer, &testutil.EchoTestDataServer{})
+	go func() {
+		rpcServer.Serve(lis)
+	}()
+	t.Cleanup(rpcServer.Stop)
+
+	testServerMap := map[string]*bufconn.Listener{"foo:123": lis}
+	bufMap := startTestProxy(ctx, t, testServerMap)
+	bufMap["foo:123"] = lis
+	want := []string{"nothing is allowed here"}
+
+	for _, p := range []string{"proxy", ""} {
+		conn, err := proxy.Dial(p, []string{"foo:123"}, testutil.WithBufDialer(bufMap), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		tu.FatalOnErr("Dial", err, t)
+		ts := tdpb.NewTestServiceClientProxy(conn)
+		resp, err := ts.TestUnaryOneMany(ctx, &tdpb.TestRequest{Input: "input"})
+		tu.FatalOnErr("TestUnaryOneMany", err, t)
+		for r := range resp {
+			tu.FatalOnNoErr(fmt.Sprintf("proxy %q target %s", p, r.Target), r.Error, t)
+			tu.DiffErr(fmt.Sprintf("proxy %q hints", p), rpcauth.DenialHints(r.Error), want, t)
+		}
+		tu.FatalOnErr("conn Close()", conn.Close(), t)
+	}
+}
+
 func TestStreaming(t *testing.T) {
 	ctx := context.Background()
 	testServerMap := testutil.StartTestDataServers(t, "foo:123", "bar:123")
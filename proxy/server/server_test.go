@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/grpc"
@@ -36,6 +37,7 @@ import (
 	pb "github.com/Snowflake-Labs/sansshell/proxy"
 	tdpb "github.com/Snowflake-Labs/sansshell/proxy/testdata"
 	"github.com/Snowflake-Labs/sansshell/proxy/testutil"
+	approvalserver "github.com/Snowflake-Labs/sansshell/services/approval/server"
 	tu "github.com/Snowflake-Labs/sansshell/testing/testutil"
 )
 
@@ -643,3 +645,92 @@ allow {
 		}
 	}
 }
+
+func TestProxyServerApprovalPartialFailure(t *testing.T) {
+	ctx := context.Background()
+	// policy which needs an approval for every TestUnary
+	policy := `
+package sansshell.authz
+
+default allow = false
+
+allow {
+  input.method = "/Proxy.Proxy/Proxy"
+}
+
+allow {
+  input.method = "/Testdata.TestService/TestUnary"
+}
+
+requires_approval {
+  input.method = "/Testdata.TestService/TestUnary"
+  not approved
+}
+
+approved {
+  count(input.approvers) > 0
+}
+`
+	alice := rpcauth.RPCAuthzHookFunc(func(ctx context.Context, input *rpcauth.RPCAuthInput) error {
+		if input.Peer != nil {
+			input.Peer.Principal = &rpcauth.PrincipalAuthInput{ID: "alice"}
+		}
+		return nil
+	})
+	authz, err := rpcauth.NewWithPolicy(ctx, policy, alice)
+	tu.FatalOnErr("NewWithPolicy", err, t)
+	store := approvalserver.NewStore(time.Hour, 0, 0)
+	authz.SetApprovalStore(store, rpcauth.ApprovalPerTarget)
+	testServerMap := testutil.StartTestDataServers(t, "foo:123", "bar:456")
+	proxyStream := startTestProxyWithAuthz(ctx, t, testServerMap, authz)
+	req := &tdpb.TestRequest{Input: "rm -rf"}
+
+	// The request to foo is held and then approved.
+	fooid := testutil.MustStartStream(t, proxyStream, "foo:123", "/Testdata.TestService/TestUnary")
+	reply := testutil.Exchange(t, proxyStream, testutil.PackStreamData(t, req, fooid))
+	if sc := reply.GetServerClose(); sc.GetStatus().GetCode() != int32(codes.FailedPrecondition) {
+		t.Fatalf("Proxy reply was %v, want ServerClose with FailedPrecondition", reply)
+	}
+	pending := store.List()
+	if len(pending) != 1 {
+		t.Fatalf("pending requests are %v, want one for foo", pending)
+	}
+	hash := pending[0].Hash
+	_, err = store.Approve(hash, &rpcauth.PrincipalAuthInput{ID: "bob"})
+	tu.FatalOnErr("Approve", err, t)
+
+	// Sending to foo and bar together fails as bar isn't approved, which
+	// leaves the approval for foo unused.
+	fooid = testutil.MustStartStream(t, proxyStream, "foo:123", "/Testdata.TestService/TestUnary")
+	barid := testutil.MustStartStream(t, proxyStream, "bar:456", "/Testdata.TestService/TestUnary")
+	replies := []*pb.ProxyReply{
+		testutil.Exchange(t, proxyStream, testutil.PackStreamData(t, req, fooid, barid)),
+		testutil.Exchange(t, proxyStream, nil),
+	}
+	for _, reply := range replies {
+		sc := reply.GetServerClose()
+		if sc == nil || len(sc.StreamIds) != 1 {
+			t.Fatalf("Proxy reply was %v, want ServerClose with single streamID", reply)
+		}
+		want := codes.Aborted
+		if sc.StreamIds[0] == barid {
+			want = codes.FailedPrecondition
+		}
+		if got := codes.Code(sc.GetStatus().GetCode()); got != want {
+			t.Errorf("Status for stream %d was %v, want %v", sc.StreamIds[0], sc.GetStatus(), want)
+		}
+	}
+	if got := store.Approvers(ctx, hash); len(got) != 1 {
+		t.Fatalf("approvers for foo after abort are %v, want bob", got)
+	}
+
+	// Sending to foo alone uses the approval.
+	fooid = testutil.MustStartStream(t, proxyStream, "foo:123", "/Testdata.TestService/TestUnary")
+	reply = testutil.Exchange(t, proxyStream, testutil.PackStreamData(t, req, fooid))
+	if ids, _ := testutil.UnpackStreamData(t, reply); len(ids) != 1 || ids[0] != fooid {
+		t.Fatalf("Proxy reply was %v, want StreamData for stream %d", reply, fooid)
+	}
+	if got := store.Approvers(ctx, hash); got != nil {
+		t.Fatalf("approvers for foo after use are %v, want none", got)
+	}
+}
@@ -438,10 +438,16 @@ func (t *TargetStreamSet) ClientCancel(req *pb.ClientCancel) error {
 // while other streams in the same request which would otherwise have been
 // permitted will be closed with status Aborted. Any other open TargetStreams
 // which are not specified in the request are unaffected.
+// Per target approvals are only consumed once every stream has passed
+// authorization, so aborted streams keep theirs. If one was used by a
+// concurrent request in the meantime only that stream is closed (and its
+// request held for approval again).
 func (t *TargetStreamSet) Send(ctx context.Context, req *pb.StreamData) error {
 	// The set of streams which are permitted to receive the request, after
-	// authorization checks of all streams have completed.
+	// authorization checks of all streams have completed, along with the
+	// approvals they need.
 	var queued []*TargetStream
+	var approvals []*rpcauth.Approval
 
 	streamReq, err := req.Payload.UnmarshalNew()
 	if err != nil {
@@ -469,12 +475,14 @@ func (t *TargetStreamSet) Send(ctx context.Context, req *pb.StreamData) error {
 		}
 
 		// If authz fails, close immediately with an error
-		if err := t.authorizer.Eval(ctx, authinput); err != nil {
+		approval, err := t.authorizer.EvalDeferConsume(ctx, authinput)
+		if err != nil {
 			stream.CloseWith(err)
 			continue
 		}
 		// Otherwise, enqueue this request pending the completion of authz checks
 		queued = append(queued, stream)
+		approvals = append(approvals, approval)
 	}
 
 	// if at least one of the authz checks failed, we abort all other streams specified
@@ -488,7 +496,11 @@ func (t *TargetStreamSet) Send(ctx context.Context, req *pb.StreamData) error {
 	}
 
 	// All authz checks succeeded, send to all streams
-	for _, stream := range queued {
+	for i, stream := range queued {
+		if err := approvals[i].Consume(ctx); err != nil {
+			stream.CloseWith(err)
+			continue
+		}
 		reqClone := proto.Clone(streamReq)
 		// TargetStream send only enqueues the message to the stream, and only fails
 		// if the stream is being torn down, and is unable to accept it.
@@ -19,6 +19,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"sync"
@@ -28,6 +29,7 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 
+	"github.com/Snowflake-Labs/sansshell/auth/opa"
 	"github.com/Snowflake-Labs/sansshell/auth/opa/rpcauth"
 	"github.com/Snowflake-Labs/sansshell/services"
 	"github.com/Snowflake-Labs/sansshell/telemetry"
@@ -36,12 +38,34 @@ import (
 var (
 	// Provide as a var so tests can cancel the server.
 	srv *grpc.Server
-	mu  sync.Mutex
+	// The authorizer for srv which ReloadPolicy updates.
+	authz *rpcauth.Authorizer
+	// Passed to authz once it's built.
+	decisionLogger rpcauth.DecisionLogger
+	mu             sync.Mutex
 )
 
+// SetDecisionLogger records every authorization decision made by the server
+// started with Serve with l. It should be called before Serve so no
+// decisions are missed.
+func SetDecisionLogger(l rpcauth.DecisionLogger) {
+	mu.Lock()
+	defer mu.Unlock()
+	decisionLogger = l
+	if authz != nil {
+		authz.SetDecisionLogger(l)
+	}
+}
+
 // Serve wraps up BuildServer in a succinct API for callers passing along various parameters. It will automatically add
 // an authz hook for HostNet based on the listener address. Additional hooks are passed along after this one.
 func Serve(hostport string, c credentials.TransportCredentials, policy string, logger logr.Logger, authzHooks ...rpcauth.RPCAuthzHook) error {
+	return ServeWithPolicyOptions(hostport, c, policy, nil, logger, authzHooks...)
+}
+
+// ServeWithPolicyOptions is like Serve but compiles the policy with policyOpts,
+// i.e. to add the modules and data from an OPA bundle loaded with opa.LoadBundle.
+func ServeWithPolicyOptions(hostport string, c credentials.TransportCredentials, policy string, policyOpts []opa.Option, logger logr.Logger, authzHooks ...rpcauth.RPCAuthzHook) error {
 	lis, err := net.Listen("tcp", hostport)
 	if err != nil {
 		return fmt.Errorf("failed to listen: %v", err)
@@ -51,7 +75,10 @@ func Serve(hostport string, c credentials.TransportCredentials, policy string, l
 	h := []rpcauth.RPCAuthzHook{rpcauth.HostNetHook(lis.Addr())}
 	h = append(h, authzHooks...)
 
-	srv, err = BuildServer(c, policy, logger, h...)
+	srv, authz, err = buildServer(c, policy, policyOpts, logger, h...)
+	if err == nil && decisionLogger != nil {
+		authz.SetDecisionLogger(decisionLogger)
+	}
 	mu.Unlock()
 	if err != nil {
 		return err
@@ -71,10 +98,16 @@ func getSrv() *grpc.Server {
 // registers all of the imported SansShell modules. Separating this from Serve
 // primarily facilitates testing.
 func BuildServer(c credentials.TransportCredentials, policy string, logger logr.Logger, authzHooks ...rpcauth.RPCAuthzHook) (*grpc.Server, error) {
-	authz, err := rpcauth.NewWithPolicy(context.Background(), policy, authzHooks...)
+	s, _, err := buildServer(c, policy, nil, logger, authzHooks...)
+	return s, err
+}
+
+func buildServer(c credentials.TransportCredentials, policy string, policyOpts []opa.Option, logger logr.Logger, authzHooks ...rpcauth.RPCAuthzHook) (*grpc.Server, *rpcauth.Authorizer, error) {
+	p, err := opa.NewAuthzPolicy(context.Background(), policy, policyOpts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	authz := rpcauth.New(p, authzHooks...)
 	opts := []grpc.ServerOption{
 		grpc.Creds(c),
 		// NB: the order of chained interceptors is meaningful.
@@ -84,9 +117,28 @@ func BuildServer(c credentials.TransportCredentials, policy string, logger logr.
 	}
 	s := grpc.NewServer(opts...)
 	reflection.Register(s)
+	services.SetPolicyHash(authz.PolicyHash())
+	logger.Info("loaded policy", "sha256", services.PolicyHash())
 
 	for _, sansShellService := range services.ListServices() {
 		sansShellService.Register(s)
 	}
-	return s, nil
+	return s, authz, nil
+}
+
+// ReloadPolicy replaces the OPA policy of the server started by Serve with
+// policy and any modules or data in opts. If the policy doesn't compile an
+// error is returned and the current policy remains in effect.
+func ReloadPolicy(ctx context.Context, policy string, opts ...opa.Option) error {
+	mu.Lock()
+	a := authz
+	mu.Unlock()
+	if a == nil {
+		return errors.New("server isn't running")
+	}
+	if err := a.Reload(ctx, policy, opts...); err != nil {
+		return err
+	}
+	services.SetPolicyHash(a.PolicyHash())
+	return nil
 }
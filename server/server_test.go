@@ -19,6 +19,8 @@ package server
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
 	"log"
 	"net"
@@ -33,6 +35,7 @@ import (
 	"google.golang.org/grpc/test/bufconn"
 
 	"github.com/Snowflake-Labs/sansshell/auth/opa/rpcauth"
+	"github.com/Snowflake-Labs/sansshell/services"
 	_ "github.com/Snowflake-Labs/sansshell/services/healthcheck/server"
 	lfpb "github.com/Snowflake-Labs/sansshell/services/localfile"
 	_ "github.com/Snowflake-Labs/sansshell/services/localfile/server"
@@ -180,3 +183,47 @@ func TestRead(t *testing.T) {
 		})
 	}
 }
+
+func TestReloadPolicy(t *testing.T) {
+	ctx := context.Background()
+	mu.Lock()
+	saved := authz
+	authz = nil
+	mu.Unlock()
+	t.Cleanup(func() {
+		mu.Lock()
+		authz = saved
+		mu.Unlock()
+	})
+
+	testutil.FatalOnNoErr("reload without a server", ReloadPolicy(ctx, policy), t)
+
+	_, a, err := buildServer(nil, policy, nil, logr.Discard())
+	testutil.FatalOnErr("buildServer", err, t)
+	mu.Lock()
+	authz = a
+	mu.Unlock()
+
+	input := &rpcauth.RPCAuthInput{Method: "/HealthCheck.HealthCheck/Ok"}
+	testutil.FatalOnNoErr("before reload", a.Eval(ctx, input), t)
+
+	before := services.PolicyHash()
+	testutil.FatalOnNoErr("reload invalid policy", ReloadPolicy(ctx, "package sansshell.authz\nallow {"), t)
+	if got := services.PolicyHash(); got != before {
+		t.Fatalf("policy hash changed after a failed reload. Got %s want %s", got, before)
+	}
+
+	newPolicy := `
+package sansshell.authz
+
+allow {
+    input.method = "/HealthCheck.HealthCheck/Ok"
+}
+`
+	testutil.FatalOnErr("reload", ReloadPolicy(ctx, newPolicy), t)
+	testutil.FatalOnErr("after reload", a.Eval(ctx, input), t)
+	sum := sha256.Sum256([]byte(newPolicy))
+	if got, want := services.PolicyHash(), hex.EncodeToString(sum[:]); got != want {
+		t.Fatalf("policy hash not updated. Got %s want %s", got, want)
+	}
+}
@@ -24,6 +24,7 @@ package ansible
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -35,6 +36,64 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type TaskStatus int32
+
+const (
+	TaskStatus_TASK_STATUS_UNKNOWN     TaskStatus = 0
+	TaskStatus_TASK_STATUS_OK          TaskStatus = 1
+	TaskStatus_TASK_STATUS_CHANGED     TaskStatus = 2
+	TaskStatus_TASK_STATUS_FAILED      TaskStatus = 3
+	TaskStatus_TASK_STATUS_SKIPPED     TaskStatus = 4
+	TaskStatus_TASK_STATUS_UNREACHABLE TaskStatus = 5
+)
+
+// Enum value maps for TaskStatus.
+var (
+	TaskStatus_name = map[int32]string{
+		0: "TASK_STATUS_UNKNOWN",
+		1: "TASK_STATUS_OK",
+		2: "TASK_STATUS_CHANGED",
+		3: "TASK_STATUS_FAILED",
+		4: "TASK_STATUS_SKIPPED",
+		5: "TASK_STATUS_UNREACHABLE",
+	}
+	TaskStatus_value = map[string]int32{
+		"TASK_STATUS_UNKNOWN":     0,
+		"TASK_STATUS_OK":          1,
+		"TASK_STATUS_CHANGED":     2,
+		"TASK_STATUS_FAILED":      3,
+		"TASK_STATUS_SKIPPED":     4,
+		"TASK_STATUS_UNREACHABLE": 5,
+	}
+)
+
+func (x TaskStatus) Enum() *TaskStatus {
+	p := new(TaskStatus)
+	*p = x
+	return p
+}
+
+func (x TaskStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_ansible_proto_enumTypes[0].Descriptor()
+}
+
+func (TaskStatus) Type() protoreflect.EnumType {
+	return &file_ansible_proto_enumTypes[0]
+}
+
+func (x TaskStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskStatus.Descriptor instead.
+func (TaskStatus) EnumDescriptor() ([]byte, []int) {
+	return file_ansible_proto_rawDescGZIP(), []int{0}
+}
+
 type Var struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -90,14 +149,96 @@ func (x *Var) GetValue() string {
 	return ""
 }
 
+// PlaybookBundle is a gzipped tar of a playbook along with anything it
+// needs (roles, files, etc) which is fetched from a bucket and unpacked
+// into a temporary directory before running.
+type PlaybookBundle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The source bucket to copy from. See implementations for details on
+	// schemes but will at a minimum support file://<path> for local copies.
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// The key to reference inside of the bucket.
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// The hex encoded SHA256 of the bundle. Required and checked before the
+	// bundle is unpacked.
+	Sha256 string `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	// The path of the playbook to run relative to the root of the bundle.
+	Playbook string `protobuf:"bytes,4,opt,name=playbook,proto3" json:"playbook,omitempty"`
+}
+
+func (x *PlaybookBundle) Reset() {
+	*x = PlaybookBundle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ansible_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlaybookBundle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaybookBundle) ProtoMessage() {}
+
+func (x *PlaybookBundle) ProtoReflect() protoreflect.Message {
+	mi := &file_ansible_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaybookBundle.ProtoReflect.Descriptor instead.
+func (*PlaybookBundle) Descriptor() ([]byte, []int) {
+	return file_ansible_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PlaybookBundle) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *PlaybookBundle) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *PlaybookBundle) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *PlaybookBundle) GetPlaybook() string {
+	if x != nil {
+		return x.Playbook
+	}
+	return ""
+}
+
 type RunRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	// The playbook to execute. Needs to be a fully qualified path.
+	// Must be empty if bundle is set.
 	Playbook string `protobuf:"bytes,1,opt,name=playbook,proto3" json:"playbook,omitempty"`
-	// Will become N -e options to ansible-playbook
+	// Will become N -e options to ansible-playbook. Keys and values are
+	// restricted to [a-zA-Z0-9_/]. Use extra_vars_json for anything else.
 	Vars []*Var `protobuf:"bytes,2,rep,name=vars,proto3" json:"vars,omitempty"`
 	// The user to use for exection.
 	User string `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
@@ -108,12 +249,25 @@ type RunRequest struct {
 	Diff bool `protobuf:"varint,5,opt,name=diff,proto3" json:"diff,omitempty"`
 	// If true, execute ansible with verbose output enabled (equivilant to -vvv)
 	Verbose bool `protobuf:"varint,6,opt,name=verbose,proto3" json:"verbose,omitempty"`
+	// Only run plays and tasks tagged with these values (--tags).
+	Tags []string `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Only run plays and tasks not tagged with these values (--skip-tags).
+	SkipTags []string `protobuf:"bytes,8,rep,name=skip_tags,json=skipTags,proto3" json:"skip_tags,omitempty"`
+	// Start the playbook at the task with this name (--start-at-task).
+	StartAtTask string `protobuf:"bytes,9,opt,name=start_at_task,json=startAtTask,proto3" json:"start_at_task,omitempty"`
+	// A JSON object of extra vars. It's written to a temporary file which is
+	// passed as -e @file so values may be of any type. Keys starting with
+	// ansible_ and strings containing Jinja templates ({{ or {%) are rejected.
+	ExtraVarsJson string `protobuf:"bytes,10,opt,name=extra_vars_json,json=extraVarsJson,proto3" json:"extra_vars_json,omitempty"`
+	// If set the playbook is fetched from a bucket rather than read from
+	// local disk.
+	Bundle *PlaybookBundle `protobuf:"bytes,11,opt,name=bundle,proto3" json:"bundle,omitempty"`
 }
 
 func (x *RunRequest) Reset() {
 	*x = RunRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ansible_proto_msgTypes[1]
+		mi := &file_ansible_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -126,7 +280,7 @@ func (x *RunRequest) String() string {
 func (*RunRequest) ProtoMessage() {}
 
 func (x *RunRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ansible_proto_msgTypes[1]
+	mi := &file_ansible_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -139,7 +293,7 @@ func (x *RunRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunRequest.ProtoReflect.Descriptor instead.
 func (*RunRequest) Descriptor() ([]byte, []int) {
-	return file_ansible_proto_rawDescGZIP(), []int{1}
+	return file_ansible_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *RunRequest) GetPlaybook() string {
@@ -184,6 +338,41 @@ func (x *RunRequest) GetVerbose() bool {
 	return false
 }
 
+func (x *RunRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *RunRequest) GetSkipTags() []string {
+	if x != nil {
+		return x.SkipTags
+	}
+	return nil
+}
+
+func (x *RunRequest) GetStartAtTask() string {
+	if x != nil {
+		return x.StartAtTask
+	}
+	return ""
+}
+
+func (x *RunRequest) GetExtraVarsJson() string {
+	if x != nil {
+		return x.ExtraVarsJson
+	}
+	return ""
+}
+
+func (x *RunRequest) GetBundle() *PlaybookBundle {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
 type RunReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -202,7 +391,7 @@ type RunReply struct {
 func (x *RunReply) Reset() {
 	*x = RunReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ansible_proto_msgTypes[2]
+		mi := &file_ansible_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -215,7 +404,7 @@ func (x *RunReply) String() string {
 func (*RunReply) ProtoMessage() {}
 
 func (x *RunReply) ProtoReflect() protoreflect.Message {
-	mi := &file_ansible_proto_msgTypes[2]
+	mi := &file_ansible_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -228,7 +417,7 @@ func (x *RunReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunReply.ProtoReflect.Descriptor instead.
 func (*RunReply) Descriptor() ([]byte, []int) {
-	return file_ansible_proto_rawDescGZIP(), []int{2}
+	return file_ansible_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *RunReply) GetStdout() string {
@@ -252,14 +441,364 @@ func (x *RunReply) GetReturnCode() int32 {
 	return 0
 }
 
+// TaskEvent is the result of running a single task on a single host.
+type TaskEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The play the task is part of.
+	Play     string               `protobuf:"bytes,1,opt,name=play,proto3" json:"play,omitempty"`
+	Task     string               `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	Host     string               `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
+	Status   TaskStatus           `protobuf:"varint,4,opt,name=status,proto3,enum=Ansible.TaskStatus" json:"status,omitempty"`
+	Duration *durationpb.Duration `protobuf:"bytes,5,opt,name=duration,proto3" json:"duration,omitempty"`
+	// The result returned by the task's module as a JSON object.
+	ResultJson string `protobuf:"bytes,6,opt,name=result_json,json=resultJson,proto3" json:"result_json,omitempty"`
+}
+
+func (x *TaskEvent) Reset() {
+	*x = TaskEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ansible_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskEvent) ProtoMessage() {}
+
+func (x *TaskEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_ansible_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskEvent.ProtoReflect.Descriptor instead.
+func (*TaskEvent) Descriptor() ([]byte, []int) {
+	return file_ansible_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TaskEvent) GetPlay() string {
+	if x != nil {
+		return x.Play
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetStatus() TaskStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TaskStatus_TASK_STATUS_UNKNOWN
+}
+
+func (x *TaskEvent) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *TaskEvent) GetResultJson() string {
+	if x != nil {
+		return x.ResultJson
+	}
+	return ""
+}
+
+// HostStats are the totals ansible reports for each host at the end of
+// the playbook.
+type HostStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Host        string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Ok          int32  `protobuf:"varint,2,opt,name=ok,proto3" json:"ok,omitempty"`
+	Changed     int32  `protobuf:"varint,3,opt,name=changed,proto3" json:"changed,omitempty"`
+	Failed      int32  `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	Skipped     int32  `protobuf:"varint,5,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Unreachable int32  `protobuf:"varint,6,opt,name=unreachable,proto3" json:"unreachable,omitempty"`
+	Rescued     int32  `protobuf:"varint,7,opt,name=rescued,proto3" json:"rescued,omitempty"`
+	Ignored     int32  `protobuf:"varint,8,opt,name=ignored,proto3" json:"ignored,omitempty"`
+}
+
+func (x *HostStats) Reset() {
+	*x = HostStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ansible_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HostStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostStats) ProtoMessage() {}
+
+func (x *HostStats) ProtoReflect() protoreflect.Message {
+	mi := &file_ansible_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostStats.ProtoReflect.Descriptor instead.
+func (*HostStats) Descriptor() ([]byte, []int) {
+	return file_ansible_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *HostStats) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *HostStats) GetOk() int32 {
+	if x != nil {
+		return x.Ok
+	}
+	return 0
+}
+
+func (x *HostStats) GetChanged() int32 {
+	if x != nil {
+		return x.Changed
+	}
+	return 0
+}
+
+func (x *HostStats) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *HostStats) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *HostStats) GetUnreachable() int32 {
+	if x != nil {
+		return x.Unreachable
+	}
+	return 0
+}
+
+func (x *HostStats) GetRescued() int32 {
+	if x != nil {
+		return x.Rescued
+	}
+	return 0
+}
+
+func (x *HostStats) GetIgnored() int32 {
+	if x != nil {
+		return x.Ignored
+	}
+	return 0
+}
+
+type RunSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The return code from the ansible command.
+	ReturnCode int32 `protobuf:"varint,1,opt,name=return_code,json=returnCode,proto3" json:"return_code,omitempty"`
+	// All output sent to stderr.
+	Stderr string       `protobuf:"bytes,2,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	Stats  []*HostStats `protobuf:"bytes,3,rep,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (x *RunSummary) Reset() {
+	*x = RunSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ansible_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunSummary) ProtoMessage() {}
+
+func (x *RunSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_ansible_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunSummary.ProtoReflect.Descriptor instead.
+func (*RunSummary) Descriptor() ([]byte, []int) {
+	return file_ansible_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RunSummary) GetReturnCode() int32 {
+	if x != nil {
+		return x.ReturnCode
+	}
+	return 0
+}
+
+func (x *RunSummary) GetStderr() string {
+	if x != nil {
+		return x.Stderr
+	}
+	return ""
+}
+
+func (x *RunSummary) GetStats() []*HostStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type RunStreamReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Reply:
+	//	*RunStreamReply_Task
+	//	*RunStreamReply_Summary
+	Reply isRunStreamReply_Reply `protobuf_oneof:"reply"`
+}
+
+func (x *RunStreamReply) Reset() {
+	*x = RunStreamReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ansible_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunStreamReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunStreamReply) ProtoMessage() {}
+
+func (x *RunStreamReply) ProtoReflect() protoreflect.Message {
+	mi := &file_ansible_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunStreamReply.ProtoReflect.Descriptor instead.
+func (*RunStreamReply) Descriptor() ([]byte, []int) {
+	return file_ansible_proto_rawDescGZIP(), []int{7}
+}
+
+func (m *RunStreamReply) GetReply() isRunStreamReply_Reply {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+func (x *RunStreamReply) GetTask() *TaskEvent {
+	if x, ok := x.GetReply().(*RunStreamReply_Task); ok {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *RunStreamReply) GetSummary() *RunSummary {
+	if x, ok := x.GetReply().(*RunStreamReply_Summary); ok {
+		return x.Summary
+	}
+	return nil
+}
+
+type isRunStreamReply_Reply interface {
+	isRunStreamReply_Reply()
+}
+
+type RunStreamReply_Task struct {
+	Task *TaskEvent `protobuf:"bytes,1,opt,name=task,proto3,oneof"`
+}
+
+type RunStreamReply_Summary struct {
+	// Always the last reply on the stream.
+	Summary *RunSummary `protobuf:"bytes,2,opt,name=summary,proto3,oneof"`
+}
+
+func (*RunStreamReply_Task) isRunStreamReply_Reply() {}
+
+func (*RunStreamReply_Summary) isRunStreamReply_Reply() {}
+
 var File_ansible_proto protoreflect.FileDescriptor
 
 var file_ansible_proto_rawDesc = []byte{
 	0x0a, 0x0d, 0x61, 0x6e, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x07, 0x41, 0x6e, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x22, 0x2d, 0x0a, 0x03, 0x56, 0x61, 0x72, 0x12,
+	0x07, 0x41, 0x6e, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2d, 0x0a, 0x03, 0x56, 0x61, 0x72, 0x12,
 	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
 	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xa2, 0x01, 0x0a, 0x0a, 0x52, 0x75, 0x6e, 0x52,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x6e, 0x0a, 0x0e, 0x50, 0x6c, 0x61, 0x79, 0x62,
+	0x6f, 0x6f, 0x6b, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63,
+	0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x12, 0x1a, 0x0a, 0x08, 0x70,
+	0x6c, 0x61, 0x79, 0x62, 0x6f, 0x6f, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70,
+	0x6c, 0x61, 0x79, 0x62, 0x6f, 0x6f, 0x6b, 0x22, 0xd0, 0x02, 0x0a, 0x0a, 0x52, 0x75, 0x6e, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x62, 0x6f,
 	0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x62, 0x6f,
 	0x6f, 0x6b, 0x12, 0x20, 0x0a, 0x04, 0x76, 0x61, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
@@ -269,21 +808,86 @@ var file_ansible_proto_rawDesc = []byte{
 	0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x12,
 	0x0a, 0x04, 0x64, 0x69, 0x66, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x69,
 	0x66, 0x66, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x65, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x07, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x65, 0x22, 0x5b, 0x0a, 0x08,
-	0x52, 0x75, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f,
-	0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74,
-	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x74, 0x75,
-	0x72, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x72,
-	0x65, 0x74, 0x75, 0x72, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x32, 0x3b, 0x0a, 0x08, 0x50, 0x6c, 0x61,
-	0x79, 0x62, 0x6f, 0x6f, 0x6b, 0x12, 0x2f, 0x0a, 0x03, 0x52, 0x75, 0x6e, 0x12, 0x13, 0x2e, 0x41,
-	0x6e, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x2e, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x11, 0x2e, 0x41, 0x6e, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x2e, 0x52, 0x75, 0x6e, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x2d, 0x4c,
-	0x61, 0x62, 0x73, 0x2f, 0x73, 0x61, 0x6e, 0x73, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2f, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x61, 0x6e, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x61, 0x67, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73,
+	0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x74, 0x61, 0x67, 0x73, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6b, 0x69, 0x70, 0x54, 0x61, 0x67, 0x73, 0x12, 0x22, 0x0a,
+	0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x61, 0x74, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x74, 0x54, 0x61, 0x73,
+	0x6b, 0x12, 0x26, 0x0a, 0x0f, 0x65, 0x78, 0x74, 0x72, 0x61, 0x5f, 0x76, 0x61, 0x72, 0x73, 0x5f,
+	0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x78, 0x74, 0x72,
+	0x61, 0x56, 0x61, 0x72, 0x73, 0x4a, 0x73, 0x6f, 0x6e, 0x12, 0x2f, 0x0a, 0x06, 0x62, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x41, 0x6e, 0x73, 0x69,
+	0x62, 0x6c, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x6f, 0x6f, 0x6b, 0x42, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0x5b, 0x0a, 0x08, 0x52, 0x75,
+	0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e,
+	0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x72, 0x65, 0x74,
+	0x75, 0x72, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x22, 0xcc, 0x01, 0x0a, 0x09, 0x54, 0x61, 0x73, 0x6b,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6c, 0x61, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6c, 0x61, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x73,
+	0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x12, 0x12, 0x0a,
+	0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73,
+	0x74, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x13, 0x2e, 0x41, 0x6e, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x2e, 0x54, 0x61, 0x73, 0x6b,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x35,
+	0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f,
+	0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x4a, 0x73, 0x6f, 0x6e, 0x22, 0xd1, 0x01, 0x0a, 0x09, 0x48, 0x6f, 0x73, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6b,
+	0x69, 0x70, 0x70, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x73, 0x6b, 0x69,
+	0x70, 0x70, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61,
+	0x62, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x75, 0x6e, 0x72, 0x65, 0x61,
+	0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x63, 0x75, 0x65,
+	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x72, 0x65, 0x73, 0x63, 0x75, 0x65, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x07, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x64, 0x22, 0x6f, 0x0a, 0x0a, 0x52, 0x75,
+	0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x74, 0x75,
+	0x72, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x72,
+	0x65, 0x74, 0x75, 0x72, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64,
+	0x65, 0x72, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72,
+	0x72, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x41, 0x6e, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x22, 0x74, 0x0a, 0x0e, 0x52,
+	0x75, 0x6e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x28, 0x0a,
+	0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x41, 0x6e,
+	0x73, 0x69, 0x62, 0x6c, 0x65, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48,
+	0x00, 0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x12, 0x2f, 0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61,
+	0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x41, 0x6e, 0x73, 0x69, 0x62,
+	0x6c, 0x65, 0x2e, 0x52, 0x75, 0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x48, 0x00, 0x52,
+	0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c,
+	0x79, 0x2a, 0xa0, 0x01, 0x0a, 0x0a, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x17, 0x0a, 0x13, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f,
+	0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x54, 0x41, 0x53,
+	0x4b, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x4f, 0x4b, 0x10, 0x01, 0x12, 0x17, 0x0a,
+	0x13, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x48, 0x41,
+	0x4e, 0x47, 0x45, 0x44, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x17,
+	0x0a, 0x13, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x53, 0x4b,
+	0x49, 0x50, 0x50, 0x45, 0x44, 0x10, 0x04, 0x12, 0x1b, 0x0a, 0x17, 0x54, 0x41, 0x53, 0x4b, 0x5f,
+	0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x52, 0x45, 0x41, 0x43, 0x48, 0x41, 0x42,
+	0x4c, 0x45, 0x10, 0x05, 0x32, 0x7a, 0x0a, 0x08, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x6f, 0x6f, 0x6b,
+	0x12, 0x2f, 0x0a, 0x03, 0x52, 0x75, 0x6e, 0x12, 0x13, 0x2e, 0x41, 0x6e, 0x73, 0x69, 0x62, 0x6c,
+	0x65, 0x2e, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x41,
+	0x6e, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x2e, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x3d, 0x0a, 0x09, 0x52, 0x75, 0x6e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x13,
+	0x2e, 0x41, 0x6e, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x2e, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x41, 0x6e, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x2e, 0x52, 0x75,
+	0x6e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01,
+	0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x53,
+	0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x73, 0x61,
+	0x6e, 0x73, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73,
+	0x2f, 0x61, 0x6e, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -298,21 +902,37 @@ func file_ansible_proto_rawDescGZIP() []byte {
 	return file_ansible_proto_rawDescData
 }
 
-var file_ansible_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_ansible_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_ansible_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_ansible_proto_goTypes = []interface{}{
-	(*Var)(nil),        // 0: Ansible.Var
-	(*RunRequest)(nil), // 1: Ansible.RunRequest
-	(*RunReply)(nil),   // 2: Ansible.RunReply
+	(TaskStatus)(0),             // 0: Ansible.TaskStatus
+	(*Var)(nil),                 // 1: Ansible.Var
+	(*PlaybookBundle)(nil),      // 2: Ansible.PlaybookBundle
+	(*RunRequest)(nil),          // 3: Ansible.RunRequest
+	(*RunReply)(nil),            // 4: Ansible.RunReply
+	(*TaskEvent)(nil),           // 5: Ansible.TaskEvent
+	(*HostStats)(nil),           // 6: Ansible.HostStats
+	(*RunSummary)(nil),          // 7: Ansible.RunSummary
+	(*RunStreamReply)(nil),      // 8: Ansible.RunStreamReply
+	(*durationpb.Duration)(nil), // 9: google.protobuf.Duration
 }
 var file_ansible_proto_depIdxs = []int32{
-	0, // 0: Ansible.RunRequest.vars:type_name -> Ansible.Var
-	1, // 1: Ansible.Playbook.Run:input_type -> Ansible.RunRequest
-	2, // 2: Ansible.Playbook.Run:output_type -> Ansible.RunReply
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	1, // 0: Ansible.RunRequest.vars:type_name -> Ansible.Var
+	2, // 1: Ansible.RunRequest.bundle:type_name -> Ansible.PlaybookBundle
+	0, // 2: Ansible.TaskEvent.status:type_name -> Ansible.TaskStatus
+	9, // 3: Ansible.TaskEvent.duration:type_name -> google.protobuf.Duration
+	6, // 4: Ansible.RunSummary.stats:type_name -> Ansible.HostStats
+	5, // 5: Ansible.RunStreamReply.task:type_name -> Ansible.TaskEvent
+	7, // 6: Ansible.RunStreamReply.summary:type_name -> Ansible.RunSummary
+	3, // 7: Ansible.Playbook.Run:input_type -> Ansible.RunRequest
+	3, // 8: Ansible.Playbook.RunStream:input_type -> Ansible.RunRequest
+	4, // 9: Ansible.Playbook.Run:output_type -> Ansible.RunReply
+	8, // 10: Ansible.Playbook.RunStream:output_type -> Ansible.RunStreamReply
+	9, // [9:11] is the sub-list for method output_type
+	7, // [7:9] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_ansible_proto_init() }
@@ -334,7 +954,7 @@ func file_ansible_proto_init() {
 			}
 		}
 		file_ansible_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RunRequest); i {
+			switch v := v.(*PlaybookBundle); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -346,6 +966,18 @@ func file_ansible_proto_init() {
 			}
 		}
 		file_ansible_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ansible_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RunReply); i {
 			case 0:
 				return &v.state
@@ -357,19 +989,72 @@ func file_ansible_proto_init() {
 				return nil
 			}
 		}
+		file_ansible_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ansible_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HostStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ansible_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ansible_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunStreamReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_ansible_proto_msgTypes[7].OneofWrappers = []interface{}{
+		(*RunStreamReply_Task)(nil),
+		(*RunStreamReply_Summary)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_ansible_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   3,
+			NumEnums:      1,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_ansible_proto_goTypes,
 		DependencyIndexes: file_ansible_proto_depIdxs,
+		EnumInfos:         file_ansible_proto_enumTypes,
 		MessageInfos:      file_ansible_proto_msgTypes,
 	}.Build()
 	File_ansible_proto = out.File
@@ -20,6 +20,10 @@ const _ = grpc.SupportPackageIsVersion7
 type PlaybookClient interface {
 	// Will run ansible-playbook only on the local host using the args passed.
 	Run(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (*RunReply, error)
+	// RunStream is Run but returns an event as each task completes on each
+	// host followed by a summary once ansible-playbook exits. As with Run a
+	// non-zero return code isn't an RPC failure.
+	RunStream(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (Playbook_RunStreamClient, error)
 }
 
 type playbookClient struct {
@@ -39,12 +43,48 @@ func (c *playbookClient) Run(ctx context.Context, in *RunRequest, opts ...grpc.C
 	return out, nil
 }
 
+func (c *playbookClient) RunStream(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (Playbook_RunStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Playbook_ServiceDesc.Streams[0], "/Ansible.Playbook/RunStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &playbookRunStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Playbook_RunStreamClient interface {
+	Recv() (*RunStreamReply, error)
+	grpc.ClientStream
+}
+
+type playbookRunStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *playbookRunStreamClient) Recv() (*RunStreamReply, error) {
+	m := new(RunStreamReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // PlaybookServer is the server API for Playbook service.
 // All implementations should embed UnimplementedPlaybookServer
 // for forward compatibility
 type PlaybookServer interface {
 	// Will run ansible-playbook only on the local host using the args passed.
 	Run(context.Context, *RunRequest) (*RunReply, error)
+	// RunStream is Run but returns an event as each task completes on each
+	// host followed by a summary once ansible-playbook exits. As with Run a
+	// non-zero return code isn't an RPC failure.
+	RunStream(*RunRequest, Playbook_RunStreamServer) error
 }
 
 // UnimplementedPlaybookServer should be embedded to have forward compatible implementations.
@@ -54,6 +94,9 @@ type UnimplementedPlaybookServer struct {
 func (UnimplementedPlaybookServer) Run(context.Context, *RunRequest) (*RunReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Run not implemented")
 }
+func (UnimplementedPlaybookServer) RunStream(*RunRequest, Playbook_RunStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method RunStream not implemented")
+}
 
 // UnsafePlaybookServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to PlaybookServer will
@@ -84,6 +127,27 @@ func _Playbook_Run_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Playbook_RunStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RunRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PlaybookServer).RunStream(m, &playbookRunStreamServer{stream})
+}
+
+type Playbook_RunStreamServer interface {
+	Send(*RunStreamReply) error
+	grpc.ServerStream
+}
+
+type playbookRunStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *playbookRunStreamServer) Send(m *RunStreamReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Playbook_ServiceDesc is the grpc.ServiceDesc for Playbook service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -96,6 +160,12 @@ var Playbook_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Playbook_Run_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RunStream",
+			Handler:       _Playbook_RunStream_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "ansible.proto",
 }
@@ -13,12 +13,14 @@ import (
 
 import (
 	"fmt"
+	"io"
 )
 
 // PlaybookClientProxy is the superset of PlaybookClient which additionally includes the OneMany proxy methods
 type PlaybookClientProxy interface {
 	PlaybookClient
 	RunOneMany(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (<-chan *RunManyResponse, error)
+	RunStreamOneMany(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (Playbook_RunStreamClientProxy, error)
 }
 
 // Embed the original client inside of this so we get the other generated methods automatically.
@@ -98,3 +100,91 @@ func (c *playbookClientProxy) RunOneMany(ctx context.Context, in *RunRequest, op
 
 	return ret, nil
 }
+
+// RunStreamManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type RunStreamManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *RunStreamReply
+	Error error
+}
+
+type Playbook_RunStreamClientProxy interface {
+	Recv() ([]*RunStreamManyResponse, error)
+	grpc.ClientStream
+}
+
+type playbookClientRunStreamClientProxy struct {
+	cc         *proxy.Conn
+	directDone bool
+	grpc.ClientStream
+}
+
+func (x *playbookClientRunStreamClientProxy) Recv() ([]*RunStreamManyResponse, error) {
+	var ret []*RunStreamManyResponse
+	// If this is a direct connection the RecvMsg call is to a standard grpc.ClientStream
+	// and not our proxy based one. This means we need to receive a typed response and
+	// convert it into a single slice entry return. This ensures the OneMany style calls
+	// can be used by proxy with 1:N targets and non proxy with 1 target without client changes.
+	if x.cc.Direct() {
+		// Check if we're done. Just return EOF now. Any real error was already sent inside
+		// of a ManyResponse.
+		if x.directDone {
+			return nil, io.EOF
+		}
+		m := &RunStreamReply{}
+		err := x.ClientStream.RecvMsg(m)
+		ret = append(ret, &RunStreamManyResponse{
+			Resp:   m,
+			Error:  err,
+			Target: x.cc.Targets[0],
+			Index:  0,
+		})
+		// An error means we're done so set things so a later call now gets an EOF.
+		if err != nil {
+			x.directDone = true
+		}
+		return ret, nil
+	}
+
+	m := []*proxy.Ret{}
+	if err := x.ClientStream.RecvMsg(&m); err != nil {
+		return nil, err
+	}
+	for _, r := range m {
+		typedResp := &RunStreamManyResponse{
+			Resp: &RunStreamReply{},
+		}
+		typedResp.Target = r.Target
+		typedResp.Index = r.Index
+		typedResp.Error = r.Error
+		if r.Error == nil {
+			if err := r.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+				typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, r.Error)
+			}
+		}
+		ret = append(ret, typedResp)
+	}
+	return ret, nil
+}
+
+// RunStreamOneMany provides the same API as RunStream but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *playbookClientProxy) RunStreamOneMany(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (Playbook_RunStreamClientProxy, error) {
+	stream, err := c.cc.NewStream(ctx, &Playbook_ServiceDesc.Streams[0], "/Ansible.Playbook/RunStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &playbookClientRunStreamClientProxy{c.cc.(*proxy.Conn), false, stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
@@ -21,7 +21,10 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/google/subcommands"
 
@@ -65,6 +68,16 @@ type playbookCmd struct {
 	check    bool
 	diff     bool
 	verbose  bool
+	stream   bool
+
+	tags           util.StringSliceFlag
+	skipTags       util.StringSliceFlag
+	startAtTask    string
+	extraVarsJSON  string
+	bundleBucket   string
+	bundleKey      string
+	bundleSHA256   string
+	bundlePlaybook string
 }
 
 func (*playbookCmd) Name() string     { return "playbook" }
@@ -72,6 +85,10 @@ func (*playbookCmd) Synopsis() string { return "Run an ansible playbook on the s
 func (*playbookCmd) Usage() string {
 	return `ansible:
   Run an ansible playbook on the remote server.
+
+  The playbook is either an absolute path on the remote server (--playbook) or
+  a gzipped tar fetched from a bucket (--bundle-bucket and friends).
+  See https://gocloud.dev/howto/blob/ for details on bucket options.
 `
 }
 
@@ -82,13 +99,37 @@ func (a *playbookCmd) SetFlags(f *flag.FlagSet) {
 	f.BoolVar(&a.check, "check", false, "If true the playbook will be run with --check passed as an argument")
 	f.BoolVar(&a.diff, "diff", false, "If true the playbook will be run with --diff passed as an argument")
 	f.BoolVar(&a.verbose, "verbose", false, "If true the playbook wiill be run with -vvv passed as an argument")
+	f.BoolVar(&a.stream, "stream", false, "If true print each task result as it completes followed by a summary table per target")
+	a.tags.Target = &[]string{}
+	f.Var(&a.tags, "tags", "Only run plays and tasks tagged with these values, separated by commas")
+	a.skipTags.Target = &[]string{}
+	f.Var(&a.skipTags, "skip-tags", "Only run plays and tasks not tagged with these values, separated by commas")
+	f.StringVar(&a.startAtTask, "start-at-task", "", "Start the playbook at the task with this name")
+	f.StringVar(&a.extraVarsJSON, "extra-vars-json", "", "A JSON object of extra vars to pass to ansible-playbook. If prefixed with @ the rest is the path of a local file to read it from")
+	f.StringVar(&a.bundleBucket, "bundle-bucket", "", "The bucket to fetch a playbook bundle (gzipped tar) from instead of using --playbook")
+	f.StringVar(&a.bundleKey, "bundle-key", "", "The key of the bundle in --bundle-bucket")
+	f.StringVar(&a.bundleSHA256, "bundle-sha256", "", "The hex encoded SHA256 of the bundle which is verified before it's unpacked")
+	f.StringVar(&a.bundlePlaybook, "bundle-playbook", "site.yml", "The path of the playbook to run relative to the root of the bundle")
 }
 
 func (a *playbookCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
-	if a.playbook == "" {
-		fmt.Fprintln(os.Stderr, "--playbook is required")
+	if (a.playbook == "") == (a.bundleBucket == "") {
+		fmt.Fprintln(os.Stderr, "exactly one of --playbook or --bundle-bucket is required")
 		return subcommands.ExitFailure
 	}
+	if a.bundleBucket != "" && (a.bundleKey == "" || a.bundleSHA256 == "") {
+		fmt.Fprintln(os.Stderr, "--bundle-key and --bundle-sha256 are required with --bundle-bucket")
+		return subcommands.ExitFailure
+	}
+	extraVars := a.extraVarsJSON
+	if strings.HasPrefix(extraVars, "@") {
+		b, err := os.ReadFile(extraVars[1:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "can't read extra vars: %v\n", err)
+			return subcommands.ExitFailure
+		}
+		extraVars = string(b)
+	}
 
 	state := args[0].(*util.ExecuteState)
 
@@ -100,6 +141,19 @@ func (a *playbookCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...inte
 		Check:    a.check,
 		Diff:     a.diff,
 		Verbose:  a.verbose,
+
+		Tags:          *a.tags.Target,
+		SkipTags:      *a.skipTags.Target,
+		StartAtTask:   a.startAtTask,
+		ExtraVarsJson: extraVars,
+	}
+	if a.bundleBucket != "" {
+		req.Bundle = &pb.PlaybookBundle{
+			Bucket:   a.bundleBucket,
+			Key:      a.bundleKey,
+			Sha256:   a.bundleSHA256,
+			Playbook: a.bundlePlaybook,
+		}
 	}
 	for _, kv := range a.vars {
 		req.Vars = append(req.Vars, &pb.Var{
@@ -108,6 +162,10 @@ func (a *playbookCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...inte
 		})
 	}
 
+	if a.stream {
+		return runStream(ctx, state, c, req)
+	}
+
 	resp, err := c.RunOneMany(ctx, req)
 	if err != nil {
 		// Emit this to every error file as it's not specific to a given target.
@@ -129,3 +187,72 @@ func (a *playbookCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...inte
 	}
 	return retCode
 }
+
+// taskStatus returns the status as ansible prints it (i.e. changed).
+func taskStatus(s pb.TaskStatus) string {
+	return strings.ToLower(strings.TrimPrefix(s.String(), "TASK_STATUS_"))
+}
+
+// runStream runs the playbook printing each task as it completes on every
+// target and then a summary table once the target's playbook exits.
+func runStream(ctx context.Context, state *util.ExecuteState, c pb.PlaybookClientProxy, req *pb.RunRequest) subcommands.ExitStatus {
+	stream, err := c.RunStreamOneMany(ctx, req)
+	if err != nil {
+		// Emit this to every error file as it's not specific to a given target.
+		for _, e := range state.Err {
+			fmt.Fprintf(e, "RunStream returned error: %v\n", err)
+		}
+		return subcommands.ExitFailure
+	}
+
+	tasks := make(map[int][]*pb.TaskEvent)
+	retCode := subcommands.ExitSuccess
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		// If the stream returns an error we're just done.
+		if err != nil {
+			// Emit this to every error file as it's not specific to a given target.
+			for _, e := range state.Err {
+				fmt.Fprintf(e, "Receive error: %v\n", err)
+			}
+			retCode = subcommands.ExitFailure
+			break
+		}
+		for _, r := range resp {
+			if r.Error != nil && r.Error != io.EOF {
+				fmt.Fprintf(state.Err[r.Index], "Ansible for target %s (%d) returned error: %v\n", r.Target, r.Index, r.Error)
+				retCode = subcommands.ExitFailure
+				continue
+			}
+			if t := r.Resp.GetTask(); t != nil {
+				tasks[r.Index] = append(tasks[r.Index], t)
+				fmt.Fprintf(state.Out[r.Index], "%s: [%s] %s (%s)\n", taskStatus(t.Status), t.Host, t.Task, t.Duration.AsDuration())
+			}
+			if s := r.Resp.GetSummary(); s != nil {
+				outputSummary(state.Out[r.Index], r.Target, r.Index, tasks[r.Index], s)
+			}
+		}
+	}
+	return retCode
+}
+
+// outputSummary prints a table of every task result followed by the
+// per-host totals, return code and stderr.
+func outputSummary(out io.Writer, target string, index int, tasks []*pb.TaskEvent, s *pb.RunSummary) {
+	fmt.Fprintf(out, "\nTarget: %s (%d)\n\n", target, index)
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "PLAY\tTASK\tHOST\tSTATUS\tDURATION")
+	for _, t := range tasks {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", t.Play, t.Task, t.Host, taskStatus(t.Status), t.Duration.AsDuration())
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "HOST\tOK\tCHANGED\tFAILED\tSKIPPED\tUNREACHABLE\tRESCUED\tIGNORED")
+	for _, h := range s.Stats {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d\n", h.Host, h.Ok, h.Changed, h.Failed, h.Skipped, h.Unreachable, h.Rescued, h.Ignored)
+	}
+	w.Flush()
+	fmt.Fprintf(out, "\nReturn code: %d\nStderr:%s\n", s.ReturnCode, s.Stderr)
+}
@@ -19,10 +19,13 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
 
 	"github.com/Snowflake-Labs/sansshell/services"
 	pb "github.com/Snowflake-Labs/sansshell/services/ansible"
@@ -32,7 +35,10 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-var ansiblePlaybookBin = flag.String("ansible_playbook_bin", "/usr/bin/ansible-playbook", "Path to ansible-playbook binary")
+var (
+	ansiblePlaybookBin    = flag.String("ansible_playbook_bin", "/usr/bin/ansible-playbook", "Path to ansible-playbook binary")
+	ansibleStdoutCallback = flag.String("ansible_stdout_callback", "ansible.posix.jsonl", "The stdout callback plugin used by RunStream. It must emit the same JSON lines format as ansible.posix.jsonl")
+)
 
 // A test hook so we can take the args passed and transform them as needed.
 var cmdArgsTransform = func(input []string) []string {
@@ -44,23 +50,103 @@ type server struct{}
 
 var re = regexp.MustCompile("[^a-zA-Z0-9_/]+")
 
-func (s *server) Run(ctx context.Context, req *pb.RunRequest) (*pb.RunReply, error) {
-	// Basic sanity checking up front.
-	if req.Playbook == "" {
-		return nil, status.Error(codes.InvalidArgument, "playbook path must be filled in")
+// validateTags checks tags can be passed as a comma separated list.
+func validateTags(name string, tags []string) error {
+	for _, t := range tags {
+		if t == "" || strings.Contains(t, ",") {
+			return status.Errorf(codes.InvalidArgument, "%s must be non-empty and not contain commas - %q is invalid", name, t)
+		}
 	}
-	if err := util.ValidPath(req.Playbook); err != nil {
-		return nil, err
+	return nil
+}
+
+// validateExtraVars checks extra vars can't change how ansible runs. They
+// have the highest precedence so ansible_* variables (i.e. the interpreter,
+// connection or become settings) could run arbitrary commands, as could
+// Jinja templates which are evaluated wherever the value is used.
+func validateExtraVars(vars map[string]interface{}) error {
+	for k := range vars {
+		if strings.HasPrefix(strings.ToLower(k), "ansible_") {
+			return status.Errorf(codes.InvalidArgument, "extra_vars_json can't set ansible variables - %q is invalid", k)
+		}
+	}
+	return validateNoTemplates(vars)
+}
+
+// validateNoTemplates checks no string in v, including object keys, contains a
+// Jinja expression or statement.
+func validateNoTemplates(v interface{}) error {
+	switch v := v.(type) {
+	case string:
+		if strings.Contains(v, "{{") || strings.Contains(v, "{%") {
+			return status.Errorf(codes.InvalidArgument, "extra_vars_json can't contain templates - %q is invalid", v)
+		}
+	case []interface{}:
+		for _, e := range v {
+			if err := validateNoTemplates(e); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		for k, e := range v {
+			if err := validateNoTemplates(k); err != nil {
+				return err
+			}
+			if err := validateNoTemplates(e); err != nil {
+				return err
+			}
+		}
 	}
+	return nil
+}
+
+// buildArgs validates req and returns the arguments to pass to ansible-playbook.
+// Any bundle is fetched and unpacked and extra vars are written out to a
+// temporary directory which is removed by calling cleanup once the command
+// has run. cleanup is always non-nil.
+func buildArgs(ctx context.Context, req *pb.RunRequest) (cmdArgs []string, cleanup func(), retErr error) {
+	cleanup = func() {}
+
+	// Basic sanity checking up front.
+	if req.Bundle != nil {
+		if req.Playbook != "" {
+			return nil, cleanup, status.Error(codes.InvalidArgument, "playbook path must be empty when using a bundle")
+		}
+		if err := validateBundle(req.Bundle); err != nil {
+			return nil, cleanup, err
+		}
+	} else {
+		if req.Playbook == "" {
+			return nil, cleanup, status.Error(codes.InvalidArgument, "playbook path must be filled in")
+		}
+		if err := util.ValidPath(req.Playbook); err != nil {
+			return nil, cleanup, err
+		}
 
-	// Make sure it's a valid file and nothing something which might be malicious like
-	// /some/path && rm -rf /
-	stat, err := os.Stat(req.Playbook)
-	if err != nil || stat.IsDir() {
-		return nil, status.Errorf(codes.InvalidArgument, "%s is not a valid file", req.Playbook)
+		// Make sure it's a valid file and nothing something which might be malicious like
+		// /some/path && rm -rf /
+		stat, err := os.Stat(req.Playbook)
+		if err != nil || stat.IsDir() {
+			return nil, cleanup, status.Errorf(codes.InvalidArgument, "%s is not a valid file", req.Playbook)
+		}
+	}
+	if req.ExtraVarsJson != "" {
+		var vars map[string]interface{}
+		if err := json.Unmarshal([]byte(req.ExtraVarsJson), &vars); err != nil {
+			return nil, cleanup, status.Errorf(codes.InvalidArgument, "extra_vars_json must be a JSON object: %v", err)
+		}
+		if err := validateExtraVars(vars); err != nil {
+			return nil, cleanup, err
+		}
+	}
+	if err := validateTags("tags", req.Tags); err != nil {
+		return nil, cleanup, err
+	}
+	if err := validateTags("skip_tags", req.SkipTags); err != nil {
+		return nil, cleanup, err
 	}
 
-	cmdArgs := []string{
+	cmdArgs = []string{
 		"-i",
 		"localhost,",         // Keeps it only to this host
 		"--connection=local", // Make sure it doesn't try and ssh out
@@ -68,7 +154,7 @@ func (s *server) Run(ctx context.Context, req *pb.RunRequest) (*pb.RunReply, err
 
 	for _, v := range req.Vars {
 		if v.Key != re.ReplaceAllString(v.Key, "") || v.Value != re.ReplaceAllString(v.Value, "") {
-			return nil, status.Errorf(codes.InvalidArgument, "vars must contain key/value that is only contains %s - '%s=%s' is invalid", re.String(), v.Key, v.Value)
+			return nil, cleanup, status.Errorf(codes.InvalidArgument, "vars must contain key/value that is only contains %s - '%s=%s' is invalid", re.String(), v.Key, v.Value)
 		}
 		cmdArgs = append(cmdArgs, "-e")
 		cmdArgs = append(cmdArgs, fmt.Sprintf("%s=%s", v.Key, v.Value))
@@ -76,7 +162,7 @@ func (s *server) Run(ctx context.Context, req *pb.RunRequest) (*pb.RunReply, err
 
 	if req.User != "" {
 		if req.User != re.ReplaceAllString(req.User, "") {
-			return nil, status.Errorf(codes.InvalidArgument, "user must only contain %s - %q is invalid", re.String(), req.User)
+			return nil, cleanup, status.Errorf(codes.InvalidArgument, "user must only contain %s - %q is invalid", re.String(), req.User)
 		}
 		cmdArgs = append(cmdArgs, "--become")
 		cmdArgs = append(cmdArgs, req.User)
@@ -94,9 +180,64 @@ func (s *server) Run(ctx context.Context, req *pb.RunRequest) (*pb.RunReply, err
 		cmdArgs = append(cmdArgs, "-vvv")
 	}
 
-	cmdArgs = append(cmdArgs, req.Playbook)
+	// Use the = forms so nothing can be mistaken for another option.
+	if len(req.Tags) > 0 {
+		cmdArgs = append(cmdArgs, "--tags="+strings.Join(req.Tags, ","))
+	}
+
+	if len(req.SkipTags) > 0 {
+		cmdArgs = append(cmdArgs, "--skip-tags="+strings.Join(req.SkipTags, ","))
+	}
+
+	if req.StartAtTask != "" {
+		cmdArgs = append(cmdArgs, "--start-at-task="+req.StartAtTask)
+	}
+
+	playbook := req.Playbook
+	if req.ExtraVarsJson != "" || req.Bundle != nil {
+		dir, err := os.MkdirTemp("", "sansshell-ansible-")
+		if err != nil {
+			return nil, cleanup, status.Errorf(codes.Internal, "can't create temporary directory: %v", err)
+		}
+		cleanup = func() { os.RemoveAll(dir) }
+		defer func() {
+			if retErr != nil {
+				cleanup()
+			}
+		}()
+
+		if req.ExtraVarsJson != "" {
+			vars := filepath.Join(dir, "extra-vars.json")
+			if err := os.WriteFile(vars, []byte(req.ExtraVarsJson), 0600); err != nil {
+				return nil, cleanup, status.Errorf(codes.Internal, "can't write extra vars: %v", err)
+			}
+			cmdArgs = append(cmdArgs, "-e", "@"+vars)
+		}
+
+		if req.Bundle != nil {
+			bundleDir := filepath.Join(dir, "bundle")
+			if err := fetchBundle(ctx, req.Bundle, bundleDir); err != nil {
+				return nil, cleanup, err
+			}
+			playbook = filepath.Join(bundleDir, req.Bundle.Playbook)
+			stat, err := os.Stat(playbook)
+			if err != nil || stat.IsDir() {
+				return nil, cleanup, status.Errorf(codes.InvalidArgument, "%s is not a valid file in bundle %s/%s", req.Bundle.Playbook, req.Bundle.Bucket, req.Bundle.Key)
+			}
+		}
+	}
+
+	cmdArgs = append(cmdArgs, playbook)
+
+	return cmdArgsTransform(cmdArgs), cleanup, nil
+}
 
-	cmdArgs = cmdArgsTransform(cmdArgs)
+func (s *server) Run(ctx context.Context, req *pb.RunRequest) (*pb.RunReply, error) {
+	cmdArgs, cleanup, err := buildArgs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
 
 	run, err := util.RunCommand(ctx, *ansiblePlaybookBin, cmdArgs)
 	if err != nil {
@@ -110,6 +251,37 @@ func (s *server) Run(ctx context.Context, req *pb.RunRequest) (*pb.RunReply, err
 	}, nil
 }
 
+func (s *server) RunStream(req *pb.RunRequest, stream pb.Playbook_RunStreamServer) error {
+	cmdArgs, cleanup, err := buildArgs(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	parser := &eventParser{send: stream.Send}
+	// Events have already been sent so don't buffer more than is needed for errors.
+	run, err := util.RunCommand(stream.Context(), *ansiblePlaybookBin, cmdArgs,
+		util.EnvVar("ANSIBLE_STDOUT_CALLBACK="+*ansibleStdoutCallback),
+		util.StdoutWriter(parser),
+		util.StdoutMax(util.MaxBuf))
+	if err != nil {
+		return err
+	}
+	if err := parser.Flush(); err != nil {
+		return err
+	}
+
+	return stream.Send(&pb.RunStreamReply{
+		Reply: &pb.RunStreamReply_Summary{
+			Summary: &pb.RunSummary{
+				ReturnCode: int32(run.ExitCode),
+				Stderr:     run.Stderr.String(),
+				Stats:      parser.stats,
+			},
+		},
+	})
+}
+
 // Install is called to expose this handler to the gRPC server
 func (s *server) Register(gs *grpc.Server) {
 	pb.RegisterPlaybookServer(gs, s)
@@ -23,19 +23,29 @@ package server
 //       If you want a local integration test use testdata/test.yml with a built client/server to prove the real
 //       binary works as well. i.e. what testing/integrate.sh does.
 import (
+	"archive/tar"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
 	"log"
 	"net"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	pb "github.com/Snowflake-Labs/sansshell/services/ansible"
 	"github.com/Snowflake-Labs/sansshell/testing/testutil"
 	"github.com/google/go-cmp/cmp"
+	_ "gocloud.dev/blob/fileblob"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 var (
@@ -99,6 +109,8 @@ func TestRun(t *testing.T) {
 		args              []string
 		user              string
 		vars              []*pb.Var
+		tags              []string
+		extraVars         string
 		wantErr           bool
 		returnCodeNonZero bool
 		stdout            string
@@ -160,6 +172,55 @@ func TestRun(t *testing.T) {
 			user:    "user && rm -rf /",
 			wantErr: true,
 		},
+		{
+			name:    "Tag with a comma",
+			bin:     testutil.ResolvePath(t, "cat"),
+			path:    path,
+			tags:    []string{"foo,bar"},
+			wantErr: true,
+		},
+		{
+			name:      "Extra vars aren't an object",
+			bin:       testutil.ResolvePath(t, "cat"),
+			path:      path,
+			extraVars: `["foo"]`,
+			wantErr:   true,
+		},
+		{
+			name:      "Extra vars set an ansible variable",
+			bin:       testutil.ResolvePath(t, "cat"),
+			path:      path,
+			extraVars: `{"ansible_python_interpreter": "/tmp/evil"}`,
+			wantErr:   true,
+		},
+		{
+			name:      "Extra vars set become with different case",
+			bin:       testutil.ResolvePath(t, "cat"),
+			path:      path,
+			extraVars: `{"Ansible_Become_Exe": "sh"}`,
+			wantErr:   true,
+		},
+		{
+			name:      "Extra vars with a template expression",
+			bin:       testutil.ResolvePath(t, "cat"),
+			path:      path,
+			extraVars: `{"foo": {"bar": ["ok", "{{ lookup('pipe', 'id') }}"]}}`,
+			wantErr:   true,
+		},
+		{
+			name:      "Extra vars with a template statement",
+			bin:       testutil.ResolvePath(t, "cat"),
+			path:      path,
+			extraVars: `{"foo": "{% raw %}x{% endraw %}"}`,
+			wantErr:   true,
+		},
+		{
+			name:      "Extra vars with a template key",
+			bin:       testutil.ResolvePath(t, "cat"),
+			path:      path,
+			extraVars: `{"foo": {"{{ lookup('pipe', 'id') }}": 1}}`,
+			wantErr:   true,
+		},
 		{
 			name: "Bad Key",
 			bin:  testutil.ResolvePath(t, "cat"),
@@ -191,9 +252,11 @@ func TestRun(t *testing.T) {
 				return tc.args
 			}
 			resp, err := client.Run(ctx, &pb.RunRequest{
-				Playbook: tc.path,
-				User:     tc.user,
-				Vars:     tc.vars,
+				Playbook:      tc.path,
+				User:          tc.user,
+				Vars:          tc.vars,
+				Tags:          tc.tags,
+				ExtraVarsJson: tc.extraVars,
 			})
 			t.Logf("%s: resp: %+v", tc.name, resp)
 			t.Logf("%s: err: %v", tc.name, err)
@@ -278,6 +341,19 @@ func TestRun(t *testing.T) {
 				Verbose: true,
 			},
 		},
+		{
+			name: "tags",
+			wantArgs: append(baseArgs, []string{
+				"--tags=foo,bar",
+				"--skip-tags=baz",
+				"--start-at-task=Some task",
+			}...),
+			req: &pb.RunRequest{
+				Tags:        []string{"foo", "bar"},
+				SkipTags:    []string{"baz"},
+				StartAtTask: "Some task",
+			},
+		},
 	} {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
@@ -301,3 +377,355 @@ func TestRun(t *testing.T) {
 		})
 	}
 }
+
+func TestRunStream(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("Failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+
+	savedAnsiblePlaybookBin := *ansiblePlaybookBin
+	savedCmdArgsTransform := cmdArgsTransform
+	t.Cleanup(func() {
+		*ansiblePlaybookBin = savedAnsiblePlaybookBin
+		cmdArgsTransform = savedCmdArgsTransform
+	})
+
+	client := pb.NewPlaybookClient(conn)
+
+	wd, err := os.Getwd()
+	testutil.FatalOnErr("can't get current working directory", err, t)
+	path := filepath.Join(wd, "testdata", "test.yml")
+	events := filepath.Join(wd, "testdata", "stream.jsonl")
+
+	task := func(name string, s pb.TaskStatus, d time.Duration, result string) *pb.RunStreamReply {
+		return &pb.RunStreamReply{
+			Reply: &pb.RunStreamReply_Task{
+				Task: &pb.TaskEvent{
+					Play:       "localhost",
+					Task:       name,
+					Host:       "localhost",
+					Status:     s,
+					Duration:   durationpb.New(d),
+					ResultJson: result,
+				},
+			},
+		}
+	}
+	summary := func(rc int32, stderr string, stats ...*pb.HostStats) *pb.RunStreamReply {
+		return &pb.RunStreamReply{
+			Reply: &pb.RunStreamReply_Summary{
+				Summary: &pb.RunSummary{
+					ReturnCode: rc,
+					Stderr:     stderr,
+					Stats:      stats,
+				},
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name    string
+		bin     string
+		path    string
+		args    []string
+		wantErr bool
+		want    []*pb.RunStreamReply
+	}{
+		{
+			name: "events",
+			bin:  testutil.ResolvePath(t, "sh"),
+			path: path,
+			args: []string{"-c", "cat " + events + "; echo failed >&2; exit 2"},
+			want: []*pb.RunStreamReply{
+				task("Gathering Facts", pb.TaskStatus_TASK_STATUS_OK, 1500*time.Millisecond,
+					`{"_ansible_no_log": false, "_ansible_verbose_override": true, "action": "gather_facts", "changed": false}`),
+				task("Test", pb.TaskStatus_TASK_STATUS_CHANGED, 250*time.Millisecond,
+					`{"_ansible_no_log": false, "action": "shell", "changed": true, "cmd": "echo foo >&2 ; ls / /tmp", "rc": 0, "stderr": "foo"}`),
+				task("Optional", pb.TaskStatus_TASK_STATUS_SKIPPED, 10*time.Millisecond,
+					`{"action": "shell", "changed": false, "skip_reason": "Conditional result was False"}`),
+				task("Fails", pb.TaskStatus_TASK_STATUS_FAILED, 500*time.Millisecond,
+					`{"action": "command", "changed": true, "msg": "non-zero return code", "rc": 2}`),
+				summary(2, "failed\n", &pb.HostStats{
+					Host:    "localhost",
+					Ok:      2,
+					Changed: 1,
+					Failed:  1,
+					Skipped: 1,
+				}),
+			},
+		},
+		{
+			name: "callback is set",
+			bin:  testutil.ResolvePath(t, "sh"),
+			path: path,
+			args: []string{"-c", "echo $ANSIBLE_STDOUT_CALLBACK >&2"},
+			want: []*pb.RunStreamReply{
+				summary(0, "ansible.posix.jsonl\n"),
+			},
+		},
+		{
+			name:    "Run without a path set",
+			bin:     testutil.ResolvePath(t, "cat"),
+			wantErr: true,
+		},
+		{
+			name:    "A non-absolute bin path",
+			bin:     "something",
+			path:    path,
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			*ansiblePlaybookBin = tc.bin
+			cmdArgsTransform = func(input []string) []string {
+				return tc.args
+			}
+			stream, err := client.RunStream(ctx, &pb.RunRequest{Playbook: tc.path})
+			testutil.FatalOnErr("RunStream", err, t)
+			var got []*pb.RunStreamReply
+			var streamErr error
+			for {
+				resp, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					streamErr = err
+					break
+				}
+				got = append(got, resp)
+			}
+			testutil.WantErr(tc.name, streamErr, tc.wantErr, t)
+			testutil.DiffErr(tc.name, got, tc.want, t)
+		})
+	}
+}
+
+func TestExtraVars(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("Failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+
+	savedAnsiblePlaybookBin := *ansiblePlaybookBin
+	savedCmdArgsTransform := cmdArgsTransform
+	t.Cleanup(func() {
+		*ansiblePlaybookBin = savedAnsiblePlaybookBin
+		cmdArgsTransform = savedCmdArgsTransform
+	})
+	*ansiblePlaybookBin = testutil.ResolvePath(t, "cat")
+
+	client := pb.NewPlaybookClient(conn)
+
+	wd, err := os.Getwd()
+	testutil.FatalOnErr("can't get current working directory", err, t)
+	path := filepath.Join(wd, "testdata", "test.yml")
+
+	extraVars := `{"path": "/tmp && rm -rf /", "list": [1, 2]}`
+	var varsFile string
+	cmdArgsTransform = func(input []string) []string {
+		// -e @file comes just before the playbook.
+		if len(input) < 3 || input[len(input)-3] != "-e" || input[len(input)-2][0] != '@' {
+			t.Errorf("extra vars not passed as -e @file in %q", input)
+			return []string{"/dev/null"}
+		}
+		varsFile = input[len(input)-2][1:]
+		return []string{varsFile}
+	}
+	resp, err := client.Run(ctx, &pb.RunRequest{
+		Playbook:      path,
+		ExtraVarsJson: extraVars,
+	})
+	testutil.FatalOnErr("Run", err, t)
+	if got, want := resp.Stdout, extraVars; got != want {
+		t.Fatalf("extra vars file doesn't match. Want %q Got %q", want, got)
+	}
+	if _, err := os.Stat(varsFile); !os.IsNotExist(err) {
+		t.Fatalf("extra vars file %s wasn't removed: %v", varsFile, err)
+	}
+}
+
+type bundleEntry struct {
+	name     string
+	body     string
+	typeflag byte
+}
+
+// makeBundle writes a gzipped tar of entries to dir/name and returns its sha256.
+func makeBundle(t *testing.T, dir string, name string, entries []bundleEntry) string {
+	t.Helper()
+	f, err := os.Create(filepath.Join(dir, name))
+	testutil.FatalOnErr("create bundle", err, t)
+	defer f.Close()
+	h := sha256.New()
+	gz := gzip.NewWriter(io.MultiWriter(f, h))
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		hdr := &tar.Header{
+			Name:     e.name,
+			Mode:     0644,
+			Size:     int64(len(e.body)),
+			Typeflag: e.typeflag,
+		}
+		switch e.typeflag {
+		case tar.TypeDir:
+			hdr.Mode = 0755
+			hdr.Size = 0
+		case tar.TypeSymlink:
+			hdr.Linkname = e.body
+			hdr.Size = 0
+		}
+		testutil.FatalOnErr("tar header", tw.WriteHeader(hdr), t)
+		if hdr.Size > 0 {
+			_, err := tw.Write([]byte(e.body))
+			testutil.FatalOnErr("tar write", err, t)
+		}
+	}
+	testutil.FatalOnErr("tar close", tw.Close(), t)
+	testutil.FatalOnErr("gzip close", gz.Close(), t)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func TestBundle(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("Failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+
+	savedAnsiblePlaybookBin := *ansiblePlaybookBin
+	savedCmdArgsTransform := cmdArgsTransform
+	t.Cleanup(func() {
+		*ansiblePlaybookBin = savedAnsiblePlaybookBin
+		cmdArgsTransform = savedCmdArgsTransform
+	})
+	*ansiblePlaybookBin = testutil.ResolvePath(t, "cat")
+	// The playbook is always last so cat prints it.
+	cmdArgsTransform = func(input []string) []string {
+		return input[len(input)-1:]
+	}
+
+	client := pb.NewPlaybookClient(conn)
+
+	dir := t.TempDir()
+	bucket := fmt.Sprintf("file://%s", dir)
+	good := makeBundle(t, dir, "good.tar.gz", []bundleEntry{
+		{name: "./", typeflag: tar.TypeDir},
+		{name: "./roles/", typeflag: tar.TypeDir},
+		{name: "./roles/main.yml", body: "role", typeflag: tar.TypeReg},
+		{name: "./site.yml", body: "playbook", typeflag: tar.TypeReg},
+	})
+	escape := makeBundle(t, dir, "escape.tar.gz", []bundleEntry{
+		{name: "../site.yml", body: "playbook", typeflag: tar.TypeReg},
+	})
+	symlink := makeBundle(t, dir, "symlink.tar.gz", []bundleEntry{
+		{name: "site.yml", body: "/etc/passwd", typeflag: tar.TypeSymlink},
+	})
+
+	for _, tc := range []struct {
+		name     string
+		playbook string
+		bundle   *pb.PlaybookBundle
+		wantErr  bool
+		stdout   string
+	}{
+		{
+			name:   "good bundle",
+			bundle: &pb.PlaybookBundle{Bucket: bucket, Key: "good.tar.gz", Sha256: good, Playbook: "site.yml"},
+			stdout: "playbook",
+		},
+		{
+			name:   "playbook in a subdirectory",
+			bundle: &pb.PlaybookBundle{Bucket: bucket, Key: "good.tar.gz", Sha256: good, Playbook: "roles/main.yml"},
+			stdout: "role",
+		},
+		{
+			name:     "playbook and bundle",
+			playbook: filepath.Join(dir, "site.yml"),
+			bundle:   &pb.PlaybookBundle{Bucket: bucket, Key: "good.tar.gz", Sha256: good, Playbook: "site.yml"},
+			wantErr:  true,
+		},
+		{
+			name:    "checksum mismatch",
+			bundle:  &pb.PlaybookBundle{Bucket: bucket, Key: "good.tar.gz", Sha256: escape, Playbook: "site.yml"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid checksum",
+			bundle:  &pb.PlaybookBundle{Bucket: bucket, Key: "good.tar.gz", Sha256: "abc", Playbook: "site.yml"},
+			wantErr: true,
+		},
+		{
+			name:    "missing key",
+			bundle:  &pb.PlaybookBundle{Bucket: bucket, Key: "missing.tar.gz", Sha256: good, Playbook: "site.yml"},
+			wantErr: true,
+		},
+		{
+			name:    "playbook outside of bundle",
+			bundle:  &pb.PlaybookBundle{Bucket: bucket, Key: "good.tar.gz", Sha256: good, Playbook: "../good.tar.gz"},
+			wantErr: true,
+		},
+		{
+			name:    "playbook missing from bundle",
+			bundle:  &pb.PlaybookBundle{Bucket: bucket, Key: "good.tar.gz", Sha256: good, Playbook: "other.yml"},
+			wantErr: true,
+		},
+		{
+			name:    "entry escapes bundle",
+			bundle:  &pb.PlaybookBundle{Bucket: bucket, Key: "escape.tar.gz", Sha256: escape, Playbook: "site.yml"},
+			wantErr: true,
+		},
+		{
+			name:    "symlink entry",
+			bundle:  &pb.PlaybookBundle{Bucket: bucket, Key: "symlink.tar.gz", Sha256: symlink, Playbook: "site.yml"},
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := client.Run(ctx, &pb.RunRequest{
+				Playbook: tc.playbook,
+				Bundle:   tc.bundle,
+			})
+			testutil.WantErr(tc.name, err, tc.wantErr, t)
+			if tc.wantErr {
+				return
+			}
+			if got, want := resp.Stdout, tc.stdout; got != want {
+				t.Fatalf("%s: Stdout doesn't match. Want %q Got %q", tc.name, want, got)
+			}
+		})
+	}
+
+	// Each limit rejects the good bundle when set just below what it needs.
+	for _, tc := range []struct {
+		name    string
+		set     func()
+		wantErr string
+	}{
+		{name: "download size", set: func() { *bundleMaxSize = 10 }, wantErr: "larger than 10 bytes"},
+		{name: "unpacked size", set: func() { *bundleMaxUnpackedSize = 11 }, wantErr: "larger than 11 bytes unpacked"},
+		{name: "entries", set: func() { *bundleMaxEntries = 3 }, wantErr: "more than 3 entries"},
+	} {
+		savedSize, savedUnpacked, savedEntries := *bundleMaxSize, *bundleMaxUnpackedSize, *bundleMaxEntries
+		tc.set()
+		_, err := client.Run(ctx, &pb.RunRequest{
+			Bundle: &pb.PlaybookBundle{Bucket: bucket, Key: "good.tar.gz", Sha256: good, Playbook: "site.yml"},
+		})
+		*bundleMaxSize, *bundleMaxUnpackedSize, *bundleMaxEntries = savedSize, savedUnpacked, savedEntries
+		if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Fatalf("%s: got error %v, want one containing %q", tc.name, err, tc.wantErr)
+		}
+	}
+
+	// Nothing should be left behind in the temporary directory.
+	leftover, err := filepath.Glob(filepath.Join(os.TempDir(), "sansshell-ansible-*"))
+	testutil.FatalOnErr("glob", err, t)
+	if len(leftover) != 0 {
+		t.Fatalf("temporary directories not cleaned up: %q", leftover)
+	}
+}
@@ -0,0 +1,190 @@
+/* Copyright (c) 2019 Snowflake Inc. All rights reserved.
+
+   Licensed under the Apache License, Version 2.0 (the
+   "License"); you may not use this file except in compliance
+   with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing,
+   software distributed under the License is distributed on an
+   "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+   KIND, either express or implied.  See the License for the
+   specific language governing permissions and limitations
+   under the License.
+*/
+
+package server
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	pb "github.com/Snowflake-Labs/sansshell/services/ansible"
+	"gocloud.dev/blob"
+	_ "gocloud.dev/blob/azureblob" // Pull in Azure blob support
+	_ "gocloud.dev/blob/gcsblob"   // Pull in GCS blob support
+	_ "gocloud.dev/blob/s3blob"    // Pull in S3 blob support
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	bundleMaxSize         = flag.Int64("ansible_bundle_max_size", 100<<20, "Largest playbook bundle in bytes (as stored in the bucket) which will be fetched")
+	bundleMaxUnpackedSize = flag.Int64("ansible_bundle_max_unpacked_size", 1<<30, "Largest total size in bytes of the files in a playbook bundle once unpacked")
+	bundleMaxEntries      = flag.Int("ansible_bundle_max_entries", 10000, "Most files and directories a playbook bundle may contain")
+)
+
+// relativePath returns an error unless p is a clean path which stays
+// inside of the directory it's relative to.
+func relativePath(p string) error {
+	if p == "" || filepath.IsAbs(p) || filepath.Clean(p) != p || p == ".." || strings.HasPrefix(p, "../") {
+		return status.Errorf(codes.InvalidArgument, "%q must be a clean relative path", p)
+	}
+	return nil
+}
+
+func validateBundle(b *pb.PlaybookBundle) error {
+	if b.Bucket == "" {
+		return status.Error(codes.InvalidArgument, "bundle bucket must be filled in")
+	}
+	if b.Key == "" {
+		return status.Error(codes.InvalidArgument, "bundle key must be filled in")
+	}
+	if sum, err := hex.DecodeString(b.Sha256); err != nil || len(sum) != sha256.Size {
+		return status.Errorf(codes.InvalidArgument, "bundle sha256 must be a hex encoded SHA256 - %q is invalid", b.Sha256)
+	}
+	return relativePath(b.Playbook)
+}
+
+// fetchBundle copies the bundle from its bucket, verifies the checksum and
+// then unpacks it into dest.
+func fetchBundle(ctx context.Context, b *pb.PlaybookBundle, dest string) (retErr error) {
+	bucket, err := blob.OpenBucket(ctx, b.Bucket)
+	if err != nil {
+		return status.Errorf(codes.Internal, "can't open bucket %s - %v", b.Bucket, err)
+	}
+	// Something else may error so append onto it.
+	defer func() {
+		err := bucket.Close()
+		switch {
+		case err == nil:
+		case retErr == nil:
+			retErr = status.Errorf(codes.Internal, "can't close bucket %s - %v", b.Bucket, err)
+		default:
+			retErr = fmt.Errorf("%w %v", retErr, err)
+		}
+	}()
+
+	reader, err := bucket.NewReader(ctx, b.Key, nil)
+	if err != nil {
+		return status.Errorf(codes.Internal, "can't open key %s in bucket %s - %v", b.Key, b.Bucket, err)
+	}
+	defer reader.Close()
+
+	// Nothing is unpacked until the checksum is known to match so keep a copy.
+	archive, err := os.Create(dest + ".tar.gz")
+	if err != nil {
+		return status.Errorf(codes.Internal, "can't create bundle file: %v", err)
+	}
+	defer archive.Close()
+
+	// Read one byte past the limit so a bundle which is too large can be told apart.
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(archive, h), io.LimitReader(reader, *bundleMaxSize+1))
+	if err != nil {
+		return status.Errorf(codes.Internal, "can't copy from bucket %s/%s - %v", b.Bucket, b.Key, err)
+	}
+	if n > *bundleMaxSize {
+		return status.Errorf(codes.InvalidArgument, "bundle %s/%s is larger than %d bytes", b.Bucket, b.Key, *bundleMaxSize)
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != strings.ToLower(b.Sha256) {
+		return status.Errorf(codes.InvalidArgument, "bundle %s/%s has sha256 %s, want %s", b.Bucket, b.Key, got, b.Sha256)
+	}
+
+	if _, err := archive.Seek(0, io.SeekStart); err != nil {
+		return status.Errorf(codes.Internal, "can't rewind bundle file: %v", err)
+	}
+	return unpackBundle(archive, dest)
+}
+
+// unpackBundle extracts a gzipped tar into dest. Only regular files and
+// directories are supported and every entry must stay inside of dest. The
+// number of entries and total size of the files are limited by flags so a
+// small archive can't fill the disk.
+func unpackBundle(r io.Reader, dest string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "bundle isn't gzipped: %v", err)
+	}
+	defer gz.Close()
+
+	if err := os.MkdirAll(dest, 0700); err != nil {
+		return status.Errorf(codes.Internal, "can't create bundle directory: %v", err)
+	}
+	tr := tar.NewReader(gz)
+	var entries int
+	var size int64
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid bundle: %v", err)
+		}
+		entries++
+		if entries > *bundleMaxEntries {
+			return status.Errorf(codes.InvalidArgument, "bundle has more than %d entries", *bundleMaxEntries)
+		}
+		// The tar reader never returns more than Size bytes for an entry.
+		size += hdr.Size
+		if hdr.Size < 0 || size > *bundleMaxUnpackedSize {
+			return status.Errorf(codes.InvalidArgument, "bundle is larger than %d bytes unpacked", *bundleMaxUnpackedSize)
+		}
+		name := filepath.Clean(hdr.Name)
+		if name == "." {
+			continue
+		}
+		if err := relativePath(name); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid bundle entry: %v", err)
+		}
+		target := filepath.Join(dest, name)
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0700); err != nil {
+				return status.Errorf(codes.Internal, "can't create %s: %v", name, err)
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+				return status.Errorf(codes.Internal, "can't create directory for %s: %v", name, err)
+			}
+			if err := writeBundleFile(tr, target, os.FileMode(hdr.Mode).Perm()); err != nil {
+				return status.Errorf(codes.Internal, "can't write %s: %v", name, err)
+			}
+		default:
+			return status.Errorf(codes.InvalidArgument, "bundle entry %s has unsupported type %q", name, hdr.Typeflag)
+		}
+	}
+}
+
+func writeBundleFile(r io.Reader, target string, mode os.FileMode) error {
+	f, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
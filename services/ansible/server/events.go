@@ -0,0 +1,183 @@
+/* Copyright (c) 2019 Snowflake Inc. All rights reserved.
+
+   Licensed under the Apache License, Version 2.0 (the
+   "License"); you may not use this file except in compliance
+   with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing,
+   software distributed under the License is distributed on an
+   "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+   KIND, either express or implied.  See the License for the
+   specific language governing permissions and limitations
+   under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+
+	pb "github.com/Snowflake-Labs/sansshell/services/ansible"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// jsonlEvent is a single line of output from the ansible.posix.jsonl callback.
+// Only the fields needed to build a TaskEvent or HostStats are decoded.
+type jsonlEvent struct {
+	Event string `json:"_event"`
+	Play  *struct {
+		Name string `json:"name"`
+	} `json:"play"`
+	Task *struct {
+		Name     string `json:"name"`
+		Duration struct {
+			Start string `json:"start"`
+			End   string `json:"end"`
+		} `json:"duration"`
+	} `json:"task"`
+	Hosts map[string]json.RawMessage `json:"hosts"`
+	Stats map[string]struct {
+		Ok          int32 `json:"ok"`
+		Changed     int32 `json:"changed"`
+		Failures    int32 `json:"failures"`
+		Skipped     int32 `json:"skipped"`
+		Unreachable int32 `json:"unreachable"`
+		Rescued     int32 `json:"rescued"`
+		Ignored     int32 `json:"ignored"`
+	} `json:"stats"`
+}
+
+// jsonlStatus maps the runner events to a task status. v2_runner_on_ok is
+// changed or not depending on the result.
+var jsonlStatus = map[string]pb.TaskStatus{
+	"v2_runner_on_ok":          pb.TaskStatus_TASK_STATUS_OK,
+	"v2_runner_on_failed":      pb.TaskStatus_TASK_STATUS_FAILED,
+	"v2_runner_on_skipped":     pb.TaskStatus_TASK_STATUS_SKIPPED,
+	"v2_runner_on_unreachable": pb.TaskStatus_TASK_STATUS_UNREACHABLE,
+}
+
+// eventParser is an io.Writer which parses each line of output from
+// ansible-playbook and sends a TaskEvent for each task result. Anything
+// which isn't an event (i.e. warnings) is ignored.
+type eventParser struct {
+	send    func(*pb.RunStreamReply) error
+	partial []byte
+	play    string
+	stats   []*pb.HostStats
+	// err is the first error from sending. Once set all further output is dropped.
+	err error
+}
+
+func (e *eventParser) Write(p []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	e.partial = append(e.partial, p...)
+	for {
+		i := bytes.IndexByte(e.partial, '\n')
+		if i < 0 {
+			break
+		}
+		line := e.partial[:i]
+		e.partial = e.partial[i+1:]
+		if e.err = e.parseLine(line); e.err != nil {
+			return 0, e.err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush parses anything left after the last newline and returns any
+// error from sending events.
+func (e *eventParser) Flush() error {
+	if e.err != nil {
+		return e.err
+	}
+	line := e.partial
+	e.partial = nil
+	return e.parseLine(line)
+}
+
+func (e *eventParser) parseLine(line []byte) error {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 || line[0] != '{' {
+		return nil
+	}
+	var ev jsonlEvent
+	if err := json.Unmarshal(line, &ev); err != nil {
+		return nil
+	}
+
+	switch ev.Event {
+	case "v2_playbook_on_play_start":
+		if ev.Play != nil {
+			e.play = ev.Play.Name
+		}
+		return nil
+	case "v2_playbook_on_stats":
+		e.stats = nil
+		for host, s := range ev.Stats {
+			e.stats = append(e.stats, &pb.HostStats{
+				Host:        host,
+				Ok:          s.Ok,
+				Changed:     s.Changed,
+				Failed:      s.Failures,
+				Skipped:     s.Skipped,
+				Unreachable: s.Unreachable,
+				Rescued:     s.Rescued,
+				Ignored:     s.Ignored,
+			})
+		}
+		sort.Slice(e.stats, func(i, j int) bool { return e.stats[i].Host < e.stats[j].Host })
+		return nil
+	}
+
+	st, ok := jsonlStatus[ev.Event]
+	if !ok || ev.Task == nil {
+		return nil
+	}
+	var duration *durationpb.Duration
+	start, startErr := time.Parse(time.RFC3339Nano, ev.Task.Duration.Start)
+	end, endErr := time.Parse(time.RFC3339Nano, ev.Task.Duration.End)
+	if startErr == nil && endErr == nil {
+		duration = durationpb.New(end.Sub(start))
+	}
+
+	var hosts []string
+	for h := range ev.Hosts {
+		hosts = append(hosts, h)
+	}
+	sort.Strings(hosts)
+	for _, h := range hosts {
+		result := ev.Hosts[h]
+		taskStatus := st
+		if taskStatus == pb.TaskStatus_TASK_STATUS_OK {
+			var r struct {
+				Changed bool `json:"changed"`
+			}
+			if err := json.Unmarshal(result, &r); err == nil && r.Changed {
+				taskStatus = pb.TaskStatus_TASK_STATUS_CHANGED
+			}
+		}
+		if err := e.send(&pb.RunStreamReply{
+			Reply: &pb.RunStreamReply_Task{
+				Task: &pb.TaskEvent{
+					Play:       e.play,
+					Task:       ev.Task.Name,
+					Host:       h,
+					Status:     taskStatus,
+					Duration:   duration,
+					ResultJson: string(result),
+				},
+			},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
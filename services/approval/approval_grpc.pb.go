@@ -0,0 +1,145 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package approval
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ApprovalClient is the client API for Approval service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ApprovalClient interface {
+	// List returns the requests waiting for, or holding, approvals.
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListReply, error)
+	// Approve records the caller as an approver of a pending request. A
+	// principal can't approve its own requests. Unless the proxy uses fleet
+	// wide approvals the approval is consumed by the first request it lets
+	// through.
+	Approve(ctx context.Context, in *ApproveRequest, opts ...grpc.CallOption) (*ApproveReply, error)
+}
+
+type approvalClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewApprovalClient(cc grpc.ClientConnInterface) ApprovalClient {
+	return &approvalClient{cc}
+}
+
+func (c *approvalClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListReply, error) {
+	out := new(ListReply)
+	err := c.cc.Invoke(ctx, "/Approval.Approval/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *approvalClient) Approve(ctx context.Context, in *ApproveRequest, opts ...grpc.CallOption) (*ApproveReply, error) {
+	out := new(ApproveReply)
+	err := c.cc.Invoke(ctx, "/Approval.Approval/Approve", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ApprovalServer is the server API for Approval service.
+// All implementations should embed UnimplementedApprovalServer
+// for forward compatibility
+type ApprovalServer interface {
+	// List returns the requests waiting for, or holding, approvals.
+	List(context.Context, *ListRequest) (*ListReply, error)
+	// Approve records the caller as an approver of a pending request. A
+	// principal can't approve its own requests. Unless the proxy uses fleet
+	// wide approvals the approval is consumed by the first request it lets
+	// through.
+	Approve(context.Context, *ApproveRequest) (*ApproveReply, error)
+}
+
+// UnimplementedApprovalServer should be embedded to have forward compatible implementations.
+type UnimplementedApprovalServer struct {
+}
+
+func (UnimplementedApprovalServer) List(context.Context, *ListRequest) (*ListReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedApprovalServer) Approve(context.Context, *ApproveRequest) (*ApproveReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Approve not implemented")
+}
+
+// UnsafeApprovalServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ApprovalServer will
+// result in compilation errors.
+type UnsafeApprovalServer interface {
+	mustEmbedUnimplementedApprovalServer()
+}
+
+func RegisterApprovalServer(s grpc.ServiceRegistrar, srv ApprovalServer) {
+	s.RegisterService(&Approval_ServiceDesc, srv)
+}
+
+func _Approval_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApprovalServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Approval.Approval/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApprovalServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Approval_Approve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApprovalServer).Approve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Approval.Approval/Approve",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApprovalServer).Approve(ctx, req.(*ApproveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Approval_ServiceDesc is the grpc.ServiceDesc for Approval service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Approval_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Approval.Approval",
+	HandlerType: (*ApprovalServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _Approval_List_Handler,
+		},
+		{
+			MethodName: "Approve",
+			Handler:    _Approval_Approve_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "approval.proto",
+}
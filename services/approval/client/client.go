@@ -0,0 +1,152 @@
+/* Copyright (c) 2019 Snowflake Inc. All rights reserved.
+
+   Licensed under the Apache License, Version 2.0 (the
+   "License"); you may not use this file except in compliance
+   with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing,
+   software distributed under the License is distributed on an
+   "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+   KIND, either express or implied.  See the License for the
+   specific language governing permissions and limitations
+   under the License.
+*/
+
+// Package client provides the client interface for 'approval'
+package client
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/subcommands"
+
+	"github.com/Snowflake-Labs/sansshell/client"
+	pb "github.com/Snowflake-Labs/sansshell/services/approval"
+	"github.com/Snowflake-Labs/sansshell/services/util"
+)
+
+const subPackage = "approval"
+
+func init() {
+	subcommands.Register(&approvalCmd{}, subPackage)
+}
+
+func setup(f *flag.FlagSet) *subcommands.Commander {
+	c := client.SetupSubpackage(subPackage, f)
+	c.Register(&listCmd{}, "")
+	c.Register(&approveCmd{}, "")
+	return c
+}
+
+type approvalCmd struct{}
+
+func (*approvalCmd) Name() string { return subPackage }
+func (p *approvalCmd) Synopsis() string {
+	return client.GenerateSynopsis(setup(flag.NewFlagSet("", flag.ContinueOnError)))
+}
+func (p *approvalCmd) Usage() string {
+	return client.GenerateUsage(subPackage, p.Synopsis())
+}
+func (*approvalCmd) SetFlags(f *flag.FlagSet) {}
+
+func (p *approvalCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	c := setup(f)
+	return c.Execute(ctx, args...)
+}
+
+type listCmd struct{}
+
+func (*listCmd) Name() string     { return "list" }
+func (*listCmd) Synopsis() string { return "List requests held on the proxy for approval." }
+func (*listCmd) Usage() string {
+	return `list:
+  Prints the requests the proxy is holding for approval along with who has
+  approved them so far.
+`
+}
+
+func (*listCmd) SetFlags(f *flag.FlagSet) {}
+
+func (*listCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	state := args[0].(*util.ExecuteState)
+	if len(state.Out) > 1 {
+		fmt.Fprintf(os.Stderr, "can't call proxy approval with multiple targets")
+	}
+	// Get a real connection to the proxy
+	c := pb.NewApprovalClient(state.Conn.Proxy())
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	resp, err := c.List(ctx, &pb.ListRequest{})
+	if err != nil {
+		fmt.Fprintf(state.Err[0], "Could not list approvals: %v\n", err)
+		return subcommands.ExitFailure
+	}
+	w := tabwriter.NewWriter(state.Out[0], 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "HASH\tREQUESTER\tMETHOD\tTARGET\tEXPIRES\tAPPROVERS\tMESSAGE")
+	for _, r := range resp.Requests {
+		target := r.Target
+		if target == "" {
+			// Fleet wide approvals apply to any target.
+			target = "*"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", r.Hash, r.Requester, r.Method, target, r.Expires.AsTime().Local().Format(time.RFC3339), approvers(r), r.MessageJson)
+	}
+	w.Flush()
+	return subcommands.ExitSuccess
+}
+
+type approveCmd struct{}
+
+func (*approveCmd) Name() string     { return "approve" }
+func (*approveCmd) Synopsis() string { return "Approve a request held on the proxy." }
+func (*approveCmd) Usage() string {
+	return `approve <hash>:
+  Records you as an approver of the request with the given hash (as printed
+  by list or in the original request's error). Once policy is satisfied the
+  requester can retry it. Unless the proxy uses fleet wide approvals the
+  approval is used up by that retry.
+`
+}
+
+func (*approveCmd) SetFlags(f *flag.FlagSet) {}
+
+func (*approveCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	state := args[0].(*util.ExecuteState)
+	if f.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "please specify the hash of the request to approve")
+		return subcommands.ExitUsageError
+	}
+	if len(state.Out) > 1 {
+		fmt.Fprintf(os.Stderr, "can't call proxy approval with multiple targets")
+	}
+	// Get a real connection to the proxy
+	c := pb.NewApprovalClient(state.Conn.Proxy())
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	resp, err := c.Approve(ctx, &pb.ApproveRequest{Hash: f.Arg(0)})
+	if err != nil {
+		fmt.Fprintf(state.Err[0], "Could not approve request: %v\n", err)
+		return subcommands.ExitFailure
+	}
+	fmt.Fprintf(state.Out[0], "Approved %s %s from %s, approvers: %s\n", resp.Request.Hash, resp.Request.Method, resp.Request.Requester, approvers(resp.Request))
+	return subcommands.ExitSuccess
+}
+
+// approvers returns the IDs of the approvers of r separated by commas.
+func approvers(r *pb.PendingRequest) string {
+	var ids []string
+	for _, a := range r.Approvers {
+		ids = append(ids, a.Id)
+	}
+	return strings.Join(ids, ",")
+}
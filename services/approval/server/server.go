@@ -0,0 +1,271 @@
+/* Copyright (c) 2019 Snowflake Inc. All rights reserved.
+
+   Licensed under the Apache License, Version 2.0 (the
+   "License"); you may not use this file except in compliance
+   with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing,
+   software distributed under the License is distributed on an
+   "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+   KIND, either express or implied.  See the License for the
+   specific language governing permissions and limitations
+   under the License.
+*/
+
+// Package server implements the sansshell 'Approval' service along with
+// the store of pending requests it shares with the proxy's authorizer.
+package server
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Snowflake-Labs/sansshell/auth/opa/rpcauth"
+	pb "github.com/Snowflake-Labs/sansshell/services/approval"
+)
+
+const (
+	// DefaultMaxPending is the number of requests a Store holds if
+	// NewStore isn't given a limit.
+	DefaultMaxPending = 1000
+	// DefaultMaxPendingPerRequester is the number of requests a Store holds
+	// for a single requester if NewStore isn't given a limit.
+	DefaultMaxPendingPerRequester = 10
+)
+
+// entry is a request held in a Store.
+type entry struct {
+	requester string
+	method    string
+	target    string
+	message   string
+	created   time.Time
+	approvers []*rpcauth.PrincipalAuthInput
+}
+
+// Store is an in-memory rpcauth.ApprovalStore. Requests (and their
+// approvals) are forgotten once they're consumed or older than the TTL it
+// was created with. As every request which needs approval is held the
+// number held, in total and for each requester, is limited.
+type Store struct {
+	ttl             time.Duration
+	maxPending      int
+	maxPerRequester int
+	// now is a var so tests can control time.
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+// NewStore returns an empty Store which keeps requests for ttl. At most
+// maxPending requests are held, and maxPerRequester for each requester.
+// If either is zero (or less) DefaultMaxPending or
+// DefaultMaxPendingPerRequester is used.
+func NewStore(ttl time.Duration, maxPending int, maxPerRequester int) *Store {
+	if maxPending <= 0 {
+		maxPending = DefaultMaxPending
+	}
+	if maxPerRequester <= 0 {
+		maxPerRequester = DefaultMaxPendingPerRequester
+	}
+	return &Store{
+		ttl:             ttl,
+		maxPending:      maxPending,
+		maxPerRequester: maxPerRequester,
+		now:             time.Now,
+		entries:         make(map[string]*entry),
+	}
+}
+
+// expire removes old entries. It must be called with s.mu held.
+func (s *Store) expire() {
+	now := s.now()
+	for hash, e := range s.entries {
+		if now.Sub(e.created) >= s.ttl {
+			delete(s.entries, hash)
+		}
+	}
+}
+
+// Approvers implements rpcauth.ApprovalStore.
+func (s *Store) Approvers(ctx context.Context, hash string) []*rpcauth.PrincipalAuthInput {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+	e := s.entries[hash]
+	if e == nil {
+		return nil
+	}
+	return append([]*rpcauth.PrincipalAuthInput(nil), e.approvers...)
+}
+
+// AddPending implements rpcauth.ApprovalStore. Adding a request which is
+// already pending keeps the original entry. If the store already holds
+// its limit of requests, in total or for the requester, it fails with
+// ResourceExhausted. Requests without a requester fail with
+// PermissionDenied.
+func (s *Store) AddPending(ctx context.Context, hash string, input *rpcauth.RPCAuthInput) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+	requester := rpcauth.Requester(input.Peer)
+	if requester == "" {
+		return status.Error(codes.PermissionDenied, "requests from unidentified requesters can't be held for approval")
+	}
+	if _, ok := s.entries[hash]; ok {
+		return nil
+	}
+	if len(s.entries) >= s.maxPending {
+		return status.Errorf(codes.ResourceExhausted, "too many requests pending approval (%d)", s.maxPending)
+	}
+	var n int
+	for _, e := range s.entries {
+		if e.requester == requester {
+			n++
+		}
+	}
+	if n >= s.maxPerRequester {
+		return status.Errorf(codes.ResourceExhausted, "%s has too many requests pending approval (%d)", requester, s.maxPerRequester)
+	}
+	s.entries[hash] = &entry{
+		requester: requester,
+		method:    input.Method,
+		target:    rpcauth.RequestTarget(input),
+		message:   string(input.Message),
+		created:   s.now(),
+	}
+	logr.FromContextOrDiscard(ctx).Info("request pending approval", "hash", hash, "method", input.Method)
+	return nil
+}
+
+// Consume implements rpcauth.ApprovalStore.
+func (s *Store) Consume(ctx context.Context, hash string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+	if _, ok := s.entries[hash]; !ok {
+		return false
+	}
+	delete(s.entries, hash)
+	logr.FromContextOrDiscard(ctx).Info("approval consumed", "hash", hash)
+	return true
+}
+
+// Approve records approver as approving the request with the given hash
+// and returns the updated request. Approving a request twice has no
+// further effect.
+func (s *Store) Approve(hash string, approver *rpcauth.PrincipalAuthInput) (*pb.PendingRequest, error) {
+	if approver == nil || approver.ID == "" {
+		return nil, status.Error(codes.PermissionDenied, "approver has no principal")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+	e := s.entries[hash]
+	if e == nil {
+		return nil, status.Errorf(codes.NotFound, "no pending request with hash %s", hash)
+	}
+	if e.requester == approver.ID {
+		return nil, status.Error(codes.PermissionDenied, "requests can't be approved by their requester")
+	}
+	found := false
+	for _, a := range e.approvers {
+		if a.ID == approver.ID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		e.approvers = append(e.approvers, approver)
+	}
+	return s.pending(hash, e), nil
+}
+
+// List returns the requests in the store sorted by creation time.
+func (s *Store) List() []*pb.PendingRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+	var out []*pb.PendingRequest
+	for hash, e := range s.entries {
+		out = append(out, s.pending(hash, e))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		ci, cj := out[i].Created.AsTime(), out[j].Created.AsTime()
+		if !ci.Equal(cj) {
+			return ci.Before(cj)
+		}
+		return out[i].Hash < out[j].Hash
+	})
+	return out
+}
+
+// pending converts e into a PendingRequest. It must be called with s.mu held.
+func (s *Store) pending(hash string, e *entry) *pb.PendingRequest {
+	p := &pb.PendingRequest{
+		Hash:        hash,
+		Requester:   e.requester,
+		Method:      e.method,
+		Target:      e.target,
+		MessageJson: e.message,
+		Created:     timestamppb.New(e.created),
+		Expires:     timestamppb.New(e.created.Add(s.ttl)),
+	}
+	for _, a := range e.approvers {
+		p.Approvers = append(p.Approvers, &pb.Approver{Id: a.ID, Groups: a.Groups})
+	}
+	return p
+}
+
+// Server is used to implement the gRPC server
+type Server struct {
+	store *Store
+}
+
+// NewServer returns a Server for the requests in store.
+func NewServer(store *Store) *Server {
+	return &Server{store: store}
+}
+
+// List implements ApprovalServer.
+func (s *Server) List(ctx context.Context, req *pb.ListRequest) (*pb.ListReply, error) {
+	return &pb.ListReply{Requests: s.store.List()}, nil
+}
+
+// Approve implements ApprovalServer. The approver is the principal of the
+// caller as determined by the authorizer's hooks.
+func (s *Server) Approve(ctx context.Context, req *pb.ApproveRequest) (*pb.ApproveReply, error) {
+	if req.Hash == "" {
+		return nil, status.Error(codes.InvalidArgument, "hash must be set")
+	}
+	input := rpcauth.InputFromContext(ctx)
+	if input == nil {
+		return nil, status.Error(codes.PermissionDenied, "approver can't be identified")
+	}
+	approver := &rpcauth.PrincipalAuthInput{ID: rpcauth.Requester(input.Peer)}
+	if input.Peer != nil && input.Peer.Principal != nil {
+		approver.Groups = input.Peer.Principal.Groups
+	}
+	p, err := s.store.Approve(req.Hash, approver)
+	if err != nil {
+		return nil, err
+	}
+	logr.FromContextOrDiscard(ctx).Info("request approved", "hash", req.Hash, "approver", approver.ID)
+	return &pb.ApproveReply{Request: p}, nil
+}
+
+// Register is called to expose this handler to the gRPC server
+func (s *Server) Register(gs *grpc.Server) {
+	pb.RegisterApprovalServer(gs, s)
+}
@@ -0,0 +1,244 @@
+/* Copyright (c) 2019 Snowflake Inc. All rights reserved.
+
+   Licensed under the Apache License, Version 2.0 (the
+   "License"); you may not use this file except in compliance
+   with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing,
+   software distributed under the License is distributed on an
+   "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+   KIND, either express or implied.  See the License for the
+   specific language governing permissions and limitations
+   under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/Snowflake-Labs/sansshell/auth/opa/rpcauth"
+	pb "github.com/Snowflake-Labs/sansshell/services/approval"
+	"github.com/Snowflake-Labs/sansshell/testing/testutil"
+)
+
+const policy = `
+package sansshell.authz
+
+default allow = true
+
+requires_approval {
+  input.method = "/Foo/Rm"
+  not approved
+}
+
+approved {
+  input.approvers[_].groups[_] = "security"
+}
+`
+
+// principalHook sets the principal from the "principal" and "group"
+// metadata so tests can act as different callers.
+var principalHook = rpcauth.RPCAuthzHookFunc(func(ctx context.Context, input *rpcauth.RPCAuthInput) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if p := md.Get("principal"); len(p) > 0 {
+		input.Peer.Principal = &rpcauth.PrincipalAuthInput{ID: p[0], Groups: md.Get("group")}
+	}
+	return nil
+})
+
+func rmInput(principal string, target string) *rpcauth.RPCAuthInput {
+	return &rpcauth.RPCAuthInput{
+		Method:  "/Foo/Rm",
+		Message: json.RawMessage(`{"filename":"/etc/passwd"}`),
+		Peer:    &rpcauth.PeerAuthInput{Principal: &rpcauth.PrincipalAuthInput{ID: principal}},
+		Host:    &rpcauth.HostAuthInput{Net: &rpcauth.NetAuthInput{Network: "tcp", Address: target, Port: "50042"}},
+	}
+}
+
+func TestApprove(t *testing.T) {
+	ctx := context.Background()
+	authz, err := rpcauth.NewWithPolicy(ctx, policy, principalHook)
+	testutil.FatalOnErr("NewWithPolicy", err, t)
+	store := NewStore(time.Hour, 0, 0)
+	authz.SetApprovalStore(store, rpcauth.ApprovalPerTarget)
+
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer(grpc.UnaryInterceptor(authz.Authorize))
+	NewServer(store).Register(s)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("DialContext", err, t)
+	t.Cleanup(func() { conn.Close() })
+	client := pb.NewApprovalClient(conn)
+
+	// alice's request is held.
+	hash, ok := rpcauth.ApprovalRequired(authz.Eval(ctx, rmInput("alice", "10.0.0.1")))
+	if !ok {
+		t.Fatal("request wasn't held for approval")
+	}
+	resp, err := client.List(ctx, &pb.ListRequest{})
+	testutil.FatalOnErr("List", err, t)
+	if len(resp.Requests) != 1 || resp.Requests[0].Hash != hash || resp.Requests[0].Requester != "alice" || resp.Requests[0].Method != "/Foo/Rm" || resp.Requests[0].Target != "10.0.0.1:50042" {
+		t.Fatalf("List() = %v, want the request from alice to 10.0.0.1:50042", resp)
+	}
+
+	as := func(principal string, groups ...string) context.Context {
+		kv := []string{"principal", principal}
+		for _, g := range groups {
+			kv = append(kv, "group", g)
+		}
+		return metadata.AppendToOutgoingContext(ctx, kv...)
+	}
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+		hash string
+		code codes.Code
+	}{
+		{
+			name: "no principal",
+			ctx:  ctx,
+			hash: hash,
+			code: codes.PermissionDenied,
+		},
+		{
+			name: "self approval",
+			ctx:  as("alice", "security"),
+			hash: hash,
+			code: codes.PermissionDenied,
+		},
+		{
+			name: "unknown hash",
+			ctx:  as("bob", "security"),
+			hash: "nope",
+			code: codes.NotFound,
+		},
+		{
+			name: "no hash",
+			ctx:  as("bob", "security"),
+			code: codes.InvalidArgument,
+		},
+	} {
+		_, err := client.Approve(tc.ctx, &pb.ApproveRequest{Hash: tc.hash})
+		if got := status.Code(err); got != tc.code {
+			t.Errorf("%s: got %v (%v) want %v", tc.name, got, err, tc.code)
+		}
+	}
+
+	// An approval the policy doesn't accept leaves the request held.
+	_, err = client.Approve(as("carol"), &pb.ApproveRequest{Hash: hash})
+	testutil.FatalOnErr("Approve by carol", err, t)
+	if _, ok := rpcauth.ApprovalRequired(authz.Eval(ctx, rmInput("alice", "10.0.0.1"))); !ok {
+		t.Fatal("request allowed without approval from security")
+	}
+
+	for i := 0; i < 2; i++ {
+		reply, err := client.Approve(as("bob", "security"), &pb.ApproveRequest{Hash: hash})
+		testutil.FatalOnErr("Approve by bob", err, t)
+		if got := len(reply.Request.Approvers); got != 2 {
+			t.Fatalf("got %d approvers, want 2: %v", got, reply.Request.Approvers)
+		}
+	}
+	// Approvals are per requester and target.
+	if _, ok := rpcauth.ApprovalRequired(authz.Eval(ctx, rmInput("mallory", "10.0.0.1"))); !ok {
+		t.Fatal("approval applied to another requester")
+	}
+	if _, ok := rpcauth.ApprovalRequired(authz.Eval(ctx, rmInput("alice", "10.0.0.2"))); !ok {
+		t.Fatal("approval applied to another target")
+	}
+	testutil.FatalOnErr("approved", authz.Eval(ctx, rmInput("alice", "10.0.0.1")), t)
+	// and can only be used once.
+	again, ok := rpcauth.ApprovalRequired(authz.Eval(ctx, rmInput("alice", "10.0.0.1")))
+	if !ok || again != hash {
+		t.Fatal("approval was used twice")
+	}
+	if _, err := client.Approve(as("bob", "security"), &pb.ApproveRequest{Hash: hash}); err != nil {
+		t.Fatalf("Approve of a request held again: %v", err)
+	}
+}
+
+func TestStoreLimits(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore(time.Hour, 3, 2)
+
+	for _, tc := range []struct {
+		hash      string
+		requester string
+		code      codes.Code
+	}{
+		{hash: "a1", requester: "alice"},
+		{hash: "a2", requester: "alice"},
+		// Re-adding a pending request isn't limited.
+		{hash: "a2", requester: "alice"},
+		{hash: "a3", requester: "alice", code: codes.ResourceExhausted},
+		{hash: "b1", requester: "bob"},
+		{hash: "c1", requester: "carol", code: codes.ResourceExhausted},
+		// Unidentified requesters would share a quota so aren't held.
+		{hash: "x1", requester: "", code: codes.PermissionDenied},
+	} {
+		err := store.AddPending(ctx, tc.hash, rmInput(tc.requester, "10.0.0.1"))
+		if got := status.Code(err); got != tc.code {
+			t.Errorf("AddPending(%s): got %v (%v) want %v", tc.hash, got, err, tc.code)
+		}
+	}
+
+	// Consuming frees up space.
+	if !store.Consume(ctx, "a1") {
+		t.Fatal("Consume(a1) = false, want true")
+	}
+	if store.Consume(ctx, "a1") {
+		t.Fatal("Consume(a1) twice = true, want false")
+	}
+	testutil.FatalOnErr("AddPending after Consume", store.AddPending(ctx, "a3", rmInput("alice", "10.0.0.1")), t)
+}
+
+func TestStoreExpiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1000, 0)
+	store := NewStore(time.Minute, 0, 0)
+	store.now = func() time.Time { return now }
+
+	store.AddPending(ctx, "a", rmInput("alice", "10.0.0.1"))
+	now = now.Add(30 * time.Second)
+	store.AddPending(ctx, "b", rmInput("alice", "10.0.0.1"))
+	// Re-adding doesn't extend the expiry.
+	store.AddPending(ctx, "a", rmInput("alice", "10.0.0.1"))
+	_, err := store.Approve("a", &rpcauth.PrincipalAuthInput{ID: "bob"})
+	testutil.FatalOnErr("Approve", err, t)
+
+	list := store.List()
+	if len(list) != 2 || list[0].Hash != "a" || list[1].Hash != "b" {
+		t.Fatalf("List() = %v, want a then b", list)
+	}
+	if got, want := list[0].Expires.AsTime(), time.Unix(1060, 0); !got.Equal(want) {
+		t.Fatalf("expires %v, want %v", got, want)
+	}
+
+	now = now.Add(30 * time.Second)
+	if got := store.Approvers(ctx, "a"); got != nil {
+		t.Fatalf("approvals for expired request: %v", got)
+	}
+	if list := store.List(); len(list) != 1 || list[0].Hash != "b" {
+		t.Fatalf("List() = %v, want only b", list)
+	}
+	if _, err := store.Approve("a", &rpcauth.PrincipalAuthInput{ID: "bob"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("Approve of expired request: got %v want NotFound", err)
+	}
+}
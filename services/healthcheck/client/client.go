@@ -21,6 +21,8 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/google/subcommands"
@@ -40,6 +42,7 @@ func init() {
 func setup(f *flag.FlagSet) *subcommands.Commander {
 	c := client.SetupSubpackage(subPackage, f)
 	c.Register(&validateCmd{}, "")
+	c.Register(&detailedCmd{}, "")
 	return c
 }
 
@@ -97,3 +100,62 @@ func (p *validateCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...inte
 	}
 	return retCode
 }
+
+type detailedCmd struct {
+	service string
+}
+
+func (*detailedCmd) Name() string     { return "detailed" }
+func (*detailedCmd) Synopsis() string { return "Run the health checks registered by each service." }
+func (*detailedCmd) Usage() string {
+	return `detailed [--service=X]:
+  Runs the component checks registered by services on the server (i.e. systemd
+  reachable, package manager present) and prints each result. Exits non-zero if
+  any check failed.
+`
+}
+
+func (p *detailedCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&p.service, "service", "", "Only run the checks for this service (i.e. Packages.Packages)")
+}
+
+func (p *detailedCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	state := args[0].(*util.ExecuteState)
+	c := pb.NewHealthCheckClientProxy(state.Conn)
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	resp, err := c.DetailedOneMany(ctx, &pb.DetailedRequest{Service: p.service})
+	if err != nil {
+		// Emit this to every error file as it's not specific to a given target.
+		for _, e := range state.Err {
+			fmt.Fprintf(e, "Could not run detailed healthcheck: %v\n", err)
+		}
+		return subcommands.ExitFailure
+	}
+
+	retCode := subcommands.ExitSuccess
+	for r := range resp {
+		if r.Error != nil {
+			fmt.Fprintf(state.Err[r.Index], "Detailed healthcheck for target %s (%d) returned error: %v\n", r.Target, r.Index, r.Error)
+			retCode = subcommands.ExitFailure
+			continue
+		}
+		if r.Resp.Status != pb.CheckStatus_CHECK_STATUS_OK {
+			retCode = subcommands.ExitFailure
+		}
+		fmt.Fprintf(state.Out[r.Index], "Target %s (%d) %s\n", r.Target, r.Index, checkStatus(r.Resp.Status))
+		w := tabwriter.NewWriter(state.Out[r.Index], 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "SERVICE\tCHECK\tSTATUS\tDURATION\tMESSAGE")
+		for _, c := range r.Resp.Checks {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.Service, c.Name, checkStatus(c.Status), c.Duration.AsDuration(), c.Message)
+		}
+		w.Flush()
+	}
+	return retCode
+}
+
+// checkStatus returns a short lower case version of the status (i.e. ok).
+func checkStatus(s pb.CheckStatus) string {
+	return strings.ToLower(strings.TrimPrefix(s.String(), "CHECK_STATUS_"))
+}
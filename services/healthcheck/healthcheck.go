@@ -17,6 +17,65 @@
 // Package healthcheck defines the RPC interface for the sansshell HealthCheck actions.
 package healthcheck
 
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
 // To regenerate the proto headers if the proto changes, just run go generate
 // and this encodes the necessary magic:
 //go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=require_unimplemented_servers=false:. --go-grpc_opt=paths=source_relative --go-grpcproxy_out=. --go-grpcproxy_opt=paths=source_relative healthcheck.proto
+
+// CheckFunc reports the health of a component. It returns nil if the
+// component is healthy and otherwise an error describing the problem.
+type CheckFunc func(ctx context.Context) error
+
+// Check is a registered health check.
+type Check struct {
+	// The full name of the gRPC service the check affects (i.e. Packages.Packages).
+	Service string
+	Name    string
+	Func    CheckFunc
+}
+
+var (
+	mu     sync.RWMutex
+	checks = make(map[string]Check)
+)
+
+// RegisterCheck adds a check for service which is run by Detailed and when
+// the standard gRPC health status of the service is requested. Services
+// normally call this from init(). Registering the same service and name
+// twice panics.
+func RegisterCheck(service string, name string, f CheckFunc) {
+	mu.Lock()
+	defer mu.Unlock()
+	key := service + "/" + name
+	if _, ok := checks[key]; ok {
+		panic(fmt.Sprintf("healthcheck: check %s registered twice", key))
+	}
+	checks[key] = Check{
+		Service: service,
+		Name:    name,
+		Func:    f,
+	}
+}
+
+// ListChecks returns the registered checks sorted by service and then name.
+func ListChecks() []Check {
+	mu.RLock()
+	defer mu.RUnlock()
+	var out []Check
+	for _, c := range checks {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Service != out[j].Service {
+			return out[i].Service < out[j].Service
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
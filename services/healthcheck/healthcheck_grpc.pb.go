@@ -21,6 +21,9 @@ const _ = grpc.SupportPackageIsVersion7
 type HealthCheckClient interface {
 	// Ok merely signals if the endpoint is reachable.
 	Ok(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Detailed runs the checks services have registered and returns each
+	// result. A failing check isn't an RPC error.
+	Detailed(ctx context.Context, in *DetailedRequest, opts ...grpc.CallOption) (*DetailedReply, error)
 }
 
 type healthCheckClient struct {
@@ -40,12 +43,24 @@ func (c *healthCheckClient) Ok(ctx context.Context, in *emptypb.Empty, opts ...g
 	return out, nil
 }
 
+func (c *healthCheckClient) Detailed(ctx context.Context, in *DetailedRequest, opts ...grpc.CallOption) (*DetailedReply, error) {
+	out := new(DetailedReply)
+	err := c.cc.Invoke(ctx, "/HealthCheck.HealthCheck/Detailed", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HealthCheckServer is the server API for HealthCheck service.
 // All implementations should embed UnimplementedHealthCheckServer
 // for forward compatibility
 type HealthCheckServer interface {
 	// Ok merely signals if the endpoint is reachable.
 	Ok(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	// Detailed runs the checks services have registered and returns each
+	// result. A failing check isn't an RPC error.
+	Detailed(context.Context, *DetailedRequest) (*DetailedReply, error)
 }
 
 // UnimplementedHealthCheckServer should be embedded to have forward compatible implementations.
@@ -55,6 +70,9 @@ type UnimplementedHealthCheckServer struct {
 func (UnimplementedHealthCheckServer) Ok(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ok not implemented")
 }
+func (UnimplementedHealthCheckServer) Detailed(context.Context, *DetailedRequest) (*DetailedReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Detailed not implemented")
+}
 
 // UnsafeHealthCheckServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to HealthCheckServer will
@@ -85,6 +103,24 @@ func _HealthCheck_Ok_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HealthCheck_Detailed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DetailedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HealthCheckServer).Detailed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/HealthCheck.HealthCheck/Detailed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HealthCheckServer).Detailed(ctx, req.(*DetailedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // HealthCheck_ServiceDesc is the grpc.ServiceDesc for HealthCheck service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -96,6 +132,10 @@ var HealthCheck_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Ok",
 			Handler:    _HealthCheck_Ok_Handler,
 		},
+		{
+			MethodName: "Detailed",
+			Handler:    _HealthCheck_Detailed_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "healthcheck.proto",
@@ -20,6 +20,7 @@ import (
 type HealthCheckClientProxy interface {
 	HealthCheckClient
 	OkOneMany(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (<-chan *OkManyResponse, error)
+	DetailedOneMany(ctx context.Context, in *DetailedRequest, opts ...grpc.CallOption) (<-chan *DetailedManyResponse, error)
 }
 
 // Embed the original client inside of this so we get the other generated methods automatically.
@@ -99,3 +100,70 @@ func (c *healthCheckClientProxy) OkOneMany(ctx context.Context, in *emptypb.Empt
 
 	return ret, nil
 }
+
+// DetailedManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type DetailedManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *DetailedReply
+	Error error
+}
+
+// DetailedOneMany provides the same API as Detailed but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *healthCheckClientProxy) DetailedOneMany(ctx context.Context, in *DetailedRequest, opts ...grpc.CallOption) (<-chan *DetailedManyResponse, error) {
+	conn := c.cc.(*proxy.Conn)
+	ret := make(chan *DetailedManyResponse)
+	// If this is a single case we can just use Invoke and marshal it onto the channel once and be done.
+	if len(conn.Targets) == 1 {
+		go func() {
+			out := &DetailedManyResponse{
+				Target: conn.Targets[0],
+				Index:  0,
+				Resp:   &DetailedReply{},
+			}
+			err := conn.Invoke(ctx, "/HealthCheck.HealthCheck/Detailed", in, out.Resp, opts...)
+			if err != nil {
+				out.Error = err
+			}
+			// Send and close.
+			ret <- out
+			close(ret)
+		}()
+		return ret, nil
+	}
+	manyRet, err := conn.InvokeOneMany(ctx, "/HealthCheck.HealthCheck/Detailed", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// A goroutine to retrive untyped responses and convert them to typed ones.
+	go func() {
+		for {
+			typedResp := &DetailedManyResponse{
+				Resp: &DetailedReply{},
+			}
+
+			resp, ok := <-manyRet
+			if !ok {
+				// All done so we can shut down.
+				close(ret)
+				return
+			}
+			typedResp.Target = resp.Target
+			typedResp.Index = resp.Index
+			typedResp.Error = resp.Error
+			if resp.Error == nil {
+				if err := resp.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+					typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, resp.Error)
+				}
+			}
+			ret <- typedResp
+		}
+	}()
+
+	return ret, nil
+}
@@ -19,24 +19,189 @@ package server
 
 import (
 	"context"
+	"flag"
+	"sync"
+	"time"
 
 	"github.com/Snowflake-Labs/sansshell/services"
 	pb "github.com/Snowflake-Labs/sansshell/services/healthcheck"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+var (
+	checkTimeout = flag.Duration("healthcheck-timeout", 5*time.Second, "How long each registered health check may run before it's considered failed")
+	cacheTTL     = flag.Duration("healthcheck-cache-ttl", 5*time.Second, "How long check results are reused by Detailed and grpc.health.v1 Check/Watch calls before the checks are rerun. If 0 every call reruns them")
+)
+
+// watchInterval is how often grpc.health.v1 Watch calls look at the check results.
+// A var so tests can shorten it.
+var watchInterval = 10 * time.Second
+
 // server is used to implement the gRPC server
-type server struct{}
+type server struct {
+	// gs is the server this was registered with and is used to find
+	// which services exist.
+	gs *grpc.Server
+}
 
 // Ok always returns an Empty proto without error
 func (s *server) Ok(ctx context.Context, in *emptypb.Empty) (*emptypb.Empty, error) {
 	return &emptypb.Empty{}, nil
 }
 
+// resultCache holds the latest results of running every check so that
+// frequent probes and watchers share one run per cacheTTL.
+type resultCache struct {
+	mu      sync.Mutex
+	ran     time.Time
+	results []*pb.CheckResult
+}
+
+var cache resultCache
+
+// get returns the cached results, running the checks first if they're older
+// than cacheTTL. Concurrent callers wait for a single run. The checks run
+// detached from any caller's context so a cancelled RPC can't cache failures.
+func (c *resultCache) get() []*pb.CheckResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.results == nil || time.Since(c.ran) >= *cacheTTL {
+		c.results = runAllChecks(context.Background())
+		c.ran = time.Now()
+	}
+	return c.results
+}
+
+// runChecks returns the results of the registered checks for service (or every
+// service if empty) in the order ListChecks returns them. Results may be up to
+// cacheTTL old and must not be modified.
+func runChecks(service string) []*pb.CheckResult {
+	var out []*pb.CheckResult
+	for _, r := range cache.get() {
+		if service == "" || r.Service == service {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// runAllChecks runs all of the registered checks in parallel and returns the results
+// in the order ListChecks returns them.
+func runAllChecks(ctx context.Context) []*pb.CheckResult {
+	checks := pb.ListChecks()
+
+	results := make([]*pb.CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c pb.Check) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, *checkTimeout)
+			defer cancel()
+			start := time.Now()
+			err := c.Func(ctx)
+			r := &pb.CheckResult{
+				Name:     c.Name,
+				Service:  c.Service,
+				Status:   pb.CheckStatus_CHECK_STATUS_OK,
+				Duration: durationpb.New(time.Since(start)),
+			}
+			if err != nil {
+				r.Status = pb.CheckStatus_CHECK_STATUS_FAILED
+				r.Message = err.Error()
+			}
+			results[i] = r
+		}(i, c)
+	}
+	wg.Wait()
+	return results
+}
+
+// knownService returns true if service is registered with the gRPC server.
+func (s *server) knownService(service string) bool {
+	if service == "" {
+		return true
+	}
+	_, ok := s.gs.GetServiceInfo()[service]
+	return ok
+}
+
+// Detailed runs the registered checks and returns their results.
+func (s *server) Detailed(ctx context.Context, req *pb.DetailedRequest) (*pb.DetailedReply, error) {
+	if !s.knownService(req.Service) {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.Service)
+	}
+	resp := &pb.DetailedReply{
+		Status: pb.CheckStatus_CHECK_STATUS_OK,
+		Checks: runChecks(req.Service),
+	}
+	for _, c := range resp.Checks {
+		if c.Status != pb.CheckStatus_CHECK_STATUS_OK {
+			resp.Status = pb.CheckStatus_CHECK_STATUS_FAILED
+		}
+	}
+	return resp, nil
+}
+
+// healthServer implements grpc.health.v1.Health based on the registered checks.
+// The empty service is the health of the server as a whole and so depends on
+// every check.
+type healthServer struct {
+	s *server
+}
+
+func (h *healthServer) status(service string) healthpb.HealthCheckResponse_ServingStatus {
+	if !h.s.knownService(service) {
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+	}
+	for _, r := range runChecks(service) {
+		if r.Status != pb.CheckStatus_CHECK_STATUS_OK {
+			return healthpb.HealthCheckResponse_NOT_SERVING
+		}
+	}
+	return healthpb.HealthCheckResponse_SERVING
+}
+
+func (h *healthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	st := h.status(req.Service)
+	if st == healthpb.HealthCheckResponse_SERVICE_UNKNOWN {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.Service)
+	}
+	return &healthpb.HealthCheckResponse{Status: st}, nil
+}
+
+// Watch looks at the (cached) check results every watchInterval and sends the status
+// whenever it changes.
+func (h *healthServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ctx := stream.Context()
+	last := healthpb.HealthCheckResponse_ServingStatus(-1)
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	for {
+		if st := h.status(req.Service); st != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: st}); err != nil {
+				return err
+			}
+			last = st
+		}
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
 // Register is called to expose this handler to the gRPC server
 func (s *server) Register(gs *grpc.Server) {
+	s.gs = gs
 	pb.RegisterHealthCheckServer(gs, s)
+	healthpb.RegisterHealthServer(gs, &healthServer{s: s})
 }
 
 func init() {
@@ -18,16 +18,23 @@ package server
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net"
 	"os"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	pb "github.com/Snowflake-Labs/sansshell/services/healthcheck"
 	"github.com/Snowflake-Labs/sansshell/testing/testutil"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -35,6 +42,11 @@ var (
 	bufSize = 1024 * 1024
 	lis     *bufconn.Listener
 	conn    *grpc.ClientConn
+
+	// failing controls whether the test-toggle check fails.
+	failing int32
+	// toggleRuns counts how often the test-toggle check has run.
+	toggleRuns int32
 )
 
 func bufDialer(context.Context, string) (net.Conn, error) {
@@ -42,6 +54,20 @@ func bufDialer(context.Context, string) (net.Conn, error) {
 }
 
 func TestMain(m *testing.M) {
+	// Most tests change check results between calls so caching is only
+	// enabled by TestCache.
+	*cacheTTL = 0
+	service := pb.HealthCheck_ServiceDesc.ServiceName
+	pb.RegisterCheck(service, "test-ok", func(context.Context) error { return nil })
+	pb.RegisterCheck(service, "test-toggle", func(context.Context) error {
+		atomic.AddInt32(&toggleRuns, 1)
+		if atomic.LoadInt32(&failing) != 0 {
+			return errors.New("toggled off")
+		}
+		return nil
+	})
+	pb.RegisterCheck("Other.Other", "test-other", func(context.Context) error { return errors.New("never ok") })
+
 	lis = bufconn.Listen(bufSize)
 	s := grpc.NewServer()
 	lfs := &server{}
@@ -67,3 +93,251 @@ func TestRead(t *testing.T) {
 	_, err = client.Ok(ctx, &emptypb.Empty{})
 	testutil.FatalOnErr("HealthCheck failed", err, t)
 }
+
+func TestRegisterCheckTwice(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("registering a duplicate check didn't panic")
+		}
+	}()
+	pb.RegisterCheck(pb.HealthCheck_ServiceDesc.ServiceName, "test-ok", func(context.Context) error { return nil })
+}
+
+func TestDetailed(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("Failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+	t.Cleanup(func() { atomic.StoreInt32(&failing, 0) })
+
+	service := pb.HealthCheck_ServiceDesc.ServiceName
+	client := pb.NewHealthCheckClient(conn)
+	for _, tc := range []struct {
+		name    string
+		service string
+		failing bool
+		wantErr bool
+		want    *pb.DetailedReply
+	}{
+		{
+			name:    "service ok",
+			service: service,
+			want: &pb.DetailedReply{
+				Status: pb.CheckStatus_CHECK_STATUS_OK,
+				Checks: []*pb.CheckResult{
+					{Name: "test-ok", Service: service, Status: pb.CheckStatus_CHECK_STATUS_OK},
+					{Name: "test-toggle", Service: service, Status: pb.CheckStatus_CHECK_STATUS_OK},
+				},
+			},
+		},
+		{
+			name:    "service failing",
+			service: service,
+			failing: true,
+			want: &pb.DetailedReply{
+				Status: pb.CheckStatus_CHECK_STATUS_FAILED,
+				Checks: []*pb.CheckResult{
+					{Name: "test-ok", Service: service, Status: pb.CheckStatus_CHECK_STATUS_OK},
+					{Name: "test-toggle", Service: service, Status: pb.CheckStatus_CHECK_STATUS_FAILED, Message: "toggled off"},
+				},
+			},
+		},
+		{
+			name: "all services",
+			want: &pb.DetailedReply{
+				Status: pb.CheckStatus_CHECK_STATUS_FAILED,
+				Checks: []*pb.CheckResult{
+					{Name: "test-ok", Service: service, Status: pb.CheckStatus_CHECK_STATUS_OK},
+					{Name: "test-toggle", Service: service, Status: pb.CheckStatus_CHECK_STATUS_OK},
+					{Name: "test-other", Service: "Other.Other", Status: pb.CheckStatus_CHECK_STATUS_FAILED, Message: "never ok"},
+				},
+			},
+		},
+		{
+			name:    "unknown service",
+			service: "Other.Other",
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.failing {
+				atomic.StoreInt32(&failing, 1)
+			} else {
+				atomic.StoreInt32(&failing, 0)
+			}
+			resp, err := client.Detailed(ctx, &pb.DetailedRequest{Service: tc.service})
+			testutil.WantErr(tc.name, err, tc.wantErr, t)
+			if tc.wantErr {
+				return
+			}
+			for _, c := range resp.Checks {
+				if c.Duration == nil {
+					t.Errorf("%s: check %s has no duration", tc.name, c.Name)
+				}
+			}
+			testutil.DiffErr(tc.name, resp, tc.want, t, protocmp.IgnoreFields(&pb.CheckResult{}, "duration"))
+		})
+	}
+}
+
+func TestHealth(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("Failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+	t.Cleanup(func() { atomic.StoreInt32(&failing, 0) })
+
+	service := pb.HealthCheck_ServiceDesc.ServiceName
+	client := healthpb.NewHealthClient(conn)
+	for _, tc := range []struct {
+		name     string
+		service  string
+		failing  bool
+		wantCode codes.Code
+		want     healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{
+			name:    "service serving",
+			service: service,
+			want:    healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			name:    "service not serving",
+			service: service,
+			failing: true,
+			want:    healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			name:    "service without checks",
+			service: healthpb.Health_ServiceDesc.ServiceName,
+			failing: true,
+			want:    healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			// The other check never passes.
+			name: "whole server",
+			want: healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			name:     "unknown service",
+			service:  "Other.Other",
+			wantCode: codes.NotFound,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.failing {
+				atomic.StoreInt32(&failing, 1)
+			} else {
+				atomic.StoreInt32(&failing, 0)
+			}
+			resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: tc.service})
+			if got := status.Code(err); got != tc.wantCode {
+				t.Fatalf("%s: got code %v want %v (err %v)", tc.name, got, tc.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+			if got := resp.Status; got != tc.want {
+				t.Fatalf("%s: got %v want %v", tc.name, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	var err error
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("Failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+	t.Cleanup(func() { atomic.StoreInt32(&failing, 0) })
+
+	saved := watchInterval
+	watchInterval = 10 * time.Millisecond
+	t.Cleanup(func() { watchInterval = saved })
+
+	client := healthpb.NewHealthClient(conn)
+	atomic.StoreInt32(&failing, 0)
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: pb.HealthCheck_ServiceDesc.ServiceName})
+	testutil.FatalOnErr("Watch", err, t)
+
+	// Each change is sent once so flip the check after each status arrives.
+	for _, want := range []healthpb.HealthCheckResponse_ServingStatus{
+		healthpb.HealthCheckResponse_SERVING,
+		healthpb.HealthCheckResponse_NOT_SERVING,
+		healthpb.HealthCheckResponse_SERVING,
+	} {
+		resp, err := stream.Recv()
+		testutil.FatalOnErr("Watch recv", err, t)
+		if got := resp.Status; got != want {
+			t.Fatalf("got %v want %v", got, want)
+		}
+		atomic.StoreInt32(&failing, 1-atomic.LoadInt32(&failing))
+	}
+
+	// Unknown services are reported rather than failing the call.
+	stream, err = client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "Other.Other"})
+	testutil.FatalOnErr("Watch unknown", err, t)
+	resp, err := stream.Recv()
+	testutil.FatalOnErr("Watch unknown recv", err, t)
+	if got, want := resp.Status, healthpb.HealthCheckResponse_SERVICE_UNKNOWN; got != want {
+		t.Fatalf("got %v want %v", got, want)
+	}
+}
+
+func TestCache(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("Failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+	t.Cleanup(func() { atomic.StoreInt32(&failing, 0) })
+
+	*cacheTTL = time.Hour
+	t.Cleanup(func() { *cacheTTL = 0 })
+	expire := func() {
+		cache.mu.Lock()
+		defer cache.mu.Unlock()
+		cache.results = nil
+	}
+	expire()
+
+	service := pb.HealthCheck_ServiceDesc.ServiceName
+	client := pb.NewHealthCheckClient(conn)
+	health := healthpb.NewHealthClient(conn)
+	atomic.StoreInt32(&failing, 0)
+	start := atomic.LoadInt32(&toggleRuns)
+	for i := 0; i < 3; i++ {
+		resp, err := client.Detailed(ctx, &pb.DetailedRequest{Service: service})
+		testutil.FatalOnErr("Detailed", err, t)
+		if resp.Status != pb.CheckStatus_CHECK_STATUS_OK {
+			t.Fatalf("Detailed status %v, want OK", resp.Status)
+		}
+		// Other.Other never passes so the server as a whole isn't serving.
+		hc, err := health.Check(ctx, &healthpb.HealthCheckRequest{})
+		testutil.FatalOnErr("Check", err, t)
+		if hc.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+			t.Fatalf("Check status %v, want NOT_SERVING", hc.Status)
+		}
+		// Changes aren't seen until the cache expires.
+		atomic.StoreInt32(&failing, 1)
+	}
+	if got := atomic.LoadInt32(&toggleRuns) - start; got != 1 {
+		t.Fatalf("checks ran %d times, want 1", got)
+	}
+
+	expire()
+	resp, err := client.Detailed(ctx, &pb.DetailedRequest{Service: service})
+	testutil.FatalOnErr("Detailed", err, t)
+	if resp.Status != pb.CheckStatus_CHECK_STATUS_FAILED {
+		t.Fatalf("Detailed status after expiry %v, want FAILED", resp.Status)
+	}
+	if got := atomic.LoadInt32(&toggleRuns) - start; got != 2 {
+		t.Fatalf("checks ran %d times, want 2", got)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strings"
@@ -43,7 +44,19 @@ func setup(f *flag.FlagSet) *subcommands.Commander {
 	c.Register(&installCmd{}, "")
 	c.Register(&listCmd{}, "")
 	c.Register(&repoListCmd{}, "")
+	c.Register(&repoAddCmd{}, "")
+	c.Register(&repoRemoveCmd{}, "")
+	c.Register(&repoEnableCmd{}, "")
+	c.Register(&repoEnableCmd{disable: true}, "")
 	c.Register(&updateCmd{}, "")
+	c.Register(&removeCmd{}, "")
+	c.Register(&downgradeCmd{}, "")
+	c.Register(&searchCmd{}, "")
+	c.Register(&infoCmd{}, "")
+	c.Register(&filesCmd{}, "")
+	c.Register(&verifyCmd{}, "")
+	c.Register(&historyCmd{}, "")
+	c.Register(&undoCmd{}, "")
 	return c
 }
 
@@ -81,11 +94,60 @@ func shortPackageSystemNames() []string {
 	return shortNames
 }
 
+// outputPlannedChanges prints the changes a dry run would make.
+func outputPlannedChanges(out io.Writer, changes []*pb.PackageChange) {
+	fmt.Fprint(out, "Planned changes\n")
+	for _, c := range changes {
+		fmt.Fprintf(out, "%-28s %40s %24s %8s %16s\n", c.Action, c.Name, c.Version, c.Architecture, c.Repo)
+	}
+}
+
+// progressResponse is the per target part of an InstallStream or UpdateStream response.
+type progressResponse struct {
+	target string
+	index  int
+	resp   *pb.ProgressReply
+	err    error
+}
+
+// outputProgress prints each line of progress as it's received from every target.
+func outputProgress(state *util.ExecuteState, name string, recv func() ([]progressResponse, error)) subcommands.ExitStatus {
+	retCode := subcommands.ExitSuccess
+	for {
+		resp, err := recv()
+		if err == io.EOF {
+			break
+		}
+		// If the stream returns an error we're just done.
+		if err != nil {
+			// Emit this to every error file as it's not specific to a given target.
+			for _, e := range state.Err {
+				fmt.Fprintf(e, "Receive error: %v\n", err)
+			}
+			retCode = subcommands.ExitFailure
+			break
+		}
+		for _, r := range resp {
+			if r.err != nil && r.err != io.EOF {
+				fmt.Fprintf(state.Err[r.index], "%s for target %s (%d) returned error: %v\n", name, r.target, r.index, r.err)
+				retCode = subcommands.ExitFailure
+				continue
+			}
+			if r.resp != nil {
+				fmt.Fprintln(state.Out[r.index], r.resp.Line)
+			}
+		}
+	}
+	return retCode
+}
+
 type installCmd struct {
 	packageSystem string
 	name          string
 	version       string
 	repo          string
+	dryRun        bool
+	stream        bool
 }
 
 func (*installCmd) Name() string     { return "install" }
@@ -97,10 +159,12 @@ func (*installCmd) Usage() string {
 }
 
 func (i *installCmd) SetFlags(f *flag.FlagSet) {
-	f.StringVar(&i.packageSystem, "package-system", "YUM", fmt.Sprintf("Package system to use(one of: [%s])", strings.Join(shortPackageSystemNames(), ",")))
+	f.StringVar(&i.packageSystem, "package-system", "UNKNOWN", fmt.Sprintf("Package system to use(one of: [%s]). UNKNOWN lets the remote host pick", strings.Join(shortPackageSystemNames(), ",")))
 	f.StringVar(&i.name, "name", "", "Name of package to install")
-	f.StringVar(&i.version, "version", "", "Version of package to install. For YUM this must be a full nevra version")
+	f.StringVar(&i.version, "version", "", "Version of package to install. For YUM this must be a full nevra version. For APT this is the Debian version")
 	f.StringVar(&i.repo, "repo", "", "If set also enable this repo when resolving packages.")
+	f.BoolVar(&i.dryRun, "dry-run", false, "If true only resolve the install and print the changes it would make")
+	f.BoolVar(&i.stream, "stream", false, "If true print output from the package manager as the install runs")
 }
 
 func (i *installCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
@@ -118,6 +182,30 @@ func (i *installCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...inter
 		Name:          i.name,
 		Version:       i.version,
 		Repo:          i.repo,
+		DryRun:        i.dryRun,
+	}
+
+	if i.stream {
+		if i.dryRun {
+			fmt.Fprintln(os.Stderr, "--dry-run and --stream can't be used together")
+			return subcommands.ExitFailure
+		}
+		stream, err := c.InstallStreamOneMany(ctx, req)
+		if err != nil {
+			// Emit this to every error file as it's not specific to a given target.
+			for _, e := range state.Err {
+				fmt.Fprintf(e, "InstallStream returned error: %v\n", err)
+			}
+			return subcommands.ExitFailure
+		}
+		return outputProgress(state, "Install", func() ([]progressResponse, error) {
+			resp, err := stream.Recv()
+			var out []progressResponse
+			for _, r := range resp {
+				out = append(out, progressResponse{target: r.Target, index: r.Index, resp: r.Resp, err: r.Error})
+			}
+			return out, err
+		})
 	}
 
 	resp, err := c.InstallOneMany(ctx, req)
@@ -136,6 +224,10 @@ func (i *installCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...inter
 			retCode = subcommands.ExitFailure
 			continue
 		}
+		if i.dryRun {
+			outputPlannedChanges(state.Out[r.Index], r.Resp.PlannedChanges)
+			continue
+		}
 		fmt.Fprintf(state.Out[r.Index], "Success!\n\nOutput from installation:\n%s\n", r.Resp.DebugOutput)
 	}
 	return retCode
@@ -147,6 +239,8 @@ type updateCmd struct {
 	oldVersion    string
 	newVersion    string
 	repo          string
+	dryRun        bool
+	stream        bool
 }
 
 func (*updateCmd) Name() string     { return "update" }
@@ -158,11 +252,13 @@ func (*updateCmd) Usage() string {
 }
 
 func (u *updateCmd) SetFlags(f *flag.FlagSet) {
-	f.StringVar(&u.packageSystem, "package-system", "YUM", fmt.Sprintf("Package system to use(one of: [%s])", strings.Join(shortPackageSystemNames(), ",")))
+	f.StringVar(&u.packageSystem, "package-system", "UNKNOWN", fmt.Sprintf("Package system to use(one of: [%s]). UNKNOWN lets the remote host pick", strings.Join(shortPackageSystemNames(), ",")))
 	f.StringVar(&u.name, "name", "", "Name of package to install")
-	f.StringVar(&u.oldVersion, "old_version", "", "Old version of package which must be on the system. For YUM this must be a full nevra version")
-	f.StringVar(&u.newVersion, "new_version", "", "New version of package to update. For YUM this must be a full nevra version")
+	f.StringVar(&u.oldVersion, "old_version", "", "Old version of package which must be on the system. For YUM this must be a full nevra version. For APT this is the Debian version")
+	f.StringVar(&u.newVersion, "new_version", "", "New version of package to update. For YUM this must be a full nevra version. For APT this is the Debian version")
 	f.StringVar(&u.repo, "repo", "", "If set also enable this repo when resolving packages.")
+	f.BoolVar(&u.dryRun, "dry-run", false, "If true only resolve the update and print the changes it would make")
+	f.BoolVar(&u.stream, "stream", false, "If true print output from the package manager as the update runs")
 }
 
 func (u *updateCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
@@ -186,6 +282,30 @@ func (u *updateCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interf
 		OldVersion:    u.oldVersion,
 		NewVersion:    u.newVersion,
 		Repo:          u.repo,
+		DryRun:        u.dryRun,
+	}
+
+	if u.stream {
+		if u.dryRun {
+			fmt.Fprintln(os.Stderr, "--dry-run and --stream can't be used together")
+			return subcommands.ExitFailure
+		}
+		stream, err := c.UpdateStreamOneMany(ctx, req)
+		if err != nil {
+			// Emit this to every error file as it's not specific to a given target.
+			for _, e := range state.Err {
+				fmt.Fprintf(e, "UpdateStream returned error: %v\n", err)
+			}
+			return subcommands.ExitFailure
+		}
+		return outputProgress(state, "Update", func() ([]progressResponse, error) {
+			resp, err := stream.Recv()
+			var out []progressResponse
+			for _, r := range resp {
+				out = append(out, progressResponse{target: r.Target, index: r.Index, resp: r.Resp, err: r.Error})
+			}
+			return out, err
+		})
 	}
 
 	resp, err := c.UpdateOneMany(ctx, req)
@@ -204,11 +324,532 @@ func (u *updateCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interf
 			retCode = subcommands.ExitFailure
 			continue
 		}
+		if u.dryRun {
+			outputPlannedChanges(state.Out[r.Index], r.Resp.PlannedChanges)
+			continue
+		}
 		fmt.Fprintf(state.Out[r.Index], "Success!\n\nOutput from update:\n%s\n", r.Resp.DebugOutput)
 	}
 	return retCode
 }
 
+type removeCmd struct {
+	packageSystem string
+	name          string
+	version       string
+}
+
+func (*removeCmd) Name() string     { return "remove" }
+func (*removeCmd) Synopsis() string { return "Remove an installed package" }
+func (*removeCmd) Usage() string {
+	return `remove:
+  Remove a package from the remote machine. The package must be installed at the given version.
+`
+}
+
+func (rm *removeCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&rm.packageSystem, "package-system", "UNKNOWN", fmt.Sprintf("Package system to use(one of: [%s]). UNKNOWN lets the remote host pick", strings.Join(shortPackageSystemNames(), ",")))
+	f.StringVar(&rm.name, "name", "", "Name of package to remove")
+	f.StringVar(&rm.version, "version", "", "Version of package which must be on the system. For YUM this must be a full nevra version. For APT this is the Debian version")
+}
+
+func (rm *removeCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	if rm.name == "" || rm.version == "" {
+		fmt.Fprintln(os.Stderr, "--name and --version must be supplied")
+		return subcommands.ExitFailure
+	}
+
+	ps, err := flagToType(rm.packageSystem)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Can't parse package system for --package-system: %s invalid\n", rm.packageSystem)
+		return subcommands.ExitFailure
+	}
+
+	state := args[0].(*util.ExecuteState)
+	c := pb.NewPackagesClientProxy(state.Conn)
+
+	req := &pb.RemoveRequest{
+		PackageSystem: ps,
+		Name:          rm.name,
+		Version:       rm.version,
+	}
+
+	resp, err := c.RemoveOneMany(ctx, req)
+	if err != nil {
+		// Emit this to every error file as it's not specific to a given target.
+		for _, e := range state.Err {
+			fmt.Fprintf(e, "Remove returned error: %v\n", err)
+		}
+		return subcommands.ExitFailure
+	}
+
+	retCode := subcommands.ExitSuccess
+	for r := range resp {
+		if r.Error != nil {
+			fmt.Fprintf(state.Err[r.Index], "Remove for target %s (%d) returned error: %v\n", r.Target, r.Index, r.Error)
+			retCode = subcommands.ExitFailure
+			continue
+		}
+		fmt.Fprintf(state.Out[r.Index], "Success!\n\nOutput from remove:\n%s\n", r.Resp.DebugOutput)
+	}
+	return retCode
+}
+
+type downgradeCmd struct {
+	packageSystem string
+	name          string
+	oldVersion    string
+	newVersion    string
+	repo          string
+}
+
+func (*downgradeCmd) Name() string     { return "downgrade" }
+func (*downgradeCmd) Synopsis() string { return "Downgrade an existing package" }
+func (*downgradeCmd) Usage() string {
+	return `downgrade:
+  Downgrade a package on the remote machine. The package must already be installed at a known version.
+`
+}
+
+func (d *downgradeCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&d.packageSystem, "package-system", "UNKNOWN", fmt.Sprintf("Package system to use(one of: [%s]). UNKNOWN lets the remote host pick", strings.Join(shortPackageSystemNames(), ",")))
+	f.StringVar(&d.name, "name", "", "Name of package to downgrade")
+	f.StringVar(&d.oldVersion, "old_version", "", "Old version of package which must be on the system. For YUM this must be a full nevra version. For APT this is the Debian version")
+	f.StringVar(&d.newVersion, "new_version", "", "Version of package to downgrade to. For YUM this must be a full nevra version. For APT this is the Debian version")
+	f.StringVar(&d.repo, "repo", "", "If set also enable this repo when resolving packages.")
+}
+
+func (d *downgradeCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	if d.name == "" || d.oldVersion == "" || d.newVersion == "" {
+		fmt.Fprintln(os.Stderr, "--name, --old_version and --new_version must be supplied")
+		return subcommands.ExitFailure
+	}
+
+	ps, err := flagToType(d.packageSystem)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Can't parse package system for --package-system: %s invalid\n", d.packageSystem)
+		return subcommands.ExitFailure
+	}
+
+	state := args[0].(*util.ExecuteState)
+	c := pb.NewPackagesClientProxy(state.Conn)
+
+	req := &pb.DowngradeRequest{
+		PackageSystem: ps,
+		Name:          d.name,
+		OldVersion:    d.oldVersion,
+		NewVersion:    d.newVersion,
+		Repo:          d.repo,
+	}
+
+	resp, err := c.DowngradeOneMany(ctx, req)
+	if err != nil {
+		// Emit this to every error file as it's not specific to a given target.
+		for _, e := range state.Err {
+			fmt.Fprintf(e, "Downgrade returned error: %v\n", err)
+		}
+		return subcommands.ExitFailure
+	}
+
+	retCode := subcommands.ExitSuccess
+	for r := range resp {
+		if r.Error != nil {
+			fmt.Fprintf(state.Err[r.Index], "Downgrade for target %s (%d) returned error: %v\n", r.Target, r.Index, r.Error)
+			retCode = subcommands.ExitFailure
+			continue
+		}
+		fmt.Fprintf(state.Out[r.Index], "Success!\n\nOutput from downgrade:\n%s\n", r.Resp.DebugOutput)
+	}
+	return retCode
+}
+
+type searchCmd struct {
+	packageSystem string
+	name          string
+	repo          string
+}
+
+func (*searchCmd) Name() string     { return "search" }
+func (*searchCmd) Synopsis() string { return "List available versions of a package" }
+func (*searchCmd) Usage() string {
+	return `search:
+  List the versions of a package available to the remote machine and the repo providing each.
+`
+}
+
+func (s *searchCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&s.packageSystem, "package-system", "UNKNOWN", fmt.Sprintf("Package system to use(one of: [%s]). UNKNOWN lets the remote host pick", strings.Join(shortPackageSystemNames(), ",")))
+	f.StringVar(&s.name, "name", "", "Name of package to search for")
+	f.StringVar(&s.repo, "repo", "", "If set also enable this repo when searching (YUM only).")
+}
+
+func (s *searchCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	if s.name == "" {
+		fmt.Fprintln(os.Stderr, "--name must be supplied")
+		return subcommands.ExitFailure
+	}
+
+	ps, err := flagToType(s.packageSystem)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Can't parse package system for --package-system: %s invalid\n", s.packageSystem)
+		return subcommands.ExitFailure
+	}
+
+	state := args[0].(*util.ExecuteState)
+	c := pb.NewPackagesClientProxy(state.Conn)
+
+	resp, err := c.SearchOneMany(ctx, &pb.SearchRequest{
+		PackageSystem: ps,
+		Name:          s.name,
+		Repo:          s.repo,
+	})
+	if err != nil {
+		// Emit this to every error file as it's not specific to a given target.
+		for _, e := range state.Err {
+			fmt.Fprintf(e, "Search returned error: %v\n", err)
+		}
+		return subcommands.ExitFailure
+	}
+
+	retCode := subcommands.ExitSuccess
+	for r := range resp {
+		if r.Error != nil {
+			fmt.Fprintf(state.Err[r.Index], "Search for target %s (%d) returned error: %v\n", r.Target, r.Index, r.Error)
+			retCode = subcommands.ExitFailure
+			continue
+		}
+		fmt.Fprint(state.Out[r.Index], "Available Packages\n")
+		for _, pkg := range r.Resp.Packages {
+			// Print the package name, version and repo with some reasonable spacing.
+			fmt.Fprintf(state.Out[r.Index], "%40s %16s %32s\n", pkg.Name, pkg.Version, pkg.Repo)
+		}
+	}
+	return retCode
+}
+
+type infoCmd struct {
+	packageSystem string
+	name          string
+}
+
+func (*infoCmd) Name() string     { return "info" }
+func (*infoCmd) Synopsis() string { return "Show details of an installed package" }
+func (*infoCmd) Usage() string {
+	return `info:
+  Show the version, size, install time, source repo and dependencies of an installed package.
+`
+}
+
+func (i *infoCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&i.packageSystem, "package-system", "UNKNOWN", fmt.Sprintf("Package system to use(one of: [%s]). UNKNOWN lets the remote host pick", strings.Join(shortPackageSystemNames(), ",")))
+	f.StringVar(&i.name, "name", "", "Name of package")
+}
+
+func (i *infoCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	if i.name == "" {
+		fmt.Fprintln(os.Stderr, "--name must be supplied")
+		return subcommands.ExitFailure
+	}
+
+	ps, err := flagToType(i.packageSystem)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Can't parse package system for --package-system: %s invalid\n", i.packageSystem)
+		return subcommands.ExitFailure
+	}
+
+	state := args[0].(*util.ExecuteState)
+	c := pb.NewPackagesClientProxy(state.Conn)
+
+	resp, err := c.InfoOneMany(ctx, &pb.InfoRequest{
+		PackageSystem: ps,
+		Name:          i.name,
+	})
+	if err != nil {
+		// Emit this to every error file as it's not specific to a given target.
+		for _, e := range state.Err {
+			fmt.Fprintf(e, "Info returned error: %v\n", err)
+		}
+		return subcommands.ExitFailure
+	}
+
+	retCode := subcommands.ExitSuccess
+	for r := range resp {
+		if r.Error != nil {
+			fmt.Fprintf(state.Err[r.Index], "Info for target %s (%d) returned error: %v\n", r.Target, r.Index, r.Error)
+			retCode = subcommands.ExitFailure
+			continue
+		}
+		for _, pkg := range r.Resp.Packages {
+			out := state.Out[r.Index]
+			fmt.Fprintf(out, "Name         : %s\n", pkg.Name)
+			fmt.Fprintf(out, "Version      : %s\n", pkg.Version)
+			fmt.Fprintf(out, "Architecture : %s\n", pkg.Architecture)
+			fmt.Fprintf(out, "Size         : %d\n", pkg.Size)
+			if pkg.InstallTime != nil {
+				fmt.Fprintf(out, "Install time : %s\n", pkg.InstallTime.AsTime().Local())
+			}
+			fmt.Fprintf(out, "Repo         : %s\n", pkg.Repo)
+			fmt.Fprintf(out, "Summary      : %s\n", pkg.Summary)
+			for _, d := range pkg.Dependencies {
+				fmt.Fprintf(out, "Depends      : %s\n", d)
+			}
+			fmt.Fprintf(out, "Description  :\n%s\n\n", pkg.Description)
+		}
+	}
+	return retCode
+}
+
+type filesCmd struct {
+	packageSystem string
+	name          string
+}
+
+func (*filesCmd) Name() string     { return "files" }
+func (*filesCmd) Synopsis() string { return "List the files owned by an installed package" }
+func (*filesCmd) Usage() string {
+	return `files:
+  List the files owned by an installed package on the remote machine.
+`
+}
+
+func (fc *filesCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&fc.packageSystem, "package-system", "UNKNOWN", fmt.Sprintf("Package system to use(one of: [%s]). UNKNOWN lets the remote host pick", strings.Join(shortPackageSystemNames(), ",")))
+	f.StringVar(&fc.name, "name", "", "Name of package")
+}
+
+func (fc *filesCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	if fc.name == "" {
+		fmt.Fprintln(os.Stderr, "--name must be supplied")
+		return subcommands.ExitFailure
+	}
+
+	ps, err := flagToType(fc.packageSystem)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Can't parse package system for --package-system: %s invalid\n", fc.packageSystem)
+		return subcommands.ExitFailure
+	}
+
+	state := args[0].(*util.ExecuteState)
+	c := pb.NewPackagesClientProxy(state.Conn)
+
+	resp, err := c.FilesOneMany(ctx, &pb.FilesRequest{
+		PackageSystem: ps,
+		Name:          fc.name,
+	})
+	if err != nil {
+		// Emit this to every error file as it's not specific to a given target.
+		for _, e := range state.Err {
+			fmt.Fprintf(e, "Files returned error: %v\n", err)
+		}
+		return subcommands.ExitFailure
+	}
+
+	retCode := subcommands.ExitSuccess
+	for r := range resp {
+		if r.Error != nil {
+			fmt.Fprintf(state.Err[r.Index], "Files for target %s (%d) returned error: %v\n", r.Target, r.Index, r.Error)
+			retCode = subcommands.ExitFailure
+			continue
+		}
+		for _, file := range r.Resp.Files {
+			fmt.Fprintln(state.Out[r.Index], file)
+		}
+	}
+	return retCode
+}
+
+type verifyCmd struct {
+	packageSystem string
+	name          string
+}
+
+func (*verifyCmd) Name() string     { return "verify" }
+func (*verifyCmd) Synopsis() string { return "Verify the files owned by an installed package" }
+func (*verifyCmd) Usage() string {
+	return `verify:
+  Compare the files owned by an installed package against the package database and
+  list any which differ. Exits non-zero if any discrepancies are found.
+`
+}
+
+func (v *verifyCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&v.packageSystem, "package-system", "UNKNOWN", fmt.Sprintf("Package system to use(one of: [%s]). UNKNOWN lets the remote host pick", strings.Join(shortPackageSystemNames(), ",")))
+	f.StringVar(&v.name, "name", "", "Name of package")
+}
+
+func (v *verifyCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	if v.name == "" {
+		fmt.Fprintln(os.Stderr, "--name must be supplied")
+		return subcommands.ExitFailure
+	}
+
+	ps, err := flagToType(v.packageSystem)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Can't parse package system for --package-system: %s invalid\n", v.packageSystem)
+		return subcommands.ExitFailure
+	}
+
+	state := args[0].(*util.ExecuteState)
+	c := pb.NewPackagesClientProxy(state.Conn)
+
+	resp, err := c.VerifyOneMany(ctx, &pb.VerifyRequest{
+		PackageSystem: ps,
+		Name:          v.name,
+	})
+	if err != nil {
+		// Emit this to every error file as it's not specific to a given target.
+		for _, e := range state.Err {
+			fmt.Fprintf(e, "Verify returned error: %v\n", err)
+		}
+		return subcommands.ExitFailure
+	}
+
+	retCode := subcommands.ExitSuccess
+	for r := range resp {
+		if r.Error != nil {
+			fmt.Fprintf(state.Err[r.Index], "Verify for target %s (%d) returned error: %v\n", r.Target, r.Index, r.Error)
+			retCode = subcommands.ExitFailure
+			continue
+		}
+		for _, d := range r.Resp.Discrepancies {
+			retCode = subcommands.ExitFailure
+			var failed []string
+			for _, c := range d.Failed {
+				failed = append(failed, strings.ToLower(strings.TrimPrefix(c.String(), "VERIFY_CHECK_")))
+			}
+			config := ""
+			if d.Config {
+				config = " (config)"
+			}
+			fmt.Fprintf(state.Out[r.Index], "%s%s: %s\n", d.Path, config, strings.Join(failed, ","))
+		}
+	}
+	return retCode
+}
+
+type historyCmd struct {
+	packageSystem string
+	limit         uint
+}
+
+func (*historyCmd) Name() string     { return "history" }
+func (*historyCmd) Synopsis() string { return "List recent package transactions" }
+func (*historyCmd) Usage() string {
+	return `history:
+  List the most recent package transactions on the remote machine along with the packages each changed.
+  The id of a transaction can be passed to undo.
+`
+}
+
+func (h *historyCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&h.packageSystem, "package-system", "UNKNOWN", fmt.Sprintf("Package system to use(one of: [%s]). UNKNOWN lets the remote host pick", strings.Join(shortPackageSystemNames(), ",")))
+	f.UintVar(&h.limit, "limit", 0, "Maximum number of transactions to return. If 0 the remote host picks a default")
+}
+
+func (h *historyCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	ps, err := flagToType(h.packageSystem)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Can't parse package system for --package-system: %s invalid\n", h.packageSystem)
+		return subcommands.ExitFailure
+	}
+
+	state := args[0].(*util.ExecuteState)
+	c := pb.NewPackagesClientProxy(state.Conn)
+
+	resp, err := c.HistoryOneMany(ctx, &pb.HistoryRequest{
+		PackageSystem: ps,
+		Limit:         uint32(h.limit),
+	})
+	if err != nil {
+		// Emit this to every error file as it's not specific to a given target.
+		for _, e := range state.Err {
+			fmt.Fprintf(e, "History returned error: %v\n", err)
+		}
+		return subcommands.ExitFailure
+	}
+
+	retCode := subcommands.ExitSuccess
+	for r := range resp {
+		if r.Error != nil {
+			fmt.Fprintf(state.Err[r.Index], "History for target %s (%d) returned error: %v\n", r.Target, r.Index, r.Error)
+			retCode = subcommands.ExitFailure
+			continue
+		}
+		for _, t := range r.Resp.Transactions {
+			out := state.Out[r.Index]
+			fmt.Fprintf(out, "Transaction ID : %d\n", t.Id)
+			if t.Time != nil {
+				fmt.Fprintf(out, "Time           : %s\n", t.Time.AsTime().Local())
+			}
+			fmt.Fprintf(out, "User           : %s\n", t.User)
+			fmt.Fprintf(out, "Command line   : %s\n", t.CommandLine)
+			fmt.Fprintf(out, "Actions        : %s\n", strings.Join(t.Actions, ", "))
+			for _, pkg := range t.Packages {
+				fmt.Fprintf(out, "    %-12s %s %s\n", pkg.Action, pkg.Name, pkg.Repo)
+			}
+			fmt.Fprintln(out)
+		}
+	}
+	return retCode
+}
+
+type undoCmd struct {
+	packageSystem string
+	id            int64
+}
+
+func (*undoCmd) Name() string     { return "undo" }
+func (*undoCmd) Synopsis() string { return "Undo a package transaction" }
+func (*undoCmd) Usage() string {
+	return `undo:
+  Revert all of the package changes made by a transaction as listed by history.
+`
+}
+
+func (u *undoCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&u.packageSystem, "package-system", "UNKNOWN", fmt.Sprintf("Package system to use(one of: [%s]). UNKNOWN lets the remote host pick", strings.Join(shortPackageSystemNames(), ",")))
+	f.Int64Var(&u.id, "id", 0, "Id of the transaction to undo")
+}
+
+func (u *undoCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	if u.id <= 0 {
+		fmt.Fprintln(os.Stderr, "--id must be supplied")
+		return subcommands.ExitFailure
+	}
+
+	ps, err := flagToType(u.packageSystem)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Can't parse package system for --package-system: %s invalid\n", u.packageSystem)
+		return subcommands.ExitFailure
+	}
+
+	state := args[0].(*util.ExecuteState)
+	c := pb.NewPackagesClientProxy(state.Conn)
+
+	resp, err := c.UndoOneMany(ctx, &pb.UndoRequest{
+		PackageSystem: ps,
+		TransactionId: u.id,
+	})
+	if err != nil {
+		// Emit this to every error file as it's not specific to a given target.
+		for _, e := range state.Err {
+			fmt.Fprintf(e, "Undo returned error: %v\n", err)
+		}
+		return subcommands.ExitFailure
+	}
+
+	retCode := subcommands.ExitSuccess
+	for r := range resp {
+		if r.Error != nil {
+			fmt.Fprintf(state.Err[r.Index], "Undo for target %s (%d) returned error: %v\n", r.Target, r.Index, r.Error)
+			retCode = subcommands.ExitFailure
+			continue
+		}
+		fmt.Fprintf(state.Out[r.Index], "Success!\n\nOutput from undo:\n%s\n", r.Resp.DebugOutput)
+	}
+	return retCode
+}
+
 type listCmd struct {
 	packageSystem string
 }
@@ -222,7 +863,7 @@ func (*listCmd) Usage() string {
 }
 
 func (l *listCmd) SetFlags(f *flag.FlagSet) {
-	f.StringVar(&l.packageSystem, "package-system", "YUM", fmt.Sprintf("Package system to use(one of: [%s])", strings.Join(shortPackageSystemNames(), ",")))
+	f.StringVar(&l.packageSystem, "package-system", "UNKNOWN", fmt.Sprintf("Package system to use(one of: [%s]). UNKNOWN lets the remote host pick", strings.Join(shortPackageSystemNames(), ",")))
 }
 
 func (l *listCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
@@ -276,7 +917,7 @@ func (*repoListCmd) Usage() string {
 }
 
 func (r *repoListCmd) SetFlags(f *flag.FlagSet) {
-	f.StringVar(&r.packageSystem, "package-system", "YUM", fmt.Sprintf("Package system to use(one of: [%s])", strings.Join(shortPackageSystemNames(), ",")))
+	f.StringVar(&r.packageSystem, "package-system", "UNKNOWN", fmt.Sprintf("Package system to use(one of: [%s]). UNKNOWN lets the remote host pick", strings.Join(shortPackageSystemNames(), ",")))
 	f.BoolVar(&r.verbose, "verbose", false, "If true print out fully verbose outage")
 }
 
@@ -330,6 +971,238 @@ func (r *repoListCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...inte
 	return retCode
 }
 
+type repoAddCmd struct {
+	packageSystem string
+	id            string
+	name          string
+	baseurl       string
+	gpgcheck      bool
+	gpgkey        string
+	suite         string
+	components    util.StringSliceFlag
+	disabled      bool
+	allowUnsigned bool
+}
+
+func (*repoAddCmd) Name() string     { return "repoadd" }
+func (*repoAddCmd) Synopsis() string { return "Add a repo definition" }
+func (*repoAddCmd) Usage() string {
+	return `repoadd:
+  Add a new repo on the remote machine. It's written to its own file named after the id.
+`
+}
+
+func (r *repoAddCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&r.packageSystem, "package-system", "UNKNOWN", fmt.Sprintf("Package system to use(one of: [%s]). UNKNOWN lets the remote host pick", strings.Join(shortPackageSystemNames(), ",")))
+	f.StringVar(&r.id, "id", "", "Id of the repo. Also used for the file name")
+	f.StringVar(&r.name, "name", "", "Human readable name of the repo")
+	f.StringVar(&r.baseurl, "baseurl", "", "Base URL of the repo")
+	f.BoolVar(&r.gpgcheck, "gpgcheck", true, "If false packages from the repo aren't signature checked")
+	f.StringVar(&r.gpgkey, "gpgkey", "", "For YUM the URL of the signing key. For APT the absolute path of the keyring")
+	f.StringVar(&r.suite, "suite", "", "APT only. Suite of the repo (i.e. bookworm)")
+	r.components.Target = &[]string{}
+	f.Var(&r.components, "components", "APT only. Components of the repo separated by commas (i.e. main,contrib)")
+	f.BoolVar(&r.disabled, "disabled", false, "If true add the repo disabled")
+	f.BoolVar(&r.allowUnsigned, "allow-unsigned", false, "Required with --gpgcheck=false. For APT the repo is then marked trusted")
+}
+
+func (r *repoAddCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	if r.id == "" || r.baseurl == "" {
+		fmt.Fprintln(os.Stderr, "--id and --baseurl must be supplied")
+		return subcommands.ExitFailure
+	}
+
+	ps, err := flagToType(r.packageSystem)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Can't parse package system for --package-system: %s invalid\n", r.packageSystem)
+		return subcommands.ExitFailure
+	}
+
+	state := args[0].(*util.ExecuteState)
+	c := pb.NewPackagesClientProxy(state.Conn)
+
+	resp, err := c.RepoAddOneMany(ctx, &pb.RepoAddRequest{
+		PackageSystem: ps,
+		Id:            r.id,
+		Name:          r.name,
+		Baseurl:       r.baseurl,
+		Gpgcheck:      r.gpgcheck,
+		Gpgkey:        r.gpgkey,
+		Suite:         r.suite,
+		Components:    *r.components.Target,
+		Disabled:      r.disabled,
+		AllowUnsigned: r.allowUnsigned,
+	})
+	if err != nil {
+		// Emit this to every error file as it's not specific to a given target.
+		for _, e := range state.Err {
+			fmt.Fprintf(e, "Repo add returned error: %v\n", err)
+		}
+		return subcommands.ExitFailure
+	}
+
+	retCode := subcommands.ExitSuccess
+	for s := range resp {
+		if s.Error != nil {
+			fmt.Fprintf(state.Err[s.Index], "Repo add for target %s (%d) returned error: %v\n", s.Target, s.Index, s.Error)
+			retCode = subcommands.ExitFailure
+			continue
+		}
+		fmt.Fprintf(state.Out[s.Index], "Added repo %s in %s\n", r.id, s.Resp.Filename)
+	}
+	return retCode
+}
+
+type repoRemoveCmd struct {
+	packageSystem string
+	id            string
+}
+
+func (*repoRemoveCmd) Name() string     { return "reporemove" }
+func (*repoRemoveCmd) Synopsis() string { return "Remove a repo definition" }
+func (*repoRemoveCmd) Usage() string {
+	return `reporemove:
+  Remove a repo added with repoadd from the remote machine.
+`
+}
+
+func (r *repoRemoveCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&r.packageSystem, "package-system", "UNKNOWN", fmt.Sprintf("Package system to use(one of: [%s]). UNKNOWN lets the remote host pick", strings.Join(shortPackageSystemNames(), ",")))
+	f.StringVar(&r.id, "id", "", "Id of the repo to remove")
+}
+
+func (r *repoRemoveCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	if r.id == "" {
+		fmt.Fprintln(os.Stderr, "--id must be supplied")
+		return subcommands.ExitFailure
+	}
+
+	ps, err := flagToType(r.packageSystem)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Can't parse package system for --package-system: %s invalid\n", r.packageSystem)
+		return subcommands.ExitFailure
+	}
+
+	state := args[0].(*util.ExecuteState)
+	c := pb.NewPackagesClientProxy(state.Conn)
+
+	resp, err := c.RepoRemoveOneMany(ctx, &pb.RepoRemoveRequest{
+		PackageSystem: ps,
+		Id:            r.id,
+	})
+	if err != nil {
+		// Emit this to every error file as it's not specific to a given target.
+		for _, e := range state.Err {
+			fmt.Fprintf(e, "Repo remove returned error: %v\n", err)
+		}
+		return subcommands.ExitFailure
+	}
+
+	retCode := subcommands.ExitSuccess
+	for s := range resp {
+		if s.Error != nil {
+			fmt.Fprintf(state.Err[s.Index], "Repo remove for target %s (%d) returned error: %v\n", s.Target, s.Index, s.Error)
+			retCode = subcommands.ExitFailure
+			continue
+		}
+		fmt.Fprintf(state.Out[s.Index], "Removed %s\n", s.Resp.Filename)
+	}
+	return retCode
+}
+
+// repoEnableCmd implements both repoenable and repodisable.
+type repoEnableCmd struct {
+	disable       bool
+	packageSystem string
+	id            string
+}
+
+func (r *repoEnableCmd) Name() string {
+	if r.disable {
+		return "repodisable"
+	}
+	return "repoenable"
+}
+
+func (r *repoEnableCmd) Synopsis() string {
+	if r.disable {
+		return "Disable a repo"
+	}
+	return "Enable a repo"
+}
+
+func (r *repoEnableCmd) Usage() string {
+	return fmt.Sprintf(`%s:
+  %s a repo on the remote machine. For APT only repos added with repoadd are supported.
+`, r.Name(), r.Synopsis())
+}
+
+func (r *repoEnableCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&r.packageSystem, "package-system", "UNKNOWN", fmt.Sprintf("Package system to use(one of: [%s]). UNKNOWN lets the remote host pick", strings.Join(shortPackageSystemNames(), ",")))
+	f.StringVar(&r.id, "id", "", "Id of the repo")
+}
+
+func (r *repoEnableCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	if r.id == "" {
+		fmt.Fprintln(os.Stderr, "--id must be supplied")
+		return subcommands.ExitFailure
+	}
+
+	ps, err := flagToType(r.packageSystem)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Can't parse package system for --package-system: %s invalid\n", r.packageSystem)
+		return subcommands.ExitFailure
+	}
+
+	state := args[0].(*util.ExecuteState)
+	c := pb.NewPackagesClientProxy(state.Conn)
+
+	// Both replies only contain the file name so collect that per target.
+	type result struct {
+		target   string
+		index    int
+		filename string
+		err      error
+	}
+	var results []result
+	if r.disable {
+		resp, err := c.RepoDisableOneMany(ctx, &pb.RepoDisableRequest{PackageSystem: ps, Id: r.id})
+		if err != nil {
+			// Emit this to every error file as it's not specific to a given target.
+			for _, e := range state.Err {
+				fmt.Fprintf(e, "Repo disable returned error: %v\n", err)
+			}
+			return subcommands.ExitFailure
+		}
+		for s := range resp {
+			results = append(results, result{s.Target, s.Index, s.Resp.GetFilename(), s.Error})
+		}
+	} else {
+		resp, err := c.RepoEnableOneMany(ctx, &pb.RepoEnableRequest{PackageSystem: ps, Id: r.id})
+		if err != nil {
+			// Emit this to every error file as it's not specific to a given target.
+			for _, e := range state.Err {
+				fmt.Fprintf(e, "Repo enable returned error: %v\n", err)
+			}
+			return subcommands.ExitFailure
+		}
+		for s := range resp {
+			results = append(results, result{s.Target, s.Index, s.Resp.GetFilename(), s.Error})
+		}
+	}
+
+	retCode := subcommands.ExitSuccess
+	for _, s := range results {
+		if s.err != nil {
+			fmt.Fprintf(state.Err[s.index], "%s for target %s (%d) returned error: %v\n", r.Name(), s.target, s.index, s.err)
+			retCode = subcommands.ExitFailure
+			continue
+		}
+		fmt.Fprintf(state.Out[s.index], "Updated repo %s in %s\n", r.id, s.filename)
+	}
+	return retCode
+}
+
 func getStatus(s pb.RepoStatus) string {
 	status := "unknown"
 	switch s {
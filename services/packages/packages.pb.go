@@ -24,6 +24,7 @@ package packages
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -42,6 +43,8 @@ const (
 	// The remote side will attempt to pick the appropriate one.
 	PackageSystem_PACKAGE_SYSTEM_UNKNOWN PackageSystem = 0
 	PackageSystem_PACKAGE_SYSTEM_YUM     PackageSystem = 1
+	// Debian style systems using apt-get and dpkg.
+	PackageSystem_PACKAGE_SYSTEM_APT PackageSystem = 2
 )
 
 // Enum value maps for PackageSystem.
@@ -49,10 +52,12 @@ var (
 	PackageSystem_name = map[int32]string{
 		0: "PACKAGE_SYSTEM_UNKNOWN",
 		1: "PACKAGE_SYSTEM_YUM",
+		2: "PACKAGE_SYSTEM_APT",
 	}
 	PackageSystem_value = map[string]int32{
 		"PACKAGE_SYSTEM_UNKNOWN": 0,
 		"PACKAGE_SYSTEM_YUM":     1,
+		"PACKAGE_SYSTEM_APT":     2,
 	}
 )
 
@@ -132,6 +137,83 @@ func (RepoStatus) EnumDescriptor() ([]byte, []int) {
 	return file_packages_proto_rawDescGZIP(), []int{1}
 }
 
+// The checks done when verifying a file. Not every package system
+// supports every check (dpkg only checks digests).
+type VerifyCheck int32
+
+const (
+	VerifyCheck_VERIFY_CHECK_UNKNOWN VerifyCheck = 0
+	// The file no longer exists.
+	VerifyCheck_VERIFY_CHECK_MISSING VerifyCheck = 1
+	VerifyCheck_VERIFY_CHECK_SIZE    VerifyCheck = 2
+	// Permissions or file type.
+	VerifyCheck_VERIFY_CHECK_MODE         VerifyCheck = 3
+	VerifyCheck_VERIFY_CHECK_DIGEST       VerifyCheck = 4
+	VerifyCheck_VERIFY_CHECK_DEVICE       VerifyCheck = 5
+	VerifyCheck_VERIFY_CHECK_LINK         VerifyCheck = 6
+	VerifyCheck_VERIFY_CHECK_OWNER        VerifyCheck = 7
+	VerifyCheck_VERIFY_CHECK_GROUP        VerifyCheck = 8
+	VerifyCheck_VERIFY_CHECK_MTIME        VerifyCheck = 9
+	VerifyCheck_VERIFY_CHECK_CAPABILITIES VerifyCheck = 10
+)
+
+// Enum value maps for VerifyCheck.
+var (
+	VerifyCheck_name = map[int32]string{
+		0:  "VERIFY_CHECK_UNKNOWN",
+		1:  "VERIFY_CHECK_MISSING",
+		2:  "VERIFY_CHECK_SIZE",
+		3:  "VERIFY_CHECK_MODE",
+		4:  "VERIFY_CHECK_DIGEST",
+		5:  "VERIFY_CHECK_DEVICE",
+		6:  "VERIFY_CHECK_LINK",
+		7:  "VERIFY_CHECK_OWNER",
+		8:  "VERIFY_CHECK_GROUP",
+		9:  "VERIFY_CHECK_MTIME",
+		10: "VERIFY_CHECK_CAPABILITIES",
+	}
+	VerifyCheck_value = map[string]int32{
+		"VERIFY_CHECK_UNKNOWN":      0,
+		"VERIFY_CHECK_MISSING":      1,
+		"VERIFY_CHECK_SIZE":         2,
+		"VERIFY_CHECK_MODE":         3,
+		"VERIFY_CHECK_DIGEST":       4,
+		"VERIFY_CHECK_DEVICE":       5,
+		"VERIFY_CHECK_LINK":         6,
+		"VERIFY_CHECK_OWNER":        7,
+		"VERIFY_CHECK_GROUP":        8,
+		"VERIFY_CHECK_MTIME":        9,
+		"VERIFY_CHECK_CAPABILITIES": 10,
+	}
+)
+
+func (x VerifyCheck) Enum() *VerifyCheck {
+	p := new(VerifyCheck)
+	*p = x
+	return p
+}
+
+func (x VerifyCheck) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VerifyCheck) Descriptor() protoreflect.EnumDescriptor {
+	return file_packages_proto_enumTypes[2].Descriptor()
+}
+
+func (VerifyCheck) Type() protoreflect.EnumType {
+	return &file_packages_proto_enumTypes[2]
+}
+
+func (x VerifyCheck) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VerifyCheck.Descriptor instead.
+func (VerifyCheck) EnumDescriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{2}
+}
+
 type InstallRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -142,9 +224,15 @@ type InstallRequest struct {
 	// Version must be the full nevra version if this is YUM:
 	//
 	// i.e. epoch:version.arch
+	//
+	// For APT this is the Debian version (i.e. [epoch:]upstream-revision).
 	Version string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
 	// If set enables this repo for resolving package/version.
+	// For APT this is the target release (i.e. bookworm-backports).
 	Repo string `protobuf:"bytes,4,opt,name=repo,proto3" json:"repo,omitempty"`
+	// If set the transaction is resolved but not applied and the planned
+	// changes are returned. Not supported for InstallStream.
+	DryRun bool `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 }
 
 func (x *InstallRequest) Reset() {
@@ -207,18 +295,112 @@ func (x *InstallRequest) GetRepo() string {
 	return ""
 }
 
+func (x *InstallRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// PackageChange is a single change a transaction makes (or would make
+// for a dry run).
+type PackageChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name         string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version      string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Architecture string `protobuf:"bytes,3,opt,name=architecture,proto3" json:"architecture,omitempty"`
+	// As reported by the package system. For YUM this is the section of
+	// the transaction (i.e. Installing, Updating, Installing for
+	// dependencies). For APT it's one of Install, Upgrade, Remove or Purge
+	// where Upgrade is any replacement of an installed version.
+	Action string `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	Repo   string `protobuf:"bytes,5,opt,name=repo,proto3" json:"repo,omitempty"`
+}
+
+func (x *PackageChange) Reset() {
+	*x = PackageChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PackageChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageChange) ProtoMessage() {}
+
+func (x *PackageChange) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageChange.ProtoReflect.Descriptor instead.
+func (*PackageChange) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PackageChange) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PackageChange) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *PackageChange) GetArchitecture() string {
+	if x != nil {
+		return x.Architecture
+	}
+	return ""
+}
+
+func (x *PackageChange) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *PackageChange) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
 type InstallReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	DebugOutput string `protobuf:"bytes,1,opt,name=debug_output,json=debugOutput,proto3" json:"debug_output,omitempty"`
+	// Only set for a dry run.
+	PlannedChanges []*PackageChange `protobuf:"bytes,2,rep,name=planned_changes,json=plannedChanges,proto3" json:"planned_changes,omitempty"`
 }
 
 func (x *InstallReply) Reset() {
 	*x = InstallReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_packages_proto_msgTypes[1]
+		mi := &file_packages_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -231,7 +413,7 @@ func (x *InstallReply) String() string {
 func (*InstallReply) ProtoMessage() {}
 
 func (x *InstallReply) ProtoReflect() protoreflect.Message {
-	mi := &file_packages_proto_msgTypes[1]
+	mi := &file_packages_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -244,7 +426,7 @@ func (x *InstallReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstallReply.ProtoReflect.Descriptor instead.
 func (*InstallReply) Descriptor() ([]byte, []int) {
-	return file_packages_proto_rawDescGZIP(), []int{1}
+	return file_packages_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *InstallReply) GetDebugOutput() string {
@@ -254,6 +436,13 @@ func (x *InstallReply) GetDebugOutput() string {
 	return ""
 }
 
+func (x *InstallReply) GetPlannedChanges() []*PackageChange {
+	if x != nil {
+		return x.PlannedChanges
+	}
+	return nil
+}
+
 type UpdateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -267,12 +456,14 @@ type UpdateRequest struct {
 	NewVersion string `protobuf:"bytes,4,opt,name=new_version,json=newVersion,proto3" json:"new_version,omitempty"`
 	// If set enables this repo as well for resolving package/version.
 	Repo string `protobuf:"bytes,5,opt,name=repo,proto3" json:"repo,omitempty"`
+	// As with install above.
+	DryRun bool `protobuf:"varint,6,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 }
 
 func (x *UpdateRequest) Reset() {
 	*x = UpdateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_packages_proto_msgTypes[2]
+		mi := &file_packages_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -285,7 +476,7 @@ func (x *UpdateRequest) String() string {
 func (*UpdateRequest) ProtoMessage() {}
 
 func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_packages_proto_msgTypes[2]
+	mi := &file_packages_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -298,7 +489,7 @@ func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
 func (*UpdateRequest) Descriptor() ([]byte, []int) {
-	return file_packages_proto_rawDescGZIP(), []int{2}
+	return file_packages_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *UpdateRequest) GetPackageSystem() PackageSystem {
@@ -336,18 +527,27 @@ func (x *UpdateRequest) GetRepo() string {
 	return ""
 }
 
+func (x *UpdateRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
 type UpdateReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	DebugOutput string `protobuf:"bytes,1,opt,name=debug_output,json=debugOutput,proto3" json:"debug_output,omitempty"`
+	// Only set for a dry run.
+	PlannedChanges []*PackageChange `protobuf:"bytes,2,rep,name=planned_changes,json=plannedChanges,proto3" json:"planned_changes,omitempty"`
 }
 
 func (x *UpdateReply) Reset() {
 	*x = UpdateReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_packages_proto_msgTypes[3]
+		mi := &file_packages_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -360,7 +560,7 @@ func (x *UpdateReply) String() string {
 func (*UpdateReply) ProtoMessage() {}
 
 func (x *UpdateReply) ProtoReflect() protoreflect.Message {
-	mi := &file_packages_proto_msgTypes[3]
+	mi := &file_packages_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -373,7 +573,7 @@ func (x *UpdateReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateReply.ProtoReflect.Descriptor instead.
 func (*UpdateReply) Descriptor() ([]byte, []int) {
-	return file_packages_proto_rawDescGZIP(), []int{3}
+	return file_packages_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *UpdateReply) GetDebugOutput() string {
@@ -383,31 +583,39 @@ func (x *UpdateReply) GetDebugOutput() string {
 	return ""
 }
 
-type ListInstalledRequest struct {
+func (x *UpdateReply) GetPlannedChanges() []*PackageChange {
+	if x != nil {
+		return x.PlannedChanges
+	}
+	return nil
+}
+
+// ProgressReply is a single line of output from the package manager.
+type ProgressReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PackageSystem PackageSystem `protobuf:"varint,1,opt,name=package_system,json=packageSystem,proto3,enum=Packages.PackageSystem" json:"package_system,omitempty"`
+	Line string `protobuf:"bytes,1,opt,name=line,proto3" json:"line,omitempty"`
 }
 
-func (x *ListInstalledRequest) Reset() {
-	*x = ListInstalledRequest{}
+func (x *ProgressReply) Reset() {
+	*x = ProgressReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_packages_proto_msgTypes[4]
+		mi := &file_packages_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListInstalledRequest) String() string {
+func (x *ProgressReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListInstalledRequest) ProtoMessage() {}
+func (*ProgressReply) ProtoMessage() {}
 
-func (x *ListInstalledRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_packages_proto_msgTypes[4]
+func (x *ProgressReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -418,45 +626,47 @@ func (x *ListInstalledRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListInstalledRequest.ProtoReflect.Descriptor instead.
-func (*ListInstalledRequest) Descriptor() ([]byte, []int) {
-	return file_packages_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ProgressReply.ProtoReflect.Descriptor instead.
+func (*ProgressReply) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ListInstalledRequest) GetPackageSystem() PackageSystem {
+func (x *ProgressReply) GetLine() string {
 	if x != nil {
-		return x.PackageSystem
+		return x.Line
 	}
-	return PackageSystem_PACKAGE_SYSTEM_UNKNOWN
+	return ""
 }
 
-type PackageInfo struct {
+type RemoveRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
-	Repo    string `protobuf:"bytes,3,opt,name=repo,proto3" json:"repo,omitempty"`
+	PackageSystem PackageSystem `protobuf:"varint,1,opt,name=package_system,json=packageSystem,proto3,enum=Packages.PackageSystem" json:"package_system,omitempty"`
+	Name          string        `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// This version must be installed for remove to execute.
+	// As with install above for YUM this must be a full nevra version.
+	Version string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
 }
 
-func (x *PackageInfo) Reset() {
-	*x = PackageInfo{}
+func (x *RemoveRequest) Reset() {
+	*x = RemoveRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_packages_proto_msgTypes[5]
+		mi := &file_packages_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PackageInfo) String() string {
+func (x *RemoveRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PackageInfo) ProtoMessage() {}
+func (*RemoveRequest) ProtoMessage() {}
 
-func (x *PackageInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_packages_proto_msgTypes[5]
+func (x *RemoveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -467,57 +677,57 @@ func (x *PackageInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PackageInfo.ProtoReflect.Descriptor instead.
-func (*PackageInfo) Descriptor() ([]byte, []int) {
-	return file_packages_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use RemoveRequest.ProtoReflect.Descriptor instead.
+func (*RemoveRequest) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *PackageInfo) GetName() string {
+func (x *RemoveRequest) GetPackageSystem() PackageSystem {
 	if x != nil {
-		return x.Name
+		return x.PackageSystem
 	}
-	return ""
+	return PackageSystem_PACKAGE_SYSTEM_UNKNOWN
 }
 
-func (x *PackageInfo) GetVersion() string {
+func (x *RemoveRequest) GetName() string {
 	if x != nil {
-		return x.Version
+		return x.Name
 	}
 	return ""
 }
 
-func (x *PackageInfo) GetRepo() string {
+func (x *RemoveRequest) GetVersion() string {
 	if x != nil {
-		return x.Repo
+		return x.Version
 	}
 	return ""
 }
 
-type ListInstalledReply struct {
+type RemoveReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Packages []*PackageInfo `protobuf:"bytes,1,rep,name=packages,proto3" json:"packages,omitempty"`
+	DebugOutput string `protobuf:"bytes,1,opt,name=debug_output,json=debugOutput,proto3" json:"debug_output,omitempty"`
 }
 
-func (x *ListInstalledReply) Reset() {
-	*x = ListInstalledReply{}
+func (x *RemoveReply) Reset() {
+	*x = RemoveReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_packages_proto_msgTypes[6]
+		mi := &file_packages_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListInstalledReply) String() string {
+func (x *RemoveReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListInstalledReply) ProtoMessage() {}
+func (*RemoveReply) ProtoMessage() {}
 
-func (x *ListInstalledReply) ProtoReflect() protoreflect.Message {
-	mi := &file_packages_proto_msgTypes[6]
+func (x *RemoveReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -528,43 +738,50 @@ func (x *ListInstalledReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListInstalledReply.ProtoReflect.Descriptor instead.
-func (*ListInstalledReply) Descriptor() ([]byte, []int) {
-	return file_packages_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use RemoveReply.ProtoReflect.Descriptor instead.
+func (*RemoveReply) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ListInstalledReply) GetPackages() []*PackageInfo {
+func (x *RemoveReply) GetDebugOutput() string {
 	if x != nil {
-		return x.Packages
+		return x.DebugOutput
 	}
-	return nil
+	return ""
 }
 
-type RepoListRequest struct {
+type DowngradeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	PackageSystem PackageSystem `protobuf:"varint,1,opt,name=package_system,json=packageSystem,proto3,enum=Packages.PackageSystem" json:"package_system,omitempty"`
+	Name          string        `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// This version must be installed for downgrade to execute.
+	// As with install above for YUM this must be a full nevra version.
+	OldVersion string `protobuf:"bytes,3,opt,name=old_version,json=oldVersion,proto3" json:"old_version,omitempty"`
+	NewVersion string `protobuf:"bytes,4,opt,name=new_version,json=newVersion,proto3" json:"new_version,omitempty"`
+	// If set enables this repo as well for resolving package/version.
+	Repo string `protobuf:"bytes,5,opt,name=repo,proto3" json:"repo,omitempty"`
 }
 
-func (x *RepoListRequest) Reset() {
-	*x = RepoListRequest{}
+func (x *DowngradeRequest) Reset() {
+	*x = DowngradeRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_packages_proto_msgTypes[7]
+		mi := &file_packages_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RepoListRequest) String() string {
+func (x *DowngradeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RepoListRequest) ProtoMessage() {}
+func (*DowngradeRequest) ProtoMessage() {}
 
-func (x *RepoListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_packages_proto_msgTypes[7]
+func (x *DowngradeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -575,47 +792,71 @@ func (x *RepoListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RepoListRequest.ProtoReflect.Descriptor instead.
-func (*RepoListRequest) Descriptor() ([]byte, []int) {
-	return file_packages_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use DowngradeRequest.ProtoReflect.Descriptor instead.
+func (*DowngradeRequest) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *RepoListRequest) GetPackageSystem() PackageSystem {
+func (x *DowngradeRequest) GetPackageSystem() PackageSystem {
 	if x != nil {
 		return x.PackageSystem
 	}
 	return PackageSystem_PACKAGE_SYSTEM_UNKNOWN
 }
 
-type Repo struct {
+func (x *DowngradeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DowngradeRequest) GetOldVersion() string {
+	if x != nil {
+		return x.OldVersion
+	}
+	return ""
+}
+
+func (x *DowngradeRequest) GetNewVersion() string {
+	if x != nil {
+		return x.NewVersion
+	}
+	return ""
+}
+
+func (x *DowngradeRequest) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+type DowngradeReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id       string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name     string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Status   RepoStatus `protobuf:"varint,3,opt,name=status,proto3,enum=Packages.RepoStatus" json:"status,omitempty"`
-	Filename string     `protobuf:"bytes,4,opt,name=filename,proto3" json:"filename,omitempty"`
-	Url      string     `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	DebugOutput string `protobuf:"bytes,1,opt,name=debug_output,json=debugOutput,proto3" json:"debug_output,omitempty"`
 }
 
-func (x *Repo) Reset() {
-	*x = Repo{}
+func (x *DowngradeReply) Reset() {
+	*x = DowngradeReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_packages_proto_msgTypes[8]
+		mi := &file_packages_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Repo) String() string {
+func (x *DowngradeReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Repo) ProtoMessage() {}
+func (*DowngradeReply) ProtoMessage() {}
 
-func (x *Repo) ProtoReflect() protoreflect.Message {
-	mi := &file_packages_proto_msgTypes[8]
+func (x *DowngradeReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -626,71 +867,1691 @@ func (x *Repo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Repo.ProtoReflect.Descriptor instead.
-func (*Repo) Descriptor() ([]byte, []int) {
-	return file_packages_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use DowngradeReply.ProtoReflect.Descriptor instead.
+func (*DowngradeReply) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DowngradeReply) GetDebugOutput() string {
+	if x != nil {
+		return x.DebugOutput
+	}
+	return ""
+}
+
+type SearchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PackageSystem PackageSystem `protobuf:"varint,1,opt,name=package_system,json=packageSystem,proto3,enum=Packages.PackageSystem" json:"package_system,omitempty"`
+	// The exact package name to search for.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// If set enables this repo as well when searching. For APT all
+	// configured sources are always searched.
+	Repo string `protobuf:"bytes,3,opt,name=repo,proto3" json:"repo,omitempty"`
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchRequest) GetPackageSystem() PackageSystem {
+	if x != nil {
+		return x.PackageSystem
+	}
+	return PackageSystem_PACKAGE_SYSTEM_UNKNOWN
+}
+
+func (x *SearchRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+// Each available version of the package is returned along with the
+// repo providing it.
+type SearchReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Packages []*PackageInfo `protobuf:"bytes,1,rep,name=packages,proto3" json:"packages,omitempty"`
+}
+
+func (x *SearchReply) Reset() {
+	*x = SearchReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchReply) ProtoMessage() {}
+
+func (x *SearchReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchReply.ProtoReflect.Descriptor instead.
+func (*SearchReply) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SearchReply) GetPackages() []*PackageInfo {
+	if x != nil {
+		return x.Packages
+	}
+	return nil
+}
+
+type ListInstalledRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PackageSystem PackageSystem `protobuf:"varint,1,opt,name=package_system,json=packageSystem,proto3,enum=Packages.PackageSystem" json:"package_system,omitempty"`
+}
+
+func (x *ListInstalledRequest) Reset() {
+	*x = ListInstalledRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListInstalledRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInstalledRequest) ProtoMessage() {}
+
+func (x *ListInstalledRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInstalledRequest.ProtoReflect.Descriptor instead.
+func (*ListInstalledRequest) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListInstalledRequest) GetPackageSystem() PackageSystem {
+	if x != nil {
+		return x.PackageSystem
+	}
+	return PackageSystem_PACKAGE_SYSTEM_UNKNOWN
+}
+
+type PackageInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// The repo the package was installed from (or is available from for
+	// search). dpkg doesn't track this so it's always empty for installed
+	// APT packages.
+	Repo string `protobuf:"bytes,3,opt,name=repo,proto3" json:"repo,omitempty"`
+}
+
+func (x *PackageInfo) Reset() {
+	*x = PackageInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PackageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageInfo) ProtoMessage() {}
+
+func (x *PackageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageInfo.ProtoReflect.Descriptor instead.
+func (*PackageInfo) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PackageInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PackageInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *PackageInfo) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+type ListInstalledReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Packages []*PackageInfo `protobuf:"bytes,1,rep,name=packages,proto3" json:"packages,omitempty"`
+}
+
+func (x *ListInstalledReply) Reset() {
+	*x = ListInstalledReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListInstalledReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInstalledReply) ProtoMessage() {}
+
+func (x *ListInstalledReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInstalledReply.ProtoReflect.Descriptor instead.
+func (*ListInstalledReply) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListInstalledReply) GetPackages() []*PackageInfo {
+	if x != nil {
+		return x.Packages
+	}
+	return nil
+}
+
+type RepoListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PackageSystem PackageSystem `protobuf:"varint,1,opt,name=package_system,json=packageSystem,proto3,enum=Packages.PackageSystem" json:"package_system,omitempty"`
+}
+
+func (x *RepoListRequest) Reset() {
+	*x = RepoListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RepoListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepoListRequest) ProtoMessage() {}
+
+func (x *RepoListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepoListRequest.ProtoReflect.Descriptor instead.
+func (*RepoListRequest) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RepoListRequest) GetPackageSystem() PackageSystem {
+	if x != nil {
+		return x.PackageSystem
+	}
+	return PackageSystem_PACKAGE_SYSTEM_UNKNOWN
+}
+
+// For APT each entry in a sources file is reported as a repo per
+// type/uri/suite combination. The id is the suite, which can be passed
+// as the repo for install/update.
+type Repo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name     string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Status   RepoStatus `protobuf:"varint,3,opt,name=status,proto3,enum=Packages.RepoStatus" json:"status,omitempty"`
+	Filename string     `protobuf:"bytes,4,opt,name=filename,proto3" json:"filename,omitempty"`
+	Url      string     `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+}
+
+func (x *Repo) Reset() {
+	*x = Repo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Repo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Repo) ProtoMessage() {}
+
+func (x *Repo) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Repo.ProtoReflect.Descriptor instead.
+func (*Repo) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Repo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Repo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Repo) GetStatus() RepoStatus {
+	if x != nil {
+		return x.Status
+	}
+	return RepoStatus_REPO_STATUS_UNKNOWN
+}
+
+func (x *Repo) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Repo) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type RepoListReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Repos []*Repo `protobuf:"bytes,1,rep,name=repos,proto3" json:"repos,omitempty"`
+}
+
+func (x *RepoListReply) Reset() {
+	*x = RepoListReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RepoListReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepoListReply) ProtoMessage() {}
+
+func (x *RepoListReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepoListReply.ProtoReflect.Descriptor instead.
+func (*RepoListReply) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RepoListReply) GetRepos() []*Repo {
+	if x != nil {
+		return x.Repos
+	}
+	return nil
+}
+
+// The repo is written to <id>.repo in the YUM repos directory or as a
+// deb822 style <id>.sources file in the APT sources directory.
+type RepoAddRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PackageSystem PackageSystem `protobuf:"varint,1,opt,name=package_system,json=packageSystem,proto3,enum=Packages.PackageSystem" json:"package_system,omitempty"`
+	// Must be unique as it's also the name of the file.
+	Id string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// A human readable name. Defaults to the id for YUM.
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// An http, https, ftp or file URL.
+	Baseurl string `protobuf:"bytes,4,opt,name=baseurl,proto3" json:"baseurl,omitempty"`
+	// If false packages from the repo aren't signature checked, which also
+	// requires allow_unsigned. For APT this marks the whole repo trusted.
+	Gpgcheck bool `protobuf:"varint,5,opt,name=gpgcheck,proto3" json:"gpgcheck,omitempty"`
+	// For YUM the URL of the signing key. For APT the absolute path of the
+	// keyring the repo is signed by.
+	Gpgkey string `protobuf:"bytes,6,opt,name=gpgkey,proto3" json:"gpgkey,omitempty"`
+	// APT only. The suite (i.e. bookworm) which is required.
+	Suite string `protobuf:"bytes,7,opt,name=suite,proto3" json:"suite,omitempty"`
+	// APT only. The components (i.e. main contrib).
+	Components []string `protobuf:"bytes,8,rep,name=components,proto3" json:"components,omitempty"`
+	// If set the repo is added but not enabled.
+	Disabled bool `protobuf:"varint,9,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	// Must be set along with gpgcheck=false to add a repo without signature
+	// checks (for APT one marked trusted, i.e. unsigned Release files are
+	// accepted).
+	AllowUnsigned bool `protobuf:"varint,10,opt,name=allow_unsigned,json=allowUnsigned,proto3" json:"allow_unsigned,omitempty"`
+}
+
+func (x *RepoAddRequest) Reset() {
+	*x = RepoAddRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RepoAddRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepoAddRequest) ProtoMessage() {}
+
+func (x *RepoAddRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepoAddRequest.ProtoReflect.Descriptor instead.
+func (*RepoAddRequest) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RepoAddRequest) GetPackageSystem() PackageSystem {
+	if x != nil {
+		return x.PackageSystem
+	}
+	return PackageSystem_PACKAGE_SYSTEM_UNKNOWN
+}
+
+func (x *RepoAddRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RepoAddRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RepoAddRequest) GetBaseurl() string {
+	if x != nil {
+		return x.Baseurl
+	}
+	return ""
+}
+
+func (x *RepoAddRequest) GetGpgcheck() bool {
+	if x != nil {
+		return x.Gpgcheck
+	}
+	return false
+}
+
+func (x *RepoAddRequest) GetGpgkey() string {
+	if x != nil {
+		return x.Gpgkey
+	}
+	return ""
+}
+
+func (x *RepoAddRequest) GetSuite() string {
+	if x != nil {
+		return x.Suite
+	}
+	return ""
+}
+
+func (x *RepoAddRequest) GetComponents() []string {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *RepoAddRequest) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *RepoAddRequest) GetAllowUnsigned() bool {
+	if x != nil {
+		return x.AllowUnsigned
+	}
+	return false
+}
+
+type RepoAddReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The file the repo was written to.
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+}
+
+func (x *RepoAddReply) Reset() {
+	*x = RepoAddReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RepoAddReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepoAddReply) ProtoMessage() {}
+
+func (x *RepoAddReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepoAddReply.ProtoReflect.Descriptor instead.
+func (*RepoAddReply) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RepoAddReply) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+type RepoRemoveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PackageSystem PackageSystem `protobuf:"varint,1,opt,name=package_system,json=packageSystem,proto3,enum=Packages.PackageSystem" json:"package_system,omitempty"`
+	Id            string        `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *RepoRemoveRequest) Reset() {
+	*x = RepoRemoveRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RepoRemoveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepoRemoveRequest) ProtoMessage() {}
+
+func (x *RepoRemoveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepoRemoveRequest.ProtoReflect.Descriptor instead.
+func (*RepoRemoveRequest) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RepoRemoveRequest) GetPackageSystem() PackageSystem {
+	if x != nil {
+		return x.PackageSystem
+	}
+	return PackageSystem_PACKAGE_SYSTEM_UNKNOWN
+}
+
+func (x *RepoRemoveRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RepoRemoveReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The file which was removed.
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+}
+
+func (x *RepoRemoveReply) Reset() {
+	*x = RepoRemoveReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RepoRemoveReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepoRemoveReply) ProtoMessage() {}
+
+func (x *RepoRemoveReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepoRemoveReply.ProtoReflect.Descriptor instead.
+func (*RepoRemoveReply) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RepoRemoveReply) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+// For YUM any repo defined in the repos directory can be enabled or
+// disabled. For APT only repos defined in <id>.sources (i.e. from RepoAdd)
+// are supported.
+type RepoEnableRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PackageSystem PackageSystem `protobuf:"varint,1,opt,name=package_system,json=packageSystem,proto3,enum=Packages.PackageSystem" json:"package_system,omitempty"`
+	Id            string        `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *RepoEnableRequest) Reset() {
+	*x = RepoEnableRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RepoEnableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepoEnableRequest) ProtoMessage() {}
+
+func (x *RepoEnableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepoEnableRequest.ProtoReflect.Descriptor instead.
+func (*RepoEnableRequest) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RepoEnableRequest) GetPackageSystem() PackageSystem {
+	if x != nil {
+		return x.PackageSystem
+	}
+	return PackageSystem_PACKAGE_SYSTEM_UNKNOWN
+}
+
+func (x *RepoEnableRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RepoEnableReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The file which was changed.
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+}
+
+func (x *RepoEnableReply) Reset() {
+	*x = RepoEnableReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RepoEnableReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepoEnableReply) ProtoMessage() {}
+
+func (x *RepoEnableReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepoEnableReply.ProtoReflect.Descriptor instead.
+func (*RepoEnableReply) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RepoEnableReply) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+type RepoDisableRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PackageSystem PackageSystem `protobuf:"varint,1,opt,name=package_system,json=packageSystem,proto3,enum=Packages.PackageSystem" json:"package_system,omitempty"`
+	Id            string        `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *RepoDisableRequest) Reset() {
+	*x = RepoDisableRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RepoDisableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepoDisableRequest) ProtoMessage() {}
+
+func (x *RepoDisableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepoDisableRequest.ProtoReflect.Descriptor instead.
+func (*RepoDisableRequest) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RepoDisableRequest) GetPackageSystem() PackageSystem {
+	if x != nil {
+		return x.PackageSystem
+	}
+	return PackageSystem_PACKAGE_SYSTEM_UNKNOWN
+}
+
+func (x *RepoDisableRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RepoDisableReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The file which was changed.
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+}
+
+func (x *RepoDisableReply) Reset() {
+	*x = RepoDisableReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RepoDisableReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepoDisableReply) ProtoMessage() {}
+
+func (x *RepoDisableReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepoDisableReply.ProtoReflect.Descriptor instead.
+func (*RepoDisableReply) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RepoDisableReply) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+type InfoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PackageSystem PackageSystem `protobuf:"varint,1,opt,name=package_system,json=packageSystem,proto3,enum=Packages.PackageSystem" json:"package_system,omitempty"`
+	Name          string        `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *InfoRequest) Reset() {
+	*x = InfoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InfoRequest) ProtoMessage() {}
+
+func (x *InfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InfoRequest.ProtoReflect.Descriptor instead.
+func (*InfoRequest) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *InfoRequest) GetPackageSystem() PackageSystem {
+	if x != nil {
+		return x.PackageSystem
+	}
+	return PackageSystem_PACKAGE_SYSTEM_UNKNOWN
+}
+
+func (x *InfoRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type PackageDetails struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name         string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version      string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Architecture string `protobuf:"bytes,3,opt,name=architecture,proto3" json:"architecture,omitempty"`
+	// A one line description of the package.
+	Summary     string `protobuf:"bytes,4,opt,name=summary,proto3" json:"summary,omitempty"`
+	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	// Installed size in bytes.
+	Size uint64 `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
+	// For APT this is approximated by when dpkg last recorded the
+	// package's file list.
+	InstallTime *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=install_time,json=installTime,proto3" json:"install_time,omitempty"`
+	// The repo the package was installed from. Empty if unknown
+	// (i.e. installed from a local file).
+	Repo string `protobuf:"bytes,8,opt,name=repo,proto3" json:"repo,omitempty"`
+	// Each dependency as given by the package (i.e. "libc6 (>= 2.34)").
+	Dependencies []string `protobuf:"bytes,9,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+}
+
+func (x *PackageDetails) Reset() {
+	*x = PackageDetails{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PackageDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageDetails) ProtoMessage() {}
+
+func (x *PackageDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageDetails.ProtoReflect.Descriptor instead.
+func (*PackageDetails) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PackageDetails) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PackageDetails) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *PackageDetails) GetArchitecture() string {
+	if x != nil {
+		return x.Architecture
+	}
+	return ""
+}
+
+func (x *PackageDetails) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *PackageDetails) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PackageDetails) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *PackageDetails) GetInstallTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.InstallTime
+	}
+	return nil
+}
+
+func (x *PackageDetails) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *PackageDetails) GetDependencies() []string {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+// More than one package may be returned if several versions (i.e. kernels)
+// or architectures are installed.
+type InfoReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Packages []*PackageDetails `protobuf:"bytes,1,rep,name=packages,proto3" json:"packages,omitempty"`
+}
+
+func (x *InfoReply) Reset() {
+	*x = InfoReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InfoReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InfoReply) ProtoMessage() {}
+
+func (x *InfoReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InfoReply.ProtoReflect.Descriptor instead.
+func (*InfoReply) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *InfoReply) GetPackages() []*PackageDetails {
+	if x != nil {
+		return x.Packages
+	}
+	return nil
+}
+
+type FilesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PackageSystem PackageSystem `protobuf:"varint,1,opt,name=package_system,json=packageSystem,proto3,enum=Packages.PackageSystem" json:"package_system,omitempty"`
+	Name          string        `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *FilesRequest) Reset() {
+	*x = FilesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilesRequest) ProtoMessage() {}
+
+func (x *FilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilesRequest.ProtoReflect.Descriptor instead.
+func (*FilesRequest) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *FilesRequest) GetPackageSystem() PackageSystem {
+	if x != nil {
+		return x.PackageSystem
+	}
+	return PackageSystem_PACKAGE_SYSTEM_UNKNOWN
+}
+
+func (x *FilesRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type FilesReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Files []string `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+}
+
+func (x *FilesReply) Reset() {
+	*x = FilesReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FilesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilesReply) ProtoMessage() {}
+
+func (x *FilesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilesReply.ProtoReflect.Descriptor instead.
+func (*FilesReply) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *FilesReply) GetFiles() []string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type VerifyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PackageSystem PackageSystem `protobuf:"varint,1,opt,name=package_system,json=packageSystem,proto3,enum=Packages.PackageSystem" json:"package_system,omitempty"`
+	Name          string        `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *VerifyRequest) Reset() {
+	*x = VerifyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyRequest) ProtoMessage() {}
+
+func (x *VerifyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyRequest.ProtoReflect.Descriptor instead.
+func (*VerifyRequest) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *VerifyRequest) GetPackageSystem() PackageSystem {
+	if x != nil {
+		return x.PackageSystem
+	}
+	return PackageSystem_PACKAGE_SYSTEM_UNKNOWN
+}
+
+func (x *VerifyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type FileDiscrepancy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Config files are expected to be changed by administrators.
+	Config bool `protobuf:"varint,2,opt,name=config,proto3" json:"config,omitempty"`
+	// The checks which failed.
+	Failed []VerifyCheck `protobuf:"varint,3,rep,packed,name=failed,proto3,enum=Packages.VerifyCheck" json:"failed,omitempty"`
+}
+
+func (x *FileDiscrepancy) Reset() {
+	*x = FileDiscrepancy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileDiscrepancy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileDiscrepancy) ProtoMessage() {}
+
+func (x *FileDiscrepancy) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileDiscrepancy.ProtoReflect.Descriptor instead.
+func (*FileDiscrepancy) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *FileDiscrepancy) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileDiscrepancy) GetConfig() bool {
+	if x != nil {
+		return x.Config
+	}
+	return false
+}
+
+func (x *FileDiscrepancy) GetFailed() []VerifyCheck {
+	if x != nil {
+		return x.Failed
+	}
+	return nil
+}
+
+// Only files which failed a check are returned.
+type VerifyReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Discrepancies []*FileDiscrepancy `protobuf:"bytes,1,rep,name=discrepancies,proto3" json:"discrepancies,omitempty"`
+}
+
+func (x *VerifyReply) Reset() {
+	*x = VerifyReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyReply) ProtoMessage() {}
+
+func (x *VerifyReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyReply.ProtoReflect.Descriptor instead.
+func (*VerifyReply) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *VerifyReply) GetDiscrepancies() []*FileDiscrepancy {
+	if x != nil {
+		return x.Discrepancies
+	}
+	return nil
+}
+
+type HistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PackageSystem PackageSystem `protobuf:"varint,1,opt,name=package_system,json=packageSystem,proto3,enum=Packages.PackageSystem" json:"package_system,omitempty"`
+	// The maximum number of transactions to return, most recent first.
+	// If unset a default of 10 is used.
+	Limit uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *HistoryRequest) Reset() {
+	*x = HistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryRequest) ProtoMessage() {}
+
+func (x *HistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryRequest.ProtoReflect.Descriptor instead.
+func (*HistoryRequest) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *HistoryRequest) GetPackageSystem() PackageSystem {
+	if x != nil {
+		return x.PackageSystem
+	}
+	return PackageSystem_PACKAGE_SYSTEM_UNKNOWN
+}
+
+func (x *HistoryRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type TransactionPackage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The full package name (i.e. bash-4.2.46-35.el7_9.x86_64 for YUM).
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// What happened to the package (i.e. Install, Updated, Update, Erase).
+	Action string `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Repo   string `protobuf:"bytes,3,opt,name=repo,proto3" json:"repo,omitempty"`
+}
+
+func (x *TransactionPackage) Reset() {
+	*x = TransactionPackage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransactionPackage) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Repo) GetId() string {
+func (*TransactionPackage) ProtoMessage() {}
+
+func (x *TransactionPackage) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionPackage.ProtoReflect.Descriptor instead.
+func (*TransactionPackage) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TransactionPackage) GetName() string {
 	if x != nil {
-		return x.Id
+		return x.Name
 	}
 	return ""
 }
 
-func (x *Repo) GetName() string {
+func (x *TransactionPackage) GetAction() string {
 	if x != nil {
-		return x.Name
+		return x.Action
 	}
 	return ""
 }
 
-func (x *Repo) GetStatus() RepoStatus {
+func (x *TransactionPackage) GetRepo() string {
 	if x != nil {
-		return x.Status
+		return x.Repo
 	}
-	return RepoStatus_REPO_STATUS_UNKNOWN
+	return ""
 }
 
-func (x *Repo) GetFilename() string {
+type Transaction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id   int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Time *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	User string                 `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	// The command which started the transaction if known.
+	CommandLine string `protobuf:"bytes,4,opt,name=command_line,json=commandLine,proto3" json:"command_line,omitempty"`
+	// The types of change made (i.e. Install, Update).
+	Actions  []string              `protobuf:"bytes,5,rep,name=actions,proto3" json:"actions,omitempty"`
+	Packages []*TransactionPackage `protobuf:"bytes,6,rep,name=packages,proto3" json:"packages,omitempty"`
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Transaction) GetId() int64 {
 	if x != nil {
-		return x.Filename
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Transaction) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Transaction) GetUser() string {
+	if x != nil {
+		return x.User
 	}
 	return ""
 }
 
-func (x *Repo) GetUrl() string {
+func (x *Transaction) GetCommandLine() string {
 	if x != nil {
-		return x.Url
+		return x.CommandLine
 	}
 	return ""
 }
 
-type RepoListReply struct {
+func (x *Transaction) GetActions() []string {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *Transaction) GetPackages() []*TransactionPackage {
+	if x != nil {
+		return x.Packages
+	}
+	return nil
+}
+
+type HistoryReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Repos []*Repo `protobuf:"bytes,1,rep,name=repos,proto3" json:"repos,omitempty"`
+	Transactions []*Transaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
 }
 
-func (x *RepoListReply) Reset() {
-	*x = RepoListReply{}
+func (x *HistoryReply) Reset() {
+	*x = HistoryReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_packages_proto_msgTypes[9]
+		mi := &file_packages_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RepoListReply) String() string {
+func (x *HistoryReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RepoListReply) ProtoMessage() {}
+func (*HistoryReply) ProtoMessage() {}
 
-func (x *RepoListReply) ProtoReflect() protoreflect.Message {
-	mi := &file_packages_proto_msgTypes[9]
+func (x *HistoryReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -701,101 +2562,471 @@ func (x *RepoListReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RepoListReply.ProtoReflect.Descriptor instead.
-func (*RepoListReply) Descriptor() ([]byte, []int) {
-	return file_packages_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use HistoryReply.ProtoReflect.Descriptor instead.
+func (*HistoryReply) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *RepoListReply) GetRepos() []*Repo {
+func (x *HistoryReply) GetTransactions() []*Transaction {
 	if x != nil {
-		return x.Repos
+		return x.Transactions
 	}
 	return nil
 }
 
+type UndoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PackageSystem PackageSystem `protobuf:"varint,1,opt,name=package_system,json=packageSystem,proto3,enum=Packages.PackageSystem" json:"package_system,omitempty"`
+	TransactionId int64         `protobuf:"varint,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+}
+
+func (x *UndoRequest) Reset() {
+	*x = UndoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UndoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndoRequest) ProtoMessage() {}
+
+func (x *UndoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndoRequest.ProtoReflect.Descriptor instead.
+func (*UndoRequest) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *UndoRequest) GetPackageSystem() PackageSystem {
+	if x != nil {
+		return x.PackageSystem
+	}
+	return PackageSystem_PACKAGE_SYSTEM_UNKNOWN
+}
+
+func (x *UndoRequest) GetTransactionId() int64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+type UndoReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DebugOutput string `protobuf:"bytes,1,opt,name=debug_output,json=debugOutput,proto3" json:"debug_output,omitempty"`
+}
+
+func (x *UndoReply) Reset() {
+	*x = UndoReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UndoReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndoReply) ProtoMessage() {}
+
+func (x *UndoReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndoReply.ProtoReflect.Descriptor instead.
+func (*UndoReply) Descriptor() ([]byte, []int) {
+	return file_packages_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *UndoReply) GetDebugOutput() string {
+	if x != nil {
+		return x.DebugOutput
+	}
+	return ""
+}
+
 var File_packages_proto protoreflect.FileDescriptor
 
 var file_packages_proto_rawDesc = []byte{
 	0x0a, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x12, 0x08, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x22, 0x92, 0x01, 0x0a, 0x0e, 0x49,
-	0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3e, 0x0a,
+	0x12, 0x08, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xab, 0x01, 0x0a, 0x0e,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3e,
+	0x0a, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x73, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52,
+	0x0d, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x72, 0x65, 0x70, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f,
+	0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x22, 0x8d, 0x01, 0x0a, 0x0d, 0x50, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x72, 0x63,
+	0x68, 0x69, 0x74, 0x65, 0x63, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x61, 0x72, 0x63, 0x68, 0x69, 0x74, 0x65, 0x63, 0x74, 0x75, 0x72, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x22, 0x73, 0x0a, 0x0c, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x65, 0x62,
+	0x75, 0x67, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x65, 0x62, 0x75, 0x67, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x40, 0x0a, 0x0f,
+	0x70, 0x6c, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73,
+	0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0e,
+	0x70, 0x6c, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0xd2,
+	0x01, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x3e, 0x0a, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x52, 0x0d, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x6c, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x6c, 0x64, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x77, 0x5f, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x77, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72,
+	0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79,
+	0x52, 0x75, 0x6e, 0x22, 0x72, 0x0a, 0x0b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x65, 0x62, 0x75, 0x67, 0x5f, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x62, 0x75, 0x67, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x40, 0x0a, 0x0f, 0x70, 0x6c, 0x61, 0x6e, 0x6e, 0x65, 0x64,
+	0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0e, 0x70, 0x6c, 0x61, 0x6e, 0x6e, 0x65, 0x64,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0x23, 0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x67, 0x72,
+	0x65, 0x73, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0x7d, 0x0a, 0x0d,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3e, 0x0a,
 	0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73,
 	0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x0d,
 	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x12, 0x0a,
 	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
 	0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x72,
-	0x65, 0x70, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x22,
-	0x31, 0x0a, 0x0c, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
-	0x21, 0x0a, 0x0c, 0x64, 0x65, 0x62, 0x75, 0x67, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x62, 0x75, 0x67, 0x4f, 0x75, 0x74, 0x70,
-	0x75, 0x74, 0x22, 0xb9, 0x01, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x30, 0x0a, 0x0b, 0x52,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x65,
+	0x62, 0x75, 0x67, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x65, 0x62, 0x75, 0x67, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0xbc, 0x01,
+	0x0a, 0x10, 0x44, 0x6f, 0x77, 0x6e, 0x67, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x3e, 0x0a, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x52, 0x0d, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x6c, 0x64, 0x5f, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x6c, 0x64,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x77, 0x5f, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65,
+	0x77, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x22, 0x33, 0x0a, 0x0e,
+	0x44, 0x6f, 0x77, 0x6e, 0x67, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x21,
+	0x0a, 0x0c, 0x64, 0x65, 0x62, 0x75, 0x67, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x62, 0x75, 0x67, 0x4f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x22, 0x77, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x3e, 0x0a, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x52, 0x0d, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x22, 0x40, 0x0a, 0x0b, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x31, 0x0a, 0x08, 0x70, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x50, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x08, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x22, 0x56, 0x0a, 0x14,
+	0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x3e, 0x0a, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f,
+	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x50,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x0d, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x22, 0x4f, 0x0a, 0x0b, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x72, 0x65, 0x70, 0x6f, 0x22, 0x47, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x31, 0x0a, 0x08, 0x70,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x22, 0x51,
+	0x0a, 0x0f, 0x52, 0x65, 0x70, 0x6f, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x3e, 0x0a, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x52, 0x0d, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x22, 0x86, 0x01, 0x0a, 0x04, 0x52, 0x65, 0x70, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2c,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14,
+	0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x35, 0x0a, 0x0d, 0x52, 0x65,
+	0x70, 0x6f, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x24, 0x0a, 0x05, 0x72,
+	0x65, 0x70, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x05, 0x72, 0x65, 0x70, 0x6f,
+	0x73, 0x22, 0xbb, 0x02, 0x0a, 0x0e, 0x52, 0x65, 0x70, 0x6f, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x3e, 0x0a, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f,
+	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x50,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x0d, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x73, 0x65,
+	0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x73, 0x65, 0x75,
+	0x72, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x67, 0x70, 0x67, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x67, 0x70, 0x67, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x16,
+	0x0a, 0x06, 0x67, 0x70, 0x67, 0x6b, 0x65, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x67, 0x70, 0x67, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x75, 0x69, 0x74, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x75, 0x69, 0x74, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x6c, 0x6c, 0x6f,
+	0x77, 0x5f, 0x75, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0d, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x22,
+	0x2a, 0x0a, 0x0c, 0x52, 0x65, 0x70, 0x6f, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x63, 0x0a, 0x11, 0x52,
+	0x65, 0x70, 0x6f, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x3e, 0x0a, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x52, 0x0d, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0x2d, 0x0a, 0x0f, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x22,
+	0x63, 0x0a, 0x11, 0x52, 0x65, 0x70, 0x6f, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x3e, 0x0a, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f,
+	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x50,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x0d, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x22, 0x2d, 0x0a, 0x0f, 0x52, 0x65, 0x70, 0x6f, 0x45, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e,
+	0x61, 0x6d, 0x65, 0x22, 0x64, 0x0a, 0x12, 0x52, 0x65, 0x70, 0x6f, 0x44, 0x69, 0x73, 0x61, 0x62,
+	0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3e, 0x0a, 0x0e, 0x70, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x17, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x0d, 0x70, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x2e, 0x0a, 0x10, 0x52, 0x65, 0x70,
+	0x6f, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a,
+	0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x61, 0x0a, 0x0b, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3e, 0x0a, 0x0e, 0x70, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x17, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x0d, 0x70, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xa9, 0x02, 0x0a,
+	0x0e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a,
+	0x0c, 0x61, 0x72, 0x63, 0x68, 0x69, 0x74, 0x65, 0x63, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x72, 0x63, 0x68, 0x69, 0x74, 0x65, 0x63, 0x74, 0x75, 0x72,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x12, 0x3d, 0x0a, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x72, 0x65, 0x70, 0x6f, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e,
+	0x63, 0x69, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x65, 0x70, 0x65,
+	0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x22, 0x41, 0x0a, 0x09, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x34, 0x0a, 0x08, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x73, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x73, 0x52, 0x08, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x22, 0x62, 0x0a, 0x0c, 0x46,
+	0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3e, 0x0a, 0x0e, 0x70,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x50,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x0d, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22,
+	0x22, 0x0a, 0x0a, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69,
+	0x6c, 0x65, 0x73, 0x22, 0x63, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x12, 0x3e, 0x0a, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f,
 	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x50,
 	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53,
 	0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x0d, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x79,
 	0x73, 0x74, 0x65, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x6c, 0x64, 0x5f,
-	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f,
-	0x6c, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x77,
-	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
-	0x6e, 0x65, 0x77, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65,
-	0x70, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x22, 0x30,
-	0x0a, 0x0b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x21, 0x0a,
-	0x0c, 0x64, 0x65, 0x62, 0x75, 0x67, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x62, 0x75, 0x67, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
-	0x22, 0x56, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65,
-	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3e, 0x0a, 0x0e, 0x70, 0x61, 0x63, 0x6b,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x6c, 0x0a, 0x0f, 0x46, 0x69, 0x6c, 0x65,
+	0x44, 0x69, 0x73, 0x63, 0x72, 0x65, 0x70, 0x61, 0x6e, 0x63, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12,
+	0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x2d, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x73, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x06,
+	0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x22, 0x4e, 0x0a, 0x0b, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3f, 0x0a, 0x0d, 0x64, 0x69, 0x73, 0x63, 0x72, 0x65, 0x70,
+	0x61, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x50,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x69, 0x73, 0x63,
+	0x72, 0x65, 0x70, 0x61, 0x6e, 0x63, 0x79, 0x52, 0x0d, 0x64, 0x69, 0x73, 0x63, 0x72, 0x65, 0x70,
+	0x61, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x22, 0x66, 0x0a, 0x0e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3e, 0x0a, 0x0e, 0x70, 0x61, 0x63, 0x6b,
 	0x61, 0x67, 0x65, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
 	0x32, 0x17, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x63, 0x6b,
 	0x61, 0x67, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x0d, 0x70, 0x61, 0x63, 0x6b, 0x61,
-	0x67, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x22, 0x4f, 0x0a, 0x0b, 0x50, 0x61, 0x63, 0x6b,
-	0x61, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x22, 0x47, 0x0a, 0x12, 0x4c, 0x69, 0x73,
-	0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
-	0x31, 0x0a, 0x08, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x15, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x63,
-	0x6b, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
-	0x65, 0x73, 0x22, 0x51, 0x0a, 0x0f, 0x52, 0x65, 0x70, 0x6f, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3e, 0x0a, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
-	0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e,
-	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
-	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x0d, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53,
-	0x79, 0x73, 0x74, 0x65, 0x6d, 0x22, 0x86, 0x01, 0x0a, 0x04, 0x52, 0x65, 0x70, 0x6f, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x14, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65,
-	0x70, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03,
-	0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x35,
-	0x0a, 0x0d, 0x52, 0x65, 0x70, 0x6f, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
-	0x24, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e,
-	0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x05,
-	0x72, 0x65, 0x70, 0x6f, 0x73, 0x2a, 0x43, 0x0a, 0x0d, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
-	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x1a, 0x0a, 0x16, 0x50, 0x41, 0x43, 0x4b, 0x41, 0x47,
-	0x45, 0x5f, 0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e,
-	0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x41, 0x43, 0x4b, 0x41, 0x47, 0x45, 0x5f, 0x53, 0x59,
-	0x53, 0x54, 0x45, 0x4d, 0x5f, 0x59, 0x55, 0x4d, 0x10, 0x01, 0x2a, 0x58, 0x0a, 0x0a, 0x52, 0x65,
-	0x70, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x17, 0x0a, 0x13, 0x52, 0x45, 0x50, 0x4f,
-	0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10,
-	0x00, 0x12, 0x17, 0x0a, 0x13, 0x52, 0x45, 0x50, 0x4f, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
-	0x5f, 0x45, 0x4e, 0x41, 0x42, 0x4c, 0x45, 0x44, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x52, 0x45,
-	0x50, 0x4f, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x44, 0x49, 0x53, 0x41, 0x42, 0x4c,
-	0x45, 0x44, 0x10, 0x02, 0x32, 0x98, 0x02, 0x0a, 0x08, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
-	0x73, 0x12, 0x3d, 0x0a, 0x07, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x12, 0x18, 0x2e, 0x50,
-	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
-	0x73, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
-	0x12, 0x3a, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x17, 0x2e, 0x50, 0x61, 0x63,
-	0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x0d,
+	0x67, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x54,
+	0x0a, 0x12, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x72, 0x65, 0x70, 0x6f, 0x22, 0xd8, 0x01, 0x0a, 0x0b, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x4c, 0x69, 0x6e, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x38, 0x0a, 0x08, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x08, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x22,
+	0x49, 0x0a, 0x0c, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x39, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73,
+	0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x74, 0x0a, 0x0b, 0x55, 0x6e,
+	0x64, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3e, 0x0a, 0x0e, 0x70, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x17, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x0d, 0x70, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x22, 0x2e, 0x0a, 0x09, 0x55, 0x6e, 0x64, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x21, 0x0a,
+	0x0c, 0x64, 0x65, 0x62, 0x75, 0x67, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x62, 0x75, 0x67, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x2a, 0x5b, 0x0a, 0x0d, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x12, 0x1a, 0x0a, 0x16, 0x50, 0x41, 0x43, 0x4b, 0x41, 0x47, 0x45, 0x5f, 0x53, 0x59, 0x53,
+	0x54, 0x45, 0x4d, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x16, 0x0a,
+	0x12, 0x50, 0x41, 0x43, 0x4b, 0x41, 0x47, 0x45, 0x5f, 0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x5f,
+	0x59, 0x55, 0x4d, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x41, 0x43, 0x4b, 0x41, 0x47, 0x45,
+	0x5f, 0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x5f, 0x41, 0x50, 0x54, 0x10, 0x02, 0x2a, 0x58, 0x0a,
+	0x0a, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x17, 0x0a, 0x13, 0x52,
+	0x45, 0x50, 0x4f, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
+	0x57, 0x4e, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x52, 0x45, 0x50, 0x4f, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x55, 0x53, 0x5f, 0x45, 0x4e, 0x41, 0x42, 0x4c, 0x45, 0x44, 0x10, 0x01, 0x12, 0x18, 0x0a,
+	0x14, 0x52, 0x45, 0x50, 0x4f, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x44, 0x49, 0x53,
+	0x41, 0x42, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x2a, 0x9f, 0x02, 0x0a, 0x0b, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x79, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x18, 0x0a, 0x14, 0x56, 0x45, 0x52, 0x49, 0x46,
+	0x59, 0x5f, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10,
+	0x00, 0x12, 0x18, 0x0a, 0x14, 0x56, 0x45, 0x52, 0x49, 0x46, 0x59, 0x5f, 0x43, 0x48, 0x45, 0x43,
+	0x4b, 0x5f, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x56,
+	0x45, 0x52, 0x49, 0x46, 0x59, 0x5f, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x5f, 0x53, 0x49, 0x5a, 0x45,
+	0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x56, 0x45, 0x52, 0x49, 0x46, 0x59, 0x5f, 0x43, 0x48, 0x45,
+	0x43, 0x4b, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x56, 0x45, 0x52,
+	0x49, 0x46, 0x59, 0x5f, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x5f, 0x44, 0x49, 0x47, 0x45, 0x53, 0x54,
+	0x10, 0x04, 0x12, 0x17, 0x0a, 0x13, 0x56, 0x45, 0x52, 0x49, 0x46, 0x59, 0x5f, 0x43, 0x48, 0x45,
+	0x43, 0x4b, 0x5f, 0x44, 0x45, 0x56, 0x49, 0x43, 0x45, 0x10, 0x05, 0x12, 0x15, 0x0a, 0x11, 0x56,
+	0x45, 0x52, 0x49, 0x46, 0x59, 0x5f, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x5f, 0x4c, 0x49, 0x4e, 0x4b,
+	0x10, 0x06, 0x12, 0x16, 0x0a, 0x12, 0x56, 0x45, 0x52, 0x49, 0x46, 0x59, 0x5f, 0x43, 0x48, 0x45,
+	0x43, 0x4b, 0x5f, 0x4f, 0x57, 0x4e, 0x45, 0x52, 0x10, 0x07, 0x12, 0x16, 0x0a, 0x12, 0x56, 0x45,
+	0x52, 0x49, 0x46, 0x59, 0x5f, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x5f, 0x47, 0x52, 0x4f, 0x55, 0x50,
+	0x10, 0x08, 0x12, 0x16, 0x0a, 0x12, 0x56, 0x45, 0x52, 0x49, 0x46, 0x59, 0x5f, 0x43, 0x48, 0x45,
+	0x43, 0x4b, 0x5f, 0x4d, 0x54, 0x49, 0x4d, 0x45, 0x10, 0x09, 0x12, 0x1d, 0x0a, 0x19, 0x56, 0x45,
+	0x52, 0x49, 0x46, 0x59, 0x5f, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x5f, 0x43, 0x41, 0x50, 0x41, 0x42,
+	0x49, 0x4c, 0x49, 0x54, 0x49, 0x45, 0x53, 0x10, 0x0a, 0x32, 0x9d, 0x09, 0x0a, 0x08, 0x50, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x12, 0x3d, 0x0a, 0x07, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c,
+	0x6c, 0x12, 0x18, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x50, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12,
+	0x17, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x73, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x46, 0x0a, 0x0d, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x12, 0x18, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x50,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12, 0x44, 0x0a, 0x0c, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x17, 0x2e, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x73, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x17, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x50, 0x72,
+	0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12,
+	0x3a, 0x0a, 0x06, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x17, 0x2e, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x15, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x09, 0x44,
+	0x6f, 0x77, 0x6e, 0x67, 0x72, 0x61, 0x64, 0x65, 0x12, 0x1a, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x73, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x67, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e,
+	0x44, 0x6f, 0x77, 0x6e, 0x67, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x3a, 0x0a, 0x06, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x17, 0x2e, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x04,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x15, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x50, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x37, 0x0a, 0x05, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x50, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x46,
+	0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x06, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x79, 0x12, 0x17, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73,
+	0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15,
+	0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x07, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x12, 0x18, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x50,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x04, 0x55, 0x6e, 0x64, 0x6f, 0x12, 0x15,
+	0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x55, 0x6e, 0x64, 0x6f, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73,
+	0x2e, 0x55, 0x6e, 0x64, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x0d,
 	0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x12, 0x1e, 0x2e,
 	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x73,
 	0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
@@ -804,11 +3035,29 @@ var file_packages_proto_rawDesc = []byte{
 	0x08, 0x52, 0x65, 0x70, 0x6f, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x19, 0x2e, 0x50, 0x61, 0x63, 0x6b,
 	0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e,
-	0x52, 0x65, 0x70, 0x6f, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42,
-	0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x53, 0x6e,
-	0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x73, 0x61, 0x6e,
-	0x73, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f,
-	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x65, 0x70, 0x6f, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x3d, 0x0a, 0x07, 0x52, 0x65, 0x70, 0x6f, 0x41, 0x64, 0x64, 0x12, 0x18, 0x2e, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e,
+	0x52, 0x65, 0x70, 0x6f, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x46,
+	0x0a, 0x0a, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x1b, 0x2e, 0x50,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x0a, 0x52, 0x65, 0x70, 0x6f, 0x45, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x12, 0x1b, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e,
+	0x52, 0x65, 0x70, 0x6f, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x70,
+	0x6f, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x49,
+	0x0a, 0x0b, 0x52, 0x65, 0x70, 0x6f, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1c, 0x2e,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x44, 0x69, 0x73,
+	0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x50, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x44, 0x69, 0x73, 0x61, 0x62,
+	0x6c, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b,
+	0x65, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x73, 0x61, 0x6e, 0x73, 0x73, 0x68, 0x65, 0x6c, 0x6c,
+	0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -823,43 +3072,125 @@ func file_packages_proto_rawDescGZIP() []byte {
 	return file_packages_proto_rawDescData
 }
 
-var file_packages_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_packages_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_packages_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_packages_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_packages_proto_goTypes = []interface{}{
-	(PackageSystem)(0),           // 0: Packages.PackageSystem
-	(RepoStatus)(0),              // 1: Packages.RepoStatus
-	(*InstallRequest)(nil),       // 2: Packages.InstallRequest
-	(*InstallReply)(nil),         // 3: Packages.InstallReply
-	(*UpdateRequest)(nil),        // 4: Packages.UpdateRequest
-	(*UpdateReply)(nil),          // 5: Packages.UpdateReply
-	(*ListInstalledRequest)(nil), // 6: Packages.ListInstalledRequest
-	(*PackageInfo)(nil),          // 7: Packages.PackageInfo
-	(*ListInstalledReply)(nil),   // 8: Packages.ListInstalledReply
-	(*RepoListRequest)(nil),      // 9: Packages.RepoListRequest
-	(*Repo)(nil),                 // 10: Packages.Repo
-	(*RepoListReply)(nil),        // 11: Packages.RepoListReply
+	(PackageSystem)(0),            // 0: Packages.PackageSystem
+	(RepoStatus)(0),               // 1: Packages.RepoStatus
+	(VerifyCheck)(0),              // 2: Packages.VerifyCheck
+	(*InstallRequest)(nil),        // 3: Packages.InstallRequest
+	(*PackageChange)(nil),         // 4: Packages.PackageChange
+	(*InstallReply)(nil),          // 5: Packages.InstallReply
+	(*UpdateRequest)(nil),         // 6: Packages.UpdateRequest
+	(*UpdateReply)(nil),           // 7: Packages.UpdateReply
+	(*ProgressReply)(nil),         // 8: Packages.ProgressReply
+	(*RemoveRequest)(nil),         // 9: Packages.RemoveRequest
+	(*RemoveReply)(nil),           // 10: Packages.RemoveReply
+	(*DowngradeRequest)(nil),      // 11: Packages.DowngradeRequest
+	(*DowngradeReply)(nil),        // 12: Packages.DowngradeReply
+	(*SearchRequest)(nil),         // 13: Packages.SearchRequest
+	(*SearchReply)(nil),           // 14: Packages.SearchReply
+	(*ListInstalledRequest)(nil),  // 15: Packages.ListInstalledRequest
+	(*PackageInfo)(nil),           // 16: Packages.PackageInfo
+	(*ListInstalledReply)(nil),    // 17: Packages.ListInstalledReply
+	(*RepoListRequest)(nil),       // 18: Packages.RepoListRequest
+	(*Repo)(nil),                  // 19: Packages.Repo
+	(*RepoListReply)(nil),         // 20: Packages.RepoListReply
+	(*RepoAddRequest)(nil),        // 21: Packages.RepoAddRequest
+	(*RepoAddReply)(nil),          // 22: Packages.RepoAddReply
+	(*RepoRemoveRequest)(nil),     // 23: Packages.RepoRemoveRequest
+	(*RepoRemoveReply)(nil),       // 24: Packages.RepoRemoveReply
+	(*RepoEnableRequest)(nil),     // 25: Packages.RepoEnableRequest
+	(*RepoEnableReply)(nil),       // 26: Packages.RepoEnableReply
+	(*RepoDisableRequest)(nil),    // 27: Packages.RepoDisableRequest
+	(*RepoDisableReply)(nil),      // 28: Packages.RepoDisableReply
+	(*InfoRequest)(nil),           // 29: Packages.InfoRequest
+	(*PackageDetails)(nil),        // 30: Packages.PackageDetails
+	(*InfoReply)(nil),             // 31: Packages.InfoReply
+	(*FilesRequest)(nil),          // 32: Packages.FilesRequest
+	(*FilesReply)(nil),            // 33: Packages.FilesReply
+	(*VerifyRequest)(nil),         // 34: Packages.VerifyRequest
+	(*FileDiscrepancy)(nil),       // 35: Packages.FileDiscrepancy
+	(*VerifyReply)(nil),           // 36: Packages.VerifyReply
+	(*HistoryRequest)(nil),        // 37: Packages.HistoryRequest
+	(*TransactionPackage)(nil),    // 38: Packages.TransactionPackage
+	(*Transaction)(nil),           // 39: Packages.Transaction
+	(*HistoryReply)(nil),          // 40: Packages.HistoryReply
+	(*UndoRequest)(nil),           // 41: Packages.UndoRequest
+	(*UndoReply)(nil),             // 42: Packages.UndoReply
+	(*timestamppb.Timestamp)(nil), // 43: google.protobuf.Timestamp
 }
 var file_packages_proto_depIdxs = []int32{
 	0,  // 0: Packages.InstallRequest.package_system:type_name -> Packages.PackageSystem
-	0,  // 1: Packages.UpdateRequest.package_system:type_name -> Packages.PackageSystem
-	0,  // 2: Packages.ListInstalledRequest.package_system:type_name -> Packages.PackageSystem
-	7,  // 3: Packages.ListInstalledReply.packages:type_name -> Packages.PackageInfo
-	0,  // 4: Packages.RepoListRequest.package_system:type_name -> Packages.PackageSystem
-	1,  // 5: Packages.Repo.status:type_name -> Packages.RepoStatus
-	10, // 6: Packages.RepoListReply.repos:type_name -> Packages.Repo
-	2,  // 7: Packages.Packages.Install:input_type -> Packages.InstallRequest
-	4,  // 8: Packages.Packages.Update:input_type -> Packages.UpdateRequest
-	6,  // 9: Packages.Packages.ListInstalled:input_type -> Packages.ListInstalledRequest
-	9,  // 10: Packages.Packages.RepoList:input_type -> Packages.RepoListRequest
-	3,  // 11: Packages.Packages.Install:output_type -> Packages.InstallReply
-	5,  // 12: Packages.Packages.Update:output_type -> Packages.UpdateReply
-	8,  // 13: Packages.Packages.ListInstalled:output_type -> Packages.ListInstalledReply
-	11, // 14: Packages.Packages.RepoList:output_type -> Packages.RepoListReply
-	11, // [11:15] is the sub-list for method output_type
-	7,  // [7:11] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	4,  // 1: Packages.InstallReply.planned_changes:type_name -> Packages.PackageChange
+	0,  // 2: Packages.UpdateRequest.package_system:type_name -> Packages.PackageSystem
+	4,  // 3: Packages.UpdateReply.planned_changes:type_name -> Packages.PackageChange
+	0,  // 4: Packages.RemoveRequest.package_system:type_name -> Packages.PackageSystem
+	0,  // 5: Packages.DowngradeRequest.package_system:type_name -> Packages.PackageSystem
+	0,  // 6: Packages.SearchRequest.package_system:type_name -> Packages.PackageSystem
+	16, // 7: Packages.SearchReply.packages:type_name -> Packages.PackageInfo
+	0,  // 8: Packages.ListInstalledRequest.package_system:type_name -> Packages.PackageSystem
+	16, // 9: Packages.ListInstalledReply.packages:type_name -> Packages.PackageInfo
+	0,  // 10: Packages.RepoListRequest.package_system:type_name -> Packages.PackageSystem
+	1,  // 11: Packages.Repo.status:type_name -> Packages.RepoStatus
+	19, // 12: Packages.RepoListReply.repos:type_name -> Packages.Repo
+	0,  // 13: Packages.RepoAddRequest.package_system:type_name -> Packages.PackageSystem
+	0,  // 14: Packages.RepoRemoveRequest.package_system:type_name -> Packages.PackageSystem
+	0,  // 15: Packages.RepoEnableRequest.package_system:type_name -> Packages.PackageSystem
+	0,  // 16: Packages.RepoDisableRequest.package_system:type_name -> Packages.PackageSystem
+	0,  // 17: Packages.InfoRequest.package_system:type_name -> Packages.PackageSystem
+	43, // 18: Packages.PackageDetails.install_time:type_name -> google.protobuf.Timestamp
+	30, // 19: Packages.InfoReply.packages:type_name -> Packages.PackageDetails
+	0,  // 20: Packages.FilesRequest.package_system:type_name -> Packages.PackageSystem
+	0,  // 21: Packages.VerifyRequest.package_system:type_name -> Packages.PackageSystem
+	2,  // 22: Packages.FileDiscrepancy.failed:type_name -> Packages.VerifyCheck
+	35, // 23: Packages.VerifyReply.discrepancies:type_name -> Packages.FileDiscrepancy
+	0,  // 24: Packages.HistoryRequest.package_system:type_name -> Packages.PackageSystem
+	43, // 25: Packages.Transaction.time:type_name -> google.protobuf.Timestamp
+	38, // 26: Packages.Transaction.packages:type_name -> Packages.TransactionPackage
+	39, // 27: Packages.HistoryReply.transactions:type_name -> Packages.Transaction
+	0,  // 28: Packages.UndoRequest.package_system:type_name -> Packages.PackageSystem
+	3,  // 29: Packages.Packages.Install:input_type -> Packages.InstallRequest
+	6,  // 30: Packages.Packages.Update:input_type -> Packages.UpdateRequest
+	3,  // 31: Packages.Packages.InstallStream:input_type -> Packages.InstallRequest
+	6,  // 32: Packages.Packages.UpdateStream:input_type -> Packages.UpdateRequest
+	9,  // 33: Packages.Packages.Remove:input_type -> Packages.RemoveRequest
+	11, // 34: Packages.Packages.Downgrade:input_type -> Packages.DowngradeRequest
+	13, // 35: Packages.Packages.Search:input_type -> Packages.SearchRequest
+	29, // 36: Packages.Packages.Info:input_type -> Packages.InfoRequest
+	32, // 37: Packages.Packages.Files:input_type -> Packages.FilesRequest
+	34, // 38: Packages.Packages.Verify:input_type -> Packages.VerifyRequest
+	37, // 39: Packages.Packages.History:input_type -> Packages.HistoryRequest
+	41, // 40: Packages.Packages.Undo:input_type -> Packages.UndoRequest
+	15, // 41: Packages.Packages.ListInstalled:input_type -> Packages.ListInstalledRequest
+	18, // 42: Packages.Packages.RepoList:input_type -> Packages.RepoListRequest
+	21, // 43: Packages.Packages.RepoAdd:input_type -> Packages.RepoAddRequest
+	23, // 44: Packages.Packages.RepoRemove:input_type -> Packages.RepoRemoveRequest
+	25, // 45: Packages.Packages.RepoEnable:input_type -> Packages.RepoEnableRequest
+	27, // 46: Packages.Packages.RepoDisable:input_type -> Packages.RepoDisableRequest
+	5,  // 47: Packages.Packages.Install:output_type -> Packages.InstallReply
+	7,  // 48: Packages.Packages.Update:output_type -> Packages.UpdateReply
+	8,  // 49: Packages.Packages.InstallStream:output_type -> Packages.ProgressReply
+	8,  // 50: Packages.Packages.UpdateStream:output_type -> Packages.ProgressReply
+	10, // 51: Packages.Packages.Remove:output_type -> Packages.RemoveReply
+	12, // 52: Packages.Packages.Downgrade:output_type -> Packages.DowngradeReply
+	14, // 53: Packages.Packages.Search:output_type -> Packages.SearchReply
+	31, // 54: Packages.Packages.Info:output_type -> Packages.InfoReply
+	33, // 55: Packages.Packages.Files:output_type -> Packages.FilesReply
+	36, // 56: Packages.Packages.Verify:output_type -> Packages.VerifyReply
+	40, // 57: Packages.Packages.History:output_type -> Packages.HistoryReply
+	42, // 58: Packages.Packages.Undo:output_type -> Packages.UndoReply
+	17, // 59: Packages.Packages.ListInstalled:output_type -> Packages.ListInstalledReply
+	20, // 60: Packages.Packages.RepoList:output_type -> Packages.RepoListReply
+	22, // 61: Packages.Packages.RepoAdd:output_type -> Packages.RepoAddReply
+	24, // 62: Packages.Packages.RepoRemove:output_type -> Packages.RepoRemoveReply
+	26, // 63: Packages.Packages.RepoEnable:output_type -> Packages.RepoEnableReply
+	28, // 64: Packages.Packages.RepoDisable:output_type -> Packages.RepoDisableReply
+	47, // [47:65] is the sub-list for method output_type
+	29, // [29:47] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
 }
 
 func init() { file_packages_proto_init() }
@@ -880,8 +3211,80 @@ func file_packages_proto_init() {
 				return nil
 			}
 		}
-		file_packages_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InstallReply); i {
+		file_packages_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PackageChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InstallReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProgressReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -892,8 +3295,8 @@ func file_packages_proto_init() {
 				return nil
 			}
 		}
-		file_packages_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateRequest); i {
+		file_packages_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DowngradeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -904,8 +3307,8 @@ func file_packages_proto_init() {
 				return nil
 			}
 		}
-		file_packages_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateReply); i {
+		file_packages_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DowngradeReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -916,7 +3319,31 @@ func file_packages_proto_init() {
 				return nil
 			}
 		}
-		file_packages_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_packages_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListInstalledRequest); i {
 			case 0:
 				return &v.state
@@ -928,7 +3355,7 @@ func file_packages_proto_init() {
 				return nil
 			}
 		}
-		file_packages_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_packages_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PackageInfo); i {
 			case 0:
 				return &v.state
@@ -940,7 +3367,7 @@ func file_packages_proto_init() {
 				return nil
 			}
 		}
-		file_packages_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_packages_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListInstalledReply); i {
 			case 0:
 				return &v.state
@@ -952,7 +3379,7 @@ func file_packages_proto_init() {
 				return nil
 			}
 		}
-		file_packages_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_packages_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RepoListRequest); i {
 			case 0:
 				return &v.state
@@ -964,7 +3391,7 @@ func file_packages_proto_init() {
 				return nil
 			}
 		}
-		file_packages_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_packages_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Repo); i {
 			case 0:
 				return &v.state
@@ -976,7 +3403,7 @@ func file_packages_proto_init() {
 				return nil
 			}
 		}
-		file_packages_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_packages_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RepoListReply); i {
 			case 0:
 				return &v.state
@@ -988,14 +3415,278 @@ func file_packages_proto_init() {
 				return nil
 			}
 		}
+		file_packages_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RepoAddRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RepoAddReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RepoRemoveRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RepoRemoveReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RepoEnableRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RepoEnableReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RepoDisableRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RepoDisableReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InfoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PackageDetails); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InfoReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FilesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FilesReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileDiscrepancy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransactionPackage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Transaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HistoryReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UndoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packages_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UndoReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_packages_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   10,
+			NumEnums:      3,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,8 +20,39 @@ const _ = grpc.SupportPackageIsVersion7
 type PackagesClient interface {
 	Install(ctx context.Context, in *InstallRequest, opts ...grpc.CallOption) (*InstallReply, error)
 	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateReply, error)
+	// InstallStream is Install but returns output from the package manager
+	// (i.e. download and install progress) as it runs. The status of the
+	// stream indicates whether the install succeeded.
+	InstallStream(ctx context.Context, in *InstallRequest, opts ...grpc.CallOption) (Packages_InstallStreamClient, error)
+	// UpdateStream is Update but returns output as it runs as with
+	// InstallStream.
+	UpdateStream(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (Packages_UpdateStreamClient, error)
+	Remove(ctx context.Context, in *RemoveRequest, opts ...grpc.CallOption) (*RemoveReply, error)
+	Downgrade(ctx context.Context, in *DowngradeRequest, opts ...grpc.CallOption) (*DowngradeReply, error)
+	// Search returns the versions of a package available from the
+	// configured repos.
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchReply, error)
+	// Info returns details about an installed package.
+	Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoReply, error)
+	// Files returns the files owned by an installed package.
+	Files(ctx context.Context, in *FilesRequest, opts ...grpc.CallOption) (*FilesReply, error)
+	// Verify compares the files owned by an installed package against the
+	// package database and returns any discrepancies.
+	Verify(ctx context.Context, in *VerifyRequest, opts ...grpc.CallOption) (*VerifyReply, error)
+	// History lists the most recent package transactions. Only supported
+	// for YUM.
+	History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryReply, error)
+	// Undo reverts all of the changes made by a transaction from History.
+	Undo(ctx context.Context, in *UndoRequest, opts ...grpc.CallOption) (*UndoReply, error)
 	ListInstalled(ctx context.Context, in *ListInstalledRequest, opts ...grpc.CallOption) (*ListInstalledReply, error)
 	RepoList(ctx context.Context, in *RepoListRequest, opts ...grpc.CallOption) (*RepoListReply, error)
+	// RepoAdd writes a new repo definition to its own file.
+	RepoAdd(ctx context.Context, in *RepoAddRequest, opts ...grpc.CallOption) (*RepoAddReply, error)
+	// RepoRemove removes a repo definition added by RepoAdd. Other repo
+	// files (i.e. ones from the distribution) are refused.
+	RepoRemove(ctx context.Context, in *RepoRemoveRequest, opts ...grpc.CallOption) (*RepoRemoveReply, error)
+	RepoEnable(ctx context.Context, in *RepoEnableRequest, opts ...grpc.CallOption) (*RepoEnableReply, error)
+	RepoDisable(ctx context.Context, in *RepoDisableRequest, opts ...grpc.CallOption) (*RepoDisableReply, error)
 }
 
 type packagesClient struct {
@@ -50,6 +81,142 @@ func (c *packagesClient) Update(ctx context.Context, in *UpdateRequest, opts ...
 	return out, nil
 }
 
+func (c *packagesClient) InstallStream(ctx context.Context, in *InstallRequest, opts ...grpc.CallOption) (Packages_InstallStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Packages_ServiceDesc.Streams[0], "/Packages.Packages/InstallStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &packagesInstallStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Packages_InstallStreamClient interface {
+	Recv() (*ProgressReply, error)
+	grpc.ClientStream
+}
+
+type packagesInstallStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *packagesInstallStreamClient) Recv() (*ProgressReply, error) {
+	m := new(ProgressReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *packagesClient) UpdateStream(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (Packages_UpdateStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Packages_ServiceDesc.Streams[1], "/Packages.Packages/UpdateStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &packagesUpdateStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Packages_UpdateStreamClient interface {
+	Recv() (*ProgressReply, error)
+	grpc.ClientStream
+}
+
+type packagesUpdateStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *packagesUpdateStreamClient) Recv() (*ProgressReply, error) {
+	m := new(ProgressReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *packagesClient) Remove(ctx context.Context, in *RemoveRequest, opts ...grpc.CallOption) (*RemoveReply, error) {
+	out := new(RemoveReply)
+	err := c.cc.Invoke(ctx, "/Packages.Packages/Remove", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *packagesClient) Downgrade(ctx context.Context, in *DowngradeRequest, opts ...grpc.CallOption) (*DowngradeReply, error) {
+	out := new(DowngradeReply)
+	err := c.cc.Invoke(ctx, "/Packages.Packages/Downgrade", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *packagesClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchReply, error) {
+	out := new(SearchReply)
+	err := c.cc.Invoke(ctx, "/Packages.Packages/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *packagesClient) Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoReply, error) {
+	out := new(InfoReply)
+	err := c.cc.Invoke(ctx, "/Packages.Packages/Info", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *packagesClient) Files(ctx context.Context, in *FilesRequest, opts ...grpc.CallOption) (*FilesReply, error) {
+	out := new(FilesReply)
+	err := c.cc.Invoke(ctx, "/Packages.Packages/Files", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *packagesClient) Verify(ctx context.Context, in *VerifyRequest, opts ...grpc.CallOption) (*VerifyReply, error) {
+	out := new(VerifyReply)
+	err := c.cc.Invoke(ctx, "/Packages.Packages/Verify", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *packagesClient) History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryReply, error) {
+	out := new(HistoryReply)
+	err := c.cc.Invoke(ctx, "/Packages.Packages/History", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *packagesClient) Undo(ctx context.Context, in *UndoRequest, opts ...grpc.CallOption) (*UndoReply, error) {
+	out := new(UndoReply)
+	err := c.cc.Invoke(ctx, "/Packages.Packages/Undo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *packagesClient) ListInstalled(ctx context.Context, in *ListInstalledRequest, opts ...grpc.CallOption) (*ListInstalledReply, error) {
 	out := new(ListInstalledReply)
 	err := c.cc.Invoke(ctx, "/Packages.Packages/ListInstalled", in, out, opts...)
@@ -68,14 +235,81 @@ func (c *packagesClient) RepoList(ctx context.Context, in *RepoListRequest, opts
 	return out, nil
 }
 
+func (c *packagesClient) RepoAdd(ctx context.Context, in *RepoAddRequest, opts ...grpc.CallOption) (*RepoAddReply, error) {
+	out := new(RepoAddReply)
+	err := c.cc.Invoke(ctx, "/Packages.Packages/RepoAdd", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *packagesClient) RepoRemove(ctx context.Context, in *RepoRemoveRequest, opts ...grpc.CallOption) (*RepoRemoveReply, error) {
+	out := new(RepoRemoveReply)
+	err := c.cc.Invoke(ctx, "/Packages.Packages/RepoRemove", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *packagesClient) RepoEnable(ctx context.Context, in *RepoEnableRequest, opts ...grpc.CallOption) (*RepoEnableReply, error) {
+	out := new(RepoEnableReply)
+	err := c.cc.Invoke(ctx, "/Packages.Packages/RepoEnable", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *packagesClient) RepoDisable(ctx context.Context, in *RepoDisableRequest, opts ...grpc.CallOption) (*RepoDisableReply, error) {
+	out := new(RepoDisableReply)
+	err := c.cc.Invoke(ctx, "/Packages.Packages/RepoDisable", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PackagesServer is the server API for Packages service.
 // All implementations should embed UnimplementedPackagesServer
 // for forward compatibility
 type PackagesServer interface {
 	Install(context.Context, *InstallRequest) (*InstallReply, error)
 	Update(context.Context, *UpdateRequest) (*UpdateReply, error)
+	// InstallStream is Install but returns output from the package manager
+	// (i.e. download and install progress) as it runs. The status of the
+	// stream indicates whether the install succeeded.
+	InstallStream(*InstallRequest, Packages_InstallStreamServer) error
+	// UpdateStream is Update but returns output as it runs as with
+	// InstallStream.
+	UpdateStream(*UpdateRequest, Packages_UpdateStreamServer) error
+	Remove(context.Context, *RemoveRequest) (*RemoveReply, error)
+	Downgrade(context.Context, *DowngradeRequest) (*DowngradeReply, error)
+	// Search returns the versions of a package available from the
+	// configured repos.
+	Search(context.Context, *SearchRequest) (*SearchReply, error)
+	// Info returns details about an installed package.
+	Info(context.Context, *InfoRequest) (*InfoReply, error)
+	// Files returns the files owned by an installed package.
+	Files(context.Context, *FilesRequest) (*FilesReply, error)
+	// Verify compares the files owned by an installed package against the
+	// package database and returns any discrepancies.
+	Verify(context.Context, *VerifyRequest) (*VerifyReply, error)
+	// History lists the most recent package transactions. Only supported
+	// for YUM.
+	History(context.Context, *HistoryRequest) (*HistoryReply, error)
+	// Undo reverts all of the changes made by a transaction from History.
+	Undo(context.Context, *UndoRequest) (*UndoReply, error)
 	ListInstalled(context.Context, *ListInstalledRequest) (*ListInstalledReply, error)
 	RepoList(context.Context, *RepoListRequest) (*RepoListReply, error)
+	// RepoAdd writes a new repo definition to its own file.
+	RepoAdd(context.Context, *RepoAddRequest) (*RepoAddReply, error)
+	// RepoRemove removes a repo definition added by RepoAdd. Other repo
+	// files (i.e. ones from the distribution) are refused.
+	RepoRemove(context.Context, *RepoRemoveRequest) (*RepoRemoveReply, error)
+	RepoEnable(context.Context, *RepoEnableRequest) (*RepoEnableReply, error)
+	RepoDisable(context.Context, *RepoDisableRequest) (*RepoDisableReply, error)
 }
 
 // UnimplementedPackagesServer should be embedded to have forward compatible implementations.
@@ -88,12 +322,54 @@ func (UnimplementedPackagesServer) Install(context.Context, *InstallRequest) (*I
 func (UnimplementedPackagesServer) Update(context.Context, *UpdateRequest) (*UpdateReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
 }
+func (UnimplementedPackagesServer) InstallStream(*InstallRequest, Packages_InstallStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method InstallStream not implemented")
+}
+func (UnimplementedPackagesServer) UpdateStream(*UpdateRequest, Packages_UpdateStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method UpdateStream not implemented")
+}
+func (UnimplementedPackagesServer) Remove(context.Context, *RemoveRequest) (*RemoveReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Remove not implemented")
+}
+func (UnimplementedPackagesServer) Downgrade(context.Context, *DowngradeRequest) (*DowngradeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Downgrade not implemented")
+}
+func (UnimplementedPackagesServer) Search(context.Context, *SearchRequest) (*SearchReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedPackagesServer) Info(context.Context, *InfoRequest) (*InfoReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Info not implemented")
+}
+func (UnimplementedPackagesServer) Files(context.Context, *FilesRequest) (*FilesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Files not implemented")
+}
+func (UnimplementedPackagesServer) Verify(context.Context, *VerifyRequest) (*VerifyReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Verify not implemented")
+}
+func (UnimplementedPackagesServer) History(context.Context, *HistoryRequest) (*HistoryReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method History not implemented")
+}
+func (UnimplementedPackagesServer) Undo(context.Context, *UndoRequest) (*UndoReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Undo not implemented")
+}
 func (UnimplementedPackagesServer) ListInstalled(context.Context, *ListInstalledRequest) (*ListInstalledReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListInstalled not implemented")
 }
 func (UnimplementedPackagesServer) RepoList(context.Context, *RepoListRequest) (*RepoListReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RepoList not implemented")
 }
+func (UnimplementedPackagesServer) RepoAdd(context.Context, *RepoAddRequest) (*RepoAddReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RepoAdd not implemented")
+}
+func (UnimplementedPackagesServer) RepoRemove(context.Context, *RepoRemoveRequest) (*RepoRemoveReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RepoRemove not implemented")
+}
+func (UnimplementedPackagesServer) RepoEnable(context.Context, *RepoEnableRequest) (*RepoEnableReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RepoEnable not implemented")
+}
+func (UnimplementedPackagesServer) RepoDisable(context.Context, *RepoDisableRequest) (*RepoDisableReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RepoDisable not implemented")
+}
 
 // UnsafePackagesServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to PackagesServer will
@@ -142,6 +418,192 @@ func _Packages_Update_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Packages_InstallStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InstallRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PackagesServer).InstallStream(m, &packagesInstallStreamServer{stream})
+}
+
+type Packages_InstallStreamServer interface {
+	Send(*ProgressReply) error
+	grpc.ServerStream
+}
+
+type packagesInstallStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *packagesInstallStreamServer) Send(m *ProgressReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Packages_UpdateStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UpdateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PackagesServer).UpdateStream(m, &packagesUpdateStreamServer{stream})
+}
+
+type Packages_UpdateStreamServer interface {
+	Send(*ProgressReply) error
+	grpc.ServerStream
+}
+
+type packagesUpdateStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *packagesUpdateStreamServer) Send(m *ProgressReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Packages_Remove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PackagesServer).Remove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Packages.Packages/Remove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PackagesServer).Remove(ctx, req.(*RemoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Packages_Downgrade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DowngradeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PackagesServer).Downgrade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Packages.Packages/Downgrade",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PackagesServer).Downgrade(ctx, req.(*DowngradeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Packages_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PackagesServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Packages.Packages/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PackagesServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Packages_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PackagesServer).Info(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Packages.Packages/Info",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PackagesServer).Info(ctx, req.(*InfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Packages_Files_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PackagesServer).Files(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Packages.Packages/Files",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PackagesServer).Files(ctx, req.(*FilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Packages_Verify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PackagesServer).Verify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Packages.Packages/Verify",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PackagesServer).Verify(ctx, req.(*VerifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Packages_History_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PackagesServer).History(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Packages.Packages/History",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PackagesServer).History(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Packages_Undo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PackagesServer).Undo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Packages.Packages/Undo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PackagesServer).Undo(ctx, req.(*UndoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Packages_ListInstalled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListInstalledRequest)
 	if err := dec(in); err != nil {
@@ -178,6 +640,78 @@ func _Packages_RepoList_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Packages_RepoAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepoAddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PackagesServer).RepoAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Packages.Packages/RepoAdd",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PackagesServer).RepoAdd(ctx, req.(*RepoAddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Packages_RepoRemove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepoRemoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PackagesServer).RepoRemove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Packages.Packages/RepoRemove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PackagesServer).RepoRemove(ctx, req.(*RepoRemoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Packages_RepoEnable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepoEnableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PackagesServer).RepoEnable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Packages.Packages/RepoEnable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PackagesServer).RepoEnable(ctx, req.(*RepoEnableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Packages_RepoDisable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepoDisableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PackagesServer).RepoDisable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Packages.Packages/RepoDisable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PackagesServer).RepoDisable(ctx, req.(*RepoDisableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Packages_ServiceDesc is the grpc.ServiceDesc for Packages service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -193,6 +727,38 @@ var Packages_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Update",
 			Handler:    _Packages_Update_Handler,
 		},
+		{
+			MethodName: "Remove",
+			Handler:    _Packages_Remove_Handler,
+		},
+		{
+			MethodName: "Downgrade",
+			Handler:    _Packages_Downgrade_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _Packages_Search_Handler,
+		},
+		{
+			MethodName: "Info",
+			Handler:    _Packages_Info_Handler,
+		},
+		{
+			MethodName: "Files",
+			Handler:    _Packages_Files_Handler,
+		},
+		{
+			MethodName: "Verify",
+			Handler:    _Packages_Verify_Handler,
+		},
+		{
+			MethodName: "History",
+			Handler:    _Packages_History_Handler,
+		},
+		{
+			MethodName: "Undo",
+			Handler:    _Packages_Undo_Handler,
+		},
 		{
 			MethodName: "ListInstalled",
 			Handler:    _Packages_ListInstalled_Handler,
@@ -201,7 +767,34 @@ var Packages_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RepoList",
 			Handler:    _Packages_RepoList_Handler,
 		},
+		{
+			MethodName: "RepoAdd",
+			Handler:    _Packages_RepoAdd_Handler,
+		},
+		{
+			MethodName: "RepoRemove",
+			Handler:    _Packages_RepoRemove_Handler,
+		},
+		{
+			MethodName: "RepoEnable",
+			Handler:    _Packages_RepoEnable_Handler,
+		},
+		{
+			MethodName: "RepoDisable",
+			Handler:    _Packages_RepoDisable_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "InstallStream",
+			Handler:       _Packages_InstallStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UpdateStream",
+			Handler:       _Packages_UpdateStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "packages.proto",
 }
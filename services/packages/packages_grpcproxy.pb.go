@@ -13,6 +13,7 @@ import (
 
 import (
 	"fmt"
+	"io"
 )
 
 // PackagesClientProxy is the superset of PackagesClient which additionally includes the OneMany proxy methods
@@ -20,8 +21,22 @@ type PackagesClientProxy interface {
 	PackagesClient
 	InstallOneMany(ctx context.Context, in *InstallRequest, opts ...grpc.CallOption) (<-chan *InstallManyResponse, error)
 	UpdateOneMany(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (<-chan *UpdateManyResponse, error)
+	InstallStreamOneMany(ctx context.Context, in *InstallRequest, opts ...grpc.CallOption) (Packages_InstallStreamClientProxy, error)
+	UpdateStreamOneMany(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (Packages_UpdateStreamClientProxy, error)
+	RemoveOneMany(ctx context.Context, in *RemoveRequest, opts ...grpc.CallOption) (<-chan *RemoveManyResponse, error)
+	DowngradeOneMany(ctx context.Context, in *DowngradeRequest, opts ...grpc.CallOption) (<-chan *DowngradeManyResponse, error)
+	SearchOneMany(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (<-chan *SearchManyResponse, error)
+	InfoOneMany(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (<-chan *InfoManyResponse, error)
+	FilesOneMany(ctx context.Context, in *FilesRequest, opts ...grpc.CallOption) (<-chan *FilesManyResponse, error)
+	VerifyOneMany(ctx context.Context, in *VerifyRequest, opts ...grpc.CallOption) (<-chan *VerifyManyResponse, error)
+	HistoryOneMany(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (<-chan *HistoryManyResponse, error)
+	UndoOneMany(ctx context.Context, in *UndoRequest, opts ...grpc.CallOption) (<-chan *UndoManyResponse, error)
 	ListInstalledOneMany(ctx context.Context, in *ListInstalledRequest, opts ...grpc.CallOption) (<-chan *ListInstalledManyResponse, error)
 	RepoListOneMany(ctx context.Context, in *RepoListRequest, opts ...grpc.CallOption) (<-chan *RepoListManyResponse, error)
+	RepoAddOneMany(ctx context.Context, in *RepoAddRequest, opts ...grpc.CallOption) (<-chan *RepoAddManyResponse, error)
+	RepoRemoveOneMany(ctx context.Context, in *RepoRemoveRequest, opts ...grpc.CallOption) (<-chan *RepoRemoveManyResponse, error)
+	RepoEnableOneMany(ctx context.Context, in *RepoEnableRequest, opts ...grpc.CallOption) (<-chan *RepoEnableManyResponse, error)
+	RepoDisableOneMany(ctx context.Context, in *RepoDisableRequest, opts ...grpc.CallOption) (<-chan *RepoDisableManyResponse, error)
 }
 
 // Embed the original client inside of this so we get the other generated methods automatically.
@@ -169,6 +184,718 @@ func (c *packagesClientProxy) UpdateOneMany(ctx context.Context, in *UpdateReque
 	return ret, nil
 }
 
+// InstallStreamManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type InstallStreamManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *ProgressReply
+	Error error
+}
+
+type Packages_InstallStreamClientProxy interface {
+	Recv() ([]*InstallStreamManyResponse, error)
+	grpc.ClientStream
+}
+
+type packagesClientInstallStreamClientProxy struct {
+	cc         *proxy.Conn
+	directDone bool
+	grpc.ClientStream
+}
+
+func (x *packagesClientInstallStreamClientProxy) Recv() ([]*InstallStreamManyResponse, error) {
+	var ret []*InstallStreamManyResponse
+	// If this is a direct connection the RecvMsg call is to a standard grpc.ClientStream
+	// and not our proxy based one. This means we need to receive a typed response and
+	// convert it into a single slice entry return. This ensures the OneMany style calls
+	// can be used by proxy with 1:N targets and non proxy with 1 target without client changes.
+	if x.cc.Direct() {
+		// Check if we're done. Just return EOF now. Any real error was already sent inside
+		// of a ManyResponse.
+		if x.directDone {
+			return nil, io.EOF
+		}
+		m := &ProgressReply{}
+		err := x.ClientStream.RecvMsg(m)
+		ret = append(ret, &InstallStreamManyResponse{
+			Resp:   m,
+			Error:  err,
+			Target: x.cc.Targets[0],
+			Index:  0,
+		})
+		// An error means we're done so set things so a later call now gets an EOF.
+		if err != nil {
+			x.directDone = true
+		}
+		return ret, nil
+	}
+
+	m := []*proxy.Ret{}
+	if err := x.ClientStream.RecvMsg(&m); err != nil {
+		return nil, err
+	}
+	for _, r := range m {
+		typedResp := &InstallStreamManyResponse{
+			Resp: &ProgressReply{},
+		}
+		typedResp.Target = r.Target
+		typedResp.Index = r.Index
+		typedResp.Error = r.Error
+		if r.Error == nil {
+			if err := r.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+				typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, r.Error)
+			}
+		}
+		ret = append(ret, typedResp)
+	}
+	return ret, nil
+}
+
+// InstallStreamOneMany provides the same API as InstallStream but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *packagesClientProxy) InstallStreamOneMany(ctx context.Context, in *InstallRequest, opts ...grpc.CallOption) (Packages_InstallStreamClientProxy, error) {
+	stream, err := c.cc.NewStream(ctx, &Packages_ServiceDesc.Streams[0], "/Packages.Packages/InstallStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &packagesClientInstallStreamClientProxy{c.cc.(*proxy.Conn), false, stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// UpdateStreamManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type UpdateStreamManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *ProgressReply
+	Error error
+}
+
+type Packages_UpdateStreamClientProxy interface {
+	Recv() ([]*UpdateStreamManyResponse, error)
+	grpc.ClientStream
+}
+
+type packagesClientUpdateStreamClientProxy struct {
+	cc         *proxy.Conn
+	directDone bool
+	grpc.ClientStream
+}
+
+func (x *packagesClientUpdateStreamClientProxy) Recv() ([]*UpdateStreamManyResponse, error) {
+	var ret []*UpdateStreamManyResponse
+	// If this is a direct connection the RecvMsg call is to a standard grpc.ClientStream
+	// and not our proxy based one. This means we need to receive a typed response and
+	// convert it into a single slice entry return. This ensures the OneMany style calls
+	// can be used by proxy with 1:N targets and non proxy with 1 target without client changes.
+	if x.cc.Direct() {
+		// Check if we're done. Just return EOF now. Any real error was already sent inside
+		// of a ManyResponse.
+		if x.directDone {
+			return nil, io.EOF
+		}
+		m := &ProgressReply{}
+		err := x.ClientStream.RecvMsg(m)
+		ret = append(ret, &UpdateStreamManyResponse{
+			Resp:   m,
+			Error:  err,
+			Target: x.cc.Targets[0],
+			Index:  0,
+		})
+		// An error means we're done so set things so a later call now gets an EOF.
+		if err != nil {
+			x.directDone = true
+		}
+		return ret, nil
+	}
+
+	m := []*proxy.Ret{}
+	if err := x.ClientStream.RecvMsg(&m); err != nil {
+		return nil, err
+	}
+	for _, r := range m {
+		typedResp := &UpdateStreamManyResponse{
+			Resp: &ProgressReply{},
+		}
+		typedResp.Target = r.Target
+		typedResp.Index = r.Index
+		typedResp.Error = r.Error
+		if r.Error == nil {
+			if err := r.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+				typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, r.Error)
+			}
+		}
+		ret = append(ret, typedResp)
+	}
+	return ret, nil
+}
+
+// UpdateStreamOneMany provides the same API as UpdateStream but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *packagesClientProxy) UpdateStreamOneMany(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (Packages_UpdateStreamClientProxy, error) {
+	stream, err := c.cc.NewStream(ctx, &Packages_ServiceDesc.Streams[1], "/Packages.Packages/UpdateStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &packagesClientUpdateStreamClientProxy{c.cc.(*proxy.Conn), false, stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// RemoveManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type RemoveManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *RemoveReply
+	Error error
+}
+
+// RemoveOneMany provides the same API as Remove but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *packagesClientProxy) RemoveOneMany(ctx context.Context, in *RemoveRequest, opts ...grpc.CallOption) (<-chan *RemoveManyResponse, error) {
+	conn := c.cc.(*proxy.Conn)
+	ret := make(chan *RemoveManyResponse)
+	// If this is a single case we can just use Invoke and marshal it onto the channel once and be done.
+	if len(conn.Targets) == 1 {
+		go func() {
+			out := &RemoveManyResponse{
+				Target: conn.Targets[0],
+				Index:  0,
+				Resp:   &RemoveReply{},
+			}
+			err := conn.Invoke(ctx, "/Packages.Packages/Remove", in, out.Resp, opts...)
+			if err != nil {
+				out.Error = err
+			}
+			// Send and close.
+			ret <- out
+			close(ret)
+		}()
+		return ret, nil
+	}
+	manyRet, err := conn.InvokeOneMany(ctx, "/Packages.Packages/Remove", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// A goroutine to retrive untyped responses and convert them to typed ones.
+	go func() {
+		for {
+			typedResp := &RemoveManyResponse{
+				Resp: &RemoveReply{},
+			}
+
+			resp, ok := <-manyRet
+			if !ok {
+				// All done so we can shut down.
+				close(ret)
+				return
+			}
+			typedResp.Target = resp.Target
+			typedResp.Index = resp.Index
+			typedResp.Error = resp.Error
+			if resp.Error == nil {
+				if err := resp.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+					typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, resp.Error)
+				}
+			}
+			ret <- typedResp
+		}
+	}()
+
+	return ret, nil
+}
+
+// DowngradeManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type DowngradeManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *DowngradeReply
+	Error error
+}
+
+// DowngradeOneMany provides the same API as Downgrade but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *packagesClientProxy) DowngradeOneMany(ctx context.Context, in *DowngradeRequest, opts ...grpc.CallOption) (<-chan *DowngradeManyResponse, error) {
+	conn := c.cc.(*proxy.Conn)
+	ret := make(chan *DowngradeManyResponse)
+	// If this is a single case we can just use Invoke and marshal it onto the channel once and be done.
+	if len(conn.Targets) == 1 {
+		go func() {
+			out := &DowngradeManyResponse{
+				Target: conn.Targets[0],
+				Index:  0,
+				Resp:   &DowngradeReply{},
+			}
+			err := conn.Invoke(ctx, "/Packages.Packages/Downgrade", in, out.Resp, opts...)
+			if err != nil {
+				out.Error = err
+			}
+			// Send and close.
+			ret <- out
+			close(ret)
+		}()
+		return ret, nil
+	}
+	manyRet, err := conn.InvokeOneMany(ctx, "/Packages.Packages/Downgrade", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// A goroutine to retrive untyped responses and convert them to typed ones.
+	go func() {
+		for {
+			typedResp := &DowngradeManyResponse{
+				Resp: &DowngradeReply{},
+			}
+
+			resp, ok := <-manyRet
+			if !ok {
+				// All done so we can shut down.
+				close(ret)
+				return
+			}
+			typedResp.Target = resp.Target
+			typedResp.Index = resp.Index
+			typedResp.Error = resp.Error
+			if resp.Error == nil {
+				if err := resp.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+					typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, resp.Error)
+				}
+			}
+			ret <- typedResp
+		}
+	}()
+
+	return ret, nil
+}
+
+// SearchManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type SearchManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *SearchReply
+	Error error
+}
+
+// SearchOneMany provides the same API as Search but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *packagesClientProxy) SearchOneMany(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (<-chan *SearchManyResponse, error) {
+	conn := c.cc.(*proxy.Conn)
+	ret := make(chan *SearchManyResponse)
+	// If this is a single case we can just use Invoke and marshal it onto the channel once and be done.
+	if len(conn.Targets) == 1 {
+		go func() {
+			out := &SearchManyResponse{
+				Target: conn.Targets[0],
+				Index:  0,
+				Resp:   &SearchReply{},
+			}
+			err := conn.Invoke(ctx, "/Packages.Packages/Search", in, out.Resp, opts...)
+			if err != nil {
+				out.Error = err
+			}
+			// Send and close.
+			ret <- out
+			close(ret)
+		}()
+		return ret, nil
+	}
+	manyRet, err := conn.InvokeOneMany(ctx, "/Packages.Packages/Search", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// A goroutine to retrive untyped responses and convert them to typed ones.
+	go func() {
+		for {
+			typedResp := &SearchManyResponse{
+				Resp: &SearchReply{},
+			}
+
+			resp, ok := <-manyRet
+			if !ok {
+				// All done so we can shut down.
+				close(ret)
+				return
+			}
+			typedResp.Target = resp.Target
+			typedResp.Index = resp.Index
+			typedResp.Error = resp.Error
+			if resp.Error == nil {
+				if err := resp.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+					typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, resp.Error)
+				}
+			}
+			ret <- typedResp
+		}
+	}()
+
+	return ret, nil
+}
+
+// InfoManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type InfoManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *InfoReply
+	Error error
+}
+
+// InfoOneMany provides the same API as Info but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *packagesClientProxy) InfoOneMany(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (<-chan *InfoManyResponse, error) {
+	conn := c.cc.(*proxy.Conn)
+	ret := make(chan *InfoManyResponse)
+	// If this is a single case we can just use Invoke and marshal it onto the channel once and be done.
+	if len(conn.Targets) == 1 {
+		go func() {
+			out := &InfoManyResponse{
+				Target: conn.Targets[0],
+				Index:  0,
+				Resp:   &InfoReply{},
+			}
+			err := conn.Invoke(ctx, "/Packages.Packages/Info", in, out.Resp, opts...)
+			if err != nil {
+				out.Error = err
+			}
+			// Send and close.
+			ret <- out
+			close(ret)
+		}()
+		return ret, nil
+	}
+	manyRet, err := conn.InvokeOneMany(ctx, "/Packages.Packages/Info", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// A goroutine to retrive untyped responses and convert them to typed ones.
+	go func() {
+		for {
+			typedResp := &InfoManyResponse{
+				Resp: &InfoReply{},
+			}
+
+			resp, ok := <-manyRet
+			if !ok {
+				// All done so we can shut down.
+				close(ret)
+				return
+			}
+			typedResp.Target = resp.Target
+			typedResp.Index = resp.Index
+			typedResp.Error = resp.Error
+			if resp.Error == nil {
+				if err := resp.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+					typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, resp.Error)
+				}
+			}
+			ret <- typedResp
+		}
+	}()
+
+	return ret, nil
+}
+
+// FilesManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type FilesManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *FilesReply
+	Error error
+}
+
+// FilesOneMany provides the same API as Files but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *packagesClientProxy) FilesOneMany(ctx context.Context, in *FilesRequest, opts ...grpc.CallOption) (<-chan *FilesManyResponse, error) {
+	conn := c.cc.(*proxy.Conn)
+	ret := make(chan *FilesManyResponse)
+	// If this is a single case we can just use Invoke and marshal it onto the channel once and be done.
+	if len(conn.Targets) == 1 {
+		go func() {
+			out := &FilesManyResponse{
+				Target: conn.Targets[0],
+				Index:  0,
+				Resp:   &FilesReply{},
+			}
+			err := conn.Invoke(ctx, "/Packages.Packages/Files", in, out.Resp, opts...)
+			if err != nil {
+				out.Error = err
+			}
+			// Send and close.
+			ret <- out
+			close(ret)
+		}()
+		return ret, nil
+	}
+	manyRet, err := conn.InvokeOneMany(ctx, "/Packages.Packages/Files", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// A goroutine to retrive untyped responses and convert them to typed ones.
+	go func() {
+		for {
+			typedResp := &FilesManyResponse{
+				Resp: &FilesReply{},
+			}
+
+			resp, ok := <-manyRet
+			if !ok {
+				// All done so we can shut down.
+				close(ret)
+				return
+			}
+			typedResp.Target = resp.Target
+			typedResp.Index = resp.Index
+			typedResp.Error = resp.Error
+			if resp.Error == nil {
+				if err := resp.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+					typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, resp.Error)
+				}
+			}
+			ret <- typedResp
+		}
+	}()
+
+	return ret, nil
+}
+
+// VerifyManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type VerifyManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *VerifyReply
+	Error error
+}
+
+// VerifyOneMany provides the same API as Verify but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *packagesClientProxy) VerifyOneMany(ctx context.Context, in *VerifyRequest, opts ...grpc.CallOption) (<-chan *VerifyManyResponse, error) {
+	conn := c.cc.(*proxy.Conn)
+	ret := make(chan *VerifyManyResponse)
+	// If this is a single case we can just use Invoke and marshal it onto the channel once and be done.
+	if len(conn.Targets) == 1 {
+		go func() {
+			out := &VerifyManyResponse{
+				Target: conn.Targets[0],
+				Index:  0,
+				Resp:   &VerifyReply{},
+			}
+			err := conn.Invoke(ctx, "/Packages.Packages/Verify", in, out.Resp, opts...)
+			if err != nil {
+				out.Error = err
+			}
+			// Send and close.
+			ret <- out
+			close(ret)
+		}()
+		return ret, nil
+	}
+	manyRet, err := conn.InvokeOneMany(ctx, "/Packages.Packages/Verify", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// A goroutine to retrive untyped responses and convert them to typed ones.
+	go func() {
+		for {
+			typedResp := &VerifyManyResponse{
+				Resp: &VerifyReply{},
+			}
+
+			resp, ok := <-manyRet
+			if !ok {
+				// All done so we can shut down.
+				close(ret)
+				return
+			}
+			typedResp.Target = resp.Target
+			typedResp.Index = resp.Index
+			typedResp.Error = resp.Error
+			if resp.Error == nil {
+				if err := resp.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+					typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, resp.Error)
+				}
+			}
+			ret <- typedResp
+		}
+	}()
+
+	return ret, nil
+}
+
+// HistoryManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type HistoryManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *HistoryReply
+	Error error
+}
+
+// HistoryOneMany provides the same API as History but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *packagesClientProxy) HistoryOneMany(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (<-chan *HistoryManyResponse, error) {
+	conn := c.cc.(*proxy.Conn)
+	ret := make(chan *HistoryManyResponse)
+	// If this is a single case we can just use Invoke and marshal it onto the channel once and be done.
+	if len(conn.Targets) == 1 {
+		go func() {
+			out := &HistoryManyResponse{
+				Target: conn.Targets[0],
+				Index:  0,
+				Resp:   &HistoryReply{},
+			}
+			err := conn.Invoke(ctx, "/Packages.Packages/History", in, out.Resp, opts...)
+			if err != nil {
+				out.Error = err
+			}
+			// Send and close.
+			ret <- out
+			close(ret)
+		}()
+		return ret, nil
+	}
+	manyRet, err := conn.InvokeOneMany(ctx, "/Packages.Packages/History", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// A goroutine to retrive untyped responses and convert them to typed ones.
+	go func() {
+		for {
+			typedResp := &HistoryManyResponse{
+				Resp: &HistoryReply{},
+			}
+
+			resp, ok := <-manyRet
+			if !ok {
+				// All done so we can shut down.
+				close(ret)
+				return
+			}
+			typedResp.Target = resp.Target
+			typedResp.Index = resp.Index
+			typedResp.Error = resp.Error
+			if resp.Error == nil {
+				if err := resp.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+					typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, resp.Error)
+				}
+			}
+			ret <- typedResp
+		}
+	}()
+
+	return ret, nil
+}
+
+// UndoManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type UndoManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *UndoReply
+	Error error
+}
+
+// UndoOneMany provides the same API as Undo but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *packagesClientProxy) UndoOneMany(ctx context.Context, in *UndoRequest, opts ...grpc.CallOption) (<-chan *UndoManyResponse, error) {
+	conn := c.cc.(*proxy.Conn)
+	ret := make(chan *UndoManyResponse)
+	// If this is a single case we can just use Invoke and marshal it onto the channel once and be done.
+	if len(conn.Targets) == 1 {
+		go func() {
+			out := &UndoManyResponse{
+				Target: conn.Targets[0],
+				Index:  0,
+				Resp:   &UndoReply{},
+			}
+			err := conn.Invoke(ctx, "/Packages.Packages/Undo", in, out.Resp, opts...)
+			if err != nil {
+				out.Error = err
+			}
+			// Send and close.
+			ret <- out
+			close(ret)
+		}()
+		return ret, nil
+	}
+	manyRet, err := conn.InvokeOneMany(ctx, "/Packages.Packages/Undo", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// A goroutine to retrive untyped responses and convert them to typed ones.
+	go func() {
+		for {
+			typedResp := &UndoManyResponse{
+				Resp: &UndoReply{},
+			}
+
+			resp, ok := <-manyRet
+			if !ok {
+				// All done so we can shut down.
+				close(ret)
+				return
+			}
+			typedResp.Target = resp.Target
+			typedResp.Index = resp.Index
+			typedResp.Error = resp.Error
+			if resp.Error == nil {
+				if err := resp.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+					typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, resp.Error)
+				}
+			}
+			ret <- typedResp
+		}
+	}()
+
+	return ret, nil
+}
+
 // ListInstalledManyResponse encapsulates a proxy data packet.
 // It includes the target, index, response and possible error returned.
 type ListInstalledManyResponse struct {
@@ -302,3 +1029,271 @@ func (c *packagesClientProxy) RepoListOneMany(ctx context.Context, in *RepoListR
 
 	return ret, nil
 }
+
+// RepoAddManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type RepoAddManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *RepoAddReply
+	Error error
+}
+
+// RepoAddOneMany provides the same API as RepoAdd but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *packagesClientProxy) RepoAddOneMany(ctx context.Context, in *RepoAddRequest, opts ...grpc.CallOption) (<-chan *RepoAddManyResponse, error) {
+	conn := c.cc.(*proxy.Conn)
+	ret := make(chan *RepoAddManyResponse)
+	// If this is a single case we can just use Invoke and marshal it onto the channel once and be done.
+	if len(conn.Targets) == 1 {
+		go func() {
+			out := &RepoAddManyResponse{
+				Target: conn.Targets[0],
+				Index:  0,
+				Resp:   &RepoAddReply{},
+			}
+			err := conn.Invoke(ctx, "/Packages.Packages/RepoAdd", in, out.Resp, opts...)
+			if err != nil {
+				out.Error = err
+			}
+			// Send and close.
+			ret <- out
+			close(ret)
+		}()
+		return ret, nil
+	}
+	manyRet, err := conn.InvokeOneMany(ctx, "/Packages.Packages/RepoAdd", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// A goroutine to retrive untyped responses and convert them to typed ones.
+	go func() {
+		for {
+			typedResp := &RepoAddManyResponse{
+				Resp: &RepoAddReply{},
+			}
+
+			resp, ok := <-manyRet
+			if !ok {
+				// All done so we can shut down.
+				close(ret)
+				return
+			}
+			typedResp.Target = resp.Target
+			typedResp.Index = resp.Index
+			typedResp.Error = resp.Error
+			if resp.Error == nil {
+				if err := resp.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+					typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, resp.Error)
+				}
+			}
+			ret <- typedResp
+		}
+	}()
+
+	return ret, nil
+}
+
+// RepoRemoveManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type RepoRemoveManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *RepoRemoveReply
+	Error error
+}
+
+// RepoRemoveOneMany provides the same API as RepoRemove but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *packagesClientProxy) RepoRemoveOneMany(ctx context.Context, in *RepoRemoveRequest, opts ...grpc.CallOption) (<-chan *RepoRemoveManyResponse, error) {
+	conn := c.cc.(*proxy.Conn)
+	ret := make(chan *RepoRemoveManyResponse)
+	// If this is a single case we can just use Invoke and marshal it onto the channel once and be done.
+	if len(conn.Targets) == 1 {
+		go func() {
+			out := &RepoRemoveManyResponse{
+				Target: conn.Targets[0],
+				Index:  0,
+				Resp:   &RepoRemoveReply{},
+			}
+			err := conn.Invoke(ctx, "/Packages.Packages/RepoRemove", in, out.Resp, opts...)
+			if err != nil {
+				out.Error = err
+			}
+			// Send and close.
+			ret <- out
+			close(ret)
+		}()
+		return ret, nil
+	}
+	manyRet, err := conn.InvokeOneMany(ctx, "/Packages.Packages/RepoRemove", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// A goroutine to retrive untyped responses and convert them to typed ones.
+	go func() {
+		for {
+			typedResp := &RepoRemoveManyResponse{
+				Resp: &RepoRemoveReply{},
+			}
+
+			resp, ok := <-manyRet
+			if !ok {
+				// All done so we can shut down.
+				close(ret)
+				return
+			}
+			typedResp.Target = resp.Target
+			typedResp.Index = resp.Index
+			typedResp.Error = resp.Error
+			if resp.Error == nil {
+				if err := resp.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+					typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, resp.Error)
+				}
+			}
+			ret <- typedResp
+		}
+	}()
+
+	return ret, nil
+}
+
+// RepoEnableManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type RepoEnableManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *RepoEnableReply
+	Error error
+}
+
+// RepoEnableOneMany provides the same API as RepoEnable but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *packagesClientProxy) RepoEnableOneMany(ctx context.Context, in *RepoEnableRequest, opts ...grpc.CallOption) (<-chan *RepoEnableManyResponse, error) {
+	conn := c.cc.(*proxy.Conn)
+	ret := make(chan *RepoEnableManyResponse)
+	// If this is a single case we can just use Invoke and marshal it onto the channel once and be done.
+	if len(conn.Targets) == 1 {
+		go func() {
+			out := &RepoEnableManyResponse{
+				Target: conn.Targets[0],
+				Index:  0,
+				Resp:   &RepoEnableReply{},
+			}
+			err := conn.Invoke(ctx, "/Packages.Packages/RepoEnable", in, out.Resp, opts...)
+			if err != nil {
+				out.Error = err
+			}
+			// Send and close.
+			ret <- out
+			close(ret)
+		}()
+		return ret, nil
+	}
+	manyRet, err := conn.InvokeOneMany(ctx, "/Packages.Packages/RepoEnable", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// A goroutine to retrive untyped responses and convert them to typed ones.
+	go func() {
+		for {
+			typedResp := &RepoEnableManyResponse{
+				Resp: &RepoEnableReply{},
+			}
+
+			resp, ok := <-manyRet
+			if !ok {
+				// All done so we can shut down.
+				close(ret)
+				return
+			}
+			typedResp.Target = resp.Target
+			typedResp.Index = resp.Index
+			typedResp.Error = resp.Error
+			if resp.Error == nil {
+				if err := resp.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+					typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, resp.Error)
+				}
+			}
+			ret <- typedResp
+		}
+	}()
+
+	return ret, nil
+}
+
+// RepoDisableManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type RepoDisableManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *RepoDisableReply
+	Error error
+}
+
+// RepoDisableOneMany provides the same API as RepoDisable but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *packagesClientProxy) RepoDisableOneMany(ctx context.Context, in *RepoDisableRequest, opts ...grpc.CallOption) (<-chan *RepoDisableManyResponse, error) {
+	conn := c.cc.(*proxy.Conn)
+	ret := make(chan *RepoDisableManyResponse)
+	// If this is a single case we can just use Invoke and marshal it onto the channel once and be done.
+	if len(conn.Targets) == 1 {
+		go func() {
+			out := &RepoDisableManyResponse{
+				Target: conn.Targets[0],
+				Index:  0,
+				Resp:   &RepoDisableReply{},
+			}
+			err := conn.Invoke(ctx, "/Packages.Packages/RepoDisable", in, out.Resp, opts...)
+			if err != nil {
+				out.Error = err
+			}
+			// Send and close.
+			ret <- out
+			close(ret)
+		}()
+		return ret, nil
+	}
+	manyRet, err := conn.InvokeOneMany(ctx, "/Packages.Packages/RepoDisable", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// A goroutine to retrive untyped responses and convert them to typed ones.
+	go func() {
+		for {
+			typedResp := &RepoDisableManyResponse{
+				Resp: &RepoDisableReply{},
+			}
+
+			resp, ok := <-manyRet
+			if !ok {
+				// All done so we can shut down.
+				close(ret)
+				return
+			}
+			typedResp.Target = resp.Target
+			typedResp.Index = resp.Index
+			typedResp.Error = resp.Error
+			if resp.Error == nil {
+				if err := resp.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+					typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, resp.Error)
+				}
+			}
+			ret <- typedResp
+		}
+	}()
+
+	return ret, nil
+}
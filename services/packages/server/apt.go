@@ -0,0 +1,574 @@
+/* Copyright (c) 2019 Snowflake Inc. All rights reserved.
+
+   Licensed under the Apache License, Version 2.0 (the
+   "License"); you may not use this file except in compliance
+   with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing,
+   software distributed under the License is distributed on an
+   "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+   KIND, either express or implied.  See the License for the
+   specific language governing permissions and limitations
+   under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	pb "github.com/Snowflake-Labs/sansshell/services/packages"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	// These are vars for testing to be able to replace them.
+	aptSourcesList = "/etc/apt/sources.list"
+	aptSourcesDir  = "/etc/apt/sources.list.d"
+	dpkgInfoDir    = "/var/lib/dpkg/info"
+)
+
+// aptInstalledFormat is the dpkg-query --showformat used for listing packages.
+// dpkg-query expands the escapes itself.
+const aptInstalledFormat = `${binary:Package}\t${Version}\t${db:Status-Status}\n`
+
+// aptStatusFormat is the dpkg-query --showformat used to validate the
+// installed version of a single package.
+const aptStatusFormat = `${db:Status-Status}\t${Version}\n`
+
+// aptInfoFormat is the dpkg-query --showformat used for package info.
+// It produces control file style stanzas separated by blank lines.
+const aptInfoFormat = `Package: ${binary:Package}\n` +
+	`Version: ${Version}\n` +
+	`Architecture: ${Architecture}\n` +
+	`Installed-Size: ${Installed-Size}\n` +
+	`Status: ${db:Status-Status}\n` +
+	`Pre-Depends: ${Pre-Depends}\n` +
+	`Depends: ${Depends}\n` +
+	`Description: ${Description}\n\n`
+
+// dpkgInstalled is the db:Status-Status value for a fully installed package.
+// Anything else (config-files, half-installed, etc) is either removed or broken.
+const dpkgInstalled = "installed"
+
+func parseAptListInstallOutput(r io.Reader) (*pb.ListInstalledReply, error) {
+	scanner := bufio.NewScanner(r)
+
+	reply := &pb.ListInstalledReply{}
+	for scanner.Scan() {
+		// All lines look like (see aptInstalledFormat):
+		//
+		// PACKAGE_NAME\tPACKAGE_VERSION\tSTATUS
+		text := scanner.Text()
+		if text == "" {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+		if len(fields) != 3 || fields[0] == "" {
+			return nil, status.Errorf(codes.Internal, "invalid input line. Expecting 3 tab separated fields and got %q", text)
+		}
+		// dpkg remembers removed packages which still have config files around.
+		if fields[2] != dpkgInstalled {
+			continue
+		}
+		reply.Packages = append(reply.Packages, &pb.PackageInfo{
+			Name:    fields[0],
+			Version: fields[1],
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "parsing error:\n%v", err)
+	}
+
+	return reply, nil
+}
+
+// parseAptInfoOutput parses the output of dpkg-query with aptInfoFormat.
+// Packages which aren't fully installed are skipped.
+func parseAptInfoOutput(r io.Reader) ([]*pb.PackageDetails, error) {
+	scanner := bufio.NewScanner(r)
+
+	var out []*pb.PackageDetails
+	fields := make(map[string][]string)
+	last := ""
+	// The first line of a field (i.e. everything for single line ones).
+	get := func(k string) string {
+		if len(fields[k]) == 0 {
+			return ""
+		}
+		return fields[k][0]
+	}
+	finish := func() error {
+		defer func() {
+			fields = make(map[string][]string)
+			last = ""
+		}()
+		if len(fields) == 0 || get("Status") != dpkgInstalled {
+			return nil
+		}
+		pkg := &pb.PackageDetails{
+			Name:         get("Package"),
+			Version:      get("Version"),
+			Architecture: get("Architecture"),
+			Summary:      get("Description"),
+		}
+		if pkg.Name == "" {
+			return status.Errorf(codes.Internal, "package entry without a name")
+		}
+		if size := get("Installed-Size"); size != "" {
+			kb, err := strconv.ParseUint(size, 10, 64)
+			if err != nil {
+				return status.Errorf(codes.Internal, "invalid installed size %q: %v", size, err)
+			}
+			pkg.Size = kb * 1024
+		}
+		for _, dep := range []string{"Pre-Depends", "Depends"} {
+			for _, d := range strings.Split(get(dep), ",") {
+				if d = strings.TrimSpace(d); d != "" {
+					pkg.Dependencies = append(pkg.Dependencies, d)
+				}
+			}
+		}
+		// The first line is the synopsis and the rest are indented by a space with
+		// a lone . representing a blank line.
+		var long []string
+		for i, l := range fields["Description"] {
+			if i == 0 {
+				continue
+			}
+			if l == "." {
+				l = ""
+			}
+			long = append(long, l)
+		}
+		pkg.Description = strings.Join(long, "\n")
+		out = append(out, pkg)
+		return nil
+	}
+	for scanner.Scan() {
+		text := scanner.Text()
+		switch {
+		case text == "":
+			if err := finish(); err != nil {
+				return nil, err
+			}
+		case text[0] == ' ':
+			if last == "" {
+				return nil, status.Errorf(codes.Internal, "continuation line %q without a field", text)
+			}
+			fields[last] = append(fields[last], text[1:])
+		default:
+			k, v, ok := strings.Cut(text, ":")
+			if !ok {
+				return nil, status.Errorf(codes.Internal, "invalid input line %q", text)
+			}
+			last = k
+			fields[k] = []string{strings.TrimSpace(v)}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "parsing error:\n%v", err)
+	}
+	if len(fields) != 0 {
+		return nil, status.Errorf(codes.Internal, "truncated package entry for %q", get("Package"))
+	}
+	return out, nil
+}
+
+// aptInstallTime approximates when a package was installed. dpkg doesn't record
+// this but rewrites the package's file list on every install or upgrade.
+func aptInstallTime(name string) *timestamppb.Timestamp {
+	fi, err := os.Stat(filepath.Join(dpkgInfoDir, name+".list"))
+	if err != nil {
+		return nil
+	}
+	return timestamppb.New(fi.ModTime())
+}
+
+// parseAptPolicyRepo returns the suite the installed version of a package came from
+// given the output of apt-cache policy:
+//
+//	nginx:
+//	  Installed: 1.22.1-9
+//	  Candidate: 1.22.1-9+deb12u1
+//	  Version table:
+//	     1.22.1-9+deb12u1 500
+//	        500 http://deb.debian.org/debian-security bookworm-security/main amd64 Packages
+//	 *** 1.22.1-9 500
+//	        500 http://deb.debian.org/debian bookworm/main amd64 Packages
+//	        100 /var/lib/dpkg/status
+//
+// An empty string is returned if no configured source has the installed version.
+func parseAptPolicyRepo(r io.Reader) (string, error) {
+	scanner := bufio.NewScanner(r)
+
+	installed := false
+	repo := ""
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "***" {
+			installed = true
+			continue
+		}
+		if !installed || repo != "" {
+			continue
+		}
+		// Sources are a priority followed by the uri and suite while versions
+		// are followed by a priority. Once we hit the next version we're done.
+		if len(fields) < 2 {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[1]); err == nil {
+			installed = false
+			continue
+		}
+		if len(fields) >= 3 {
+			repo, _, _ = strings.Cut(fields[2], "/")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", status.Errorf(codes.Internal, "parsing error:\n%v", err)
+	}
+	return repo, nil
+}
+
+// parseAptSearchOutput parses the output of apt-cache madison which has lines like:
+//
+//	nginx | 1.22.1-9 | http://deb.debian.org/debian bookworm/main amd64 Packages
+//
+// The repo is reported as the suite so it can be passed back as the repo for install.
+// Source package lines are skipped.
+func parseAptSearchOutput(r io.Reader) (*pb.SearchReply, error) {
+	scanner := bufio.NewScanner(r)
+
+	reply := &pb.SearchReply{}
+	for scanner.Scan() {
+		text := scanner.Text()
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		fields := strings.Split(text, "|")
+		if len(fields) != 3 {
+			return nil, status.Errorf(codes.Internal, "invalid input line. Expecting 3 | separated fields and got %q", text)
+		}
+		source := strings.Fields(fields[2])
+		if len(source) < 3 {
+			return nil, status.Errorf(codes.Internal, "invalid input line. Expecting uri, suite and type and got %q", text)
+		}
+		if source[len(source)-1] != "Packages" {
+			continue
+		}
+		suite, _, _ := strings.Cut(source[1], "/")
+		reply.Packages = append(reply.Packages, &pb.PackageInfo{
+			Name:    strings.TrimSpace(fields[0]),
+			Version: strings.TrimSpace(fields[1]),
+			Repo:    suite,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "parsing error:\n%v", err)
+	}
+
+	return reply, nil
+}
+
+// aptSimulateRe matches a change in the output of apt-get --simulate such as:
+//
+//	Inst bash [5.2.15-2] (5.2.15-2+b2 Debian:12.1/stable [amd64])
+//	Remv telnet [0.17+2.4-2]
+//
+// The bracketed version after the name is the one currently installed.
+var aptSimulateRe = regexp.MustCompile(`^(Inst|Remv|Purg) (\S+)(?: \[([^\]]*)\])?(?: \((\S+) (.*) \[([^\]]+)\]\))?`)
+
+// aptSimulateActions maps the apt-get --simulate verbs to PackageChange actions.
+var aptSimulateActions = map[string]string{
+	"Inst": "Install",
+	"Remv": "Remove",
+	"Purg": "Purge",
+}
+
+// parseAptSimulateOutput parses the changes apt-get would make from its --simulate output.
+// Configure (Conf) steps and informational lines are skipped. As with search the repo is
+// the suite of the first source providing the version.
+func parseAptSimulateOutput(r io.Reader) ([]*pb.PackageChange, error) {
+	scanner := bufio.NewScanner(r)
+
+	var out []*pb.PackageChange
+	for scanner.Scan() {
+		text := scanner.Text()
+		if !strings.HasPrefix(text, "Inst ") && !strings.HasPrefix(text, "Remv ") && !strings.HasPrefix(text, "Purg ") {
+			continue
+		}
+		m := aptSimulateRe.FindStringSubmatch(text)
+		if m == nil || (m[1] == "Inst" && m[4] == "") {
+			return nil, status.Errorf(codes.Internal, "invalid input line %q", text)
+		}
+		c := &pb.PackageChange{
+			Name:         m[2],
+			Version:      m[4],
+			Architecture: m[6],
+			Action:       aptSimulateActions[m[1]],
+		}
+		if c.Action == "Install" && m[3] != "" {
+			c.Action = "Upgrade"
+		}
+		if c.Version == "" {
+			// Removals only show the installed version.
+			c.Version = m[3]
+		}
+		if m[5] != "" {
+			source, _, _ := strings.Cut(m[5], ",")
+			if _, suite, ok := strings.Cut(source, "/"); ok {
+				c.Repo = suite
+			}
+		}
+		out = append(out, c)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "parsing error:\n%v", err)
+	}
+	return out, nil
+}
+
+// checkAptInstalledVersion validates the output of dpkg-query run with
+// aptStatusFormat shows the package installed at the given version.
+// dpkg-query returns success for any package it knows about which
+// includes removed ones so the exit code alone isn't enough.
+func checkAptInstalledVersion(output string, name string, version string) error {
+	state, got, ok := strings.Cut(strings.TrimSpace(output), "\t")
+	if !ok {
+		return status.Errorf(codes.Internal, "unexpected output from dpkg-query for package %s: %q", name, output)
+	}
+	if state != dpkgInstalled || got != version {
+		return status.Errorf(codes.FailedPrecondition, "package %s at version %s doesn't appear to be installed. dpkg reports state %q version %q", name, version, state, got)
+	}
+	return nil
+}
+
+// newAptRepos expands one sources entry into a pb.Repo per type/uri/suite.
+func newAptRepos(filename string, enabled bool, types []string, uris []string, suites []string, components []string) []*pb.Repo {
+	st := pb.RepoStatus_REPO_STATUS_ENABLED
+	if !enabled {
+		st = pb.RepoStatus_REPO_STATUS_DISABLED
+	}
+	var out []*pb.Repo
+	for _, t := range types {
+		for _, u := range uris {
+			for _, s := range suites {
+				out = append(out, &pb.Repo{
+					Id:       s,
+					Name:     strings.Join(append([]string{t, u, s}, components...), " "),
+					Status:   st,
+					Filename: filename,
+					Url:      u,
+				})
+			}
+		}
+	}
+	return out
+}
+
+// parseAptSourcesLine parses a single one line style entry of the form:
+//
+// deb [ option1=value1 option2=value2 ] uri suite [component1] [component2] [...]
+//
+// It returns nil if the line isn't an entry.
+func parseAptSourcesLine(filename string, enabled bool, line string) ([]*pb.Repo, error) {
+	t, rest, _ := strings.Cut(strings.TrimSpace(line), " ")
+	if t != "deb" && t != "deb-src" {
+		return nil, nil
+	}
+	rest = strings.TrimSpace(rest)
+	if strings.HasPrefix(rest, "[") {
+		end := strings.Index(rest, "]")
+		if end == -1 {
+			return nil, status.Errorf(codes.Internal, "%s: unterminated options in %q", filename, line)
+		}
+		rest = rest[end+1:]
+	}
+	fields := strings.Fields(rest)
+	// cdrom uris contain the (space separated) disc label in brackets.
+	for len(fields) > 1 && strings.Contains(fields[0], "[") && !strings.Contains(fields[0], "]") {
+		fields = append([]string{fields[0] + " " + fields[1]}, fields[2:]...)
+	}
+	if len(fields) < 2 {
+		return nil, status.Errorf(codes.Internal, "%s: expecting a uri and suite in %q", filename, line)
+	}
+	return newAptRepos(filename, enabled, []string{t}, fields[:1], fields[1:2], fields[2:]), nil
+}
+
+// parseAptSourcesList parses a one line style sources.list file.
+// Commented out entries are reported as disabled repos.
+func parseAptSourcesList(filename string, r io.Reader) ([]*pb.Repo, error) {
+	scanner := bufio.NewScanner(r)
+
+	var repos []*pb.Repo
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(text, "#") {
+			// Plenty of comments aren't entries so ignore anything which doesn't parse.
+			out, err := parseAptSourcesLine(filename, false, strings.TrimLeft(text, "# "))
+			if err == nil {
+				repos = append(repos, out...)
+			}
+			continue
+		}
+		// Trailing comments are allowed on entries.
+		text, _, _ = strings.Cut(text, "#")
+		out, err := parseAptSourcesLine(filename, true, text)
+		if err != nil {
+			return nil, err
+		}
+		if out == nil && strings.TrimSpace(text) != "" {
+			return nil, status.Errorf(codes.Internal, "%s: invalid entry %q", filename, text)
+		}
+		repos = append(repos, out...)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "parsing error:\n%v", err)
+	}
+	return repos, nil
+}
+
+// deb822Stanza converts the fields of one deb822 style stanza into repos.
+func deb822Stanza(filename string, fields map[string]string) ([]*pb.Repo, error) {
+	types, uris, suites := strings.Fields(fields["types"]), strings.Fields(fields["uris"]), strings.Fields(fields["suites"])
+	if len(types) == 0 || len(uris) == 0 || len(suites) == 0 {
+		return nil, status.Errorf(codes.Internal, "%s: entry must contain Types, URIs and Suites: %v", filename, fields)
+	}
+	enabled := true
+	switch strings.ToLower(fields["enabled"]) {
+	case "no", "false", "without", "0":
+		enabled = false
+	}
+	return newAptRepos(filename, enabled, types, uris, suites, strings.Fields(fields["components"])), nil
+}
+
+// parseAptDeb822Sources parses a deb822 style .sources file which contains
+// stanzas separated by blank lines such as:
+//
+// Types: deb deb-src
+// URIs: http://deb.debian.org/debian
+// Suites: bookworm bookworm-updates
+// Components: main
+// Enabled: yes
+func parseAptDeb822Sources(filename string, r io.Reader) ([]*pb.Repo, error) {
+	scanner := bufio.NewScanner(r)
+
+	var repos []*pb.Repo
+	fields := make(map[string]string)
+	last := ""
+	finish := func() error {
+		if len(fields) == 0 {
+			return nil
+		}
+		out, err := deb822Stanza(filename, fields)
+		if err != nil {
+			return err
+		}
+		repos = append(repos, out...)
+		fields = make(map[string]string)
+		last = ""
+		return nil
+	}
+	for scanner.Scan() {
+		text := scanner.Text()
+		switch {
+		case strings.HasPrefix(text, "#"):
+			continue
+		case strings.TrimSpace(text) == "":
+			if err := finish(); err != nil {
+				return nil, err
+			}
+		case text[0] == ' ' || text[0] == '\t':
+			// A continuation of the previous field (i.e. an inline Signed-By key).
+			if last == "" {
+				return nil, status.Errorf(codes.Internal, "%s: continuation line %q without a field", filename, text)
+			}
+			fields[last] += " " + strings.TrimSpace(text)
+		default:
+			k, v, ok := strings.Cut(text, ":")
+			if !ok {
+				return nil, status.Errorf(codes.Internal, "%s: invalid line %q", filename, text)
+			}
+			// Field names are case insensitive.
+			last = strings.ToLower(strings.TrimSpace(k))
+			fields[last] = strings.TrimSpace(v)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "parsing error:\n%v", err)
+	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
+	return repos, nil
+}
+
+// readAptSourcesFile parses a single sources file with the parser matching its name.
+func readAptSourcesFile(filename string) ([]*pb.Repo, error) {
+	parser := parseAptSourcesList
+	if strings.HasSuffix(filename, ".sources") {
+		parser = parseAptDeb822Sources
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parser(filename, f)
+}
+
+// readAptSources returns the repos defined in the main sources list and every
+// .list or .sources file in the sources directory. As with apt either may
+// be missing (newer releases only use the directory).
+func readAptSources(list string, dir string) (*pb.RepoListReply, error) {
+	files := []string{list}
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, status.Errorf(codes.Internal, "can't read %s: %v", dir, err)
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		// apt ignores anything else (.save, .disabled, etc).
+		if ext := filepath.Ext(e.Name()); ext == ".list" || ext == ".sources" {
+			files = append(files, filepath.Join(dir, e.Name()))
+		}
+	}
+
+	reply := &pb.RepoListReply{}
+	for _, f := range files {
+		repos, err := readAptSourcesFile(f)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			if _, ok := status.FromError(err); !ok {
+				err = status.Errorf(codes.Internal, "can't read %s: %v", f, err)
+			}
+			return nil, err
+		}
+		reply.Repos = append(reply.Repos, repos...)
+	}
+	return reply, nil
+}
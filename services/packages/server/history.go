@@ -0,0 +1,188 @@
+/* Copyright (c) 2019 Snowflake Inc. All rights reserved.
+
+   Licensed under the Apache License, Version 2.0 (the
+   "License"); you may not use this file except in compliance
+   with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing,
+   software distributed under the License is distributed on an
+   "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+   KIND, either express or implied.  See the License for the
+   specific language governing permissions and limitations
+   under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	pb "github.com/Snowflake-Labs/sansshell/services/packages"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// yumHistoryTimeFormat is the format of the date column in yum history list.
+// It's in the host's local time.
+const yumHistoryTimeFormat = "2006-01-02 15:04"
+
+// yumHistoryActions expands the abbreviations yum uses in history list
+// when a transaction did more than one type of action.
+var yumHistoryActions = map[string]string{
+	"D": "Downgrade",
+	"E": "Erase",
+	"I": "Install",
+	"O": "Obsoleting",
+	"R": "Reinstall",
+	"U": "Update",
+}
+
+// parseYumHistoryList parses the output of yum history list. The table looks like:
+//
+// ID     | Login user               | Date and time    | Action(s)      | Altered
+// -------------------------------------------------------------------------------
+//
+//	12 | root <root>              | 2022-04-01 10:15 | Update         |    2
+//
+// Columns are found by name as newer versions (dnf) replace the user with the command line.
+func parseYumHistoryList(r io.Reader) ([]*pb.Transaction, error) {
+	scanner := bufio.NewScanner(r)
+
+	var out []*pb.Transaction
+	var columns map[string]int
+	for scanner.Scan() {
+		text := scanner.Text()
+		if !strings.Contains(text, "|") {
+			// Plugin noise, the separator line and the trailing "history list".
+			continue
+		}
+		fields := strings.Split(text, "|")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		if columns == nil {
+			if fields[0] != "ID" {
+				continue
+			}
+			columns = make(map[string]int)
+			for i, f := range fields {
+				columns[f] = i
+			}
+			for _, c := range []string{"Date and time", "Action(s)"} {
+				if _, ok := columns[c]; !ok {
+					return nil, status.Errorf(codes.Internal, "history header %q missing column %q", text, c)
+				}
+			}
+			continue
+		}
+		if len(fields) != len(columns) {
+			return nil, status.Errorf(codes.Internal, "invalid input line. Expecting %d fields and got %q", len(columns), text)
+		}
+		id, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "invalid transaction id in %q: %v", text, err)
+		}
+		when, err := time.ParseInLocation(yumHistoryTimeFormat, fields[columns["Date and time"]], time.Local)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "invalid time in %q: %v", text, err)
+		}
+		t := &pb.Transaction{
+			Id:   id,
+			Time: timestamppb.New(when),
+		}
+		if i, ok := columns["Login user"]; ok {
+			t.User = fields[i]
+		}
+		if i, ok := columns["Command line"]; ok {
+			t.CommandLine = fields[i]
+		}
+		for _, a := range strings.Split(fields[columns["Action(s)"]], ",") {
+			a = strings.TrimSpace(a)
+			if full, ok := yumHistoryActions[a]; ok {
+				a = full
+			}
+			if a != "" {
+				t.Actions = append(t.Actions, a)
+			}
+		}
+		out = append(out, t)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "parsing error:\n%v", err)
+	}
+	return out, nil
+}
+
+// parseYumHistoryInfo parses the output of yum history info for a single transaction
+// and fills in the user, command line and packages of t.
+func parseYumHistoryInfo(r io.Reader, t *pb.Transaction) error {
+	scanner := bufio.NewScanner(r)
+
+	altered := false
+	for scanner.Scan() {
+		text := scanner.Text()
+		if text == "" {
+			continue
+		}
+		indented := text[0] == ' ' || text[0] == '\t'
+		if altered && indented {
+			fields := strings.Fields(text)
+			// Problem packages are flagged with markers (i.e. **) before the action.
+			for len(fields) > 0 && !unicode.IsLetter(rune(fields[0][0])) {
+				fields = fields[1:]
+			}
+			if len(fields) < 2 {
+				return status.Errorf(codes.Internal, "invalid package line %q", text)
+			}
+			p := &pb.TransactionPackage{
+				Action: fields[0],
+				Name:   fields[1],
+			}
+			// yum only prints the new version for the second half of an update
+			// so take the name from the package before it.
+			if unicode.IsDigit(rune(p.Name[0])) && len(t.Packages) > 0 {
+				prev := strings.Split(t.Packages[len(t.Packages)-1].Name, "-")
+				if len(prev) > 2 {
+					p.Name = strings.Join(prev[:len(prev)-2], "-") + "-" + p.Name
+				}
+			}
+			if len(fields) > 2 {
+				p.Repo = fields[2]
+			}
+			t.Packages = append(t.Packages, p)
+			continue
+		}
+		altered = false
+		if strings.HasPrefix(text, "Packages Altered:") {
+			altered = true
+			continue
+		}
+		k, v, ok := strings.Cut(text, ":")
+		if !ok || indented {
+			continue
+		}
+		switch strings.TrimSpace(k) {
+		case "Transaction ID":
+			// Ranges (i.e. 3..5) are printed when transactions are merged. Only compare singles.
+			if id, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64); err == nil && id != t.Id {
+				return status.Errorf(codes.Internal, "history info for transaction %d returned transaction %d", t.Id, id)
+			}
+		case "User":
+			t.User = strings.TrimSpace(v)
+		case "Command Line":
+			t.CommandLine = strings.TrimSpace(v)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return status.Errorf(codes.Internal, "parsing error:\n%v", err)
+	}
+	return nil
+}
@@ -19,13 +19,18 @@ package server
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"os"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/Snowflake-Labs/sansshell/services"
+	"github.com/Snowflake-Labs/sansshell/services/healthcheck"
 	pb "github.com/Snowflake-Labs/sansshell/services/packages"
 	"github.com/Snowflake-Labs/sansshell/services/util"
 	"google.golang.org/grpc"
@@ -40,14 +45,33 @@ func genCmd(p pb.PackageSystem, m map[pb.PackageSystem][]string) ([]string, erro
 	case pb.PackageSystem_PACKAGE_SYSTEM_YUM:
 		out = append(out, *yumBin)
 		out = append(out, m[p]...)
+	case pb.PackageSystem_PACKAGE_SYSTEM_APT:
+		// APT is split across apt-get and dpkg-query so the map provides the binary.
+		return genQueryCmd(p, m)
 	default:
 		return nil, status.Errorf(codes.Unimplemented, "no support for package system enum %d", p)
 	}
 	return out, nil
 }
 
+// Like genCmd but the map supplies the binary for every package system.
+// Used for queries which go to rpm/dpkg directly rather than the package manager.
+func genQueryCmd(p pb.PackageSystem, m map[pb.PackageSystem][]string) ([]string, error) {
+	out, ok := m[p]
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "no support for package system enum %d", p)
+	}
+	return out, nil
+}
+
 // Optionally add the repo arg and then append the full package name to the list.
 func addRepoAndPackage(out []string, p pb.PackageSystem, name string, version string, repo string) []string {
+	if p == pb.PackageSystem_PACKAGE_SYSTEM_APT {
+		if repo != "" {
+			out = append(out, fmt.Sprintf("--target-release=%s", repo))
+		}
+		return append(out, fmt.Sprintf("%s=%s", name, version))
+	}
 	if repo != "" && p == pb.PackageSystem_PACKAGE_SYSTEM_YUM {
 		out = append(out, fmt.Sprintf("--enablerepo=%s", repo))
 	}
@@ -56,15 +80,70 @@ func addRepoAndPackage(out []string, p pb.PackageSystem, name string, version st
 	return out
 }
 
+// pickPackageSystem returns the package system to use for a request.
+// Unset means whatever the host uses.
+func pickPackageSystem(p pb.PackageSystem) pb.PackageSystem {
+	if p == pb.PackageSystem_PACKAGE_SYSTEM_UNKNOWN {
+		return detectPackageSystem()
+	}
+	return p
+}
+
+// runOptions returns any options needed when running commands for the given package system.
+func runOptions(p pb.PackageSystem) []util.Option {
+	if p != pb.PackageSystem_PACKAGE_SYSTEM_APT {
+		return nil
+	}
+	// Commands run with an empty environment but dpkg refuses to run without a PATH
+	// and maintainer scripts must never prompt.
+	return []util.Option{
+		util.EnvVar("PATH=/usr/sbin:/usr/bin:/sbin:/bin"),
+		util.EnvVar("DEBIAN_FRONTEND=noninteractive"),
+	}
+}
+
+// assumeFlag returns the flag which answers the package manager's confirmation prompt.
+// For a dry run the transaction is resolved and then declined (YUM) or only simulated (APT).
+func assumeFlag(p pb.PackageSystem, dryRun bool) string {
+	switch {
+	case !dryRun:
+		return "-y"
+	case p == pb.PackageSystem_PACKAGE_SYSTEM_APT:
+		return "--simulate"
+	default:
+		return "--assumeno"
+	}
+}
+
 var (
-	inputValidateRe = regexp.MustCompile("[^a-zA-Z0-9_.:-]+")
+	// Debian package names and versions can also contain + and ~ (i.e. libstdc++6 1.0~rc1).
+	inputValidateRe = regexp.MustCompile("[^a-zA-Z0-9_.:+~-]+")
 
 	// These are vars for testing to be able to replace them.
+
+	// detectPackageSystem determines the package system of the host by looking for
+	// its binaries. YUM is checked first and is the fallback to match prior behavior
+	// where unset always meant YUM.
+	detectPackageSystem = func() pb.PackageSystem {
+		if _, err := os.Stat(*yumBin); err == nil {
+			return pb.PackageSystem_PACKAGE_SYSTEM_YUM
+		}
+		if _, err := os.Stat(*aptGetBin); err == nil {
+			return pb.PackageSystem_PACKAGE_SYSTEM_APT
+		}
+		return pb.PackageSystem_PACKAGE_SYSTEM_YUM
+	}
+
 	generateInstall = func(p *pb.InstallRequest) ([]string, error) {
 		installOpts := map[pb.PackageSystem][]string{
 			pb.PackageSystem_PACKAGE_SYSTEM_YUM: {
 				"install-nevra",
-				"-y",
+				assumeFlag(p.PackageSystem, p.DryRun),
+			},
+			pb.PackageSystem_PACKAGE_SYSTEM_APT: {
+				*aptGetBin,
+				"install",
+				assumeFlag(p.PackageSystem, p.DryRun),
 			},
 		}
 		out, err := genCmd(p.PackageSystem, installOpts)
@@ -80,11 +159,20 @@ var (
 				"list",
 				"installed",
 			},
+			pb.PackageSystem_PACKAGE_SYSTEM_APT: {
+				*dpkgQueryBin,
+				"--show",
+				fmt.Sprintf("--showformat=%s", aptStatusFormat),
+			},
 		}
 		out, err := genCmd(p.PackageSystem, validateOpts)
 		if err != nil {
 			return nil, err
 		}
+		// dpkg-query only takes the name. The version is checked against its output.
+		if p.PackageSystem == pb.PackageSystem_PACKAGE_SYSTEM_APT {
+			return append(out, p.Name), nil
+		}
 		return addRepoAndPackage(out, p.PackageSystem, p.Name, p.OldVersion, ""), nil
 	}
 
@@ -92,7 +180,13 @@ var (
 		updateOpts := map[pb.PackageSystem][]string{
 			pb.PackageSystem_PACKAGE_SYSTEM_YUM: {
 				"update-to",
-				"-y",
+				assumeFlag(p.PackageSystem, p.DryRun),
+			},
+			pb.PackageSystem_PACKAGE_SYSTEM_APT: {
+				*aptGetBin,
+				"install",
+				assumeFlag(p.PackageSystem, p.DryRun),
+				"--only-upgrade",
 			},
 		}
 		out, err := genCmd(p.PackageSystem, updateOpts)
@@ -102,12 +196,192 @@ var (
 		return addRepoAndPackage(out, p.PackageSystem, p.Name, p.NewVersion, p.Repo), nil
 	}
 
+	generateRemove = func(p *pb.RemoveRequest) ([]string, error) {
+		removeOpts := map[pb.PackageSystem][]string{
+			pb.PackageSystem_PACKAGE_SYSTEM_YUM: {
+				"remove",
+				"-y",
+			},
+			pb.PackageSystem_PACKAGE_SYSTEM_APT: {
+				*aptGetBin,
+				"remove",
+				"-y",
+			},
+		}
+		out, err := genCmd(p.PackageSystem, removeOpts)
+		if err != nil {
+			return nil, err
+		}
+		return addRepoAndPackage(out, p.PackageSystem, p.Name, p.Version, ""), nil
+	}
+
+	generateDowngrade = func(p *pb.DowngradeRequest) ([]string, error) {
+		downgradeOpts := map[pb.PackageSystem][]string{
+			pb.PackageSystem_PACKAGE_SYSTEM_YUM: {
+				"downgrade",
+				"-y",
+			},
+			pb.PackageSystem_PACKAGE_SYSTEM_APT: {
+				*aptGetBin,
+				"install",
+				"-y",
+				"--allow-downgrades",
+			},
+		}
+		out, err := genCmd(p.PackageSystem, downgradeOpts)
+		if err != nil {
+			return nil, err
+		}
+		return addRepoAndPackage(out, p.PackageSystem, p.Name, p.NewVersion, p.Repo), nil
+	}
+
+	generateSearch = func(p *pb.SearchRequest) ([]string, error) {
+		searchOpts := map[pb.PackageSystem][]string{
+			pb.PackageSystem_PACKAGE_SYSTEM_YUM: {
+				"list",
+				"available",
+				"--showduplicates",
+			},
+			pb.PackageSystem_PACKAGE_SYSTEM_APT: {
+				*aptCacheBin,
+				"madison",
+			},
+		}
+		out, err := genCmd(p.PackageSystem, searchOpts)
+		if err != nil {
+			return nil, err
+		}
+		if p.Repo != "" && p.PackageSystem == pb.PackageSystem_PACKAGE_SYSTEM_YUM {
+			out = append(out, fmt.Sprintf("--enablerepo=%s", p.Repo))
+		}
+		return append(out, p.Name), nil
+	}
+
+	generateInfo = func(p pb.PackageSystem, name string) ([]string, error) {
+		infoOpts := map[pb.PackageSystem][]string{
+			pb.PackageSystem_PACKAGE_SYSTEM_YUM: {
+				*rpmBin,
+				"--query",
+				fmt.Sprintf("--queryformat=%s", rpmInfoFormat),
+			},
+			pb.PackageSystem_PACKAGE_SYSTEM_APT: {
+				*dpkgQueryBin,
+				"--show",
+				fmt.Sprintf("--showformat=%s", aptInfoFormat),
+			},
+		}
+		out, err := genQueryCmd(p, infoOpts)
+		if err != nil {
+			return nil, err
+		}
+		return append(out, name), nil
+	}
+
+	// For YUM this is run once for the requested name. For APT it's run
+	// for each installed package returned by generateInfo.
+	generateInfoRepo = func(p pb.PackageSystem, name string) ([]string, error) {
+		repoOpts := map[pb.PackageSystem][]string{
+			pb.PackageSystem_PACKAGE_SYSTEM_YUM: {
+				*yumBin,
+				"list",
+				"installed",
+			},
+			pb.PackageSystem_PACKAGE_SYSTEM_APT: {
+				*aptCacheBin,
+				"policy",
+			},
+		}
+		out, err := genQueryCmd(p, repoOpts)
+		if err != nil {
+			return nil, err
+		}
+		return append(out, name), nil
+	}
+
+	generateFiles = func(p pb.PackageSystem, name string) ([]string, error) {
+		filesOpts := map[pb.PackageSystem][]string{
+			pb.PackageSystem_PACKAGE_SYSTEM_YUM: {
+				*rpmBin,
+				"--query",
+				"--list",
+			},
+			pb.PackageSystem_PACKAGE_SYSTEM_APT: {
+				*dpkgQueryBin,
+				"--listfiles",
+			},
+		}
+		out, err := genQueryCmd(p, filesOpts)
+		if err != nil {
+			return nil, err
+		}
+		return append(out, name), nil
+	}
+
+	generateVerify = func(p pb.PackageSystem, name string) ([]string, error) {
+		verifyOpts := map[pb.PackageSystem][]string{
+			pb.PackageSystem_PACKAGE_SYSTEM_YUM: {
+				*rpmBin,
+				"--verify",
+				"--nodeps",
+			},
+			pb.PackageSystem_PACKAGE_SYSTEM_APT: {
+				*dpkgBin,
+				"--verify",
+			},
+		}
+		out, err := genQueryCmd(p, verifyOpts)
+		if err != nil {
+			return nil, err
+		}
+		return append(out, name), nil
+	}
+
+	// dpkg keeps no transaction history so these are YUM only.
+	generateHistoryList = func(p pb.PackageSystem) ([]string, error) {
+		historyOpts := map[pb.PackageSystem][]string{
+			pb.PackageSystem_PACKAGE_SYSTEM_YUM: {
+				"history",
+				"list",
+				"all",
+			},
+		}
+		return genCmd(p, historyOpts)
+	}
+
+	generateHistoryInfo = func(p pb.PackageSystem, id int64) ([]string, error) {
+		historyOpts := map[pb.PackageSystem][]string{
+			pb.PackageSystem_PACKAGE_SYSTEM_YUM: {
+				"history",
+				"info",
+				strconv.FormatInt(id, 10),
+			},
+		}
+		return genCmd(p, historyOpts)
+	}
+
+	generateUndo = func(p pb.PackageSystem, id int64) ([]string, error) {
+		undoOpts := map[pb.PackageSystem][]string{
+			pb.PackageSystem_PACKAGE_SYSTEM_YUM: {
+				"history",
+				"undo",
+				"-y",
+				strconv.FormatInt(id, 10),
+			},
+		}
+		return genCmd(p, undoOpts)
+	}
+
 	generateListInstalled = func(p pb.PackageSystem) ([]string, error) {
 		listOpts := map[pb.PackageSystem][]string{
 			pb.PackageSystem_PACKAGE_SYSTEM_YUM: {
 				"list",
 				"installed",
 			},
+			pb.PackageSystem_PACKAGE_SYSTEM_APT: {
+				*dpkgQueryBin,
+				"--show",
+				fmt.Sprintf("--showformat=%s", aptInstalledFormat),
+			},
 		}
 		return genCmd(p, listOpts)
 	}
@@ -134,12 +408,13 @@ func validateField(param string, name string) error {
 		return status.Errorf(codes.InvalidArgument, "package %s %q invalid. Cannot start with a dash", param, name)
 	}
 	if name != inputValidateRe.ReplaceAllString(name, "") {
-		return status.Errorf(codes.InvalidArgument, "package %s %q invalid. Must contain only [a-zA-Z0-9_.:-]", param, name)
+		return status.Errorf(codes.InvalidArgument, "package %s %q invalid. Must contain only [a-zA-Z0-9_.:+~-]", param, name)
 	}
 	return nil
 }
 
-func (s *server) Install(ctx context.Context, req *pb.InstallRequest) (*pb.InstallReply, error) {
+// installCommand validates req and returns the command to run for it.
+func installCommand(req *pb.InstallRequest) ([]string, error) {
 	if err := validateField("name", req.Name); err != nil {
 		return nil, err
 	}
@@ -147,16 +422,28 @@ func (s *server) Install(ctx context.Context, req *pb.InstallRequest) (*pb.Insta
 		return nil, err
 	}
 
-	// Unset means YUM.
-	if req.PackageSystem == pb.PackageSystem_PACKAGE_SYSTEM_UNKNOWN {
-		req.PackageSystem = pb.PackageSystem_PACKAGE_SYSTEM_YUM
-	}
-	command, err := generateInstall(req)
+	req.PackageSystem = pickPackageSystem(req.PackageSystem)
+	return generateInstall(req)
+}
+
+func (s *server) Install(ctx context.Context, req *pb.InstallRequest) (*pb.InstallReply, error) {
+	command, err := installCommand(req)
 	if err != nil {
 		return nil, err
 	}
 
-	run, err := util.RunCommand(ctx, command[0], command[1:])
+	if req.DryRun {
+		changes, output, err := runDryRun(ctx, req.PackageSystem, command)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.InstallReply{
+			DebugOutput:    output,
+			PlannedChanges: changes,
+		}, nil
+	}
+
+	run, err := util.RunCommand(ctx, command[0], command[1:], runOptions(req.PackageSystem)...)
 	if err != nil {
 		return nil, err
 	}
@@ -174,7 +461,40 @@ func (s *server) Install(ctx context.Context, req *pb.InstallRequest) (*pb.Insta
 // Nevra is of the form n-e:v-r.a (where n is optional since e can be 0 for no epoch).
 var nevraRe = regexp.MustCompile(`^([^-]+-)?[^:]+:[^-]+-[^\.]+\..+$`)
 
-func (s *server) Update(ctx context.Context, req *pb.UpdateRequest) (*pb.UpdateReply, error) {
+// validateNevra checks a YUM version is nevra. Other package systems have no such requirement.
+func validateNevra(p pb.PackageSystem, param string, version string) error {
+	if p == pb.PackageSystem_PACKAGE_SYSTEM_YUM && !nevraRe.MatchString(version) {
+		return status.Errorf(codes.Internal, "%s %q not in nevra format (n-e:v-r.a)", param, version)
+	}
+	return nil
+}
+
+// validateInstalled checks the given package is installed at exactly version.
+func validateInstalled(ctx context.Context, p pb.PackageSystem, name string, version string) error {
+	command, err := generateValidate(&pb.UpdateRequest{
+		PackageSystem: p,
+		Name:          name,
+		OldVersion:    version,
+	})
+	if err != nil {
+		return err
+	}
+	run, err := util.RunCommand(ctx, command[0], command[1:], runOptions(p)...)
+	if err != nil {
+		return err
+	}
+	if err := run.Error; err != nil {
+		return status.Errorf(codes.Internal, "package %s at version %s doesn't appear to be installed.\nStderr:\n%s", name, version, util.TrimString(run.Stderr.String()))
+	}
+	if p == pb.PackageSystem_PACKAGE_SYSTEM_APT {
+		return checkAptInstalledVersion(run.Stdout.String(), name, version)
+	}
+	return nil
+}
+
+// updateCommand validates req (including that the old version is installed) and
+// returns the command to run for it.
+func updateCommand(ctx context.Context, req *pb.UpdateRequest) ([]string, error) {
 	if err := validateField("name", req.Name); err != nil {
 		return nil, err
 	}
@@ -185,37 +505,47 @@ func (s *server) Update(ctx context.Context, req *pb.UpdateRequest) (*pb.UpdateR
 		return nil, err
 	}
 
-	// Unset means YUM.
-	if req.PackageSystem == pb.PackageSystem_PACKAGE_SYSTEM_UNKNOWN {
-		req.PackageSystem = pb.PackageSystem_PACKAGE_SYSTEM_YUM
-	}
+	req.PackageSystem = pickPackageSystem(req.PackageSystem)
 
 	// Update doesn't require nevra but we do so validate each version is nevra.
-	if !nevraRe.MatchString(req.OldVersion) {
-		return nil, status.Errorf(codes.Internal, "old_version %q not in nevra format (n-e:v-r.a)", req.OldVersion)
+	if err := validateNevra(req.PackageSystem, "old_version", req.OldVersion); err != nil {
+		return nil, err
 	}
-	if !nevraRe.MatchString(req.NewVersion) {
-		return nil, status.Errorf(codes.Internal, "new_version %q not in nevra format (n-e:v-r.a)", req.NewVersion)
+	if err := validateNevra(req.PackageSystem, "new_version", req.NewVersion); err != nil {
+		return nil, err
 	}
 
-	// We can generate both commands since errors duplicate here.
-	validateCommand, valErr := generateValidate(req)
-	updateCommand, upErr := generateUpdate(req)
-	if valErr != nil || upErr != nil {
-		return nil, fmt.Errorf("%v %v", valErr, upErr)
+	command, err := generateUpdate(req)
+	if err != nil {
+		return nil, err
 	}
 
 	// First need to validate the old version is what we expect.
-	run, err := util.RunCommand(ctx, validateCommand[0], validateCommand[1:])
+	// A nil return means we're ok to proceed.
+	if err := validateInstalled(ctx, req.PackageSystem, req.Name, req.OldVersion); err != nil {
+		return nil, err
+	}
+	return command, nil
+}
+
+func (s *server) Update(ctx context.Context, req *pb.UpdateRequest) (*pb.UpdateReply, error) {
+	updateCommand, err := updateCommand(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	if err := run.Error; err != nil {
-		return nil, status.Errorf(codes.Internal, "package %s at version %s doesn't appear to be installed.\nStderr:\n%s", req.Name, req.OldVersion, util.TrimString(run.Stderr.String()))
+
+	if req.DryRun {
+		changes, output, err := runDryRun(ctx, req.PackageSystem, updateCommand)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.UpdateReply{
+			DebugOutput:    output,
+			PlannedChanges: changes,
+		}, nil
 	}
 
-	// A 0 return means we're ok to proceed.
-	run, err = util.RunCommand(ctx, updateCommand[0], updateCommand[1:])
+	run, err := util.RunCommand(ctx, updateCommand[0], updateCommand[1:], runOptions(req.PackageSystem)...)
 	if err != nil {
 		return nil, err
 	}
@@ -229,9 +559,588 @@ func (s *server) Update(ctx context.Context, req *pb.UpdateRequest) (*pb.UpdateR
 	}, nil
 }
 
+func (s *server) InstallStream(req *pb.InstallRequest, stream pb.Packages_InstallStreamServer) error {
+	if req.DryRun {
+		return status.Error(codes.InvalidArgument, "dry_run isn't supported when streaming")
+	}
+	command, err := installCommand(req)
+	if err != nil {
+		return err
+	}
+	return runStream(stream.Context(), req.PackageSystem, command, stream.Send)
+}
+
+func (s *server) UpdateStream(req *pb.UpdateRequest, stream pb.Packages_UpdateStreamServer) error {
+	if req.DryRun {
+		return status.Error(codes.InvalidArgument, "dry_run isn't supported when streaming")
+	}
+	command, err := updateCommand(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	return runStream(stream.Context(), req.PackageSystem, command, stream.Send)
+}
+
+// lineSender is an io.Writer which sends each complete line written to it.
+type lineSender struct {
+	send    func(*pb.ProgressReply) error
+	partial []byte
+}
+
+func (l *lineSender) Write(p []byte) (int, error) {
+	l.partial = append(l.partial, p...)
+	for {
+		i := bytes.IndexByte(l.partial, '\n')
+		if i < 0 {
+			break
+		}
+		line := strings.TrimSuffix(string(l.partial[:i]), "\r")
+		l.partial = l.partial[i+1:]
+		if err := l.send(&pb.ProgressReply{Line: line}); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush sends anything left after the last newline.
+func (l *lineSender) Flush() error {
+	if len(l.partial) == 0 {
+		return nil
+	}
+	line := string(l.partial)
+	l.partial = nil
+	return l.send(&pb.ProgressReply{Line: line})
+}
+
+// runStream runs command sending each line of stdout as it's produced.
+func runStream(ctx context.Context, p pb.PackageSystem, command []string, send func(*pb.ProgressReply) error) error {
+	sender := &lineSender{send: send}
+	// Everything has already been sent so don't buffer more than is needed for errors.
+	opts := append(runOptions(p), util.StdoutWriter(sender), util.StdoutMax(util.MaxBuf))
+	run, err := util.RunCommand(ctx, command[0], command[1:], opts...)
+	if err != nil {
+		return err
+	}
+	if err := run.Error; err != nil {
+		return status.Errorf(codes.Internal, "error from running %q: %v\nstderr:\n%s", command, err, util.TrimString(run.Stderr.String()))
+	}
+	return sender.Flush()
+}
+
+// runDryRun runs a command generated for a dry run and returns the changes it would make
+// along with the raw output.
+func runDryRun(ctx context.Context, p pb.PackageSystem, command []string) ([]*pb.PackageChange, string, error) {
+	run, err := util.RunCommand(ctx, command[0], command[1:], runOptions(p)...)
+	if err != nil {
+		return nil, "", err
+	}
+	output := run.Stdout.String()
+	// YUM exits 1 when the transaction is declined so that's only an error if it was
+	// never resolved (i.e. the package doesn't exist).
+	declined := p == pb.PackageSystem_PACKAGE_SYSTEM_YUM && run.ExitCode == 1 && strings.Contains(output, yumTransactionSummary)
+	if err := run.Error; err != nil && !declined {
+		return nil, "", status.Errorf(codes.Internal, "error from running %q: %v\nstdout:\n%s\nstderr:\n%s", command, err, util.TrimString(output), util.TrimString(run.Stderr.String()))
+	}
+	changes, err := parsePlannedChanges(p, strings.NewReader(output))
+	if err != nil {
+		return nil, "", err
+	}
+	return changes, output, nil
+}
+
+func parsePlannedChanges(p pb.PackageSystem, r io.Reader) ([]*pb.PackageChange, error) {
+	parsers := map[pb.PackageSystem]func(r io.Reader) ([]*pb.PackageChange, error){
+		pb.PackageSystem_PACKAGE_SYSTEM_YUM: parseYumTransactionOutput,
+		pb.PackageSystem_PACKAGE_SYSTEM_APT: parseAptSimulateOutput,
+	}
+	parser, ok := parsers[p]
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "can't find parser for dry run output for package system %d", p)
+	}
+	return parser(r)
+}
+
+// yumTransactionSummary ends the table of changes in a resolved YUM transaction.
+const yumTransactionSummary = "Transaction Summary"
+
+// parseYumTransactionOutput parses the table of changes yum prints before asking to
+// proceed with a transaction. It looks like:
+//
+//	================================================================================
+//	 Package          Arch        Version                   Repository        Size
+//	================================================================================
+//	Installing:
+//	 telnet           x86_64      1:0.17-66.el7             base              64 k
+//	Updating for dependencies:
+//	 bash             x86_64      4.2.46-35.el7_9           updates          1.0 M
+//
+//	Transaction Summary
+//
+// Long package names wrap so the rest of the row is on the following line.
+func parseYumTransactionOutput(r io.Reader) ([]*pb.PackageChange, error) {
+	scanner := bufio.NewScanner(r)
+
+	var out []*pb.PackageChange
+	header, inTable := false, false
+	action := ""
+	wrapped := ""
+	for scanner.Scan() {
+		text := scanner.Text()
+		fields := strings.Fields(text)
+		if !inTable {
+			// The header can wrap as well so the table starts after the separator following it.
+			// dnf calls the Arch column Architecture.
+			inTable = header && strings.HasPrefix(text, "=")
+			header = header || (len(fields) > 3 && fields[0] == "Package" && strings.HasPrefix(fields[1], "Arch"))
+			continue
+		}
+		if text == yumTransactionSummary {
+			break
+		}
+		if len(fields) == 0 || strings.HasPrefix(text, "=") {
+			continue
+		}
+		if text[0] != ' ' {
+			action = strings.TrimSuffix(text, ":")
+			continue
+		}
+		if wrapped != "" {
+			fields = append([]string{wrapped}, fields...)
+			wrapped = ""
+		}
+		switch {
+		case len(fields) == 1:
+			wrapped = fields[0]
+			continue
+		case fields[0] == "replacing":
+			// The package being obsoleted by the one above.
+			continue
+		case len(fields) < 5 || action == "":
+			return nil, status.Errorf(codes.Internal, "invalid input line %q", text)
+		}
+		out = append(out, &pb.PackageChange{
+			Name:         fields[0],
+			Architecture: fields[1],
+			Version:      fields[2],
+			Action:       action,
+			Repo:         fields[3],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "parsing error:\n%v", err)
+	}
+	if wrapped != "" {
+		return nil, status.Errorf(codes.Internal, "truncated input after package %q", wrapped)
+	}
+	return out, nil
+}
+
+func (s *server) Remove(ctx context.Context, req *pb.RemoveRequest) (*pb.RemoveReply, error) {
+	if err := validateField("name", req.Name); err != nil {
+		return nil, err
+	}
+	if err := validateField("version", req.Version); err != nil {
+		return nil, err
+	}
+
+	req.PackageSystem = pickPackageSystem(req.PackageSystem)
+	if err := validateNevra(req.PackageSystem, "version", req.Version); err != nil {
+		return nil, err
+	}
+
+	command, err := generateRemove(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only remove what the caller expects is there.
+	if err := validateInstalled(ctx, req.PackageSystem, req.Name, req.Version); err != nil {
+		return nil, err
+	}
+
+	run, err := util.RunCommand(ctx, command[0], command[1:], runOptions(req.PackageSystem)...)
+	if err != nil {
+		return nil, err
+	}
+	if err := run.Error; err != nil {
+		return nil, status.Errorf(codes.Internal, "error from running - %v\nstdout:\n%s\nstderr:\n%s", err, util.TrimString(run.Stdout.String()), util.TrimString(run.Stderr.String()))
+	}
+
+	return &pb.RemoveReply{
+		DebugOutput: run.Stdout.String(),
+	}, nil
+}
+
+func (s *server) Downgrade(ctx context.Context, req *pb.DowngradeRequest) (*pb.DowngradeReply, error) {
+	if err := validateField("name", req.Name); err != nil {
+		return nil, err
+	}
+	if err := validateField("old_version", req.OldVersion); err != nil {
+		return nil, err
+	}
+	if err := validateField("new_version", req.NewVersion); err != nil {
+		return nil, err
+	}
+	if req.Repo != "" {
+		if err := validateField("repo", req.Repo); err != nil {
+			return nil, err
+		}
+	}
+
+	req.PackageSystem = pickPackageSystem(req.PackageSystem)
+	if err := validateNevra(req.PackageSystem, "old_version", req.OldVersion); err != nil {
+		return nil, err
+	}
+	if err := validateNevra(req.PackageSystem, "new_version", req.NewVersion); err != nil {
+		return nil, err
+	}
+
+	command, err := generateDowngrade(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// As with update the old version must be what we expect.
+	if err := validateInstalled(ctx, req.PackageSystem, req.Name, req.OldVersion); err != nil {
+		return nil, err
+	}
+
+	run, err := util.RunCommand(ctx, command[0], command[1:], runOptions(req.PackageSystem)...)
+	if err != nil {
+		return nil, err
+	}
+	if err := run.Error; err != nil {
+		return nil, status.Errorf(codes.Internal, "error from running %q: %v\nstderr:\n%s", command, err, util.TrimString(run.Stderr.String()))
+	}
+
+	return &pb.DowngradeReply{
+		DebugOutput: run.Stdout.String(),
+	}, nil
+}
+
+func parseSearchOutput(p pb.PackageSystem, r io.Reader) (*pb.SearchReply, error) {
+	parsers := map[pb.PackageSystem]func(r io.Reader) (*pb.SearchReply, error){
+		pb.PackageSystem_PACKAGE_SYSTEM_YUM: parseYumSearchOutput,
+		pb.PackageSystem_PACKAGE_SYSTEM_APT: parseAptSearchOutput,
+	}
+	parser, ok := parsers[p]
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "can't find parser for search output for package system %d", p)
+	}
+	return parser(r)
+}
+
+func parseYumSearchOutput(r io.Reader) (*pb.SearchReply, error) {
+	packages, err := parseYumListOutput("Available Packages", r)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SearchReply{Packages: packages}, nil
+}
+
+func (s *server) Search(ctx context.Context, req *pb.SearchRequest) (*pb.SearchReply, error) {
+	if err := validateField("name", req.Name); err != nil {
+		return nil, err
+	}
+	if req.Repo != "" {
+		if err := validateField("repo", req.Repo); err != nil {
+			return nil, err
+		}
+	}
+
+	req.PackageSystem = pickPackageSystem(req.PackageSystem)
+	command, err := generateSearch(req)
+	if err != nil {
+		return nil, err
+	}
+
+	run, err := util.RunCommand(ctx, command[0], command[1:], runOptions(req.PackageSystem)...)
+	if err != nil {
+		return nil, err
+	}
+	if err := run.Error; err != nil {
+		return nil, status.Errorf(codes.Internal, "error from running %q: %v\nstderr:\n%s", command, err, util.TrimString(run.Stderr.String()))
+	}
+
+	return parseSearchOutput(req.PackageSystem, run.Stdout)
+}
+
+func parseInfoOutput(p pb.PackageSystem, r io.Reader) ([]*pb.PackageDetails, error) {
+	parsers := map[pb.PackageSystem]func(r io.Reader) ([]*pb.PackageDetails, error){
+		pb.PackageSystem_PACKAGE_SYSTEM_YUM: parseRpmInfoOutput,
+		pb.PackageSystem_PACKAGE_SYSTEM_APT: parseAptInfoOutput,
+	}
+	parser, ok := parsers[p]
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "can't find parser for info output for package system %d", p)
+	}
+	return parser(r)
+}
+
+// runQuery runs a command generated for the given package system and returns stdout.
+func runQuery(ctx context.Context, p pb.PackageSystem, command []string) (*util.LimitedBuffer, error) {
+	run, err := util.RunCommand(ctx, command[0], command[1:], runOptions(p)...)
+	if err != nil {
+		return nil, err
+	}
+	if err := run.Error; err != nil {
+		return nil, status.Errorf(codes.Internal, "error from running %q: %v\nstdout:\n%s\nstderr:\n%s", command, err, util.TrimString(run.Stdout.String()), util.TrimString(run.Stderr.String()))
+	}
+	return run.Stdout, nil
+}
+
+// addInfoRepos fills in the repo each package was installed from.
+func addInfoRepos(ctx context.Context, p pb.PackageSystem, name string, pkgs []*pb.PackageDetails) error {
+	if p == pb.PackageSystem_PACKAGE_SYSTEM_YUM {
+		command, err := generateInfoRepo(p, name)
+		if err != nil {
+			return err
+		}
+		out, err := runQuery(ctx, p, command)
+		if err != nil {
+			return err
+		}
+		return yumRepoForDetails(pkgs, out)
+	}
+	for _, pkg := range pkgs {
+		command, err := generateInfoRepo(p, pkg.Name)
+		if err != nil {
+			return err
+		}
+		out, err := runQuery(ctx, p, command)
+		if err != nil {
+			return err
+		}
+		if pkg.Repo, err = parseAptPolicyRepo(out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *server) Info(ctx context.Context, req *pb.InfoRequest) (*pb.InfoReply, error) {
+	if err := validateField("name", req.Name); err != nil {
+		return nil, err
+	}
+
+	req.PackageSystem = pickPackageSystem(req.PackageSystem)
+	command, err := generateInfo(req.PackageSystem, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	out, err := runQuery(ctx, req.PackageSystem, command)
+	if err != nil {
+		return nil, err
+	}
+	pkgs, err := parseInfoOutput(req.PackageSystem, out)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) == 0 {
+		return nil, status.Errorf(codes.NotFound, "package %s is not installed", req.Name)
+	}
+	if req.PackageSystem == pb.PackageSystem_PACKAGE_SYSTEM_APT {
+		for _, pkg := range pkgs {
+			pkg.InstallTime = aptInstallTime(pkg.Name)
+		}
+	}
+	if err := addInfoRepos(ctx, req.PackageSystem, req.Name, pkgs); err != nil {
+		return nil, err
+	}
+	return &pb.InfoReply{Packages: pkgs}, nil
+}
+
+// parseFilesOutput parses the file list from rpm or dpkg-query. Both emit one path
+// per line along with some informational lines (i.e. diversions) which are skipped.
+func parseFilesOutput(r io.Reader) (*pb.FilesReply, error) {
+	scanner := bufio.NewScanner(r)
+
+	reply := &pb.FilesReply{}
+	for scanner.Scan() {
+		text := scanner.Text()
+		// dpkg always lists the root directory.
+		if !strings.HasPrefix(text, "/") || text == "/." {
+			continue
+		}
+		reply.Files = append(reply.Files, text)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "parsing error:\n%v", err)
+	}
+	return reply, nil
+}
+
+func (s *server) Files(ctx context.Context, req *pb.FilesRequest) (*pb.FilesReply, error) {
+	if err := validateField("name", req.Name); err != nil {
+		return nil, err
+	}
+
+	req.PackageSystem = pickPackageSystem(req.PackageSystem)
+	command, err := generateFiles(req.PackageSystem, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	out, err := runQuery(ctx, req.PackageSystem, command)
+	if err != nil {
+		return nil, err
+	}
+	return parseFilesOutput(out)
+}
+
+// verifyLineRe matches a line of rpm -V or dpkg --verify output such as:
+//
+// S.5....T.  c /etc/foo.conf
+// missing     /usr/bin/foo
+//
+// The optional character before the path is the file attribute (c for config).
+var verifyLineRe = regexp.MustCompile(`^(missing|[.?SM5DLUGTP]{9})\s+(?:([cdglr])\s+)?(/.*)$`)
+
+// verifyChecks maps each position in the verify result to the check it represents.
+var verifyChecks = []pb.VerifyCheck{
+	pb.VerifyCheck_VERIFY_CHECK_SIZE,
+	pb.VerifyCheck_VERIFY_CHECK_MODE,
+	pb.VerifyCheck_VERIFY_CHECK_DIGEST,
+	pb.VerifyCheck_VERIFY_CHECK_DEVICE,
+	pb.VerifyCheck_VERIFY_CHECK_LINK,
+	pb.VerifyCheck_VERIFY_CHECK_OWNER,
+	pb.VerifyCheck_VERIFY_CHECK_GROUP,
+	pb.VerifyCheck_VERIFY_CHECK_MTIME,
+	pb.VerifyCheck_VERIFY_CHECK_CAPABILITIES,
+}
+
+// parseVerifyOutput parses the output of rpm -V or dpkg --verify which share a format.
+func parseVerifyOutput(r io.Reader) (*pb.VerifyReply, error) {
+	scanner := bufio.NewScanner(r)
+
+	reply := &pb.VerifyReply{}
+	for scanner.Scan() {
+		text := scanner.Text()
+		if text == "" {
+			continue
+		}
+		m := verifyLineRe.FindStringSubmatch(text)
+		if m == nil {
+			return nil, status.Errorf(codes.Internal, "invalid input line %q", text)
+		}
+		d := &pb.FileDiscrepancy{
+			Path:   m[3],
+			Config: m[2] == "c",
+		}
+		if m[1] == "missing" {
+			d.Failed = append(d.Failed, pb.VerifyCheck_VERIFY_CHECK_MISSING)
+		} else {
+			// . means the check passed and ? that it couldn't be done.
+			for i, c := range m[1] {
+				if c != '.' && c != '?' {
+					d.Failed = append(d.Failed, verifyChecks[i])
+				}
+			}
+		}
+		if len(d.Failed) > 0 {
+			reply.Discrepancies = append(reply.Discrepancies, d)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "parsing error:\n%v", err)
+	}
+	return reply, nil
+}
+
+func (s *server) Verify(ctx context.Context, req *pb.VerifyRequest) (*pb.VerifyReply, error) {
+	if err := validateField("name", req.Name); err != nil {
+		return nil, err
+	}
+
+	req.PackageSystem = pickPackageSystem(req.PackageSystem)
+	command, err := generateVerify(req.PackageSystem, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	run, err := util.RunCommand(ctx, command[0], command[1:], runOptions(req.PackageSystem)...)
+	if err != nil {
+		return nil, err
+	}
+	// Both rpm and dpkg exit 1 if any file fails verification so only treat
+	// that as an error if something was also complained about on stderr.
+	if err := run.Error; err != nil && (run.ExitCode != 1 || len(run.Stderr.String()) != 0) {
+		return nil, status.Errorf(codes.Internal, "error from running %q: %v\nstdout:\n%s\nstderr:\n%s", command, err, util.TrimString(run.Stdout.String()), util.TrimString(run.Stderr.String()))
+	}
+	return parseVerifyOutput(run.Stdout)
+}
+
+// defaultHistoryLimit is the number of transactions History returns if no limit is given.
+const defaultHistoryLimit = 10
+
+func (s *server) History(ctx context.Context, req *pb.HistoryRequest) (*pb.HistoryReply, error) {
+	req.PackageSystem = pickPackageSystem(req.PackageSystem)
+	command, err := generateHistoryList(req.PackageSystem)
+	if err != nil {
+		return nil, err
+	}
+	out, err := runQuery(ctx, req.PackageSystem, command)
+	if err != nil {
+		return nil, err
+	}
+	transactions, err := parseYumHistoryList(out)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultHistoryLimit
+	}
+	sort.SliceStable(transactions, func(i, j int) bool { return transactions[i].Id > transactions[j].Id })
+	if len(transactions) > limit {
+		transactions = transactions[:limit]
+	}
+
+	// The list only summarizes each transaction so get the packages from info.
+	for _, t := range transactions {
+		command, err := generateHistoryInfo(req.PackageSystem, t.Id)
+		if err != nil {
+			return nil, err
+		}
+		out, err := runQuery(ctx, req.PackageSystem, command)
+		if err != nil {
+			return nil, err
+		}
+		if err := parseYumHistoryInfo(out, t); err != nil {
+			return nil, err
+		}
+	}
+	return &pb.HistoryReply{Transactions: transactions}, nil
+}
+
+func (s *server) Undo(ctx context.Context, req *pb.UndoRequest) (*pb.UndoReply, error) {
+	if req.TransactionId <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction_id must be positive: %d", req.TransactionId)
+	}
+
+	req.PackageSystem = pickPackageSystem(req.PackageSystem)
+	command, err := generateUndo(req.PackageSystem, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+
+	run, err := util.RunCommand(ctx, command[0], command[1:], runOptions(req.PackageSystem)...)
+	if err != nil {
+		return nil, err
+	}
+	if err := run.Error; err != nil {
+		return nil, status.Errorf(codes.Internal, "error from running %q: %v\nstdout:\n%s\nstderr:\n%s", command, err, util.TrimString(run.Stdout.String()), util.TrimString(run.Stderr.String()))
+	}
+
+	return &pb.UndoReply{
+		DebugOutput: run.Stdout.String(),
+	}, nil
+}
+
 func parseListInstallOutput(p pb.PackageSystem, r io.Reader) (*pb.ListInstalledReply, error) {
 	parsers := map[pb.PackageSystem]func(r io.Reader) (*pb.ListInstalledReply, error){
 		pb.PackageSystem_PACKAGE_SYSTEM_YUM: parseYumListInstallOutput,
+		pb.PackageSystem_PACKAGE_SYSTEM_APT: parseAptListInstallOutput,
 	}
 	parser, ok := parsers[p]
 	if !ok {
@@ -241,9 +1150,19 @@ func parseListInstallOutput(p pb.PackageSystem, r io.Reader) (*pb.ListInstalledR
 }
 
 func parseYumListInstallOutput(r io.Reader) (*pb.ListInstalledReply, error) {
+	packages, err := parseYumListOutput("Installed Packages", r)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListInstalledReply{Packages: packages}, nil
+}
+
+// parseYumListOutput parses the packages listed after the given section
+// header in the output of yum list.
+func parseYumListOutput(header string, r io.Reader) ([]*pb.PackageInfo, error) {
 	scanner := bufio.NewScanner(r)
 
-	reply := &pb.ListInstalledReply{}
+	var packages []*pb.PackageInfo
 	started := false
 
 	for scanner.Scan() {
@@ -251,7 +1170,7 @@ func parseYumListInstallOutput(r io.Reader) (*pb.ListInstalledReply, error) {
 
 		// Skip lines until we find the header line. Everything after this is a package.
 		if !started {
-			if strings.HasPrefix(text, "Installed Packages") {
+			if strings.HasPrefix(text, header) {
 				started = true
 			}
 			continue
@@ -285,7 +1204,7 @@ func parseYumListInstallOutput(r io.Reader) (*pb.ListInstalledReply, error) {
 			return nil, status.Errorf(codes.Internal, "invalid input line. Expecting 3 fields and got %q which is invalid", text)
 		}
 
-		reply.Packages = append(reply.Packages, &pb.PackageInfo{
+		packages = append(packages, &pb.PackageInfo{
 			Name:    fields[0],
 			Version: fields[1],
 			Repo:    fields[2],
@@ -296,14 +1215,11 @@ func parseYumListInstallOutput(r io.Reader) (*pb.ListInstalledReply, error) {
 		return nil, status.Errorf(codes.Internal, "parsing error:\n%v", err)
 	}
 
-	return reply, nil
+	return packages, nil
 }
 
 func (s *server) ListInstalled(ctx context.Context, req *pb.ListInstalledRequest) (*pb.ListInstalledReply, error) {
-	// Unset means YUM.
-	if req.PackageSystem == pb.PackageSystem_PACKAGE_SYSTEM_UNKNOWN {
-		req.PackageSystem = pb.PackageSystem_PACKAGE_SYSTEM_YUM
-	}
+	req.PackageSystem = pickPackageSystem(req.PackageSystem)
 
 	command, err := generateListInstalled(req.PackageSystem)
 	if err != nil {
@@ -311,7 +1227,7 @@ func (s *server) ListInstalled(ctx context.Context, req *pb.ListInstalledRequest
 	}
 
 	// This may return output to stderr if the lock is held and we wait. That's ok.
-	run, err := util.RunCommand(ctx, command[0], command[1:])
+	run, err := util.RunCommand(ctx, command[0], command[1:], runOptions(req.PackageSystem)...)
 	if err != nil {
 		return nil, err
 	}
@@ -405,9 +1321,11 @@ func parseYumRepoListOutput(r io.Reader) (*pb.RepoListReply, error) {
 }
 
 func (s *server) RepoList(ctx context.Context, req *pb.RepoListRequest) (*pb.RepoListReply, error) {
-	// Unset means YUM.
-	if req.PackageSystem == pb.PackageSystem_PACKAGE_SYSTEM_UNKNOWN {
-		req.PackageSystem = pb.PackageSystem_PACKAGE_SYSTEM_YUM
+	req.PackageSystem = pickPackageSystem(req.PackageSystem)
+
+	// There's no apt command which lists sources in a parsable form so read them directly.
+	if req.PackageSystem == pb.PackageSystem_PACKAGE_SYSTEM_APT {
+		return readAptSources(aptSourcesList, aptSourcesDir)
 	}
 
 	command, err := generateRepoList(req.PackageSystem)
@@ -431,6 +1349,25 @@ func (s *server) Register(gs *grpc.Server) {
 	pb.RegisterPackagesServer(gs, s)
 }
 
+// checkPackageManager is a health check which confirms the binary for the
+// host's package system exists.
+func checkPackageManager(ctx context.Context) error {
+	bins := map[pb.PackageSystem]string{
+		pb.PackageSystem_PACKAGE_SYSTEM_YUM: *yumBin,
+		pb.PackageSystem_PACKAGE_SYSTEM_APT: *aptGetBin,
+	}
+	p := detectPackageSystem()
+	bin, ok := bins[p]
+	if !ok {
+		return fmt.Errorf("unsupported package system %s", p)
+	}
+	if _, err := os.Stat(bin); err != nil {
+		return fmt.Errorf("package manager binary %s: %v", bin, err)
+	}
+	return nil
+}
+
 func init() {
 	services.RegisterSansShellService(&server{})
+	healthcheck.RegisterCheck(pb.Packages_ServiceDesc.ServiceName, "package-manager", checkPackageManager)
 }
@@ -23,4 +23,11 @@ import (
 	"flag"
 )
 
-var yumBin = flag.String("yum-bin", "false", "Path to yum binary (NOTE: no support on this platform)")
+var (
+	yumBin       = flag.String("yum-bin", "false", "Path to yum binary (NOTE: no support on this platform)")
+	aptGetBin    = flag.String("apt-get-bin", "false", "Path to apt-get binary (NOTE: no support on this platform)")
+	dpkgQueryBin = flag.String("dpkg-query-bin", "false", "Path to dpkg-query binary (NOTE: no support on this platform)")
+	aptCacheBin  = flag.String("apt-cache-bin", "false", "Path to apt-cache binary (NOTE: no support on this platform)")
+	rpmBin       = flag.String("rpm-bin", "false", "Path to rpm binary (NOTE: no support on this platform)")
+	dpkgBin      = flag.String("dpkg-bin", "false", "Path to dpkg binary (NOTE: no support on this platform)")
+)
@@ -23,4 +23,11 @@ import (
 	"flag"
 )
 
-var yumBin = flag.String("yum-bin", "/usr/bin/yum", "Path to yum binary")
+var (
+	yumBin       = flag.String("yum-bin", "/usr/bin/yum", "Path to yum binary")
+	aptGetBin    = flag.String("apt-get-bin", "/usr/bin/apt-get", "Path to apt-get binary")
+	dpkgQueryBin = flag.String("dpkg-query-bin", "/usr/bin/dpkg-query", "Path to dpkg-query binary")
+	aptCacheBin  = flag.String("apt-cache-bin", "/usr/bin/apt-cache", "Path to apt-cache binary")
+	rpmBin       = flag.String("rpm-bin", "/usr/bin/rpm", "Path to rpm binary")
+	dpkgBin      = flag.String("dpkg-bin", "/usr/bin/dpkg", "Path to dpkg binary")
+)
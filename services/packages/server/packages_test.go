@@ -19,25 +19,32 @@ package server
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	pb "github.com/Snowflake-Labs/sansshell/services/packages"
 	"github.com/Snowflake-Labs/sansshell/testing/testutil"
+	"github.com/google/go-cmp/cmp"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/test/bufconn"
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var (
 	bufSize = 1024 * 1024
 	lis     *bufconn.Listener
 	conn    *grpc.ClientConn
+
+	savedDetectPackageSystem func() pb.PackageSystem
 )
 
 func bufDialer(context.Context, string) (net.Conn, error) {
@@ -45,6 +52,12 @@ func bufDialer(context.Context, string) (net.Conn, error) {
 }
 
 func TestMain(m *testing.M) {
+	// Tests assume unset means YUM regardless of what the test host has installed.
+	savedDetectPackageSystem = detectPackageSystem
+	detectPackageSystem = func() pb.PackageSystem {
+		return pb.PackageSystem_PACKAGE_SYSTEM_YUM
+	}
+
 	lis = bufconn.Listen(bufSize)
 	s := grpc.NewServer()
 	lfs := &server{}
@@ -90,7 +103,7 @@ func TestInstall(t *testing.T) {
 		{
 			name: "bad package system",
 			req: &pb.InstallRequest{
-				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_YUM + 1,
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT + 1,
 				Name:          "package",
 				Version:       "1.2.3",
 			},
@@ -165,7 +178,20 @@ func TestInstall(t *testing.T) {
 	}
 	t.Logf("clean install response: %+v", resp)
 
-	// Test 2: Permutations on bad commands/output.
+	// Test 2: APT uses apt-get and Debian style versions.
+	wantCmdLine = fmt.Sprintf("%s install -y --target-release=bookworm-backports package=1:1.2.3+dfsg-1~bpo12+1", *aptGetBin)
+	_, err = client.Install(ctx, &pb.InstallRequest{
+		PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+		Name:          "package",
+		Version:       "1:1.2.3+dfsg-1~bpo12+1",
+		Repo:          "bookworm-backports",
+	})
+	testutil.FatalOnErr("apt install request", err, t)
+	if got, want := cmdLine, wantCmdLine; got != want {
+		t.Fatalf("command lines differ. Got %q Want %q", got, want)
+	}
+
+	// Test 3: Permutations on bad commands/output.
 	for _, tc := range []struct {
 		name     string
 		generate func(*pb.InstallRequest) ([]string, error)
@@ -255,7 +281,7 @@ func TestUpdate(t *testing.T) {
 		{
 			name: "bad package system",
 			req: &pb.UpdateRequest{
-				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_YUM + 1,
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT + 1,
 				Name:          "package",
 				OldVersion:    "0:1-1.2.3",
 				NewVersion:    "0:1-4.5.6",
@@ -264,7 +290,7 @@ func TestUpdate(t *testing.T) {
 		{
 			name: "bad old version - nevra",
 			req: &pb.UpdateRequest{
-				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_YUM + 1,
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_YUM,
 				Name:          "package",
 				OldVersion:    "1.2.3",
 				NewVersion:    "0:1-4.5.6",
@@ -273,7 +299,7 @@ func TestUpdate(t *testing.T) {
 		{
 			name: "bad new version - nevra",
 			req: &pb.UpdateRequest{
-				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_YUM + 1,
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_YUM,
 				Name:          "package",
 				OldVersion:    "0:1-1.2.3",
 				NewVersion:    "4.5.6",
@@ -391,7 +417,69 @@ func TestUpdate(t *testing.T) {
 	t.Log(err)
 	generateValidate = save
 
-	// Test 3: Permutations on bad commands/output.
+	// Test 3: APT checks the version reported by dpkg-query.
+	aptReq := &pb.UpdateRequest{
+		PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+		Name:          "package",
+		OldVersion:    "1.2.3-1",
+		NewVersion:    "1.2.4-1+deb12u1",
+		Repo:          "bookworm-security",
+	}
+	wantValidateCmdLine = fmt.Sprintf("%s --show --showformat=%s package", *dpkgQueryBin, aptStatusFormat)
+	wantCmdLine = fmt.Sprintf("%s install -y --only-upgrade --target-release=bookworm-security package=1.2.4-1+deb12u1", *aptGetBin)
+	for _, tc := range []struct {
+		name    string
+		output  string
+		wantErr bool
+	}{
+		{
+			name:   "installed",
+			output: "installed\t1.2.3-1\n",
+		},
+		{
+			name:    "other version",
+			output:  "installed\t1.2.2-1\n",
+			wantErr: true,
+		},
+		{
+			name:    "removed",
+			output:  "config-files\t1.2.3-1\n",
+			wantErr: true,
+		},
+		{
+			name:    "garbage",
+			output:  testdataInput,
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		saveValidate := generateValidate
+		t.Run(tc.name, func(t *testing.T) {
+			generateValidate = func(u *pb.UpdateRequest) ([]string, error) {
+				out, err := savedGenerateValidate(u)
+				if err != nil {
+					return nil, err
+				}
+				validateCmdLine = strings.Join(out, " ")
+				return []string{testutil.ResolvePath(t, "echo"), "-n", tc.output}, nil
+			}
+			cmdLine = ""
+			_, err := client.Update(ctx, aptReq)
+			testutil.WantErr(tc.name, err, tc.wantErr, t)
+			if got, want := validateCmdLine, wantValidateCmdLine; got != want {
+				t.Fatalf("validate command lines differ. Got %q Want %q", got, want)
+			}
+			if tc.wantErr {
+				return
+			}
+			if got, want := cmdLine, wantCmdLine; got != want {
+				t.Fatalf("command lines differ. Got %q Want %q", got, want)
+			}
+		})
+		generateValidate = saveValidate
+	}
+
+	// Test 4: Permutations on bad commands/output.
 	for _, tc := range []struct {
 		name     string
 		generate func(*pb.UpdateRequest) ([]string, error)
@@ -440,7 +528,8 @@ func TestUpdate(t *testing.T) {
 		generateValidate = saveValidate
 	}
 }
-func TestListInstalled(t *testing.T) {
+
+func TestDryRun(t *testing.T) {
 	var err error
 	ctx := context.Background()
 	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -449,120 +538,205 @@ func TestListInstalled(t *testing.T) {
 
 	client := pb.NewPackagesClient(conn)
 
-	// Setup for feeding in test data for further tests.
-	testdataInput := "./testdata/yum-installed.out"
-	testdataInputBad := "./testdata/yum-installed-bad.out"
-	testdataInputBad2 := "./testdata/yum-installed-bad2.out"
-	testdataInputBad3 := "./testdata/yum-installed-bad3.out"
-	testdataGolden := "./testdata/yum-installed.textproto"
-
-	savedGenerateListInstalled := generateListInstalled
-	var cmdLine string
-	generateListInstalled = func(p pb.PackageSystem) ([]string, error) {
-		// Capture what was generated so we can validate it.
-		out, err := savedGenerateListInstalled(p)
+	// The dry run output is read from input and the command exits with exitCode
+	// as YUM declining the transaction exits 1.
+	var cmdLine, input, validateOutput string
+	var exitCode int
+	dryRun := func(out []string) []string {
+		cmdLine = strings.Join(out, " ")
+		return []string{testutil.ResolvePath(t, "sh"), "-c", fmt.Sprintf("cat %s; exit %d", input, exitCode)}
+	}
+	savedGenerateInstall := generateInstall
+	savedGenerateUpdate := generateUpdate
+	savedGenerateValidate := generateValidate
+	generateInstall = func(i *pb.InstallRequest) ([]string, error) {
+		out, err := savedGenerateInstall(i)
 		if err != nil {
 			return nil, err
 		}
-		cmdLine = strings.Join(out, " ")
-		return []string{testutil.ResolvePath(t, "cat"), testdataInput}, nil
+		return dryRun(out), nil
 	}
-	t.Cleanup(func() {
-		generateListInstalled = savedGenerateListInstalled
-	})
-
-	input, err := os.ReadFile(testdataGolden)
-	testutil.FatalOnErr(fmt.Sprintf("can't read testdata golden from %s", testdataGolden), err, t)
-
-	testdata := &pb.ListInstalledReply{}
-	err = prototext.Unmarshal(input, testdata)
-	testutil.FatalOnErr("Can't unmarshall test data", err, t)
-
-	// Be able to sort the overall entries in a response
-	sortEntries := protocmp.SortRepeated(func(i *pb.PackageInfo, j *pb.PackageInfo) bool {
-		return i.Name < j.Name && i.Version < j.Version
-	})
-
-	// Test 0: Specify a bad package system and get an error.
-	resp, err := client.ListInstalled(ctx, &pb.ListInstalledRequest{
-		PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_YUM + 1,
-	})
-	if err == nil {
-		t.Fatalf("didn't get an error as expected for a bad package enum. Instead got %+v", resp)
+	generateUpdate = func(u *pb.UpdateRequest) ([]string, error) {
+		out, err := savedGenerateUpdate(u)
+		if err != nil {
+			return nil, err
+		}
+		return dryRun(out), nil
 	}
-	t.Log(err)
-
-	// Test 1: No options. Should pick yum w/o error and give back our list.
-
-	// This is assuming yum based installs for testing command builder.
-	wantCmdLine := fmt.Sprintf("%s list installed", *yumBin)
-
-	resp, err = client.ListInstalled(ctx, &pb.ListInstalledRequest{})
-	testutil.FatalOnErr("basic package list request", err, t)
-
-	testutil.DiffErr("basic package list request", resp, testdata, t, sortEntries)
-
-	if got, want := cmdLine, wantCmdLine; got != want {
-		t.Fatalf("command lines differ. Got %q Want %q", got, want)
+	generateValidate = func(u *pb.UpdateRequest) ([]string, error) {
+		return []string{testutil.ResolvePath(t, "echo"), validateOutput}, nil
 	}
-
-	// Test 2: Specify yum this time.
-	resp, err = client.ListInstalled(ctx, &pb.ListInstalledRequest{
-		PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_YUM,
+	t.Cleanup(func() {
+		generateInstall = savedGenerateInstall
+		generateUpdate = savedGenerateUpdate
+		generateValidate = savedGenerateValidate
 	})
-	testutil.FatalOnErr("basic package list request", err, t)
-
-	testutil.DiffErr("basic package list request yum", resp, testdata, t, sortEntries)
-
-	// Test 3: Now try with bad input. Should error out.
-	for _, b := range []string{testdataInputBad, testdataInputBad2, testdataInputBad3} {
-		generateListInstalled = func(pb.PackageSystem) ([]string, error) {
-			return []string{testutil.ResolvePath(t, "cat"), b}, nil
-		}
-		resp, err = client.ListInstalled(ctx, &pb.ListInstalledRequest{
-			PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_YUM,
-		})
-		testutil.FatalOnNoErr(fmt.Sprintf("bad input - resp %v", resp), err, t)
-		t.Log(err)
-	}
 
-	// Test 4: Permutations of bad commands/exit codes, stderr output.
 	for _, tc := range []struct {
-		name     string
-		generate func(pb.PackageSystem) ([]string, error)
+		name           string
+		install        *pb.InstallRequest
+		update         *pb.UpdateRequest
+		input          string
+		exitCode       int
+		validateOutput string
+		golden         string
+		wantCmd        string
+		wantErr        bool
 	}{
 		{
-			name: "non-existant binary",
-			generate: func(pb.PackageSystem) ([]string, error) {
-				return []string{"/non-existant-binary"}, nil
+			name: "yum install",
+			install: &pb.InstallRequest{
+				Name:    "telnet",
+				Version: "1:0.17-66.el7.x86_64",
+				Repo:    "base",
+				DryRun:  true,
 			},
+			input:    "./testdata/yum-install-dryrun.out",
+			exitCode: 1,
+			golden:   "./testdata/yum-install-dryrun.textproto",
+			wantCmd:  fmt.Sprintf("%s install-nevra --assumeno --enablerepo=base telnet-1:0.17-66.el7.x86_64", *yumBin),
 		},
 		{
-			name: "bad path",
-			generate: func(pb.PackageSystem) ([]string, error) {
-				return []string{"non-existant-binary"}, nil
+			name: "yum install - nothing to do",
+			install: &pb.InstallRequest{
+				Name:    "telnet",
+				Version: "1:0.17-66.el7.x86_64",
+				DryRun:  true,
 			},
+			input:   "/dev/null",
+			wantCmd: fmt.Sprintf("%s install-nevra --assumeno telnet-1:0.17-66.el7.x86_64", *yumBin),
 		},
 		{
-			name: "non-zero exit",
-			generate: func(pb.PackageSystem) ([]string, error) {
-				return []string{testutil.ResolvePath(t, "false")}, nil
+			name: "yum install - not resolved",
+			install: &pb.InstallRequest{
+				Name:    "telnet",
+				Version: "1:0.17-66.el7.x86_64",
+				DryRun:  true,
+			},
+			input:    "/dev/null",
+			exitCode: 1,
+			wantErr:  true,
+		},
+		{
+			name: "yum install - bad output",
+			install: &pb.InstallRequest{
+				Name:    "telnet",
+				Version: "1:0.17-66.el7.x86_64",
+				DryRun:  true,
+			},
+			input:    "./testdata/yum-dryrun-bad.out",
+			exitCode: 1,
+			wantErr:  true,
+		},
+		{
+			name: "dnf update",
+			update: &pb.UpdateRequest{
+				Name:       "bash",
+				OldVersion: "0:5.1.8-4.el9.x86_64",
+				NewVersion: "0:5.1.8-6.el9_1.x86_64",
+				DryRun:     true,
+			},
+			input:    "./testdata/dnf-update-dryrun.out",
+			exitCode: 1,
+			golden:   "./testdata/dnf-update-dryrun.textproto",
+			wantCmd:  fmt.Sprintf("%s update-to --assumeno bash-0:5.1.8-6.el9_1.x86_64", *yumBin),
+		},
+		{
+			name: "apt install",
+			install: &pb.InstallRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+				Name:          "inetutils-telnet",
+				Version:       "2:2.4-2",
+				DryRun:        true,
+			},
+			input:   "./testdata/apt-simulate.out",
+			golden:  "./testdata/apt-simulate.textproto",
+			wantCmd: fmt.Sprintf("%s install --simulate inetutils-telnet=2:2.4-2", *aptGetBin),
+		},
+		{
+			name: "apt install - failed",
+			install: &pb.InstallRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+				Name:          "inetutils-telnet",
+				Version:       "2:2.4-2",
+				DryRun:        true,
+			},
+			input:    "./testdata/apt-simulate.out",
+			exitCode: 100,
+			wantErr:  true,
+		},
+		{
+			name: "apt update",
+			update: &pb.UpdateRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+				Name:          "bash",
+				OldVersion:    "5.2.15-2",
+				NewVersion:    "5.2.15-2+b2",
+				DryRun:        true,
+			},
+			input:          "./testdata/apt-simulate.out",
+			validateOutput: "installed\t5.2.15-2",
+			golden:         "./testdata/apt-simulate.textproto",
+			wantCmd:        fmt.Sprintf("%s install --simulate --only-upgrade bash=5.2.15-2+b2", *aptGetBin),
+		},
+		{
+			name: "apt update - old version not installed",
+			update: &pb.UpdateRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+				Name:          "bash",
+				OldVersion:    "5.2.15-1",
+				NewVersion:    "5.2.15-2+b2",
+				DryRun:        true,
 			},
+			input:          "./testdata/apt-simulate.out",
+			validateOutput: "installed\t5.2.15-2",
+			wantErr:        true,
 		},
 	} {
 		tc := tc
-		saveGenerate := generateListInstalled
 		t.Run(tc.name, func(t *testing.T) {
-			generateListInstalled = tc.generate
-			resp, err = client.ListInstalled(ctx, &pb.ListInstalledRequest{})
-			testutil.FatalOnNoErr(fmt.Sprintf("%v - resp %v", tc.name, resp), err, t)
-			t.Log(err)
+			input, exitCode, validateOutput = tc.input, tc.exitCode, tc.validateOutput
+			cmdLine = ""
+			var changes []*pb.PackageChange
+			var debugOutput string
+			if tc.install != nil {
+				resp, err := client.Install(ctx, tc.install)
+				testutil.WantErr(tc.name, err, tc.wantErr, t)
+				if tc.wantErr {
+					t.Logf("%s: %v", tc.name, err)
+					return
+				}
+				changes, debugOutput = resp.PlannedChanges, resp.DebugOutput
+			} else {
+				resp, err := client.Update(ctx, tc.update)
+				testutil.WantErr(tc.name, err, tc.wantErr, t)
+				if tc.wantErr {
+					t.Logf("%s: %v", tc.name, err)
+					return
+				}
+				changes, debugOutput = resp.PlannedChanges, resp.DebugOutput
+			}
+			if got, want := cmdLine, tc.wantCmd; got != want {
+				t.Fatalf("command lines differ. Got %q Want %q", got, want)
+			}
+			wantOutput, err := os.ReadFile(tc.input)
+			testutil.FatalOnErr(fmt.Sprintf("can't read testdata input from %s", tc.input), err, t)
+			if got, want := debugOutput, string(wantOutput); got != want {
+				t.Fatalf("Output differs. Got:\n%q\nWant:\n%q", got, want)
+			}
+			// Install and update replies share the same planned changes field.
+			want := &pb.InstallReply{}
+			if tc.golden != "" {
+				golden, err := os.ReadFile(tc.golden)
+				testutil.FatalOnErr(fmt.Sprintf("can't read testdata golden from %s", tc.golden), err, t)
+				testutil.FatalOnErr("can't unmarshal test data", prototext.Unmarshal(golden, want), t)
+			}
+			testutil.DiffErr(tc.name, &pb.InstallReply{PlannedChanges: changes}, want, t)
 		})
-		generateListInstalled = saveGenerate
 	}
 }
 
-func TestRepoList(t *testing.T) {
+func TestStream(t *testing.T) {
 	var err error
 	ctx := context.Background()
 	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -571,95 +745,2229 @@ func TestRepoList(t *testing.T) {
 
 	client := pb.NewPackagesClient(conn)
 
-	// Setup for feeding in test data for further tests.
-	testdataInput := "./testdata/yum-repolist.out"
-	testdataGolden := "./testdata/yum-repolist.textproto"
-
-	savedGenerateRepoList := generateRepoList
-	var cmdLine string
-	generateRepoList = func(p pb.PackageSystem) ([]string, error) {
-		// Capture what was generated so we can validate it.
-		out, err := savedGenerateRepoList(p)
+	var cmdLine, script string
+	stream := func(out []string) []string {
+		cmdLine = strings.Join(out, " ")
+		return []string{testutil.ResolvePath(t, "sh"), "-c", script}
+	}
+	savedGenerateInstall := generateInstall
+	savedGenerateUpdate := generateUpdate
+	savedGenerateValidate := generateValidate
+	generateInstall = func(i *pb.InstallRequest) ([]string, error) {
+		out, err := savedGenerateInstall(i)
 		if err != nil {
 			return nil, err
 		}
-		cmdLine = strings.Join(out, " ")
-		return []string{testutil.ResolvePath(t, "cat"), testdataInput}, nil
+		return stream(out), nil
 	}
-	t.Cleanup(func() { generateRepoList = savedGenerateRepoList })
-
-	input, err := os.ReadFile(testdataGolden)
-	testutil.FatalOnErr(fmt.Sprintf("Can't read testdata golden %s", testdataGolden), err, t)
-
-	testdata := &pb.RepoListReply{}
-	err = prototext.Unmarshal(input, testdata)
-	testutil.FatalOnErr("can't unmarshal test data", err, t)
-
-	// Be able to sort the overall entries in a response
-	sortEntries := protocmp.SortRepeated(func(i *pb.Repo, j *pb.Repo) bool {
-		return i.Id < j.Id && i.Name < j.Name
-	})
-
-	// Test 0: Specify a bad package system and get an error.
-	resp, err := client.RepoList(ctx, &pb.RepoListRequest{
-		PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_YUM + 1,
-	})
-	testutil.FatalOnNoErr(fmt.Sprintf("bad package enum - resp %v", resp), err, t)
-	t.Log(err)
-
-	// Test 1: No options. Should pick yum w/o error and give back our list.
-
-	// This is assuming yum based installs for testing command builder.
-	wantCmdLine := fmt.Sprintf("%s repoinfo all", *yumBin)
-
-	resp, err = client.RepoList(ctx, &pb.RepoListRequest{})
-	testutil.FatalOnErr("basic repo list request", err, t)
-
-	testutil.DiffErr("no options repo list", resp, testdata, t, sortEntries)
-
-	if got, want := cmdLine, wantCmdLine; got != want {
-		t.Fatalf("command lines differ. Got %q Want %q", got, want)
+	generateUpdate = func(u *pb.UpdateRequest) ([]string, error) {
+		out, err := savedGenerateUpdate(u)
+		if err != nil {
+			return nil, err
+		}
+		return stream(out), nil
 	}
-	// Test 2: Specify yum this time.
-	resp, err = client.RepoList(ctx, &pb.RepoListRequest{
-		PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_YUM,
+	generateValidate = func(u *pb.UpdateRequest) ([]string, error) {
+		return []string{testutil.ResolvePath(t, "true")}, nil
+	}
+	t.Cleanup(func() {
+		generateInstall = savedGenerateInstall
+		generateUpdate = savedGenerateUpdate
+		generateValidate = savedGenerateValidate
 	})
-	testutil.FatalOnErr("basic repo list request", err, t)
 
-	testutil.DiffErr("repo list yum", resp, testdata, t, sortEntries)
-
-	// Test 3: Permutations of bad commands/exit codes, stderr output.
+	progress := "printf 'Downloading packages:\\ntelnet-0.17-66.el7.x86_64.rpm\\r\\n\\nComplete!'"
 	for _, tc := range []struct {
-		name     string
-		generate func(pb.PackageSystem) ([]string, error)
+		name      string
+		install   *pb.InstallRequest
+		update    *pb.UpdateRequest
+		script    string
+		wantLines []string
+		wantCmd   string
+		wantErr   bool
 	}{
 		{
-			name: "non-existant binary",
-			generate: func(pb.PackageSystem) ([]string, error) {
-				return []string{"/non-existant-binary"}, nil
+			name: "install",
+			install: &pb.InstallRequest{
+				Name:    "telnet",
+				Version: "1:0.17-66.el7.x86_64",
 			},
+			script:    progress,
+			wantLines: []string{"Downloading packages:", "telnet-0.17-66.el7.x86_64.rpm", "", "Complete!"},
+			wantCmd:   fmt.Sprintf("%s install-nevra -y telnet-1:0.17-66.el7.x86_64", *yumBin),
 		},
 		{
-			name: "bad path",
-			generate: func(pb.PackageSystem) ([]string, error) {
-				return []string{"non-existant-binary"}, nil
+			name: "install - dry run",
+			install: &pb.InstallRequest{
+				Name:    "telnet",
+				Version: "1:0.17-66.el7.x86_64",
+				DryRun:  true,
 			},
+			wantErr: true,
 		},
 		{
-			name: "non-zero exit",
-			generate: func(pb.PackageSystem) ([]string, error) {
-				return []string{testutil.ResolvePath(t, "false")}, nil
+			name: "install - bad name",
+			install: &pb.InstallRequest{
+				Name:    "-telnet",
+				Version: "1:0.17-66.el7.x86_64",
+			},
+			wantErr: true,
+		},
+		{
+			name: "install - fails",
+			install: &pb.InstallRequest{
+				Name:    "telnet",
+				Version: "1:0.17-66.el7.x86_64",
 			},
+			script:    "echo Downloading packages:; echo oops >&2; exit 1",
+			wantLines: []string{"Downloading packages:"},
+			wantErr:   true,
+		},
+		{
+			name: "update",
+			update: &pb.UpdateRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_YUM,
+				Name:          "telnet",
+				OldVersion:    "1:0.17-65.el7.x86_64",
+				NewVersion:    "1:0.17-66.el7.x86_64",
+			},
+			script:    progress,
+			wantLines: []string{"Downloading packages:", "telnet-0.17-66.el7.x86_64.rpm", "", "Complete!"},
+			wantCmd:   fmt.Sprintf("%s update-to -y telnet-1:0.17-66.el7.x86_64", *yumBin),
+		},
+		{
+			name: "update - dry run",
+			update: &pb.UpdateRequest{
+				Name:       "telnet",
+				OldVersion: "1:0.17-65.el7.x86_64",
+				NewVersion: "1:0.17-66.el7.x86_64",
+				DryRun:     true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "update - bad old version",
+			update: &pb.UpdateRequest{
+				Name:       "telnet",
+				OldVersion: "0.17",
+				NewVersion: "1:0.17-66.el7.x86_64",
+			},
+			wantErr: true,
 		},
 	} {
 		tc := tc
-		saveGenerate := generateRepoList
 		t.Run(tc.name, func(t *testing.T) {
-			generateRepoList = tc.generate
-			resp, err = client.RepoList(ctx, &pb.RepoListRequest{})
-			testutil.FatalOnNoErr(fmt.Sprintf("%v - resp %v", tc.name, resp), err, t)
-			t.Log(err)
-		})
-		generateRepoList = saveGenerate
+			script = tc.script
+			cmdLine = ""
+			var recv func() (*pb.ProgressReply, error)
+			if tc.install != nil {
+				s, err := client.InstallStream(ctx, tc.install)
+				testutil.FatalOnErr("InstallStream", err, t)
+				recv = s.Recv
+			} else {
+				s, err := client.UpdateStream(ctx, tc.update)
+				testutil.FatalOnErr("UpdateStream", err, t)
+				recv = s.Recv
+			}
+			var lines []string
+			var streamErr error
+			for {
+				resp, err := recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					streamErr = err
+					t.Logf("%s: %v", tc.name, err)
+					break
+				}
+				lines = append(lines, resp.Line)
+			}
+			testutil.WantErr(tc.name, streamErr, tc.wantErr, t)
+			if diff := cmp.Diff(tc.wantLines, lines); diff != "" {
+				t.Fatalf("lines differ (-want +got):\n%s", diff)
+			}
+			if tc.wantErr {
+				return
+			}
+			if got, want := cmdLine, tc.wantCmd; got != want {
+				t.Fatalf("command lines differ. Got %q Want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("Failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+
+	client := pb.NewPackagesClient(conn)
+
+	testdataInput := "This is output we expect to see\n\nMore output\n"
+	savedGenerateValidate := generateValidate
+	savedGenerateRemove := generateRemove
+	validateOutput := testdataInput
+	var cmdLine, validateCmdLine string
+	generateValidate = func(u *pb.UpdateRequest) ([]string, error) {
+		out, err := savedGenerateValidate(u)
+		if err != nil {
+			return nil, err
+		}
+		validateCmdLine = strings.Join(out, " ")
+		return []string{testutil.ResolvePath(t, "echo"), "-n", validateOutput}, nil
+	}
+	generateRemove = func(r *pb.RemoveRequest) ([]string, error) {
+		out, err := savedGenerateRemove(r)
+		if err != nil {
+			return nil, err
+		}
+		cmdLine = strings.Join(out, " ")
+		return []string{testutil.ResolvePath(t, "echo"), "-n", testdataInput}, nil
+	}
+	t.Cleanup(func() {
+		generateValidate = savedGenerateValidate
+		generateRemove = savedGenerateRemove
+	})
+
+	for _, tc := range []struct {
+		name            string
+		req             *pb.RemoveRequest
+		validateOutput  string
+		wantValidateCmd string
+		wantCmd         string
+		wantErr         bool
+	}{
+		{
+			name: "bad package system",
+			req: &pb.RemoveRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT + 1,
+				Name:          "package",
+				Version:       "0:1-1.2.3",
+			},
+			wantErr: true,
+		},
+		{
+			name: "no name given",
+			req: &pb.RemoveRequest{
+				Version: "0:1-1.2.3",
+			},
+			wantErr: true,
+		},
+		{
+			name: "no version given",
+			req: &pb.RemoveRequest{
+				Name: "package",
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad name - starts with a dash",
+			req: &pb.RemoveRequest{
+				Name:    "-package",
+				Version: "0:1-1.2.3",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid characters in version",
+			req: &pb.RemoveRequest{
+				Name:    "package",
+				Version: "0:1-1.2.3 && rm -rf /",
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad version - nevra",
+			req: &pb.RemoveRequest{
+				Name:    "package",
+				Version: "1.2.3",
+			},
+			wantErr: true,
+		},
+		{
+			name: "yum",
+			req: &pb.RemoveRequest{
+				Name:    "package",
+				Version: "0:1-1.2.3",
+			},
+			validateOutput:  testdataInput,
+			wantValidateCmd: fmt.Sprintf("%s list installed package-0:1-1.2.3", *yumBin),
+			wantCmd:         fmt.Sprintf("%s remove -y package-0:1-1.2.3", *yumBin),
+		},
+		{
+			name: "apt",
+			req: &pb.RemoveRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+				Name:          "package",
+				Version:       "1.2.3-1",
+			},
+			validateOutput:  "installed\t1.2.3-1\n",
+			wantValidateCmd: fmt.Sprintf("%s --show --showformat=%s package", *dpkgQueryBin, aptStatusFormat),
+			wantCmd:         fmt.Sprintf("%s remove -y package=1.2.3-1", *aptGetBin),
+		},
+		{
+			name: "apt - other version installed",
+			req: &pb.RemoveRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+				Name:          "package",
+				Version:       "1.2.3-1",
+			},
+			validateOutput:  "installed\t1.2.4-1\n",
+			wantValidateCmd: fmt.Sprintf("%s --show --showformat=%s package", *dpkgQueryBin, aptStatusFormat),
+			wantErr:         true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			validateOutput = tc.validateOutput
+			cmdLine, validateCmdLine = "", ""
+			resp, err := client.Remove(ctx, tc.req)
+			testutil.WantErr(tc.name, err, tc.wantErr, t)
+			if got, want := validateCmdLine, tc.wantValidateCmd; got != want {
+				t.Fatalf("validate command lines differ. Got %q Want %q", got, want)
+			}
+			if tc.wantErr {
+				t.Logf("%s: %v", tc.name, err)
+				return
+			}
+			if got, want := cmdLine, tc.wantCmd; got != want {
+				t.Fatalf("command lines differ. Got %q Want %q", got, want)
+			}
+			if got, want := resp.DebugOutput, testdataInput; got != want {
+				t.Fatalf("Output differs. Got:\n%q\nWant:\n%q", got, want)
+			}
+		})
+	}
+
+	// Validation or the remove itself failing is an error.
+	validateOutput = testdataInput
+	req := &pb.RemoveRequest{
+		Name:    "package",
+		Version: "0:1-1.2.3",
+	}
+	for _, tc := range []struct {
+		name     string
+		generate func(*pb.RemoveRequest) ([]string, error)
+		validate func(*pb.UpdateRequest) ([]string, error)
+	}{
+		{
+			name: "validate fails",
+			validate: func(*pb.UpdateRequest) ([]string, error) {
+				return []string{testutil.ResolvePath(t, "false")}, nil
+			},
+		},
+		{
+			name: "bad path",
+			generate: func(*pb.RemoveRequest) ([]string, error) {
+				return []string{"non-existant-binary"}, nil
+			},
+		},
+		{
+			name: "bad exit code",
+			generate: func(*pb.RemoveRequest) ([]string, error) {
+				return []string{testutil.ResolvePath(t, "false")}, nil
+			},
+		},
+	} {
+		tc := tc
+		saveGenerate := generateRemove
+		saveValidate := generateValidate
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.generate != nil {
+				generateRemove = tc.generate
+			}
+			if tc.validate != nil {
+				generateValidate = tc.validate
+			}
+			resp, err := client.Remove(ctx, req)
+			testutil.FatalOnNoErr(fmt.Sprintf("%v - resp %v", tc.name, resp), err, t)
+			t.Log(err)
+		})
+		generateRemove = saveGenerate
+		generateValidate = saveValidate
+	}
+}
+
+func TestDowngrade(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("Failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+
+	client := pb.NewPackagesClient(conn)
+
+	testdataInput := "This is output we expect to see\n\nMore output\n"
+	savedGenerateValidate := generateValidate
+	savedGenerateDowngrade := generateDowngrade
+	validateOutput := testdataInput
+	var cmdLine, validateCmdLine string
+	generateValidate = func(u *pb.UpdateRequest) ([]string, error) {
+		out, err := savedGenerateValidate(u)
+		if err != nil {
+			return nil, err
+		}
+		validateCmdLine = strings.Join(out, " ")
+		return []string{testutil.ResolvePath(t, "echo"), "-n", validateOutput}, nil
+	}
+	generateDowngrade = func(d *pb.DowngradeRequest) ([]string, error) {
+		out, err := savedGenerateDowngrade(d)
+		if err != nil {
+			return nil, err
+		}
+		cmdLine = strings.Join(out, " ")
+		return []string{testutil.ResolvePath(t, "echo"), "-n", testdataInput}, nil
+	}
+	t.Cleanup(func() {
+		generateValidate = savedGenerateValidate
+		generateDowngrade = savedGenerateDowngrade
+	})
+
+	for _, tc := range []struct {
+		name            string
+		req             *pb.DowngradeRequest
+		validateOutput  string
+		wantValidateCmd string
+		wantCmd         string
+		wantErr         bool
+	}{
+		{
+			name: "bad package system",
+			req: &pb.DowngradeRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT + 1,
+				Name:          "package",
+				OldVersion:    "0:1-4.5.6",
+				NewVersion:    "0:1-1.2.3",
+			},
+			wantErr: true,
+		},
+		{
+			name: "no old version given",
+			req: &pb.DowngradeRequest{
+				Name:       "package",
+				NewVersion: "0:1-1.2.3",
+			},
+			wantErr: true,
+		},
+		{
+			name: "no new version given",
+			req: &pb.DowngradeRequest{
+				Name:       "package",
+				OldVersion: "0:1-4.5.6",
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad new version - starts with a dash",
+			req: &pb.DowngradeRequest{
+				Name:       "package",
+				OldVersion: "0:1-4.5.6",
+				NewVersion: "-0:1-1.2.3",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid characters in repo",
+			req: &pb.DowngradeRequest{
+				Name:       "package",
+				OldVersion: "0:1-4.5.6",
+				NewVersion: "0:1-1.2.3",
+				Repo:       "base; rm -rf /",
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad old version - nevra",
+			req: &pb.DowngradeRequest{
+				Name:       "package",
+				OldVersion: "4.5.6",
+				NewVersion: "0:1-1.2.3",
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad new version - nevra",
+			req: &pb.DowngradeRequest{
+				Name:       "package",
+				OldVersion: "0:1-4.5.6",
+				NewVersion: "1.2.3",
+			},
+			wantErr: true,
+		},
+		{
+			name: "yum",
+			req: &pb.DowngradeRequest{
+				Name:       "package",
+				OldVersion: "0:1-4.5.6",
+				NewVersion: "0:1-1.2.3",
+				Repo:       "somerepo",
+			},
+			validateOutput:  testdataInput,
+			wantValidateCmd: fmt.Sprintf("%s list installed package-0:1-4.5.6", *yumBin),
+			wantCmd:         fmt.Sprintf("%s downgrade -y --enablerepo=somerepo package-0:1-1.2.3", *yumBin),
+		},
+		{
+			name: "apt",
+			req: &pb.DowngradeRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+				Name:          "package",
+				OldVersion:    "1.2.4-1+deb12u1",
+				NewVersion:    "1.2.3-1",
+				Repo:          "bookworm",
+			},
+			validateOutput:  "installed\t1.2.4-1+deb12u1\n",
+			wantValidateCmd: fmt.Sprintf("%s --show --showformat=%s package", *dpkgQueryBin, aptStatusFormat),
+			wantCmd:         fmt.Sprintf("%s install -y --allow-downgrades --target-release=bookworm package=1.2.3-1", *aptGetBin),
+		},
+		{
+			name: "apt - not installed",
+			req: &pb.DowngradeRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+				Name:          "package",
+				OldVersion:    "1.2.4-1+deb12u1",
+				NewVersion:    "1.2.3-1",
+			},
+			validateOutput:  "not-installed\t\n",
+			wantValidateCmd: fmt.Sprintf("%s --show --showformat=%s package", *dpkgQueryBin, aptStatusFormat),
+			wantErr:         true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			validateOutput = tc.validateOutput
+			cmdLine, validateCmdLine = "", ""
+			resp, err := client.Downgrade(ctx, tc.req)
+			testutil.WantErr(tc.name, err, tc.wantErr, t)
+			if got, want := validateCmdLine, tc.wantValidateCmd; got != want {
+				t.Fatalf("validate command lines differ. Got %q Want %q", got, want)
+			}
+			if tc.wantErr {
+				t.Logf("%s: %v", tc.name, err)
+				return
+			}
+			if got, want := cmdLine, tc.wantCmd; got != want {
+				t.Fatalf("command lines differ. Got %q Want %q", got, want)
+			}
+			if got, want := resp.DebugOutput, testdataInput; got != want {
+				t.Fatalf("Output differs. Got:\n%q\nWant:\n%q", got, want)
+			}
+		})
+	}
+
+	// The downgrade itself failing is an error.
+	validateOutput = testdataInput
+	generateDowngrade = func(*pb.DowngradeRequest) ([]string, error) {
+		return []string{testutil.ResolvePath(t, "false")}, nil
+	}
+	resp, err := client.Downgrade(ctx, &pb.DowngradeRequest{
+		Name:       "package",
+		OldVersion: "0:1-4.5.6",
+		NewVersion: "0:1-1.2.3",
+	})
+	testutil.FatalOnNoErr(fmt.Sprintf("bad exit code - resp %v", resp), err, t)
+	t.Log(err)
+}
+
+func TestSearch(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("Failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+
+	client := pb.NewPackagesClient(conn)
+
+	savedGenerateSearch := generateSearch
+	var cmdLine, input string
+	generateSearch = func(p *pb.SearchRequest) ([]string, error) {
+		// Capture what was generated so we can validate it.
+		out, err := savedGenerateSearch(p)
+		if err != nil {
+			return nil, err
+		}
+		cmdLine = strings.Join(out, " ")
+		return []string{testutil.ResolvePath(t, "cat"), input}, nil
+	}
+	t.Cleanup(func() { generateSearch = savedGenerateSearch })
+
+	for _, tc := range []struct {
+		name    string
+		req     *pb.SearchRequest
+		input   string
+		golden  string
+		wantCmd string
+		wantErr bool
+	}{
+		{
+			name: "bad package system",
+			req: &pb.SearchRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT + 1,
+				Name:          "bash",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "no name given",
+			req:     &pb.SearchRequest{},
+			wantErr: true,
+		},
+		{
+			name: "bad name - starts with a dash",
+			req: &pb.SearchRequest{
+				Name: "-bash",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid characters in repo",
+			req: &pb.SearchRequest{
+				Name: "bash",
+				Repo: "base && rm -rf /",
+			},
+			wantErr: true,
+		},
+		{
+			name: "yum",
+			req: &pb.SearchRequest{
+				Name: "bash",
+				Repo: "epel",
+			},
+			input:   "./testdata/yum-search.out",
+			golden:  "./testdata/yum-search.textproto",
+			wantCmd: fmt.Sprintf("%s list available --showduplicates --enablerepo=epel bash", *yumBin),
+		},
+		{
+			name: "apt",
+			req: &pb.SearchRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+				Name:          "nginx",
+			},
+			input:   "./testdata/apt-search.out",
+			golden:  "./testdata/apt-search.textproto",
+			wantCmd: fmt.Sprintf("%s madison nginx", *aptCacheBin),
+		},
+		{
+			name: "apt - bad input",
+			req: &pb.SearchRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+				Name:          "nginx",
+			},
+			input:   "./testdata/apt-search-bad.out",
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			input = tc.input
+			cmdLine = ""
+			resp, err := client.Search(ctx, tc.req)
+			testutil.WantErr(tc.name, err, tc.wantErr, t)
+			if tc.wantErr {
+				t.Logf("%s: %v", tc.name, err)
+				return
+			}
+			if got, want := cmdLine, tc.wantCmd; got != want {
+				t.Fatalf("command lines differ. Got %q Want %q", got, want)
+			}
+			golden, err := os.ReadFile(tc.golden)
+			testutil.FatalOnErr(fmt.Sprintf("can't read testdata golden from %s", tc.golden), err, t)
+			want := &pb.SearchReply{}
+			testutil.FatalOnErr("can't unmarshal test data", prototext.Unmarshal(golden, want), t)
+			testutil.DiffErr(tc.name, resp, want, t)
+		})
+	}
+
+	// A failing search is an error.
+	generateSearch = func(*pb.SearchRequest) ([]string, error) {
+		return []string{testutil.ResolvePath(t, "false")}, nil
+	}
+	resp, err := client.Search(ctx, &pb.SearchRequest{Name: "bash"})
+	testutil.FatalOnNoErr(fmt.Sprintf("non-zero exit - resp %v", resp), err, t)
+	t.Log(err)
+}
+
+// queryStub replaces a (package system, name) command generator so it captures the
+// generated command line and then cats the given file instead.
+func queryStub(t *testing.T, gen *func(pb.PackageSystem, string) ([]string, error), cmdLine *string, input *string) {
+	saved := *gen
+	*gen = func(p pb.PackageSystem, name string) ([]string, error) {
+		out, err := saved(p, name)
+		if err != nil {
+			return nil, err
+		}
+		*cmdLine = strings.Join(out, " ")
+		return []string{testutil.ResolvePath(t, "cat"), *input}, nil
+	}
+	t.Cleanup(func() { *gen = saved })
+}
+
+func TestInfo(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("Failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+
+	client := pb.NewPackagesClient(conn)
+
+	var cmdLine, input, repoCmdLine, repoInput string
+	queryStub(t, &generateInfo, &cmdLine, &input)
+	queryStub(t, &generateInfoRepo, &repoCmdLine, &repoInput)
+
+	// dpkg doesn't record install time so it comes from the file list mtime.
+	savedDpkgInfoDir := dpkgInfoDir
+	dpkgInfoDir = t.TempDir()
+	t.Cleanup(func() { dpkgInfoDir = savedDpkgInfoDir })
+	list := filepath.Join(dpkgInfoDir, "coreutils.list")
+	testutil.FatalOnErr("create list", os.WriteFile(list, nil, 0644), t)
+	installTime := time.Unix(1651234567, 0)
+	testutil.FatalOnErr("set list time", os.Chtimes(list, installTime, installTime), t)
+
+	for _, tc := range []struct {
+		name        string
+		req         *pb.InfoRequest
+		input       string
+		repoInput   string
+		golden      string
+		wantCmd     string
+		wantRepoCmd string
+		wantErr     bool
+	}{
+		{
+			name: "bad package system",
+			req: &pb.InfoRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT + 1,
+				Name:          "bash",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "no name given",
+			req:     &pb.InfoRequest{},
+			wantErr: true,
+		},
+		{
+			name: "invalid characters in name",
+			req: &pb.InfoRequest{
+				Name: "bash && rm -rf /",
+			},
+			wantErr: true,
+		},
+		{
+			name: "yum",
+			req: &pb.InfoRequest{
+				Name: "bash",
+			},
+			input:       "./testdata/rpm-info.out",
+			repoInput:   "./testdata/yum-info-installed.out",
+			golden:      "./testdata/rpm-info.textproto",
+			wantCmd:     fmt.Sprintf("%s --query --queryformat=%s bash", *rpmBin, rpmInfoFormat),
+			wantRepoCmd: fmt.Sprintf("%s list installed bash", *yumBin),
+		},
+		{
+			name: "yum - truncated",
+			req: &pb.InfoRequest{
+				Name: "bash",
+			},
+			input:     "./testdata/rpm-info-truncated.out",
+			repoInput: "./testdata/yum-info-installed.out",
+			wantErr:   true,
+		},
+		{
+			name: "yum - bad repo output",
+			req: &pb.InfoRequest{
+				Name: "bash",
+			},
+			input:     "./testdata/rpm-info.out",
+			repoInput: "./testdata/yum-installed-bad.out",
+			wantErr:   true,
+		},
+		{
+			name: "apt",
+			req: &pb.InfoRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+				Name:          "coreutils",
+			},
+			input:       "./testdata/apt-info.out",
+			repoInput:   "./testdata/apt-policy.out",
+			golden:      "./testdata/apt-info.textproto",
+			wantCmd:     fmt.Sprintf("%s --show --showformat=%s coreutils", *dpkgQueryBin, aptInfoFormat),
+			wantRepoCmd: fmt.Sprintf("%s policy libc6:amd64", *aptCacheBin),
+		},
+		{
+			name: "apt - truncated",
+			req: &pb.InfoRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+				Name:          "coreutils",
+			},
+			input:     "./testdata/apt-info-truncated.out",
+			repoInput: "./testdata/apt-policy.out",
+			wantErr:   true,
+		},
+		{
+			name: "apt - not installed",
+			req: &pb.InfoRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+				Name:          "nginx",
+			},
+			input:     "./testdata/apt-info-removed.out",
+			repoInput: "./testdata/apt-policy.out",
+			wantErr:   true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			input, repoInput = tc.input, tc.repoInput
+			cmdLine, repoCmdLine = "", ""
+			resp, err := client.Info(ctx, tc.req)
+			testutil.WantErr(tc.name, err, tc.wantErr, t)
+			if tc.wantErr {
+				t.Logf("%s: %v", tc.name, err)
+				return
+			}
+			if got, want := cmdLine, tc.wantCmd; got != want {
+				t.Fatalf("command lines differ. Got %q Want %q", got, want)
+			}
+			// For APT this is the last package looked up.
+			if got, want := repoCmdLine, tc.wantRepoCmd; got != want {
+				t.Fatalf("repo command lines differ. Got %q Want %q", got, want)
+			}
+			golden, err := os.ReadFile(tc.golden)
+			testutil.FatalOnErr(fmt.Sprintf("can't read testdata golden from %s", tc.golden), err, t)
+			want := &pb.InfoReply{}
+			testutil.FatalOnErr("can't unmarshal test data", prototext.Unmarshal(golden, want), t)
+			testutil.DiffErr(tc.name, resp, want, t)
+		})
+	}
+
+	// A failing query is an error.
+	input = "/non-existant-file"
+	resp, err := client.Info(ctx, &pb.InfoRequest{Name: "bash"})
+	testutil.FatalOnNoErr(fmt.Sprintf("non-zero exit - resp %v", resp), err, t)
+	t.Log(err)
+}
+
+func TestFiles(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("Failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+
+	client := pb.NewPackagesClient(conn)
+
+	var cmdLine, input string
+	queryStub(t, &generateFiles, &cmdLine, &input)
+
+	for _, tc := range []struct {
+		name    string
+		req     *pb.FilesRequest
+		input   string
+		want    []string
+		wantCmd string
+		wantErr bool
+	}{
+		{
+			name: "bad package system",
+			req: &pb.FilesRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT + 1,
+				Name:          "bash",
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad name - starts with a dash",
+			req: &pb.FilesRequest{
+				Name: "-bash",
+			},
+			wantErr: true,
+		},
+		{
+			name: "yum",
+			req: &pb.FilesRequest{
+				Name: "bash",
+			},
+			input: "./testdata/rpm-files.out",
+			want: []string{
+				"/etc/skel/.bash_logout",
+				"/etc/skel/.bash_profile",
+				"/etc/skel/.bashrc",
+				"/usr/bin/bash",
+				"/usr/bin/sh",
+			},
+			wantCmd: fmt.Sprintf("%s --query --list bash", *rpmBin),
+		},
+		{
+			name: "apt",
+			req: &pb.FilesRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+				Name:          "bash",
+			},
+			input: "./testdata/dpkg-files.out",
+			want: []string{
+				"/bin",
+				"/bin/sh",
+				"/usr",
+				"/usr/bin",
+				"/usr/bin/bash",
+			},
+			wantCmd: fmt.Sprintf("%s --listfiles bash", *dpkgQueryBin),
+		},
+		{
+			name: "failed query",
+			req: &pb.FilesRequest{
+				Name: "bash",
+			},
+			input:   "/non-existant-file",
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			input = tc.input
+			cmdLine = ""
+			resp, err := client.Files(ctx, tc.req)
+			testutil.WantErr(tc.name, err, tc.wantErr, t)
+			if tc.wantErr {
+				t.Logf("%s: %v", tc.name, err)
+				return
+			}
+			if got, want := cmdLine, tc.wantCmd; got != want {
+				t.Fatalf("command lines differ. Got %q Want %q", got, want)
+			}
+			testutil.DiffErr(tc.name, resp, &pb.FilesReply{Files: tc.want}, t)
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("Failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+
+	client := pb.NewPackagesClient(conn)
+
+	var cmdLine, input string
+	queryStub(t, &generateVerify, &cmdLine, &input)
+
+	for _, tc := range []struct {
+		name    string
+		req     *pb.VerifyRequest
+		input   string
+		golden  string
+		wantCmd string
+		wantErr bool
+	}{
+		{
+			name: "bad package system",
+			req: &pb.VerifyRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT + 1,
+				Name:          "bash",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "no name given",
+			req:     &pb.VerifyRequest{},
+			wantErr: true,
+		},
+		{
+			name: "yum",
+			req: &pb.VerifyRequest{
+				Name: "bash",
+			},
+			input:   "./testdata/rpm-verify.out",
+			golden:  "./testdata/rpm-verify.textproto",
+			wantCmd: fmt.Sprintf("%s --verify --nodeps bash", *rpmBin),
+		},
+		{
+			name: "yum - not installed",
+			req: &pb.VerifyRequest{
+				Name: "bash",
+			},
+			input:   "./testdata/rpm-verify-bad.out",
+			wantErr: true,
+		},
+		{
+			name: "apt",
+			req: &pb.VerifyRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+				Name:          "base-files",
+			},
+			input:   "./testdata/dpkg-verify.out",
+			golden:  "./testdata/dpkg-verify.textproto",
+			wantCmd: fmt.Sprintf("%s --verify base-files", *dpkgBin),
+		},
+		{
+			name: "apt - clean",
+			req: &pb.VerifyRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+				Name:          "base-files",
+			},
+			input:   "/dev/null",
+			wantCmd: fmt.Sprintf("%s --verify base-files", *dpkgBin),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			input = tc.input
+			cmdLine = ""
+			resp, err := client.Verify(ctx, tc.req)
+			testutil.WantErr(tc.name, err, tc.wantErr, t)
+			if tc.wantErr {
+				t.Logf("%s: %v", tc.name, err)
+				return
+			}
+			if got, want := cmdLine, tc.wantCmd; got != want {
+				t.Fatalf("command lines differ. Got %q Want %q", got, want)
+			}
+			want := &pb.VerifyReply{}
+			if tc.golden != "" {
+				golden, err := os.ReadFile(tc.golden)
+				testutil.FatalOnErr(fmt.Sprintf("can't read testdata golden from %s", tc.golden), err, t)
+				testutil.FatalOnErr("can't unmarshal test data", prototext.Unmarshal(golden, want), t)
+			}
+			testutil.DiffErr(tc.name, resp, want, t)
+		})
+	}
+
+	// Exiting 1 is how rpm and dpkg report discrepancies but anything else is an error.
+	savedGenerateVerify := generateVerify
+	t.Cleanup(func() { generateVerify = savedGenerateVerify })
+	for _, tc := range []struct {
+		name    string
+		command []string
+		wantErr bool
+	}{
+		{
+			name:    "exit 1",
+			command: []string{testutil.ResolvePath(t, "false")},
+		},
+		{
+			name:    "exit 1 with stderr",
+			command: []string{testutil.ResolvePath(t, "sh"), "-c", "echo oops >&2; exit 1"},
+			wantErr: true,
+		},
+		{
+			name:    "exit 2",
+			command: []string{testutil.ResolvePath(t, "sh"), "-c", "exit 2"},
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			generateVerify = func(pb.PackageSystem, string) ([]string, error) {
+				return tc.command, nil
+			}
+			_, err := client.Verify(ctx, &pb.VerifyRequest{Name: "bash"})
+			testutil.WantErr(tc.name, err, tc.wantErr, t)
+		})
+	}
+}
+
+func TestHistory(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("Failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+
+	client := pb.NewPackagesClient(conn)
+
+	// Info for each transaction is read from <infoPrefix>-<id>.out
+	var cmdLine, input, infoPrefix string
+	var infoCmdLines []string
+	savedGenerateHistoryList := generateHistoryList
+	savedGenerateHistoryInfo := generateHistoryInfo
+	generateHistoryList = func(p pb.PackageSystem) ([]string, error) {
+		out, err := savedGenerateHistoryList(p)
+		if err != nil {
+			return nil, err
+		}
+		cmdLine = strings.Join(out, " ")
+		return []string{testutil.ResolvePath(t, "cat"), input}, nil
+	}
+	generateHistoryInfo = func(p pb.PackageSystem, id int64) ([]string, error) {
+		out, err := savedGenerateHistoryInfo(p, id)
+		if err != nil {
+			return nil, err
+		}
+		infoCmdLines = append(infoCmdLines, strings.Join(out, " "))
+		return []string{testutil.ResolvePath(t, "cat"), fmt.Sprintf("%s-%d.out", infoPrefix, id)}, nil
+	}
+	t.Cleanup(func() {
+		generateHistoryList = savedGenerateHistoryList
+		generateHistoryInfo = savedGenerateHistoryInfo
+	})
+
+	// History times are in the host's local zone.
+	at := func(year int, month time.Month, day, hour, min int) *timestamppb.Timestamp {
+		return timestamppb.New(time.Date(year, month, day, hour, min, 0, 0, time.Local))
+	}
+	yum14 := &pb.Transaction{
+		Id:          14,
+		Time:        at(2022, time.May, 2, 9, 41),
+		User:        "admin <admin>",
+		CommandLine: "remove -y telnet-1:0.17-66.el7.x86_64",
+		Actions:     []string{"Erase"},
+		Packages: []*pb.TransactionPackage{
+			{Name: "telnet-1:0.17-66.el7.x86_64", Action: "Erase", Repo: "@base"},
+		},
+	}
+	yum13 := &pb.Transaction{
+		Id:          13,
+		Time:        at(2022, time.April, 28, 17, 3),
+		User:        "root <root>",
+		CommandLine: "install -y telnet-1:0.17-66.el7.x86_64 bash-4.2.46-35.el7_9.x86_64",
+		Actions:     []string{"Install", "Update"},
+		Packages: []*pb.TransactionPackage{
+			{Name: "telnet-1:0.17-66.el7.x86_64", Action: "Install", Repo: "@base"},
+			{Name: "bash-4.2.46-34.el7.x86_64", Action: "Updated", Repo: "@anaconda"},
+			{Name: "bash-4.2.46-35.el7_9.x86_64", Action: "Update", Repo: "@updates"},
+			{Name: "xinetd-2:2.3.15-14.el7.x86_64", Action: "Install", Repo: "@base"},
+		},
+	}
+	yum12 := &pb.Transaction{
+		Id:          12,
+		Time:        at(2022, time.April, 1, 10, 15),
+		User:        "root <root>",
+		CommandLine: "update-to -y openssl-1:1.0.2k-25.el7_9.x86_64",
+		Actions:     []string{"Update"},
+		Packages: []*pb.TransactionPackage{
+			{Name: "openssl-1:1.0.2k-24.el7_9.x86_64", Action: "Updated", Repo: "@updates"},
+			{Name: "openssl-1:1.0.2k-25.el7_9.x86_64", Action: "Update", Repo: "@updates"},
+		},
+	}
+
+	for _, tc := range []struct {
+		name         string
+		req          *pb.HistoryRequest
+		input        string
+		infoPrefix   string
+		want         *pb.HistoryReply
+		wantCmd      string
+		wantInfoCmds []string
+		wantErr      bool
+	}{
+		{
+			name: "bad package system",
+			req: &pb.HistoryRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT + 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "apt unsupported",
+			req: &pb.HistoryRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+			},
+			wantErr: true,
+		},
+		{
+			name:       "yum",
+			req:        &pb.HistoryRequest{},
+			input:      "./testdata/yum-history-list.out",
+			infoPrefix: "./testdata/yum-history-info",
+			want: &pb.HistoryReply{
+				Transactions: []*pb.Transaction{yum14, yum13, yum12},
+			},
+			wantCmd: fmt.Sprintf("%s history list all", *yumBin),
+			wantInfoCmds: []string{
+				fmt.Sprintf("%s history info 14", *yumBin),
+				fmt.Sprintf("%s history info 13", *yumBin),
+				fmt.Sprintf("%s history info 12", *yumBin),
+			},
+		},
+		{
+			name: "yum - limit",
+			req: &pb.HistoryRequest{
+				Limit: 2,
+			},
+			input:      "./testdata/yum-history-list.out",
+			infoPrefix: "./testdata/yum-history-info",
+			want: &pb.HistoryReply{
+				Transactions: []*pb.Transaction{yum14, yum13},
+			},
+			wantCmd: fmt.Sprintf("%s history list all", *yumBin),
+			wantInfoCmds: []string{
+				fmt.Sprintf("%s history info 14", *yumBin),
+				fmt.Sprintf("%s history info 13", *yumBin),
+			},
+		},
+		{
+			name:       "dnf",
+			req:        &pb.HistoryRequest{},
+			input:      "./testdata/dnf-history-list.out",
+			infoPrefix: "./testdata/dnf-history-info",
+			want: &pb.HistoryReply{
+				Transactions: []*pb.Transaction{
+					{
+						Id:          5,
+						Time:        at(2022, time.October, 12, 10, 0),
+						User:        "root <root>",
+						CommandLine: "update bash",
+						Actions:     []string{"Upgrade"},
+						Packages: []*pb.TransactionPackage{
+							{Name: "bash-5.1.8-6.el9_1.x86_64", Action: "Upgrade", Repo: "@baseos"},
+							{Name: "bash-5.1.8-4.el9.x86_64", Action: "Upgraded", Repo: "@@System"},
+						},
+					},
+					{
+						Id:          4,
+						Time:        at(2022, time.October, 11, 8, 30),
+						User:        "root <root>",
+						CommandLine: "install -y nginx",
+						Actions:     []string{"Install"},
+						Packages: []*pb.TransactionPackage{
+							{Name: "nginx-1:1.20.1-13.el9.x86_64", Action: "Install", Repo: "@appstream"},
+						},
+					},
+				},
+			},
+			wantCmd: fmt.Sprintf("%s history list all", *yumBin),
+			wantInfoCmds: []string{
+				fmt.Sprintf("%s history info 5", *yumBin),
+				fmt.Sprintf("%s history info 4", *yumBin),
+			},
+		},
+		{
+			name:    "no history",
+			req:     &pb.HistoryRequest{},
+			input:   "/dev/null",
+			want:    &pb.HistoryReply{},
+			wantCmd: fmt.Sprintf("%s history list all", *yumBin),
+		},
+		{
+			name:    "bad list",
+			req:     &pb.HistoryRequest{},
+			input:   "./testdata/yum-history-list-bad.out",
+			wantErr: true,
+		},
+		{
+			name: "info for the wrong transaction",
+			req: &pb.HistoryRequest{
+				Limit: 1,
+			},
+			input:      "./testdata/yum-history-list.out",
+			infoPrefix: "./testdata/yum-history-wrong",
+			wantErr:    true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			input, infoPrefix = tc.input, tc.infoPrefix
+			cmdLine, infoCmdLines = "", nil
+			resp, err := client.History(ctx, tc.req)
+			testutil.WantErr(tc.name, err, tc.wantErr, t)
+			if tc.wantErr {
+				t.Logf("%s: %v", tc.name, err)
+				return
+			}
+			if got, want := cmdLine, tc.wantCmd; got != want {
+				t.Fatalf("command lines differ. Got %q Want %q", got, want)
+			}
+			if diff := cmp.Diff(tc.wantInfoCmds, infoCmdLines); diff != "" {
+				t.Fatalf("info command lines differ (-want +got):\n%s", diff)
+			}
+			testutil.DiffErr(tc.name, resp, tc.want, t)
+		})
+	}
+}
+
+func TestUndo(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("Failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+
+	client := pb.NewPackagesClient(conn)
+
+	testdataInput := "This is output we expect to see\n\nMore output\n"
+	savedGenerateUndo := generateUndo
+	var cmdLine string
+	generateUndo = func(p pb.PackageSystem, id int64) ([]string, error) {
+		out, err := savedGenerateUndo(p, id)
+		if err != nil {
+			return nil, err
+		}
+		cmdLine = strings.Join(out, " ")
+		return []string{testutil.ResolvePath(t, "echo"), "-n", testdataInput}, nil
+	}
+	t.Cleanup(func() { generateUndo = savedGenerateUndo })
+
+	for _, tc := range []struct {
+		name    string
+		req     *pb.UndoRequest
+		wantCmd string
+		wantErr bool
+	}{
+		{
+			name: "bad package system",
+			req: &pb.UndoRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT + 1,
+				TransactionId: 12,
+			},
+			wantErr: true,
+		},
+		{
+			name: "apt unsupported",
+			req: &pb.UndoRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+				TransactionId: 12,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "no transaction given",
+			req:     &pb.UndoRequest{},
+			wantErr: true,
+		},
+		{
+			name: "negative transaction",
+			req: &pb.UndoRequest{
+				TransactionId: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "yum",
+			req: &pb.UndoRequest{
+				TransactionId: 12,
+			},
+			wantCmd: fmt.Sprintf("%s history undo -y 12", *yumBin),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cmdLine = ""
+			resp, err := client.Undo(ctx, tc.req)
+			testutil.WantErr(tc.name, err, tc.wantErr, t)
+			if tc.wantErr {
+				t.Logf("%s: %v", tc.name, err)
+				return
+			}
+			if got, want := cmdLine, tc.wantCmd; got != want {
+				t.Fatalf("command lines differ. Got %q Want %q", got, want)
+			}
+			if got, want := resp.DebugOutput, testdataInput; got != want {
+				t.Fatalf("Output differs. Got:\n%q\nWant:\n%q", got, want)
+			}
+		})
+	}
+
+	// The undo itself failing is an error.
+	req := &pb.UndoRequest{
+		TransactionId: 12,
+	}
+	for _, tc := range []struct {
+		name     string
+		generate func(pb.PackageSystem, int64) ([]string, error)
+	}{
+		{
+			name: "bad path",
+			generate: func(pb.PackageSystem, int64) ([]string, error) {
+				return []string{"non-existant-binary"}, nil
+			},
+		},
+		{
+			name: "bad exit code",
+			generate: func(pb.PackageSystem, int64) ([]string, error) {
+				return []string{testutil.ResolvePath(t, "false")}, nil
+			},
+		},
+	} {
+		tc := tc
+		saveGenerate := generateUndo
+		t.Run(tc.name, func(t *testing.T) {
+			generateUndo = tc.generate
+			resp, err := client.Undo(ctx, req)
+			testutil.FatalOnNoErr(fmt.Sprintf("%v - resp %v", tc.name, resp), err, t)
+			t.Log(err)
+		})
+		generateUndo = saveGenerate
+	}
+}
+
+func TestListInstalled(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("Failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+
+	client := pb.NewPackagesClient(conn)
+
+	// Setup for feeding in test data for further tests.
+	testdataInput := "./testdata/yum-installed.out"
+	testdataInputBad := "./testdata/yum-installed-bad.out"
+	testdataInputBad2 := "./testdata/yum-installed-bad2.out"
+	testdataInputBad3 := "./testdata/yum-installed-bad3.out"
+	testdataGolden := "./testdata/yum-installed.textproto"
+	testdataAptInput := "./testdata/apt-installed.out"
+	testdataAptInputBad := "./testdata/apt-installed-bad.out"
+	testdataAptGolden := "./testdata/apt-installed.textproto"
+
+	savedGenerateListInstalled := generateListInstalled
+	var cmdLine string
+	generateListInstalled = func(p pb.PackageSystem) ([]string, error) {
+		// Capture what was generated so we can validate it.
+		out, err := savedGenerateListInstalled(p)
+		if err != nil {
+			return nil, err
+		}
+		cmdLine = strings.Join(out, " ")
+		return []string{testutil.ResolvePath(t, "cat"), testdataInput}, nil
+	}
+	t.Cleanup(func() {
+		generateListInstalled = savedGenerateListInstalled
+	})
+
+	input, err := os.ReadFile(testdataGolden)
+	testutil.FatalOnErr(fmt.Sprintf("can't read testdata golden from %s", testdataGolden), err, t)
+
+	testdata := &pb.ListInstalledReply{}
+	err = prototext.Unmarshal(input, testdata)
+	testutil.FatalOnErr("Can't unmarshall test data", err, t)
+
+	// Be able to sort the overall entries in a response
+	sortEntries := protocmp.SortRepeated(func(i *pb.PackageInfo, j *pb.PackageInfo) bool {
+		return i.Name < j.Name && i.Version < j.Version
+	})
+
+	// Test 0: Specify a bad package system and get an error.
+	resp, err := client.ListInstalled(ctx, &pb.ListInstalledRequest{
+		PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT + 1,
+	})
+	if err == nil {
+		t.Fatalf("didn't get an error as expected for a bad package enum. Instead got %+v", resp)
+	}
+	t.Log(err)
+
+	// Test 1: No options. Should pick yum w/o error and give back our list.
+
+	// This is assuming yum based installs for testing command builder.
+	wantCmdLine := fmt.Sprintf("%s list installed", *yumBin)
+
+	resp, err = client.ListInstalled(ctx, &pb.ListInstalledRequest{})
+	testutil.FatalOnErr("basic package list request", err, t)
+
+	testutil.DiffErr("basic package list request", resp, testdata, t, sortEntries)
+
+	if got, want := cmdLine, wantCmdLine; got != want {
+		t.Fatalf("command lines differ. Got %q Want %q", got, want)
+	}
+
+	// Test 2: Specify yum this time.
+	resp, err = client.ListInstalled(ctx, &pb.ListInstalledRequest{
+		PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_YUM,
+	})
+	testutil.FatalOnErr("basic package list request", err, t)
+
+	testutil.DiffErr("basic package list request yum", resp, testdata, t, sortEntries)
+
+	// Test 3: Now try with bad input. Should error out.
+	for _, b := range []string{testdataInputBad, testdataInputBad2, testdataInputBad3} {
+		generateListInstalled = func(pb.PackageSystem) ([]string, error) {
+			return []string{testutil.ResolvePath(t, "cat"), b}, nil
+		}
+		resp, err = client.ListInstalled(ctx, &pb.ListInstalledRequest{
+			PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_YUM,
+		})
+		testutil.FatalOnNoErr(fmt.Sprintf("bad input - resp %v", resp), err, t)
+		t.Log(err)
+	}
+
+	// Test 4: APT parses dpkg-query output and skips anything not fully installed.
+	aptInput, err := os.ReadFile(testdataAptGolden)
+	testutil.FatalOnErr(fmt.Sprintf("can't read testdata golden from %s", testdataAptGolden), err, t)
+	aptTestdata := &pb.ListInstalledReply{}
+	err = prototext.Unmarshal(aptInput, aptTestdata)
+	testutil.FatalOnErr("Can't unmarshall test data", err, t)
+
+	generateListInstalled = func(p pb.PackageSystem) ([]string, error) {
+		out, err := savedGenerateListInstalled(p)
+		if err != nil {
+			return nil, err
+		}
+		cmdLine = strings.Join(out, " ")
+		return []string{testutil.ResolvePath(t, "cat"), testdataAptInput}, nil
+	}
+	resp, err = client.ListInstalled(ctx, &pb.ListInstalledRequest{
+		PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+	})
+	testutil.FatalOnErr("apt package list request", err, t)
+	testutil.DiffErr("apt package list request", resp, aptTestdata, t, sortEntries)
+	if got, want := cmdLine, fmt.Sprintf("%s --show --showformat=%s", *dpkgQueryBin, aptInstalledFormat); got != want {
+		t.Fatalf("command lines differ. Got %q Want %q", got, want)
+	}
+
+	generateListInstalled = func(pb.PackageSystem) ([]string, error) {
+		return []string{testutil.ResolvePath(t, "cat"), testdataAptInputBad}, nil
+	}
+	resp, err = client.ListInstalled(ctx, &pb.ListInstalledRequest{
+		PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+	})
+	testutil.FatalOnNoErr(fmt.Sprintf("bad apt input - resp %v", resp), err, t)
+	t.Log(err)
+
+	// Test 5: Permutations of bad commands/exit codes, stderr output.
+	for _, tc := range []struct {
+		name     string
+		generate func(pb.PackageSystem) ([]string, error)
+	}{
+		{
+			name: "non-existant binary",
+			generate: func(pb.PackageSystem) ([]string, error) {
+				return []string{"/non-existant-binary"}, nil
+			},
+		},
+		{
+			name: "bad path",
+			generate: func(pb.PackageSystem) ([]string, error) {
+				return []string{"non-existant-binary"}, nil
+			},
+		},
+		{
+			name: "non-zero exit",
+			generate: func(pb.PackageSystem) ([]string, error) {
+				return []string{testutil.ResolvePath(t, "false")}, nil
+			},
+		},
+	} {
+		tc := tc
+		saveGenerate := generateListInstalled
+		t.Run(tc.name, func(t *testing.T) {
+			generateListInstalled = tc.generate
+			resp, err = client.ListInstalled(ctx, &pb.ListInstalledRequest{})
+			testutil.FatalOnNoErr(fmt.Sprintf("%v - resp %v", tc.name, resp), err, t)
+			t.Log(err)
+		})
+		generateListInstalled = saveGenerate
+	}
+}
+
+func TestRepoList(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("Failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+
+	client := pb.NewPackagesClient(conn)
+
+	// Setup for feeding in test data for further tests.
+	testdataInput := "./testdata/yum-repolist.out"
+	testdataGolden := "./testdata/yum-repolist.textproto"
+	testdataAptSourcesList := "testdata/apt-sources.list"
+	testdataAptSourcesDir := "testdata/apt-sources.list.d"
+	testdataAptSourcesBad := "testdata/apt-sources-bad.list"
+	testdataAptGolden := "./testdata/apt-repolist.textproto"
+
+	savedGenerateRepoList := generateRepoList
+	var cmdLine string
+	generateRepoList = func(p pb.PackageSystem) ([]string, error) {
+		// Capture what was generated so we can validate it.
+		out, err := savedGenerateRepoList(p)
+		if err != nil {
+			return nil, err
+		}
+		cmdLine = strings.Join(out, " ")
+		return []string{testutil.ResolvePath(t, "cat"), testdataInput}, nil
+	}
+	t.Cleanup(func() { generateRepoList = savedGenerateRepoList })
+
+	input, err := os.ReadFile(testdataGolden)
+	testutil.FatalOnErr(fmt.Sprintf("Can't read testdata golden %s", testdataGolden), err, t)
+
+	testdata := &pb.RepoListReply{}
+	err = prototext.Unmarshal(input, testdata)
+	testutil.FatalOnErr("can't unmarshal test data", err, t)
+
+	// Be able to sort the overall entries in a response
+	sortEntries := protocmp.SortRepeated(func(i *pb.Repo, j *pb.Repo) bool {
+		return i.Id < j.Id && i.Name < j.Name
+	})
+
+	// Test 0: Specify a bad package system and get an error.
+	resp, err := client.RepoList(ctx, &pb.RepoListRequest{
+		PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT + 1,
+	})
+	testutil.FatalOnNoErr(fmt.Sprintf("bad package enum - resp %v", resp), err, t)
+	t.Log(err)
+
+	// Test 1: No options. Should pick yum w/o error and give back our list.
+
+	// This is assuming yum based installs for testing command builder.
+	wantCmdLine := fmt.Sprintf("%s repoinfo all", *yumBin)
+
+	resp, err = client.RepoList(ctx, &pb.RepoListRequest{})
+	testutil.FatalOnErr("basic repo list request", err, t)
+
+	testutil.DiffErr("no options repo list", resp, testdata, t, sortEntries)
+
+	if got, want := cmdLine, wantCmdLine; got != want {
+		t.Fatalf("command lines differ. Got %q Want %q", got, want)
+	}
+	// Test 2: Specify yum this time.
+	resp, err = client.RepoList(ctx, &pb.RepoListRequest{
+		PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_YUM,
+	})
+	testutil.FatalOnErr("basic repo list request", err, t)
+
+	testutil.DiffErr("repo list yum", resp, testdata, t, sortEntries)
+
+	// Test 3: APT reads the sources files directly.
+	savedSourcesList, savedSourcesDir := aptSourcesList, aptSourcesDir
+	t.Cleanup(func() { aptSourcesList, aptSourcesDir = savedSourcesList, savedSourcesDir })
+	aptSourcesList, aptSourcesDir = testdataAptSourcesList, testdataAptSourcesDir
+
+	input, err = os.ReadFile(testdataAptGolden)
+	testutil.FatalOnErr(fmt.Sprintf("Can't read testdata golden %s", testdataAptGolden), err, t)
+	aptTestdata := &pb.RepoListReply{}
+	err = prototext.Unmarshal(input, aptTestdata)
+	testutil.FatalOnErr("can't unmarshal test data", err, t)
+
+	resp, err = client.RepoList(ctx, &pb.RepoListRequest{
+		PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+	})
+	testutil.FatalOnErr("apt repo list request", err, t)
+	testutil.DiffErr("repo list apt", resp, aptTestdata, t)
+
+	// Missing files are fine but broken ones aren't.
+	for _, tc := range []struct {
+		name    string
+		list    string
+		dir     string
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "no sources.list",
+			list: "/non-existant-file",
+			dir:  testdataAptSourcesDir,
+			want: 6,
+		},
+		{
+			name: "no sources.list.d",
+			list: testdataAptSourcesList,
+			dir:  "/non-existant-dir",
+			want: 5,
+		},
+		{
+			name:    "bad sources.list",
+			list:    testdataAptSourcesBad,
+			dir:     "/non-existant-dir",
+			wantErr: true,
+		},
+		{
+			name:    "sources.list is a directory",
+			list:    testdataAptSourcesDir,
+			dir:     "/non-existant-dir",
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			aptSourcesList, aptSourcesDir = tc.list, tc.dir
+			resp, err := client.RepoList(ctx, &pb.RepoListRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+			})
+			testutil.WantErr(tc.name, err, tc.wantErr, t)
+			if tc.wantErr {
+				return
+			}
+			if got, want := len(resp.Repos), tc.want; got != want {
+				t.Fatalf("%s: got %d repos, want %d: %v", tc.name, got, want, resp)
+			}
+		})
+	}
+
+	// Test 4: Permutations of bad commands/exit codes, stderr output.
+	for _, tc := range []struct {
+		name     string
+		generate func(pb.PackageSystem) ([]string, error)
+	}{
+		{
+			name: "non-existant binary",
+			generate: func(pb.PackageSystem) ([]string, error) {
+				return []string{"/non-existant-binary"}, nil
+			},
+		},
+		{
+			name: "bad path",
+			generate: func(pb.PackageSystem) ([]string, error) {
+				return []string{"non-existant-binary"}, nil
+			},
+		},
+		{
+			name: "non-zero exit",
+			generate: func(pb.PackageSystem) ([]string, error) {
+				return []string{testutil.ResolvePath(t, "false")}, nil
+			},
+		},
+	} {
+		tc := tc
+		saveGenerate := generateRepoList
+		t.Run(tc.name, func(t *testing.T) {
+			generateRepoList = tc.generate
+			resp, err = client.RepoList(ctx, &pb.RepoListRequest{})
+			testutil.FatalOnNoErr(fmt.Sprintf("%v - resp %v", tc.name, resp), err, t)
+			t.Log(err)
+		})
+		generateRepoList = saveGenerate
+	}
+}
+
+// repoDirs points the YUM and APT repo directories at new temporary ones.
+func repoDirs(t *testing.T) (string, string) {
+	savedYumReposDir, savedAptSourcesDir := yumReposDir, aptSourcesDir
+	yumReposDir, aptSourcesDir = t.TempDir(), t.TempDir()
+	t.Cleanup(func() {
+		yumReposDir, aptSourcesDir = savedYumReposDir, savedAptSourcesDir
+	})
+	return yumReposDir, aptSourcesDir
+}
+
+// copyFile copies testdata into dir under the given name.
+func copyFile(t *testing.T, src string, dir string, name string) {
+	contents, err := os.ReadFile(src)
+	testutil.FatalOnErr(fmt.Sprintf("can't read %s", src), err, t)
+	testutil.FatalOnErr(fmt.Sprintf("can't write %s", name), os.WriteFile(filepath.Join(dir, name), contents, 0644), t)
+}
+
+// compareFile checks the contents of got match the golden file want.
+func compareFile(t *testing.T, got string, want string) {
+	gotContents, err := os.ReadFile(got)
+	testutil.FatalOnErr(fmt.Sprintf("can't read %s", got), err, t)
+	wantContents, err := os.ReadFile(want)
+	testutil.FatalOnErr(fmt.Sprintf("can't read %s", want), err, t)
+	if diff := cmp.Diff(string(wantContents), string(gotContents)); diff != "" {
+		t.Fatalf("%s differs from %s (-want +got):\n%s", got, want, diff)
+	}
+}
+
+func TestRepoAdd(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("Failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+
+	client := pb.NewPackagesClient(conn)
+
+	yumReq := func() *pb.RepoAddRequest {
+		return &pb.RepoAddRequest{
+			Id:       "internal-tools",
+			Name:     "Internal tools",
+			Baseurl:  "https://repo.example.com/tools/el7/$basearch/",
+			Gpgcheck: true,
+			Gpgkey:   "https://repo.example.com/RPM-GPG-KEY-tools",
+		}
+	}
+	aptReq := func() *pb.RepoAddRequest {
+		return &pb.RepoAddRequest{
+			PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+			Id:            "internal-tools",
+			Name:          "Internal tools",
+			Baseurl:       "https://repo.example.com/tools/debian",
+			Gpgcheck:      true,
+			Gpgkey:        "/etc/apt/keyrings/tools.gpg",
+			Suite:         "bookworm",
+			Components:    []string{"main", "contrib"},
+		}
+	}
+	with := func(req *pb.RepoAddRequest, f func(*pb.RepoAddRequest)) *pb.RepoAddRequest {
+		f(req)
+		return req
+	}
+
+	for _, tc := range []struct {
+		name     string
+		req      *pb.RepoAddRequest
+		existing map[string]string
+		golden   string
+		wantFile string
+		wantErr  bool
+	}{
+		{
+			name:     "yum",
+			req:      yumReq(),
+			golden:   "./testdata/yum-repo-add.repo",
+			wantFile: "internal-tools.repo",
+		},
+		{
+			name:     "apt",
+			req:      aptReq(),
+			golden:   "./testdata/apt-repo-add.sources",
+			wantFile: "internal-tools.sources",
+		},
+		{
+			name: "yum - unsigned",
+			req: with(yumReq(), func(r *pb.RepoAddRequest) {
+				r.Gpgcheck = false
+				r.Gpgkey = ""
+				r.AllowUnsigned = true
+			}),
+			golden:   "./testdata/yum-repo-add-unsigned.repo",
+			wantFile: "internal-tools.repo",
+		},
+		{
+			name: "apt - unsigned",
+			req: with(aptReq(), func(r *pb.RepoAddRequest) {
+				r.Gpgcheck = false
+				r.Gpgkey = ""
+				r.AllowUnsigned = true
+			}),
+			golden:   "./testdata/apt-repo-add-unsigned.sources",
+			wantFile: "internal-tools.sources",
+		},
+		{
+			name: "bad package system",
+			req: with(yumReq(), func(r *pb.RepoAddRequest) {
+				r.PackageSystem = pb.PackageSystem_PACKAGE_SYSTEM_APT + 1
+			}),
+			wantErr: true,
+		},
+		{
+			name:    "no id",
+			req:     with(yumReq(), func(r *pb.RepoAddRequest) { r.Id = "" }),
+			wantErr: true,
+		},
+		{
+			name:    "id with a path",
+			req:     with(yumReq(), func(r *pb.RepoAddRequest) { r.Id = "../../etc/passwd" }),
+			wantErr: true,
+		},
+		{
+			name:    "name with a newline",
+			req:     with(yumReq(), func(r *pb.RepoAddRequest) { r.Name = "tools\n[evil]" }),
+			wantErr: true,
+		},
+		{
+			name:    "no baseurl",
+			req:     with(yumReq(), func(r *pb.RepoAddRequest) { r.Baseurl = "" }),
+			wantErr: true,
+		},
+		{
+			name:    "bad baseurl scheme",
+			req:     with(yumReq(), func(r *pb.RepoAddRequest) { r.Baseurl = "gopher://repo.example.com/" }),
+			wantErr: true,
+		},
+		{
+			name:    "baseurl with a space",
+			req:     with(yumReq(), func(r *pb.RepoAddRequest) { r.Baseurl = "https://repo.example.com/ enabled=0" }),
+			wantErr: true,
+		},
+		{
+			name:    "yum - gpgkey not a url",
+			req:     with(yumReq(), func(r *pb.RepoAddRequest) { r.Gpgkey = "/etc/pki/key" }),
+			wantErr: true,
+		},
+		{
+			name:    "yum - suite given",
+			req:     with(yumReq(), func(r *pb.RepoAddRequest) { r.Suite = "bookworm" }),
+			wantErr: true,
+		},
+		{
+			name:     "yum - already defined",
+			req:      with(yumReq(), func(r *pb.RepoAddRequest) { r.Id = "updates" }),
+			existing: map[string]string{"CentOS-Base.repo": "./testdata/yum-repo-base.repo"},
+			wantErr:  true,
+		},
+		{
+			name:     "yum - file exists",
+			req:      yumReq(),
+			existing: map[string]string{"internal-tools.repo": "./testdata/yum-repo-base.repo"},
+			wantErr:  true,
+		},
+		{
+			name:    "apt - no suite",
+			req:     with(aptReq(), func(r *pb.RepoAddRequest) { r.Suite = "" }),
+			wantErr: true,
+		},
+		{
+			name:    "apt - bad component",
+			req:     with(aptReq(), func(r *pb.RepoAddRequest) { r.Components = []string{"main", "-x"} }),
+			wantErr: true,
+		},
+		{
+			name:    "apt - relative gpgkey",
+			req:     with(aptReq(), func(r *pb.RepoAddRequest) { r.Gpgkey = "tools.gpg" }),
+			wantErr: true,
+		},
+		{
+			name:    "apt - gpgcheck disabled without allow_unsigned",
+			req:     with(aptReq(), func(r *pb.RepoAddRequest) { r.Gpgcheck = false }),
+			wantErr: true,
+		},
+		{
+			name:    "yum - gpgcheck disabled without allow_unsigned",
+			req:     with(yumReq(), func(r *pb.RepoAddRequest) { r.Gpgcheck = false }),
+			wantErr: true,
+		},
+		{
+			name:     "apt - list file exists",
+			req:      aptReq(),
+			existing: map[string]string{"internal-tools.list": "./testdata/apt-sources.list"},
+			wantErr:  true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			yumDir, aptDir := repoDirs(t)
+			dir := yumDir
+			if tc.req.PackageSystem == pb.PackageSystem_PACKAGE_SYSTEM_APT {
+				dir = aptDir
+			}
+			for name, src := range tc.existing {
+				copyFile(t, src, dir, name)
+			}
+			resp, err := client.RepoAdd(ctx, tc.req)
+			testutil.WantErr(tc.name, err, tc.wantErr, t)
+			if tc.wantErr {
+				t.Logf("%s: %v", tc.name, err)
+				return
+			}
+			if got, want := resp.Filename, filepath.Join(dir, tc.wantFile); got != want {
+				t.Fatalf("filenames differ. Got %q Want %q", got, want)
+			}
+			compareFile(t, resp.Filename, tc.golden)
+			// Nothing else (i.e. temp files) should be left behind.
+			entries, err := os.ReadDir(dir)
+			testutil.FatalOnErr("ReadDir", err, t)
+			if len(entries) != 1 {
+				t.Fatalf("unexpected files in %s: %v", dir, entries)
+			}
+		})
+	}
+
+	// The added APT repo is reported by RepoList.
+	_, aptDir := repoDirs(t)
+	resp, err := client.RepoAdd(ctx, aptReq())
+	testutil.FatalOnErr("apt RepoAdd", err, t)
+	repos, err := readAptSources(filepath.Join(aptDir, "sources.list"), aptDir)
+	testutil.FatalOnErr("readAptSources", err, t)
+	testutil.DiffErr("RepoList after RepoAdd", repos, &pb.RepoListReply{
+		Repos: []*pb.Repo{
+			{
+				Id:       "bookworm",
+				Name:     "deb https://repo.example.com/tools/debian bookworm main contrib",
+				Status:   pb.RepoStatus_REPO_STATUS_ENABLED,
+				Filename: resp.Filename,
+				Url:      "https://repo.example.com/tools/debian",
+			},
+		},
+	}, t)
+}
+
+func TestRepoRemove(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("Failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+
+	client := pb.NewPackagesClient(conn)
+
+	for _, tc := range []struct {
+		name     string
+		req      *pb.RepoRemoveRequest
+		existing string
+		src      string
+		wantErr  bool
+	}{
+		{
+			name: "yum",
+			req: &pb.RepoRemoveRequest{
+				Id: "internal-tools",
+			},
+			existing: "internal-tools.repo",
+			src:      "./testdata/yum-repo-add.repo",
+		},
+		{
+			name: "apt",
+			req: &pb.RepoRemoveRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+				Id:            "internal-tools",
+			},
+			existing: "internal-tools.sources",
+			src:      "./testdata/apt-repo-add.sources",
+		},
+		{
+			name: "bad package system",
+			req: &pb.RepoRemoveRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT + 1,
+				Id:            "internal-tools",
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad id",
+			req: &pb.RepoRemoveRequest{
+				Id: "../internal-tools",
+			},
+			wantErr: true,
+		},
+		{
+			name: "not added with RepoAdd",
+			req: &pb.RepoRemoveRequest{
+				Id: "updates",
+			},
+			existing: "CentOS-Base.repo",
+			src:      "./testdata/yum-repo-base.repo",
+			wantErr:  true,
+		},
+		{
+			name: "yum - distro file",
+			req: &pb.RepoRemoveRequest{
+				Id: "CentOS-Base",
+			},
+			existing: "CentOS-Base.repo",
+			src:      "./testdata/yum-repo-base.repo",
+			wantErr:  true,
+		},
+		{
+			name: "yum - other sections",
+			req: &pb.RepoRemoveRequest{
+				Id: "internal-tools",
+			},
+			existing: "internal-tools.repo",
+			src:      "./testdata/yum-repo-add-extra.repo",
+			wantErr:  true,
+		},
+		{
+			name: "apt - distro file",
+			req: &pb.RepoRemoveRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+				Id:            "ubuntu",
+			},
+			existing: "ubuntu.sources",
+			src:      "./testdata/apt-repo-enabled.sources",
+			wantErr:  true,
+		},
+		{
+			name: "apt - other stanzas",
+			req: &pb.RepoRemoveRequest{
+				PackageSystem: pb.PackageSystem_PACKAGE_SYSTEM_APT,
+				Id:            "internal-tools",
+			},
+			existing: "internal-tools.sources",
+			src:      "./testdata/apt-repo-add-extra.sources",
+			wantErr:  true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			yumDir, aptDir := repoDirs(t)
+			dir := yumDir
+			if tc.req.PackageSystem == pb.PackageSystem_PACKAGE_SYSTEM_APT {
+				dir = aptDir
+			}
+			if tc.existing != "" {
+				copyFile(t, tc.src, dir, tc.existing)
+			}
+			resp, err := client.RepoRemove(ctx, tc.req)
+			testutil.WantErr(tc.name, err, tc.wantErr, t)
+			if tc.wantErr {
+				t.Logf("%s: %v", tc.name, err)
+				if tc.existing != "" {
+					// Files which can't be removed are left alone.
+					if _, err := os.Stat(filepath.Join(dir, tc.existing)); err != nil {
+						t.Fatalf("%s: %v", tc.existing, err)
+					}
+				}
+				return
+			}
+			if got, want := resp.Filename, filepath.Join(dir, tc.existing); got != want {
+				t.Fatalf("filenames differ. Got %q Want %q", got, want)
+			}
+			if _, err := os.Stat(resp.Filename); !os.IsNotExist(err) {
+				t.Fatalf("%s still exists: %v", resp.Filename, err)
+			}
+		})
+	}
+}
+
+func TestRepoEnable(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("Failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+
+	client := pb.NewPackagesClient(conn)
+
+	for _, tc := range []struct {
+		name     string
+		ps       pb.PackageSystem
+		id       string
+		enable   bool
+		existing map[string]string
+		wantFile string
+		golden   string
+		wantErr  bool
+	}{
+		{
+			name: "yum - disable",
+			id:   "updates",
+			existing: map[string]string{
+				"CentOS-Base.repo": "./testdata/yum-repo-base.repo",
+				"tools.repo":       "./testdata/yum-repo-add.repo",
+			},
+			wantFile: "CentOS-Base.repo",
+			golden:   "./testdata/yum-repo-disabled.repo",
+		},
+		{
+			name:   "yum - enable",
+			id:     "updates",
+			enable: true,
+			existing: map[string]string{
+				"CentOS-Base.repo": "./testdata/yum-repo-disabled.repo",
+			},
+			wantFile: "CentOS-Base.repo",
+			golden:   "./testdata/yum-repo-base-enabled.repo",
+		},
+		{
+			name: "yum - not found",
+			id:   "epel",
+			existing: map[string]string{
+				"CentOS-Base.repo": "./testdata/yum-repo-base.repo",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "bad id",
+			id:      "updates]",
+			wantErr: true,
+		},
+		{
+			name:    "bad package system",
+			ps:      pb.PackageSystem_PACKAGE_SYSTEM_APT + 1,
+			id:      "updates",
+			wantErr: true,
+		},
+		{
+			name:   "apt - enable",
+			ps:     pb.PackageSystem_PACKAGE_SYSTEM_APT,
+			id:     "tools",
+			enable: true,
+			existing: map[string]string{
+				"tools.sources": "./testdata/apt-repo-disabled.sources",
+			},
+			wantFile: "tools.sources",
+			golden:   "./testdata/apt-repo-enabled.sources",
+		},
+		{
+			name: "apt - not a sources file",
+			ps:   pb.PackageSystem_PACKAGE_SYSTEM_APT,
+			id:   "docker",
+			existing: map[string]string{
+				"docker.list": "./testdata/apt-sources.list.d/docker.list",
+			},
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			yumDir, aptDir := repoDirs(t)
+			dir := yumDir
+			if tc.ps == pb.PackageSystem_PACKAGE_SYSTEM_APT {
+				dir = aptDir
+			}
+			for name, src := range tc.existing {
+				copyFile(t, src, dir, name)
+			}
+			var filename string
+			if tc.enable {
+				resp, err := client.RepoEnable(ctx, &pb.RepoEnableRequest{PackageSystem: tc.ps, Id: tc.id})
+				testutil.WantErr(tc.name, err, tc.wantErr, t)
+				if tc.wantErr {
+					t.Logf("%s: %v", tc.name, err)
+					return
+				}
+				filename = resp.Filename
+			} else {
+				resp, err := client.RepoDisable(ctx, &pb.RepoDisableRequest{PackageSystem: tc.ps, Id: tc.id})
+				testutil.WantErr(tc.name, err, tc.wantErr, t)
+				if tc.wantErr {
+					t.Logf("%s: %v", tc.name, err)
+					return
+				}
+				filename = resp.Filename
+			}
+			if got, want := filename, filepath.Join(dir, tc.wantFile); got != want {
+				t.Fatalf("filenames differ. Got %q Want %q", got, want)
+			}
+			compareFile(t, filename, tc.golden)
+		})
+	}
+}
+
+func TestDetectPackageSystem(t *testing.T) {
+	savedYum, savedAptGet := *yumBin, *aptGetBin
+	t.Cleanup(func() { *yumBin, *aptGetBin = savedYum, savedAptGet })
+
+	// TestMain replaced the real one so get it back for this.
+	detect := detectPackageSystem
+	detectPackageSystem = savedDetectPackageSystem
+	t.Cleanup(func() { detectPackageSystem = detect })
+
+	dir := t.TempDir()
+	present := filepath.Join(dir, "present")
+	testutil.FatalOnErr("create binary", os.WriteFile(present, nil, 0755), t)
+	missing := filepath.Join(dir, "missing")
+
+	for _, tc := range []struct {
+		name   string
+		yum    string
+		aptGet string
+		want   pb.PackageSystem
+		// Whether the package-manager health check should fail.
+		wantCheckErr bool
+	}{
+		{
+			name:   "yum",
+			yum:    present,
+			aptGet: missing,
+			want:   pb.PackageSystem_PACKAGE_SYSTEM_YUM,
+		},
+		{
+			name:   "apt",
+			yum:    missing,
+			aptGet: present,
+			want:   pb.PackageSystem_PACKAGE_SYSTEM_APT,
+		},
+		{
+			name:   "both prefers yum",
+			yum:    present,
+			aptGet: present,
+			want:   pb.PackageSystem_PACKAGE_SYSTEM_YUM,
+		},
+		{
+			name:         "neither falls back to yum",
+			yum:          missing,
+			aptGet:       missing,
+			want:         pb.PackageSystem_PACKAGE_SYSTEM_YUM,
+			wantCheckErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			*yumBin, *aptGetBin = tc.yum, tc.aptGet
+			if got, want := pickPackageSystem(pb.PackageSystem_PACKAGE_SYSTEM_UNKNOWN), tc.want; got != want {
+				t.Fatalf("got %v want %v", got, want)
+			}
+			// An explicit choice is always honored.
+			if got, want := pickPackageSystem(pb.PackageSystem_PACKAGE_SYSTEM_APT), pb.PackageSystem_PACKAGE_SYSTEM_APT; got != want {
+				t.Fatalf("explicit: got %v want %v", got, want)
+			}
+			testutil.WantErr("package-manager check", checkPackageManager(context.Background()), tc.wantCheckErr, t)
+		})
 	}
 }
@@ -0,0 +1,426 @@
+/* Copyright (c) 2019 Snowflake Inc. All rights reserved.
+
+   Licensed under the Apache License, Version 2.0 (the
+   "License"); you may not use this file except in compliance
+   with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing,
+   software distributed under the License is distributed on an
+   "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+   KIND, either express or implied.  See the License for the
+   specific language governing permissions and limitations
+   under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	pb "github.com/Snowflake-Labs/sansshell/services/packages"
+	"github.com/Snowflake-Labs/sansshell/services/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// This is a var for testing to be able to replace it.
+	yumReposDir = "/etc/yum.repos.d"
+
+	// Repo ids are also file names so they're kept to a conservative set.
+	repoIDRe = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+	// RepoAdd starts every file it writes with this so RepoRemove only
+	// removes those files and never ones the distribution provides.
+	repoFileMarker = "# Added by sansshell RepoAdd\n"
+
+	// The URL schemes both yum and apt can fetch from without extra plugins.
+	repoURLSchemes = map[string]bool{
+		"http":  true,
+		"https": true,
+		"ftp":   true,
+		"file":  true,
+	}
+)
+
+func validateRepoID(id string) error {
+	if !repoIDRe.MatchString(id) {
+		return status.Errorf(codes.InvalidArgument, "repo id %q invalid. Must start with a letter or number and contain only [a-zA-Z0-9_.-]", id)
+	}
+	return nil
+}
+
+func validateRepoURL(param string, u string) error {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "%s %q invalid: %v", param, u, err)
+	}
+	if !repoURLSchemes[parsed.Scheme] || strings.IndexFunc(u, unicode.IsSpace) >= 0 {
+		return status.Errorf(codes.InvalidArgument, "%s %q invalid. Must be an http, https, ftp or file URL", param, u)
+	}
+	return nil
+}
+
+// validateRepoAdd checks every field which will be written into the repo file
+// can't break out of its line.
+func validateRepoAdd(req *pb.RepoAddRequest) error {
+	if err := validateRepoID(req.Id); err != nil {
+		return err
+	}
+	if strings.IndexFunc(req.Name, unicode.IsControl) >= 0 {
+		return status.Errorf(codes.InvalidArgument, "repo name %q invalid. Cannot contain control characters", req.Name)
+	}
+	if err := validateRepoURL("baseurl", req.Baseurl); err != nil {
+		return err
+	}
+	// Without signature checks whoever can tamper with the repo can run code
+	// as root, so that must be asked for explicitly where policy can see it.
+	if !req.Gpgcheck && !req.AllowUnsigned {
+		return status.Errorf(codes.InvalidArgument, "gpgcheck can't be disabled unless allow_unsigned is set")
+	}
+	if req.PackageSystem == pb.PackageSystem_PACKAGE_SYSTEM_APT {
+		if req.Gpgkey != "" {
+			if err := util.ValidPath(req.Gpgkey); err != nil {
+				return err
+			}
+			if strings.IndexFunc(req.Gpgkey, unicode.IsSpace) >= 0 {
+				return status.Errorf(codes.InvalidArgument, "gpgkey %q invalid. Cannot contain spaces", req.Gpgkey)
+			}
+		}
+		if err := validateField("suite", req.Suite); err != nil {
+			return err
+		}
+		for _, c := range req.Components {
+			if err := validateField("component", c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if req.Suite != "" || len(req.Components) > 0 {
+		return status.Errorf(codes.InvalidArgument, "suite and components are only supported for APT")
+	}
+	if req.Gpgkey != "" {
+		return validateRepoURL("gpgkey", req.Gpgkey)
+	}
+	return nil
+}
+
+// renderYumRepo returns the contents of a .repo file for req.
+func renderYumRepo(req *pb.RepoAddRequest) string {
+	name := req.Name
+	if name == "" {
+		name = req.Id
+	}
+	enabled, gpgcheck := 1, 0
+	if req.Disabled {
+		enabled = 0
+	}
+	if req.Gpgcheck {
+		gpgcheck = 1
+	}
+	var b strings.Builder
+	b.WriteString(repoFileMarker)
+	fmt.Fprintf(&b, "[%s]\n", req.Id)
+	fmt.Fprintf(&b, "name=%s\n", name)
+	fmt.Fprintf(&b, "baseurl=%s\n", req.Baseurl)
+	fmt.Fprintf(&b, "enabled=%d\n", enabled)
+	fmt.Fprintf(&b, "gpgcheck=%d\n", gpgcheck)
+	if req.Gpgkey != "" {
+		fmt.Fprintf(&b, "gpgkey=%s\n", req.Gpgkey)
+	}
+	return b.String()
+}
+
+// renderAptSources returns the contents of a deb822 style .sources file for req.
+func renderAptSources(req *pb.RepoAddRequest) string {
+	var b strings.Builder
+	b.WriteString(repoFileMarker)
+	if req.Name != "" {
+		fmt.Fprintf(&b, "X-Repolib-Name: %s\n", req.Name)
+	}
+	b.WriteString("Types: deb\n")
+	fmt.Fprintf(&b, "URIs: %s\n", req.Baseurl)
+	fmt.Fprintf(&b, "Suites: %s\n", req.Suite)
+	if len(req.Components) > 0 {
+		fmt.Fprintf(&b, "Components: %s\n", strings.Join(req.Components, " "))
+	}
+	fmt.Fprintf(&b, "Enabled: %s\n", yesNo(!req.Disabled))
+	if req.Gpgkey != "" {
+		fmt.Fprintf(&b, "Signed-By: %s\n", req.Gpgkey)
+	}
+	if !req.Gpgcheck && req.AllowUnsigned {
+		b.WriteString("Trusted: yes\n")
+	}
+	return b.String()
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// repoFile returns the file a repo added with the given id lives in.
+func repoFile(p pb.PackageSystem, id string) (string, error) {
+	switch p {
+	case pb.PackageSystem_PACKAGE_SYSTEM_YUM:
+		return filepath.Join(yumReposDir, id+".repo"), nil
+	case pb.PackageSystem_PACKAGE_SYSTEM_APT:
+		return filepath.Join(aptSourcesDir, id+".sources"), nil
+	}
+	return "", status.Errorf(codes.Unimplemented, "no support for package system enum %d", p)
+}
+
+// writeRepoFile replaces filename with contents. It's written to a temporary file
+// first (which the package managers ignore) so they never see a partial file.
+func writeRepoFile(filename string, contents string) error {
+	f, err := os.CreateTemp(filepath.Dir(filename), ".sansshell-repo-")
+	if err != nil {
+		return status.Errorf(codes.Internal, "can't create temp file for %s: %v", filename, err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(contents); err != nil {
+		f.Close()
+		return status.Errorf(codes.Internal, "can't write %s: %v", f.Name(), err)
+	}
+	if err := f.Chmod(0644); err != nil {
+		f.Close()
+		return status.Errorf(codes.Internal, "can't chmod %s: %v", f.Name(), err)
+	}
+	if err := f.Close(); err != nil {
+		return status.Errorf(codes.Internal, "can't close %s: %v", f.Name(), err)
+	}
+	if err := os.Rename(f.Name(), filename); err != nil {
+		return status.Errorf(codes.Internal, "can't rename %s to %s: %v", f.Name(), filename, err)
+	}
+	return nil
+}
+
+func (s *server) RepoAdd(ctx context.Context, req *pb.RepoAddRequest) (*pb.RepoAddReply, error) {
+	req.PackageSystem = pickPackageSystem(req.PackageSystem)
+	filename, err := repoFile(req.PackageSystem, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRepoAdd(req); err != nil {
+		return nil, err
+	}
+
+	var contents string
+	switch req.PackageSystem {
+	case pb.PackageSystem_PACKAGE_SYSTEM_YUM:
+		if _, err := findYumRepo(req.Id); err == nil {
+			return nil, status.Errorf(codes.AlreadyExists, "repo %s is already defined", req.Id)
+		}
+		contents = renderYumRepo(req)
+	case pb.PackageSystem_PACKAGE_SYSTEM_APT:
+		// A one line style file for the same id would be confusing.
+		if _, err := os.Stat(filepath.Join(aptSourcesDir, req.Id+".list")); err == nil {
+			return nil, status.Errorf(codes.AlreadyExists, "repo %s is already defined in %s.list", req.Id, req.Id)
+		}
+		contents = renderAptSources(req)
+	}
+	if _, err := os.Stat(filename); err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "%s already exists", filename)
+	}
+	if err := writeRepoFile(filename, contents); err != nil {
+		return nil, err
+	}
+	return &pb.RepoAddReply{Filename: filename}, nil
+}
+
+func (s *server) RepoRemove(ctx context.Context, req *pb.RepoRemoveRequest) (*pb.RepoRemoveReply, error) {
+	if err := validateRepoID(req.Id); err != nil {
+		return nil, err
+	}
+	req.PackageSystem = pickPackageSystem(req.PackageSystem)
+	filename, err := repoFile(req.PackageSystem, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, status.Errorf(codes.NotFound, "%s doesn't exist. Only repos added with RepoAdd can be removed", filename)
+		}
+		return nil, status.Errorf(codes.Internal, "can't read %s: %v", filename, err)
+	}
+	if !addedByRepoAdd(req.PackageSystem, req.Id, string(contents)) {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s wasn't added by RepoAdd for repo %s (or defines other repos) so can't be removed", filename, req.Id)
+	}
+	if err := os.Remove(filename); err != nil {
+		return nil, status.Errorf(codes.Internal, "can't remove %s: %v", filename, err)
+	}
+	return &pb.RepoRemoveReply{Filename: filename}, nil
+}
+
+// addedByRepoAdd returns true if contents is a repo file RepoAdd wrote for
+// id. That is it starts with repoFileMarker and, for YUM, has no sections
+// other than [id] or, for APT, has a single stanza.
+func addedByRepoAdd(p pb.PackageSystem, id string, contents string) bool {
+	if !strings.HasPrefix(contents, repoFileMarker) {
+		return false
+	}
+	stanzas, inStanza := 0, false
+	for _, line := range strings.Split(contents, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case p == pb.PackageSystem_PACKAGE_SYSTEM_YUM:
+			if strings.HasPrefix(trimmed, "[") && trimmed != "["+id+"]" {
+				return false
+			}
+		case trimmed == "":
+			inStanza = false
+		case !inStanza && !strings.HasPrefix(trimmed, "#"):
+			inStanza = true
+			stanzas++
+		}
+	}
+	return p == pb.PackageSystem_PACKAGE_SYSTEM_YUM || stanzas == 1
+}
+
+func (s *server) RepoEnable(ctx context.Context, req *pb.RepoEnableRequest) (*pb.RepoEnableReply, error) {
+	filename, err := setRepoEnabled(req.PackageSystem, req.Id, true)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RepoEnableReply{Filename: filename}, nil
+}
+
+func (s *server) RepoDisable(ctx context.Context, req *pb.RepoDisableRequest) (*pb.RepoDisableReply, error) {
+	filename, err := setRepoEnabled(req.PackageSystem, req.Id, false)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RepoDisableReply{Filename: filename}, nil
+}
+
+// setRepoEnabled rewrites the file defining repo id so it's enabled (or not)
+// and returns the file name.
+func setRepoEnabled(p pb.PackageSystem, id string, enabled bool) (string, error) {
+	if err := validateRepoID(id); err != nil {
+		return "", err
+	}
+	p = pickPackageSystem(p)
+	var filename string
+	var edit func(string) (string, bool)
+	switch p {
+	case pb.PackageSystem_PACKAGE_SYSTEM_YUM:
+		f, err := findYumRepo(id)
+		if err != nil {
+			return "", err
+		}
+		filename = f
+		edit = func(contents string) (string, bool) { return setYumRepoEnabled(contents, id, enabled) }
+	case pb.PackageSystem_PACKAGE_SYSTEM_APT:
+		filename = filepath.Join(aptSourcesDir, id+".sources")
+		edit = func(contents string) (string, bool) { return setAptSourcesEnabled(contents, enabled), true }
+	default:
+		return "", status.Errorf(codes.Unimplemented, "no support for package system enum %d", p)
+	}
+
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", status.Errorf(codes.NotFound, "repo %s not found: %s doesn't exist", id, filename)
+		}
+		return "", status.Errorf(codes.Internal, "can't read %s: %v", filename, err)
+	}
+	out, ok := edit(string(contents))
+	if !ok {
+		return "", status.Errorf(codes.NotFound, "repo %s not found in %s", id, filename)
+	}
+	if err := writeRepoFile(filename, out); err != nil {
+		return "", err
+	}
+	return filename, nil
+}
+
+// findYumRepo returns the .repo file in the repos directory which defines id.
+func findYumRepo(id string) (string, error) {
+	files, err := filepath.Glob(filepath.Join(yumReposDir, "*.repo"))
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "can't list %s: %v", yumReposDir, err)
+	}
+	sort.Strings(files)
+	for _, f := range files {
+		contents, err := os.ReadFile(f)
+		if err != nil {
+			return "", status.Errorf(codes.Internal, "can't read %s: %v", f, err)
+		}
+		if _, ok := setYumRepoEnabled(string(contents), id, true); ok {
+			return f, nil
+		}
+	}
+	return "", status.Errorf(codes.NotFound, "repo %s isn't defined in %s", id, yumReposDir)
+}
+
+// setYumRepoEnabled sets enabled in the [id] section of an ini style .repo file.
+// Any existing enabled lines are replaced with one directly after the section header.
+// It returns false if the section doesn't exist.
+func setYumRepoEnabled(contents string, id string, enabled bool) (string, bool) {
+	value := 0
+	if enabled {
+		value = 1
+	}
+	var out []string
+	found, inSection := false, false
+	for _, line := range strings.SplitAfter(contents, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			inSection = trimmed == "["+id+"]"
+			if inSection {
+				found = true
+				out = append(out, strings.TrimSuffix(line, "\n")+"\n", fmt.Sprintf("enabled=%d\n", value))
+				continue
+			}
+		}
+		if k, _, ok := strings.Cut(trimmed, "="); inSection && ok && strings.TrimSpace(k) == "enabled" {
+			continue
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, ""), found
+}
+
+// setAptSourcesEnabled sets the Enabled field of every stanza in a deb822 style
+// .sources file. Any existing Enabled field is replaced with one at the end of the stanza.
+func setAptSourcesEnabled(contents string, enabled bool) string {
+	var out []string
+	inStanza := false
+	finish := func() {
+		if inStanza {
+			out = append(out, fmt.Sprintf("Enabled: %s", yesNo(enabled)))
+		}
+		inStanza = false
+	}
+	for _, line := range strings.Split(strings.TrimRight(contents, "\n"), "\n") {
+		switch {
+		case strings.TrimSpace(line) == "":
+			finish()
+		case strings.HasPrefix(line, "#"), line[0] == ' ' || line[0] == '\t':
+		default:
+			inStanza = true
+			if k, _, _ := strings.Cut(line, ":"); strings.EqualFold(strings.TrimSpace(k), "enabled") {
+				continue
+			}
+		}
+		out = append(out, line)
+	}
+	finish()
+	return strings.Join(out, "\n") + "\n"
+}
@@ -0,0 +1,136 @@
+/* Copyright (c) 2019 Snowflake Inc. All rights reserved.
+
+   Licensed under the Apache License, Version 2.0 (the
+   "License"); you may not use this file except in compliance
+   with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing,
+   software distributed under the License is distributed on an
+   "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+   KIND, either express or implied.  See the License for the
+   specific language governing permissions and limitations
+   under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/Snowflake-Labs/sansshell/services/packages"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// rpmInfoEnd terminates each package in the output of rpmInfoFormat.
+// The description is free form text so it can't be delimited any other way.
+const rpmInfoEnd = "-- end of package --"
+
+// rpmInfoFormat is the rpm --queryformat used for package info.
+// rpm repeats the bracketed section once per dependency.
+const rpmInfoFormat = `Name: %{NAME}\n` +
+	`Version: %{EPOCHNUM}:%{VERSION}-%{RELEASE}\n` +
+	`Architecture: %{ARCH}\n` +
+	`Size: %{SIZE}\n` +
+	`Install-Time: %{INSTALLTIME}\n` +
+	`Summary: %{SUMMARY}\n` +
+	`[Requires: %{REQUIRENAME} %{REQUIREFLAGS:depflags} %{REQUIREVERSION}\n]` +
+	`Description: %{DESCRIPTION}\n` +
+	rpmInfoEnd + `\n`
+
+// parseRpmInfoOutput parses the output of rpm -q with rpmInfoFormat.
+func parseRpmInfoOutput(r io.Reader) ([]*pb.PackageDetails, error) {
+	scanner := bufio.NewScanner(r)
+
+	var out []*pb.PackageDetails
+	pkg := &pb.PackageDetails{}
+	inDescription := false
+	var description []string
+	for scanner.Scan() {
+		text := scanner.Text()
+		if text == rpmInfoEnd {
+			if pkg.Name == "" {
+				return nil, status.Errorf(codes.Internal, "package entry without a name")
+			}
+			pkg.Description = strings.Join(description, "\n")
+			out = append(out, pkg)
+			pkg, inDescription, description = &pb.PackageDetails{}, false, nil
+			continue
+		}
+		if inDescription {
+			description = append(description, text)
+			continue
+		}
+		k, v, ok := strings.Cut(text, ": ")
+		if !ok {
+			return nil, status.Errorf(codes.Internal, "invalid input line %q", text)
+		}
+		switch k {
+		case "Name":
+			pkg.Name = v
+		case "Version":
+			pkg.Version = v
+		case "Architecture":
+			pkg.Architecture = v
+		case "Size":
+			size, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "invalid size %q: %v", v, err)
+			}
+			pkg.Size = size
+		case "Install-Time":
+			secs, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "invalid install time %q: %v", v, err)
+			}
+			pkg.InstallTime = timestamppb.New(time.Unix(secs, 0))
+		case "Summary":
+			pkg.Summary = v
+		case "Requires":
+			// Internal rpm features aren't real dependencies.
+			if strings.HasPrefix(v, "rpmlib(") {
+				continue
+			}
+			pkg.Dependencies = append(pkg.Dependencies, strings.Join(strings.Fields(v), " "))
+		case "Description":
+			inDescription = true
+			description = append(description, v)
+		default:
+			return nil, status.Errorf(codes.Internal, "unknown field in input line %q", text)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "parsing error:\n%v", err)
+	}
+	if pkg.Name != "" || inDescription {
+		return nil, status.Errorf(codes.Internal, "truncated package entry for %q", pkg.Name)
+	}
+	return out, nil
+}
+
+// yumRepoForDetails finds the repo yum recorded for each package from
+// the output of yum list installed.
+func yumRepoForDetails(pkgs []*pb.PackageDetails, r io.Reader) error {
+	installed, err := parseYumListOutput("Installed Packages", r)
+	if err != nil {
+		return err
+	}
+	for _, pkg := range pkgs {
+		// yum lists name.arch and leaves off a zero epoch.
+		name, version := pkg.Name+"."+pkg.Architecture, strings.TrimPrefix(pkg.Version, "0:")
+		for _, i := range installed {
+			if i.Name == name && i.Version == version {
+				pkg.Repo = i.Repo
+				break
+			}
+		}
+	}
+	return nil
+}
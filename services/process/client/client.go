@@ -25,8 +25,10 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/google/subcommands"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/Snowflake-Labs/sansshell/client"
 	pb "github.com/Snowflake-Labs/sansshell/services/process"
@@ -42,9 +44,11 @@ func init() {
 func setup(f *flag.FlagSet) *subcommands.Commander {
 	c := client.SetupSubpackage(subPackage, f)
 	c.Register(&dumpCmd{}, "")
+	c.Register(&inspectCmd{}, "")
 	c.Register(&jstackCmd{}, "")
 	c.Register(&psCmd{}, "")
 	c.Register(&pstackCmd{}, "")
+	c.Register(&topCmd{}, "")
 	return c
 }
 
@@ -446,3 +450,203 @@ func (p *dumpCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interfac
 	}
 	return retCode
 }
+
+type topCmd struct {
+	pids         util.IntSliceFlags
+	commandRegex string
+	interval     time.Duration
+	count        int64
+	limit        int
+}
+
+func (*topCmd) Name() string     { return "top" }
+func (*topCmd) Synopsis() string { return "Monitor process resource usage." }
+func (*topCmd) Usage() string {
+	return `top [--pids=X,Y] [--command-regex=RE] [--interval=1s] [--count=N] [--limit=N]:
+  Sample process resource usage on the remote machine at the given interval and display
+  the processes using the most CPU. Note that --timeout may need to be raised in order
+  to gather more samples.
+`
+}
+
+func (p *topCmd) SetFlags(f *flag.FlagSet) {
+	f.Var(&p.pids, "pids", "Restrict to only pids listed (separated by comma)")
+	f.StringVar(&p.commandRegex, "command-regex", "", "Restrict to processes whose command line matches this regular expression")
+	f.DurationVar(&p.interval, "interval", time.Second, "How often to take a sample")
+	f.Int64Var(&p.count, "count", 0, "Number of samples to take. If 0 will run until interrupted or --timeout expires")
+	f.IntVar(&p.limit, "limit", 20, "Maximum number of processes to display per sample. If 0 all processes are displayed")
+}
+
+func (p *topCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	state := args[0].(*util.ExecuteState)
+	c := pb.NewProcessClientProxy(state.Conn)
+
+	req := &pb.MonitorRequest{
+		CommandRegex: p.commandRegex,
+		Interval:     durationpb.New(p.interval),
+		Count:        p.count,
+	}
+	for _, pid := range p.pids {
+		req.Pids = append(req.Pids, pid)
+	}
+
+	stream, err := c.MonitorOneMany(ctx, req)
+	if err != nil {
+		// Emit this to every error file as it's not specific to a given target.
+		for _, e := range state.Err {
+			fmt.Fprintf(e, "Monitor returned error: %v\n", err)
+		}
+		return subcommands.ExitFailure
+	}
+
+	retCode := subcommands.ExitSuccess
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		// If the stream returns an error we're just done.
+		if err != nil {
+			// Emit this to every error file as it's not specific to a given target.
+			for _, e := range state.Err {
+				fmt.Fprintf(e, "Receive error: %v\n", err)
+			}
+			retCode = subcommands.ExitFailure
+			break
+		}
+		for _, r := range resp {
+			if r.Error != nil && r.Error != io.EOF {
+				fmt.Fprintf(state.Err[r.Index], "Error for target %s (%d): %v\n", r.Target, r.Index, r.Error)
+				retCode = subcommands.ExitFailure
+				continue
+			}
+			if r.Resp == nil {
+				continue
+			}
+			outputTopEntry(r.Target, r.Index, r.Resp, p.limit, state.Out[r.Index])
+		}
+	}
+	return retCode
+}
+
+func outputTopEntry(target string, index int, resp *pb.MonitorReply, limit int, out io.Writer) {
+	samples := resp.Samples
+	// Busiest first and then by pid so output is stable.
+	sort.Slice(samples, func(i, j int) bool {
+		if samples[i].CpuPercent != samples[j].CpuPercent {
+			return samples[i].CpuPercent > samples[j].CpuPercent
+		}
+		return samples[i].Pid < samples[j].Pid
+	})
+	if limit > 0 && len(samples) > limit {
+		samples = samples[:limit]
+	}
+
+	// Rates are per second regardless of the sampling interval.
+	secs := resp.Interval.AsDuration().Seconds()
+	rate := func(v uint64) float64 {
+		if secs <= 0 {
+			return 0
+		}
+		return float64(v) / secs
+	}
+
+	fmt.Fprintf(out, "\nTarget: %s Index: %d Time: %s Processes: %d\n\n", target, index, resp.Timestamp.AsTime().Format(time.RFC3339), len(resp.Samples))
+	fmtHeader := "%8s %6s %12s %12s %12s %8s %8s %s\n"
+	fmtEntry := "%8d %6.1f %12d %12.0f %12.0f %8.0f %8.0f %s\n"
+	fmt.Fprintf(out, fmtHeader, "PID", "%CPU", "RSS", "READ/s", "WRITE/s", "VCSW/s", "NVCSW/s", "CMD")
+	for _, s := range samples {
+		fmt.Fprintf(out, fmtEntry, s.Pid, s.CpuPercent, s.Rss, rate(s.ReadBytes), rate(s.WriteBytes), rate(s.VoluntaryContextSwitches), rate(s.InvoluntaryContextSwitches), s.Command)
+	}
+}
+
+type inspectCmd struct {
+	pid int64
+}
+
+func (*inspectCmd) Name() string     { return "inspect" }
+func (*inspectCmd) Synopsis() string { return "Inspect a process environment." }
+func (*inspectCmd) Usage() string {
+	return `inspect --pid=X:
+  Retrieve the environment, resource limits, cgroups, namespaces and cwd/root/exe for a given process id.
+  Environment values the server considers sensitive are redacted.
+`
+}
+
+func (p *inspectCmd) SetFlags(f *flag.FlagSet) {
+	f.Int64Var(&p.pid, "pid", 0, "Process to inspect.")
+}
+
+func (p *inspectCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	if p.pid <= 0 {
+		fmt.Fprintln(os.Stderr, "--pid must be specified")
+		return subcommands.ExitFailure
+	}
+
+	state := args[0].(*util.ExecuteState)
+	c := pb.NewProcessClientProxy(state.Conn)
+
+	respChan, err := c.InspectOneMany(ctx, &pb.InspectRequest{Pid: p.pid})
+	if err != nil {
+		// Emit this to every error file as it's not specific to a given target.
+		for _, e := range state.Err {
+			fmt.Fprintf(e, "Inspect returned error: %v\n", err)
+		}
+		return subcommands.ExitFailure
+	}
+
+	retCode := subcommands.ExitSuccess
+	for resp := range respChan {
+		if resp.Error != nil {
+			fmt.Fprintf(state.Err[resp.Index], "Got error from target %s (%d) - %v\n", resp.Target, resp.Index, resp.Error)
+			retCode = subcommands.ExitFailure
+			continue
+		}
+		outputEntryHeader(state.Out[resp.Index], resp.Target, resp.Index)
+		outputInspectEntry(resp.Resp, state.Out[resp.Index])
+	}
+	return retCode
+}
+
+func outputInspectEntry(resp *pb.InspectReply, out io.Writer) {
+	fmt.Fprintf(out, "Pid: %d\nExe: %s\nCwd: %s\nRoot: %s\n", resp.Pid, resp.Exe, resp.Cwd, resp.Root)
+
+	fmt.Fprintln(out, "\nEnvironment:")
+	for _, e := range resp.Environment {
+		v := e.Value
+		if e.Redacted {
+			v = "<redacted>"
+		}
+		fmt.Fprintf(out, "  %s=%s\n", e.Key, v)
+	}
+
+	fmt.Fprintln(out, "\nLimits:")
+	limit := func(v int64) string {
+		if v < 0 {
+			return "unlimited"
+		}
+		return fmt.Sprintf("%d", v)
+	}
+	fmt.Fprintf(out, "  %-25s %-20s %-20s %s\n", "Limit", "Soft Limit", "Hard Limit", "Units")
+	for _, l := range resp.Limits {
+		fmt.Fprintf(out, "  %-25s %-20s %-20s %s\n", l.Name, limit(l.SoftLimit), limit(l.HardLimit), l.Units)
+	}
+
+	fmt.Fprintln(out, "\nCgroups:")
+	for _, cg := range resp.Cgroups {
+		fmt.Fprintf(out, "  %d:%s:%s\n", cg.HierarchyId, strings.Join(cg.Controllers, ","), cg.Path)
+		var keys []string
+		for k := range cg.Settings {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(out, "    %s: %s\n", k, cg.Settings[k])
+		}
+	}
+
+	fmt.Fprintln(out, "\nNamespaces:")
+	for _, n := range resp.Namespaces {
+		fmt.Fprintf(out, "  %s:[%d]\n", n.Type, n.Inode)
+	}
+}
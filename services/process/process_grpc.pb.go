@@ -36,6 +36,19 @@ type ProcessClient interface {
 	// NOTE: Enough disk space is required to hold the dump file before streaming
 	//       the response.
 	GetMemoryDump(ctx context.Context, in *GetMemoryDumpRequest, opts ...grpc.CallOption) (Process_GetMemoryDumpClient, error)
+	// Monitor samples process resource usage at a given interval and
+	// streams back the per-process deltas between samples (similar to top).
+	// The stream ends after the requested number of samples or when the
+	// client cancels.
+	// NOTE: Since this contains the command line this can
+	// contain sensitive data.
+	Monitor(ctx context.Context, in *MonitorRequest, opts ...grpc.CallOption) (Process_MonitorClient, error)
+	// Inspect returns the runtime view of a process (environment, resource
+	// limits, cgroups, namespaces, etc). Environment values whose keys match
+	// the server's redaction rules are removed before returning.
+	// NOTE: Even with redaction the environment and paths can
+	// contain sensitive data.
+	Inspect(ctx context.Context, in *InspectRequest, opts ...grpc.CallOption) (*InspectReply, error)
 }
 
 type processClient struct {
@@ -105,6 +118,47 @@ func (x *processGetMemoryDumpClient) Recv() (*GetMemoryDumpReply, error) {
 	return m, nil
 }
 
+func (c *processClient) Monitor(ctx context.Context, in *MonitorRequest, opts ...grpc.CallOption) (Process_MonitorClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Process_ServiceDesc.Streams[1], "/Process.Process/Monitor", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &processMonitorClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Process_MonitorClient interface {
+	Recv() (*MonitorReply, error)
+	grpc.ClientStream
+}
+
+type processMonitorClient struct {
+	grpc.ClientStream
+}
+
+func (x *processMonitorClient) Recv() (*MonitorReply, error) {
+	m := new(MonitorReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *processClient) Inspect(ctx context.Context, in *InspectRequest, opts ...grpc.CallOption) (*InspectReply, error) {
+	out := new(InspectReply)
+	err := c.cc.Invoke(ctx, "/Process.Process/Inspect", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProcessServer is the server API for Process service.
 // All implementations should embed UnimplementedProcessServer
 // for forward compatibility
@@ -127,6 +181,19 @@ type ProcessServer interface {
 	// NOTE: Enough disk space is required to hold the dump file before streaming
 	//       the response.
 	GetMemoryDump(*GetMemoryDumpRequest, Process_GetMemoryDumpServer) error
+	// Monitor samples process resource usage at a given interval and
+	// streams back the per-process deltas between samples (similar to top).
+	// The stream ends after the requested number of samples or when the
+	// client cancels.
+	// NOTE: Since this contains the command line this can
+	// contain sensitive data.
+	Monitor(*MonitorRequest, Process_MonitorServer) error
+	// Inspect returns the runtime view of a process (environment, resource
+	// limits, cgroups, namespaces, etc). Environment values whose keys match
+	// the server's redaction rules are removed before returning.
+	// NOTE: Even with redaction the environment and paths can
+	// contain sensitive data.
+	Inspect(context.Context, *InspectRequest) (*InspectReply, error)
 }
 
 // UnimplementedProcessServer should be embedded to have forward compatible implementations.
@@ -145,6 +212,12 @@ func (UnimplementedProcessServer) GetJavaStacks(context.Context, *GetJavaStacksR
 func (UnimplementedProcessServer) GetMemoryDump(*GetMemoryDumpRequest, Process_GetMemoryDumpServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetMemoryDump not implemented")
 }
+func (UnimplementedProcessServer) Monitor(*MonitorRequest, Process_MonitorServer) error {
+	return status.Errorf(codes.Unimplemented, "method Monitor not implemented")
+}
+func (UnimplementedProcessServer) Inspect(context.Context, *InspectRequest) (*InspectReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Inspect not implemented")
+}
 
 // UnsafeProcessServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to ProcessServer will
@@ -232,6 +305,45 @@ func (x *processGetMemoryDumpServer) Send(m *GetMemoryDumpReply) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Process_Monitor_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MonitorRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProcessServer).Monitor(m, &processMonitorServer{stream})
+}
+
+type Process_MonitorServer interface {
+	Send(*MonitorReply) error
+	grpc.ServerStream
+}
+
+type processMonitorServer struct {
+	grpc.ServerStream
+}
+
+func (x *processMonitorServer) Send(m *MonitorReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Process_Inspect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProcessServer).Inspect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Process.Process/Inspect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProcessServer).Inspect(ctx, req.(*InspectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Process_ServiceDesc is the grpc.ServiceDesc for Process service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -251,6 +363,10 @@ var Process_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetJavaStacks",
 			Handler:    _Process_GetJavaStacks_Handler,
 		},
+		{
+			MethodName: "Inspect",
+			Handler:    _Process_Inspect_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -258,6 +374,11 @@ var Process_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Process_GetMemoryDump_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Monitor",
+			Handler:       _Process_Monitor_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "process.proto",
 }
@@ -23,6 +23,8 @@ type ProcessClientProxy interface {
 	GetStacksOneMany(ctx context.Context, in *GetStacksRequest, opts ...grpc.CallOption) (<-chan *GetStacksManyResponse, error)
 	GetJavaStacksOneMany(ctx context.Context, in *GetJavaStacksRequest, opts ...grpc.CallOption) (<-chan *GetJavaStacksManyResponse, error)
 	GetMemoryDumpOneMany(ctx context.Context, in *GetMemoryDumpRequest, opts ...grpc.CallOption) (Process_GetMemoryDumpClientProxy, error)
+	MonitorOneMany(ctx context.Context, in *MonitorRequest, opts ...grpc.CallOption) (Process_MonitorClientProxy, error)
+	InspectOneMany(ctx context.Context, in *InspectRequest, opts ...grpc.CallOption) (<-chan *InspectManyResponse, error)
 }
 
 // Embed the original client inside of this so we get the other generated methods automatically.
@@ -324,3 +326,158 @@ func (c *processClientProxy) GetMemoryDumpOneMany(ctx context.Context, in *GetMe
 	}
 	return x, nil
 }
+
+// MonitorManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type MonitorManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *MonitorReply
+	Error error
+}
+
+type Process_MonitorClientProxy interface {
+	Recv() ([]*MonitorManyResponse, error)
+	grpc.ClientStream
+}
+
+type processClientMonitorClientProxy struct {
+	cc         *proxy.Conn
+	directDone bool
+	grpc.ClientStream
+}
+
+func (x *processClientMonitorClientProxy) Recv() ([]*MonitorManyResponse, error) {
+	var ret []*MonitorManyResponse
+	// If this is a direct connection the RecvMsg call is to a standard grpc.ClientStream
+	// and not our proxy based one. This means we need to receive a typed response and
+	// convert it into a single slice entry return. This ensures the OneMany style calls
+	// can be used by proxy with 1:N targets and non proxy with 1 target without client changes.
+	if x.cc.Direct() {
+		// Check if we're done. Just return EOF now. Any real error was already sent inside
+		// of a ManyResponse.
+		if x.directDone {
+			return nil, io.EOF
+		}
+		m := &MonitorReply{}
+		err := x.ClientStream.RecvMsg(m)
+		ret = append(ret, &MonitorManyResponse{
+			Resp:   m,
+			Error:  err,
+			Target: x.cc.Targets[0],
+			Index:  0,
+		})
+		// An error means we're done so set things so a later call now gets an EOF.
+		if err != nil {
+			x.directDone = true
+		}
+		return ret, nil
+	}
+
+	m := []*proxy.Ret{}
+	if err := x.ClientStream.RecvMsg(&m); err != nil {
+		return nil, err
+	}
+	for _, r := range m {
+		typedResp := &MonitorManyResponse{
+			Resp: &MonitorReply{},
+		}
+		typedResp.Target = r.Target
+		typedResp.Index = r.Index
+		typedResp.Error = r.Error
+		if r.Error == nil {
+			if err := r.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+				typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, r.Error)
+			}
+		}
+		ret = append(ret, typedResp)
+	}
+	return ret, nil
+}
+
+// MonitorOneMany provides the same API as Monitor but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *processClientProxy) MonitorOneMany(ctx context.Context, in *MonitorRequest, opts ...grpc.CallOption) (Process_MonitorClientProxy, error) {
+	stream, err := c.cc.NewStream(ctx, &Process_ServiceDesc.Streams[1], "/Process.Process/Monitor", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &processClientMonitorClientProxy{c.cc.(*proxy.Conn), false, stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// InspectManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type InspectManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *InspectReply
+	Error error
+}
+
+// InspectOneMany provides the same API as Inspect but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *processClientProxy) InspectOneMany(ctx context.Context, in *InspectRequest, opts ...grpc.CallOption) (<-chan *InspectManyResponse, error) {
+	conn := c.cc.(*proxy.Conn)
+	ret := make(chan *InspectManyResponse)
+	// If this is a single case we can just use Invoke and marshal it onto the channel once and be done.
+	if len(conn.Targets) == 1 {
+		go func() {
+			out := &InspectManyResponse{
+				Target: conn.Targets[0],
+				Index:  0,
+				Resp:   &InspectReply{},
+			}
+			err := conn.Invoke(ctx, "/Process.Process/Inspect", in, out.Resp, opts...)
+			if err != nil {
+				out.Error = err
+			}
+			// Send and close.
+			ret <- out
+			close(ret)
+		}()
+		return ret, nil
+	}
+	manyRet, err := conn.InvokeOneMany(ctx, "/Process.Process/Inspect", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// A goroutine to retrive untyped responses and convert them to typed ones.
+	go func() {
+		for {
+			typedResp := &InspectManyResponse{
+				Resp: &InspectReply{},
+			}
+
+			resp, ok := <-manyRet
+			if !ok {
+				// All done so we can shut down.
+				close(ret)
+				return
+			}
+			typedResp.Target = resp.Target
+			typedResp.Index = resp.Index
+			typedResp.Error = resp.Error
+			if resp.Error == nil {
+				if err := resp.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+					typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, resp.Error)
+				}
+			}
+			ret <- typedResp
+		}
+	}()
+
+	return ret, nil
+}
@@ -25,7 +25,10 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"gocloud.dev/blob"
 	_ "gocloud.dev/blob/azureblob" // Bring Azure blob support in.
@@ -40,6 +43,8 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // server is used to implement the gRPC server
@@ -50,10 +55,18 @@ var (
 	// These are effectively platform agnostic so they can here vs the architecture specific files.
 	jstackBin = flag.String("jstack-bin", "/usr/lib/jvm/adoptopenjdk-11-hotspot/bin/jstack", "Path to the jstack binary")
 	jmapBin   = flag.String("jmap-bin", "/usr/lib/jvm/adoptopenjdk-11-hotspot/bin/jmap", "Path to the jmap binary")
+
+	redactEnvRegex = flag.String("inspect-redact-regex", `(?i)(pass|secret|token|key|credential|auth|session|cookie|private)`, "Environment variables with keys matching this regular expression will have their values redacted by Inspect")
 )
 
 // Vars so we can replace for testing.
 var (
+	// procRoot is where procfs is mounted on OS's which support it.
+	procRoot = "/proc"
+
+	// cgroupRoot is where the cgroup hierarchies are mounted on OS's which support it.
+	cgroupRoot = "/sys/fs/cgroup"
+
 	pstackOptions = func(req *pb.GetStacksRequest) []string {
 		return []string{
 			fmt.Sprintf("%d", req.Pid),
@@ -463,6 +476,173 @@ func (s *server) GetMemoryDump(req *pb.GetMemoryDumpRequest, stream pb.Process_G
 	return nil
 }
 
+// procStat is a point in time accounting of the resources a process has
+// consumed over its lifetime. Two of these are diffed to generate a ProcessSample.
+type procStat struct {
+	pid     int64
+	command string
+	// CPU time (user + system) consumed so far.
+	cpuTime time.Duration
+	// Resident set size in KB.
+	rss                        int64
+	readBytes                  uint64
+	writeBytes                 uint64
+	voluntaryContextSwitches   uint64
+	involuntaryContextSwitches uint64
+}
+
+const (
+	defaultMonitorInterval = time.Second
+	minMonitorInterval     = 100 * time.Millisecond
+)
+
+// delta returns the difference between a later and earlier counter value.
+// Counters shouldn't go backwards but if a pid was reused between samples
+// they might, in which case we report 0 rather than underflowing.
+func delta(later, earlier uint64) uint64 {
+	if later < earlier {
+		return 0
+	}
+	return later - earlier
+}
+
+// computeSamples generates the samples for all processes which exist in both
+// prev and cur (i.e. new processes only show up once they've been sampled twice).
+func computeSamples(prev, cur map[int64]*procStat, elapsed time.Duration) []*pb.ProcessSample {
+	var out []*pb.ProcessSample
+	for pid, c := range cur {
+		p, ok := prev[pid]
+		if !ok {
+			continue
+		}
+		var cpu float32
+		if elapsed > 0 && c.cpuTime > p.cpuTime {
+			cpu = float32(float64(c.cpuTime-p.cpuTime) / float64(elapsed) * 100)
+		}
+		out = append(out, &pb.ProcessSample{
+			Pid:                        pid,
+			Command:                    c.command,
+			CpuPercent:                 cpu,
+			Rss:                        c.rss,
+			ReadBytes:                  delta(c.readBytes, p.readBytes),
+			WriteBytes:                 delta(c.writeBytes, p.writeBytes),
+			VoluntaryContextSwitches:   delta(c.voluntaryContextSwitches, p.voluntaryContextSwitches),
+			InvoluntaryContextSwitches: delta(c.involuntaryContextSwitches, p.involuntaryContextSwitches),
+		})
+	}
+	// Map iteration order is random so keep replies stable.
+	sort.Slice(out, func(i, j int) bool { return out[i].Pid < out[j].Pid })
+	return out
+}
+
+// filterStats returns the stats gathered which match the given regex (if non-nil).
+func filterStats(stats map[int64]*procStat, re *regexp.Regexp) map[int64]*procStat {
+	if re == nil {
+		return stats
+	}
+	out := make(map[int64]*procStat)
+	for pid, s := range stats {
+		if re.MatchString(s.command) {
+			out[pid] = s
+		}
+	}
+	return out
+}
+
+func (s *server) Monitor(req *pb.MonitorRequest, stream pb.Process_MonitorServer) error {
+	ctx := stream.Context()
+	interval := defaultMonitorInterval
+	if req.Interval != nil {
+		if err := req.Interval.CheckValid(); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid interval: %v", err)
+		}
+		interval = req.Interval.AsDuration()
+	}
+	if interval < minMonitorInterval {
+		return status.Errorf(codes.InvalidArgument, "interval must be at least %s", minMonitorInterval)
+	}
+	if req.Count < 0 {
+		return status.Error(codes.InvalidArgument, "count must be non-negative")
+	}
+	for _, pid := range req.Pids {
+		if pid <= 0 {
+			return status.Error(codes.InvalidArgument, "pid must be non-zero and positive")
+		}
+	}
+	var re *regexp.Regexp
+	if req.CommandRegex != "" {
+		var err error
+		re, err = regexp.Compile(req.CommandRegex)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid command regex %q: %v", req.CommandRegex, err)
+		}
+	}
+
+	prev, err := procStats(req.Pids)
+	if err != nil {
+		return err
+	}
+	// Unlike List we only check the pids exist once as processes may come and go while monitoring.
+	for _, pid := range req.Pids {
+		if _, ok := prev[pid]; !ok {
+			return status.Errorf(codes.InvalidArgument, "pid %d does not exist", pid)
+		}
+	}
+	prev = filterStats(prev, re)
+	last := time.Now()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for sent := int64(0); req.Count == 0 || sent < req.Count; sent++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		cur, err := procStats(req.Pids)
+		if err != nil {
+			return err
+		}
+		cur = filterStats(cur, re)
+		now := time.Now()
+		elapsed := now.Sub(last)
+		reply := &pb.MonitorReply{
+			Timestamp: timestamppb.New(now),
+			Interval:  durationpb.New(elapsed),
+			Samples:   computeSamples(prev, cur, elapsed),
+		}
+		if err := stream.Send(reply); err != nil {
+			return status.Errorf(codes.Internal, "can't send on stream: %v", err)
+		}
+		prev, last = cur, now
+	}
+	return nil
+}
+
+func (s *server) Inspect(ctx context.Context, req *pb.InspectRequest) (*pb.InspectReply, error) {
+	if req.Pid <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "pid must be non-zero and positive")
+	}
+	// Compile this each time so a bad regex fails closed (no environment returned)
+	// rather than leaking values.
+	re, err := regexp.Compile(*redactEnvRegex)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "invalid redaction regex %q: %v", *redactEnvRegex, err)
+	}
+
+	reply, err := procInspect(req.Pid)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range reply.Environment {
+		if re.MatchString(e.Key) {
+			e.Value = ""
+			e.Redacted = true
+		}
+	}
+	return reply, nil
+}
+
 // Register is called to expose this handler to the gRPC server
 func (s *server) Register(gs *grpc.Server) {
 	pb.RegisterProcessServer(gs, s)
@@ -312,3 +312,13 @@ func parser(r io.Reader) (map[int64]*pb.ProcessEntry, error) {
 
 	return entries, nil
 }
+
+// procStats isn't supported as there's no procfs to read on this OS.
+func procStats(pids []int64) (map[int64]*procStat, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
+// procInspect isn't supported as there's no procfs to read on this OS.
+func procInspect(pid int64) (*pb.InspectReply, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
@@ -41,4 +41,9 @@ var (
 	testdataPstackThreadsBadThreadNumber = "./testdata/linux_pstack_threads_bad_thread_number.txt"
 	testdataPstackThreadsBadThreadID     = "./testdata/linux_pstack_threads_bad_thread_id.txt"
 	testdataPstackThreadsBadLwp          = "./testdata/linux_pstack_threads_bad_lwp.txt"
+
+	// No procfs so Monitor/Inspect tests are skipped.
+	testdataProc             = ""
+	testdataCgroup           = ""
+	testdataInspectTextProto = ""
 )
@@ -24,6 +24,8 @@ import (
 	"fmt"
 	"io"
 	"runtime"
+
+	pb "github.com/Snowflake-Labs/sansshell/services/process"
 )
 
 var (
@@ -36,6 +38,14 @@ var (
 	}
 )
 
-func parser(r io.Reader) (map[int64]*ProcessEntry, error) {
+func parser(r io.Reader) (map[int64]*pb.ProcessEntry, error) {
+	return nil, fmt.Errorf("No support for OS %s", runtime.GOOS)
+}
+
+func procStats(pids []int64) (map[int64]*procStat, error) {
+	return nil, fmt.Errorf("No support for OS %s", runtime.GOOS)
+}
+
+func procInspect(pid int64) (*pb.InspectReply, error) {
 	return nil, fmt.Errorf("No support for OS %s", runtime.GOOS)
 }
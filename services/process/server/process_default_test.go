@@ -28,4 +28,8 @@ var (
 
 	testdataPstackNoThreadsFile = ""
 	testdataPstackThreadsFile   = ""
+
+	testdataProc             = ""
+	testdataCgroup           = ""
+	testdataInspectTextProto = ""
 )
@@ -25,11 +25,21 @@ package server
 
 import (
 	"bufio"
+	"bytes"
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
+	"github.com/Snowflake-Labs/sansshell/services/healthcheck"
 	pb "github.com/Snowflake-Labs/sansshell/services/process"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -40,6 +50,8 @@ var (
 	pstackBin = flag.String("pstack-bin", "/usr/bin/pstack", "Path to the pstack binary")
 	gcoreBin  = flag.String("gcore-bin", "/usr/bin/gcore", "Path to the gcore binary")
 
+	dumpMinFreeMB = flag.Uint64("dump-min-free-mb", 1024, "The free space needed in the temporary directory where memory dumps are staged for the dump-staging health check to pass")
+
 	// This is a var so we can replace for testing.
 	psOptions = func() []string {
 		options := []string{
@@ -83,6 +95,155 @@ var (
 	}
 )
 
+// userHZ is the unit (in ticks per second) the kernel reports CPU times in via /proc.
+// This is fixed by the kernel ABI (USER_HZ) regardless of the internal tick rate.
+const userHZ = 100
+
+// procStats reads /proc for the given pids (or all processes if empty) and returns
+// the current resource accounting for each. Processes which go away while being
+// read are silently skipped (see processGone).
+func procStats(pids []int64) (map[int64]*procStat, error) {
+	if len(pids) == 0 {
+		entries, err := os.ReadDir(procRoot)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "can't read %s: %v", procRoot, err)
+		}
+		for _, e := range entries {
+			pid, err := strconv.ParseInt(e.Name(), 10, 64)
+			if err != nil || !e.IsDir() {
+				continue
+			}
+			pids = append(pids, pid)
+		}
+	}
+
+	out := make(map[int64]*procStat)
+	for _, pid := range pids {
+		s, err := readProcStat(pid)
+		if processGone(err) {
+			continue
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "can't read stats for pid %d: %v", pid, err)
+		}
+		out[pid] = s
+	}
+	return out, nil
+}
+
+// processGone returns true if err from reading a /proc/<pid> file means the
+// process exited. Depending on timing that's either a missing file or, if
+// the file was opened first, ESRCH when reading it.
+func processGone(err error) bool {
+	return errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.ESRCH)
+}
+
+// readProcStat assembles a procStat for a single pid from the various files under /proc/<pid>.
+func readProcStat(pid int64) (*procStat, error) {
+	dir := filepath.Join(procRoot, strconv.FormatInt(pid, 10))
+	out := &procStat{pid: pid}
+
+	// /proc/<pid>/stat is of the form:
+	//
+	// pid (comm) state ppid ...
+	//
+	// where comm can contain spaces and parens so find the last ) and parse from there.
+	stat, err := os.ReadFile(filepath.Join(dir, "stat"))
+	if err != nil {
+		return nil, err
+	}
+	start, end := bytes.IndexByte(stat, '('), bytes.LastIndexByte(stat, ')')
+	if start == -1 || end < start {
+		return nil, fmt.Errorf("can't find command in stat line %q", stat)
+	}
+	comm := string(stat[start+1 : end])
+	fields := strings.Fields(string(stat[end+1:]))
+	// utime and stime are fields 14 and 15 (1 based) which is 11 and 12 once
+	// pid and comm are removed.
+	const utime, stime = 11, 12
+	if len(fields) <= stime {
+		return nil, fmt.Errorf("invalid field count in stat line %q", stat)
+	}
+	var ticks uint64
+	for _, f := range []string{fields[utime], fields[stime]} {
+		t, err := strconv.ParseUint(f, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse cpu time %q in stat line %q: %v", f, stat, err)
+		}
+		ticks += t
+	}
+	out.cpuTime = time.Duration(ticks) * time.Second / userHZ
+
+	// Kernel threads have an empty cmdline so display them as ps does.
+	cmdline, err := os.ReadFile(filepath.Join(dir, "cmdline"))
+	if err != nil {
+		return nil, err
+	}
+	out.command = strings.TrimSpace(string(bytes.ReplaceAll(cmdline, []byte{0}, []byte{' '})))
+	if out.command == "" {
+		out.command = fmt.Sprintf("[%s]", comm)
+	}
+
+	f, err := os.Open(filepath.Join(dir, "status"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var rss uint64
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		var dest *uint64
+		switch fields[0] {
+		case "VmRSS:":
+			dest = &rss
+		case "voluntary_ctxt_switches:":
+			dest = &out.voluntaryContextSwitches
+		case "nonvoluntary_ctxt_switches:":
+			dest = &out.involuntaryContextSwitches
+		default:
+			continue
+		}
+		v, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse %s value %q: %v", fields[0], fields[1], err)
+		}
+		*dest = v
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	out.rss = int64(rss)
+
+	// IO accounting may not be readable (or enabled) so treat it as optional.
+	if ioStats, err := os.ReadFile(filepath.Join(dir, "io")); err == nil {
+		for _, l := range strings.Split(string(ioStats), "\n") {
+			fields := strings.Fields(l)
+			if len(fields) != 2 {
+				continue
+			}
+			var dest *uint64
+			switch fields[0] {
+			case "read_bytes:":
+				dest = &out.readBytes
+			case "write_bytes:":
+				dest = &out.writeBytes
+			default:
+				continue
+			}
+			v, err := strconv.ParseUint(fields[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("can't parse %s value %q: %v", fields[0], fields[1], err)
+			}
+			*dest = v
+		}
+	}
+	return out, nil
+}
+
 func parser(r io.Reader) (map[int64]*pb.ProcessEntry, error) {
 	entries := make(map[int64]*pb.ProcessEntry)
 
@@ -357,3 +518,252 @@ func parser(r io.Reader) (map[int64]*pb.ProcessEntry, error) {
 
 	return entries, nil
 }
+
+// cgroupSettings are the control files returned for each cgroup controller.
+// The unified (v2) hierarchy is keyed by "".
+var cgroupSettings = map[string][]string{
+	"": {
+		"cpu.max",
+		"cpu.weight",
+		"cpuset.cpus.effective",
+		"io.max",
+		"memory.current",
+		"memory.high",
+		"memory.max",
+		"memory.swap.max",
+		"pids.current",
+		"pids.max",
+	},
+	"cpu": {
+		"cpu.cfs_period_us",
+		"cpu.cfs_quota_us",
+		"cpu.shares",
+	},
+	"cpuset": {
+		"cpuset.cpus",
+		"cpuset.mems",
+	},
+	"memory": {
+		"memory.limit_in_bytes",
+		"memory.memsw.limit_in_bytes",
+		"memory.soft_limit_in_bytes",
+		"memory.usage_in_bytes",
+	},
+	"pids": {
+		"pids.current",
+		"pids.max",
+	},
+}
+
+// procInspect gathers the various /proc/<pid> details needed for an InspectReply.
+// The environment is returned unredacted.
+func procInspect(pid int64) (*pb.InspectReply, error) {
+	dir := filepath.Join(procRoot, strconv.FormatInt(pid, 10))
+	if _, err := os.Stat(dir); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, status.Errorf(codes.InvalidArgument, "pid %d does not exist", pid)
+		}
+		return nil, status.Errorf(codes.Internal, "can't stat %s: %v", dir, err)
+	}
+
+	reply := &pb.InspectReply{
+		Pid: pid,
+	}
+
+	// These links are unreadable for kernel threads so treat missing ones as empty.
+	for _, l := range []struct {
+		name string
+		out  *string
+	}{
+		{name: "cwd", out: &reply.Cwd},
+		{name: "root", out: &reply.Root},
+		{name: "exe", out: &reply.Exe},
+	} {
+		dest, err := os.Readlink(filepath.Join(dir, l.name))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, status.Errorf(codes.Internal, "can't read %s link for pid %d: %v", l.name, pid, err)
+		}
+		*l.out = dest
+	}
+
+	environ, err := os.ReadFile(filepath.Join(dir, "environ"))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't read environment for pid %d: %v", pid, err)
+	}
+	for _, e := range strings.Split(string(environ), "\x00") {
+		if e == "" {
+			continue
+		}
+		k, v, _ := strings.Cut(e, "=")
+		reply.Environment = append(reply.Environment, &pb.EnvironmentVariable{
+			Key:   k,
+			Value: v,
+		})
+	}
+
+	limits, err := os.ReadFile(filepath.Join(dir, "limits"))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't read limits for pid %d: %v", pid, err)
+	}
+	if reply.Limits, err = parseLimits(limits); err != nil {
+		return nil, status.Errorf(codes.Internal, "can't parse limits for pid %d: %v", pid, err)
+	}
+
+	cgroups, err := os.ReadFile(filepath.Join(dir, "cgroup"))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't read cgroups for pid %d: %v", pid, err)
+	}
+	if reply.Cgroups, err = parseCgroups(cgroups); err != nil {
+		return nil, status.Errorf(codes.Internal, "can't parse cgroups for pid %d: %v", pid, err)
+	}
+
+	ns, err := os.ReadDir(filepath.Join(dir, "ns"))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't read namespaces for pid %d: %v", pid, err)
+	}
+	for _, n := range ns {
+		// Links are of the form type:[inode]
+		link, err := os.Readlink(filepath.Join(dir, "ns", n.Name()))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "can't read namespace %s for pid %d: %v", n.Name(), pid, err)
+		}
+		_, inode, ok := strings.Cut(link, ":[")
+		if !ok || !strings.HasSuffix(inode, "]") {
+			return nil, status.Errorf(codes.Internal, "unparsable namespace link %q for pid %d", link, pid)
+		}
+		i, err := strconv.ParseUint(strings.TrimSuffix(inode, "]"), 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "can't parse namespace inode %q for pid %d: %v", link, pid, err)
+		}
+		reply.Namespaces = append(reply.Namespaces, &pb.Namespace{
+			Type:  n.Name(),
+			Inode: i,
+		})
+	}
+	return reply, nil
+}
+
+// parseLimits parses the contents of /proc/<pid>/limits. This is a fixed width
+// table where the names can contain spaces so the header is used to find the columns:
+//
+// Limit                     Soft Limit           Hard Limit           Units
+// Max cpu time              unlimited            unlimited            seconds
+func parseLimits(data []byte) ([]*pb.ResourceLimit, error) {
+	lines := strings.Split(string(data), "\n")
+	header := lines[0]
+	soft, hard, units := strings.Index(header, "Soft Limit"), strings.Index(header, "Hard Limit"), strings.Index(header, "Units")
+	if !strings.HasPrefix(header, "Limit") || soft == -1 || hard < soft || units < hard {
+		return nil, fmt.Errorf("unexpected header %q", header)
+	}
+
+	parseValue := func(v string) (int64, error) {
+		if v == "unlimited" {
+			return -1, nil
+		}
+		return strconv.ParseInt(v, 10, 64)
+	}
+
+	var out []*pb.ResourceLimit
+	for _, l := range lines[1:] {
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		if len(l) < hard {
+			return nil, fmt.Errorf("short line %q", l)
+		}
+		limit := &pb.ResourceLimit{
+			Name: strings.TrimSpace(l[:soft]),
+		}
+		var err error
+		if limit.SoftLimit, err = parseValue(strings.TrimSpace(l[soft:hard])); err != nil {
+			return nil, fmt.Errorf("can't parse soft limit in %q: %v", l, err)
+		}
+		end := len(l)
+		if units < end {
+			end = units
+			limit.Units = strings.TrimSpace(l[units:])
+		}
+		if limit.HardLimit, err = parseValue(strings.TrimSpace(l[hard:end])); err != nil {
+			return nil, fmt.Errorf("can't parse hard limit in %q: %v", l, err)
+		}
+		out = append(out, limit)
+	}
+	return out, nil
+}
+
+// parseCgroups parses the contents of /proc/<pid>/cgroup which has lines of the form
+//
+// hierarchy-ID:controller-list:cgroup-path
+//
+// and then fills in the settings for each from under cgroupRoot.
+func parseCgroups(data []byte) ([]*pb.Cgroup, error) {
+	var out []*pb.Cgroup
+	for _, l := range strings.Split(string(data), "\n") {
+		if l == "" {
+			continue
+		}
+		fields := strings.SplitN(l, ":", 3)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid cgroup line %q", l)
+		}
+		id, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse hierarchy id in %q: %v", l, err)
+		}
+		cg := &pb.Cgroup{
+			HierarchyId: id,
+			Path:        fields[2],
+			Settings:    make(map[string]string),
+		}
+		if fields[1] != "" {
+			cg.Controllers = strings.Split(fields[1], ",")
+		}
+
+		// v2 is mounted at the root unless this is a hybrid setup in which case
+		// it's generally under unified. v1 hierarchies are mounted under the joined
+		// controller list.
+		var dirs []string
+		controllers := cg.Controllers
+		if id == 0 && len(controllers) == 0 {
+			dirs = []string{cgroupRoot, filepath.Join(cgroupRoot, "unified")}
+			controllers = []string{""}
+		} else {
+			dirs = []string{filepath.Join(cgroupRoot, fields[1])}
+		}
+		for _, d := range dirs {
+			for _, c := range controllers {
+				for _, f := range cgroupSettings[c] {
+					v, err := os.ReadFile(filepath.Join(d, cg.Path, f))
+					if err != nil {
+						continue
+					}
+					cg.Settings[f] = strings.TrimSpace(string(v))
+				}
+			}
+			if len(cg.Settings) > 0 {
+				break
+			}
+		}
+		out = append(out, cg)
+	}
+	return out, nil
+}
+
+// checkDumpStaging is a health check which confirms there's enough free space
+// to stage memory dumps in the temporary directory.
+func checkDumpStaging(ctx context.Context) error {
+	dir := os.TempDir()
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dir, &st); err != nil {
+		return fmt.Errorf("can't statfs %s: %v", dir, err)
+	}
+	free := st.Bavail * uint64(st.Bsize) / (1024 * 1024)
+	if free < *dumpMinFreeMB {
+		return fmt.Errorf("%s has %dMB free, need %dMB to stage dumps", dir, free, *dumpMinFreeMB)
+	}
+	return nil
+}
+
+func init() {
+	healthcheck.RegisterCheck(pb.Process_ServiceDesc.ServiceName, "dump-staging", checkDumpStaging)
+}
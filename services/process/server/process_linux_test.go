@@ -19,6 +19,19 @@
 
 package server
 
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"syscall"
+	"testing"
+
+	pb "github.com/Snowflake-Labs/sansshell/services/process"
+	"github.com/Snowflake-Labs/sansshell/testing/testutil"
+)
+
 // OS specific locations for finding test data.
 var (
 	testdataPsTextProto = "./testdata/linux_testdata.ps.textproto"
@@ -38,4 +51,86 @@ var (
 	testdataPstackThreadsBadThreadNumber = "./testdata/linux_pstack_threads_bad_thread_number.txt"
 	testdataPstackThreadsBadThreadID     = "./testdata/linux_pstack_threads_bad_thread_id.txt"
 	testdataPstackThreadsBadLwp          = "./testdata/linux_pstack_threads_bad_lwp.txt"
+
+	testdataProc             = "./testdata/proc"
+	testdataCgroup           = "./testdata/cgroup"
+	testdataInspectTextProto = "./testdata/linux_inspect.textproto"
 )
+
+func TestParseLimits(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		input   string
+		want    []*pb.ResourceLimit
+		wantErr bool
+	}{
+		{
+			name: "unitless",
+			input: `Limit                     Soft Limit           Hard Limit           Units     
+Max nice priority         0                    0                    
+Max open files            1024                 unlimited            files     
+`,
+			want: []*pb.ResourceLimit{
+				{
+					Name: "Max nice priority",
+				},
+				{
+					Name:      "Max open files",
+					SoftLimit: 1024,
+					HardLimit: -1,
+					Units:     "files",
+				},
+			},
+		},
+		{
+			name:    "bad header",
+			input:   "Max nice priority         0                    0                    \n",
+			wantErr: true,
+		},
+		{
+			name: "bad value",
+			input: `Limit                     Soft Limit           Hard Limit           Units     
+Max open files            lots                 unlimited            files     
+`,
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseLimits([]byte(tc.input))
+			testutil.WantErr(tc.name, err, tc.wantErr, t)
+			if !tc.wantErr {
+				testutil.DiffErr(tc.name, got, tc.want, t)
+			}
+		})
+	}
+}
+
+func TestCheckDumpStaging(t *testing.T) {
+	saved := *dumpMinFreeMB
+	t.Cleanup(func() { *dumpMinFreeMB = saved })
+
+	*dumpMinFreeMB = 0
+	testutil.FatalOnErr("dump-staging with no minimum", checkDumpStaging(context.Background()), t)
+
+	*dumpMinFreeMB = math.MaxUint64
+	testutil.FatalOnNoErr("dump-staging with impossible minimum", checkDumpStaging(context.Background()), t)
+}
+
+func TestProcessGone(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil},
+		{name: "missing file", err: &fs.PathError{Op: "open", Path: "/proc/1/stat", Err: syscall.ENOENT}, want: true},
+		{name: "exited mid read", err: &fs.PathError{Op: "read", Path: "/proc/1/cmdline", Err: syscall.ESRCH}, want: true},
+		{name: "wrapped", err: fmt.Errorf("reading status: %w", syscall.ESRCH), want: true},
+		{name: "permission", err: os.ErrPermission},
+	} {
+		if got := processGone(tc.err); got != tc.want {
+			t.Errorf("%s: processGone(%v) = %v, want %v", tc.name, tc.err, got, tc.want)
+		}
+	}
+}
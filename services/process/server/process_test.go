@@ -28,6 +28,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	pb "github.com/Snowflake-Labs/sansshell/services/process"
 	"github.com/Snowflake-Labs/sansshell/testing/testutil"
@@ -37,7 +38,9 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/test/bufconn"
 	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 var (
@@ -826,3 +829,342 @@ func TestMemoryDump(t *testing.T) {
 		})
 	}
 }
+
+func TestMonitorNative(t *testing.T) {
+	// We're on a platform which doesn't support this so we can't test.
+	if testdataProc == "" {
+		t.Skip("OS not supported")
+	}
+
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+
+	client := pb.NewProcessClient(conn)
+
+	// We should always be able to monitor ourselves.
+	pid := int64(os.Getpid())
+	stream, err := client.Monitor(ctx, &pb.MonitorRequest{
+		Pids:     []int64{pid},
+		Interval: durationpb.New(100 * time.Millisecond),
+		Count:    1,
+	})
+	testutil.FatalOnErr("Monitor", err, t)
+	resp, err := stream.Recv()
+	testutil.FatalOnErr("Monitor recv", err, t)
+	if len(resp.Samples) != 1 || resp.Samples[0].Pid != pid {
+		t.Fatalf("Asked for pid %d and got back something else? %+v", pid, resp)
+	}
+	if resp.Samples[0].Rss == 0 {
+		t.Errorf("Expected non-zero rss for ourselves: %+v", resp)
+	}
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Fatalf("Expected EOF after one sample and got %v", err)
+	}
+}
+
+func TestMonitor(t *testing.T) {
+	// We're on a platform which doesn't support this so we can't test.
+	if testdataProc == "" {
+		t.Skip("OS not supported")
+	}
+
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+
+	savedProcRoot := procRoot
+	procRoot = testdataProc
+	t.Cleanup(func() {
+		procRoot = savedProcRoot
+	})
+
+	client := pb.NewProcessClient(conn)
+
+	// The canned data never changes so all the deltas are zero.
+	systemd := &pb.ProcessSample{
+		Pid:     1,
+		Command: "/usr/lib/systemd/systemd --switched-root --system --deserialize 31",
+		Rss:     13208,
+	}
+	kthreadd := &pb.ProcessSample{
+		Pid:     2,
+		Command: "[kthreadd]",
+	}
+	odd := &pb.ProcessSample{
+		Pid:     3,
+		Command: "/usr/bin/odd-daemon --foreground",
+		Rss:     2048,
+	}
+	interval := durationpb.New(100 * time.Millisecond)
+
+	for _, tc := range []struct {
+		name    string
+		req     *pb.MonitorRequest
+		want    []*pb.ProcessSample
+		wantErr bool
+	}{
+		{
+			name: "all processes",
+			req: &pb.MonitorRequest{
+				Interval: interval,
+				Count:    2,
+			},
+			want: []*pb.ProcessSample{systemd, kthreadd, odd},
+		},
+		{
+			name: "pid filter",
+			req: &pb.MonitorRequest{
+				Pids:     []int64{2},
+				Interval: interval,
+				Count:    1,
+			},
+			want: []*pb.ProcessSample{kthreadd},
+		},
+		{
+			name: "command filter",
+			req: &pb.MonitorRequest{
+				CommandRegex: "odd-daemon",
+				Interval:     interval,
+				Count:        1,
+			},
+			want: []*pb.ProcessSample{odd},
+		},
+		{
+			name: "non-existant pid",
+			req: &pb.MonitorRequest{
+				Pids:     []int64{99},
+				Interval: interval,
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad pid",
+			req: &pb.MonitorRequest{
+				Pids:     []int64{-1},
+				Interval: interval,
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad regex",
+			req: &pb.MonitorRequest{
+				CommandRegex: "(",
+				Interval:     interval,
+				Count:        1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "interval too small",
+			req: &pb.MonitorRequest{
+				Interval: durationpb.New(time.Millisecond),
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative count",
+			req: &pb.MonitorRequest{
+				Interval: interval,
+				Count:    -1,
+			},
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			stream, err := client.Monitor(ctx, tc.req)
+			testutil.FatalOnErr("Monitor", err, t)
+			var replies []*pb.MonitorReply
+			for {
+				resp, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				testutil.WantErr(tc.name, err, tc.wantErr, t)
+				if err != nil {
+					break
+				}
+				replies = append(replies, resp)
+			}
+			if tc.wantErr {
+				return
+			}
+			if got, want := int64(len(replies)), tc.req.Count; got != want {
+				t.Fatalf("%s: wrong number of replies. got %d want %d", tc.name, got, want)
+			}
+			for _, r := range replies {
+				testutil.DiffErr(tc.name, r.Samples, tc.want, t)
+			}
+		})
+	}
+}
+
+func TestComputeSamples(t *testing.T) {
+	prev := map[int64]*procStat{
+		1: {
+			pid:                        1,
+			command:                    "init",
+			cpuTime:                    time.Second,
+			rss:                        100,
+			readBytes:                  1000,
+			writeBytes:                 2000,
+			voluntaryContextSwitches:   10,
+			involuntaryContextSwitches: 1,
+		},
+		// Exited before the second sample so shouldn't be reported.
+		2: {
+			pid:     2,
+			command: "gone",
+		},
+		// A recycled pid with counters lower than the last sample.
+		3: {
+			pid:       3,
+			command:   "old",
+			cpuTime:   time.Minute,
+			readBytes: 5000,
+		},
+	}
+	cur := map[int64]*procStat{
+		1: {
+			pid:                        1,
+			command:                    "init",
+			cpuTime:                    1500 * time.Millisecond,
+			rss:                        200,
+			readBytes:                  1500,
+			writeBytes:                 2000,
+			voluntaryContextSwitches:   15,
+			involuntaryContextSwitches: 3,
+		},
+		3: {
+			pid:       3,
+			command:   "new",
+			cpuTime:   time.Second,
+			readBytes: 10,
+		},
+		// New process which shows up on the next sample.
+		4: {
+			pid:     4,
+			command: "new",
+		},
+	}
+	want := []*pb.ProcessSample{
+		{
+			Pid:                        1,
+			Command:                    "init",
+			CpuPercent:                 25,
+			Rss:                        200,
+			ReadBytes:                  500,
+			VoluntaryContextSwitches:   5,
+			InvoluntaryContextSwitches: 2,
+		},
+		{
+			Pid:     3,
+			Command: "new",
+		},
+	}
+	got := computeSamples(prev, cur, 2*time.Second)
+	// Samples must come back sorted by pid.
+	testutil.DiffErr("computeSamples", got, want, t)
+}
+
+func TestInspect(t *testing.T) {
+	// We're on a platform which doesn't support this so we can't test.
+	if testdataProc == "" {
+		t.Skip("OS not supported")
+	}
+
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+
+	savedProcRoot, savedCgroupRoot, savedRedact := procRoot, cgroupRoot, *redactEnvRegex
+	procRoot, cgroupRoot = testdataProc, testdataCgroup
+	t.Cleanup(func() {
+		procRoot, cgroupRoot, *redactEnvRegex = savedProcRoot, savedCgroupRoot, savedRedact
+	})
+
+	input, err := os.ReadFile(testdataInspectTextProto)
+	testutil.FatalOnErr(fmt.Sprintf("can't open testdata %s", testdataInspectTextProto), err, t)
+	testdata := &pb.InspectReply{}
+	err = prototext.Unmarshal(input, testdata)
+	testutil.FatalOnErr("can't unmarshal test data", err, t)
+
+	client := pb.NewProcessClient(conn)
+
+	for _, tc := range []struct {
+		name    string
+		pid     int64
+		redact  string
+		want    *pb.InspectReply
+		wantErr bool
+	}{
+		{
+			name:   "default redaction",
+			pid:    1,
+			redact: savedRedact,
+			want:   testdata,
+		},
+		{
+			name:   "no redaction",
+			pid:    1,
+			redact: "^$",
+			want: func() *pb.InspectReply {
+				r := proto.Clone(testdata).(*pb.InspectReply)
+				for _, e := range r.Environment {
+					switch e.Key {
+					case "DB_PASSWORD":
+						e.Value = "hunter2"
+					case "API_TOKEN":
+						e.Value = "abc123"
+					}
+					e.Redacted = false
+				}
+				return r
+			}(),
+		},
+		{
+			name:    "bad redaction regex",
+			pid:     1,
+			redact:  "(",
+			wantErr: true,
+		},
+		{
+			name:    "bad pid",
+			pid:     -1,
+			redact:  savedRedact,
+			wantErr: true,
+		},
+		{
+			name:    "non-existant pid",
+			pid:     99,
+			redact:  savedRedact,
+			wantErr: true,
+		},
+		{
+			name:    "missing proc data",
+			pid:     3,
+			redact:  savedRedact,
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			*redactEnvRegex = tc.redact
+			resp, err := client.Inspect(ctx, &pb.InspectRequest{Pid: tc.pid})
+			testutil.WantErr(tc.name, err, tc.wantErr, t)
+			if !tc.wantErr {
+				testutil.DiffErr(tc.name, resp, tc.want, t)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/google/subcommands"
@@ -44,6 +45,7 @@ func setup(f *flag.FlagSet) *subcommands.Commander {
 	c.Register(&getVerbosityCmd{}, "")
 	c.Register(&setProxyVerbosityCmd{}, "")
 	c.Register(&getProxyVerbosityCmd{}, "")
+	c.Register(&infoCmd{}, "")
 	return c
 }
 
@@ -212,3 +214,71 @@ func (g *getProxyVerbosityCmd) Execute(ctx context.Context, f *flag.FlagSet, arg
 	fmt.Fprintf(state.Out[0], "Proxy current logging level %d\n", resp.Level)
 	return subcommands.ExitSuccess
 }
+
+type infoCmd struct {
+	methods bool
+}
+
+func (*infoCmd) Name() string     { return "info" }
+func (*infoCmd) Synopsis() string { return "Get build and runtime details of the server." }
+func (*infoCmd) Usage() string {
+	return `info [--methods]:
+  Returns the version, VCS revision, start time, hostname and policy hash of the
+  server along with the services it was built with.
+`
+}
+
+func (i *infoCmd) SetFlags(f *flag.FlagSet) {
+	f.BoolVar(&i.methods, "methods", false, "If true also print the methods of each service")
+}
+
+func (i *infoCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	state := args[0].(*util.ExecuteState)
+	c := pb.NewAdminClientProxy(state.Conn)
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	resp, err := c.InfoOneMany(ctx, &emptypb.Empty{})
+	if err != nil {
+		// Emit this to every error file as it's not specific to a given target.
+		for _, e := range state.Err {
+			fmt.Fprintf(e, "Could not get info: %v\n", err)
+		}
+		return subcommands.ExitFailure
+	}
+
+	retCode := subcommands.ExitSuccess
+	for r := range resp {
+		if r.Error != nil {
+			fmt.Fprintf(state.Err[r.Index], "Getting info for target %s (%d) returned error: %v\n", r.Target, r.Index, r.Error)
+			retCode = subcommands.ExitFailure
+			continue
+		}
+		out := state.Out[r.Index]
+		info := r.Resp
+		fmt.Fprintf(out, "Target %s (%d)\n", r.Target, r.Index)
+		fmt.Fprintf(out, "Hostname: %s\n", info.Hostname)
+		fmt.Fprintf(out, "Module: %s %s\n", info.ModulePath, info.ModuleVersion)
+		fmt.Fprintf(out, "Go version: %s\n", info.GoVersion)
+		revision := info.VcsRevision
+		if info.VcsModified {
+			revision += " (modified)"
+		}
+		fmt.Fprintf(out, "VCS revision: %s\n", revision)
+		if info.VcsTime != nil {
+			fmt.Fprintf(out, "VCS time: %s\n", info.VcsTime.AsTime().Format(time.RFC3339))
+		}
+		start := info.StartTime.AsTime()
+		fmt.Fprintf(out, "Started: %s (up %s)\n", start.Format(time.RFC3339), time.Since(start).Truncate(time.Second))
+		fmt.Fprintf(out, "Policy SHA256: %s\n", info.PolicySha256)
+		fmt.Fprintln(out, "Services:")
+		for _, s := range info.Services {
+			if i.methods {
+				fmt.Fprintf(out, "  %s: %s\n", s.Name, strings.Join(s.Methods, ", "))
+				continue
+			}
+			fmt.Fprintf(out, "  %s\n", s.Name)
+		}
+	}
+	return retCode
+}
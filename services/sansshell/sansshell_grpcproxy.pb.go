@@ -167,3 +167,87 @@ func (c *loggingClientProxy) GetVerbosityOneMany(ctx context.Context, in *emptyp
 
 	return ret, nil
 }
+
+// AdminClientProxy is the superset of AdminClient which additionally includes the OneMany proxy methods
+type AdminClientProxy interface {
+	AdminClient
+	InfoOneMany(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (<-chan *InfoManyResponse, error)
+}
+
+// Embed the original client inside of this so we get the other generated methods automatically.
+type adminClientProxy struct {
+	*adminClient
+}
+
+// NewAdminClientProxy creates a AdminClientProxy for use in proxied connections.
+// NOTE: This takes a proxy.Conn instead of a generic ClientConnInterface as the methods here are only valid in proxy.Conn contexts.
+func NewAdminClientProxy(cc *proxy.Conn) AdminClientProxy {
+	return &adminClientProxy{NewAdminClient(cc).(*adminClient)}
+}
+
+// InfoManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type InfoManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *InfoReply
+	Error error
+}
+
+// InfoOneMany provides the same API as Info but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *adminClientProxy) InfoOneMany(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (<-chan *InfoManyResponse, error) {
+	conn := c.cc.(*proxy.Conn)
+	ret := make(chan *InfoManyResponse)
+	// If this is a single case we can just use Invoke and marshal it onto the channel once and be done.
+	if len(conn.Targets) == 1 {
+		go func() {
+			out := &InfoManyResponse{
+				Target: conn.Targets[0],
+				Index:  0,
+				Resp:   &InfoReply{},
+			}
+			err := conn.Invoke(ctx, "/Sansshell.Admin/Info", in, out.Resp, opts...)
+			if err != nil {
+				out.Error = err
+			}
+			// Send and close.
+			ret <- out
+			close(ret)
+		}()
+		return ret, nil
+	}
+	manyRet, err := conn.InvokeOneMany(ctx, "/Sansshell.Admin/Info", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// A goroutine to retrive untyped responses and convert them to typed ones.
+	go func() {
+		for {
+			typedResp := &InfoManyResponse{
+				Resp: &InfoReply{},
+			}
+
+			resp, ok := <-manyRet
+			if !ok {
+				// All done so we can shut down.
+				close(ret)
+				return
+			}
+			typedResp.Target = resp.Target
+			typedResp.Index = resp.Index
+			typedResp.Error = resp.Error
+			if resp.Error == nil {
+				if err := resp.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+					typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, resp.Error)
+				}
+			}
+			ret <- typedResp
+		}
+	}()
+
+	return ret, nil
+}
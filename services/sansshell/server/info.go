@@ -0,0 +1,89 @@
+/* Copyright (c) 2019 Snowflake Inc. All rights reserved.
+
+   Licensed under the Apache License, Version 2.0 (the
+   "License"); you may not use this file except in compliance
+   with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing,
+   software distributed under the License is distributed on an
+   "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+   KIND, either express or implied.  See the License for the
+   specific language governing permissions and limitations
+   under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"os"
+	"runtime/debug"
+	"sort"
+	"time"
+
+	"github.com/Snowflake-Labs/sansshell/services"
+	pb "github.com/Snowflake-Labs/sansshell/services/sansshell"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// startTime is close enough to when the process started as package init
+// happens before main.
+var startTime = time.Now()
+
+// Vars so tests can replace them.
+var (
+	readBuildInfo = debug.ReadBuildInfo
+	hostname      = os.Hostname
+)
+
+// Info returns details about the running server and the services registered with it.
+func (s *Server) Info(ctx context.Context, req *emptypb.Empty) (*pb.InfoReply, error) {
+	host, err := hostname()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't get hostname: %v", err)
+	}
+	reply := &pb.InfoReply{
+		StartTime:    timestamppb.New(startTime),
+		Hostname:     host,
+		PolicySha256: services.PolicyHash(),
+	}
+
+	if bi, ok := readBuildInfo(); ok {
+		reply.ModulePath = bi.Main.Path
+		reply.ModuleVersion = bi.Main.Version
+		reply.GoVersion = bi.GoVersion
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				reply.VcsRevision = setting.Value
+			case "vcs.time":
+				if t, err := time.Parse(time.RFC3339, setting.Value); err == nil {
+					reply.VcsTime = timestamppb.New(t)
+				}
+			case "vcs.modified":
+				reply.VcsModified = setting.Value == "true"
+			}
+		}
+	}
+
+	s.mu.RLock()
+	gs := s.gs
+	s.mu.RUnlock()
+	if gs != nil {
+		for name, info := range gs.GetServiceInfo() {
+			si := &pb.ServiceInfo{Name: name}
+			for _, m := range info.Methods {
+				si.Methods = append(si.Methods, m.Name)
+			}
+			sort.Strings(si.Methods)
+			reply.Services = append(reply.Services, si)
+		}
+		sort.Slice(reply.Services, func(i, j int) bool { return reply.Services[i].Name < reply.Services[j].Name })
+	}
+	return reply, nil
+}
@@ -33,6 +33,8 @@ import (
 type Server struct {
 	mu      sync.RWMutex
 	lastVal int32
+	// gs is the server this was registered with and is used to list services for Info.
+	gs *grpc.Server
 }
 
 // SetVerbosity sets the logging level and returns the last value before this was called.
@@ -58,7 +60,11 @@ func (s *Server) GetVerbosity(ctx context.Context, req *emptypb.Empty) (*pb.Verb
 
 // Register is called to expose this handler to the gRPC server
 func (s *Server) Register(gs *grpc.Server) {
+	s.mu.Lock()
+	s.gs = gs
+	s.mu.Unlock()
 	pb.RegisterLoggingServer(gs, s)
+	pb.RegisterAdminServer(gs, s)
 }
 
 func init() {
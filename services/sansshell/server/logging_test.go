@@ -18,17 +18,24 @@ package server
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"log"
 	"net"
 	"os"
+	"runtime/debug"
 	"testing"
+	"time"
 
+	"github.com/Snowflake-Labs/sansshell/services"
 	pb "github.com/Snowflake-Labs/sansshell/services/sansshell"
 	"github.com/Snowflake-Labs/sansshell/testing/testutil"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/test/bufconn"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var (
@@ -73,3 +80,92 @@ func TestGetSetVerbosity(t *testing.T) {
 		t.Fatalf("Didn't get expected value back from GetVerbosity. Got %d want %d", got, want)
 	}
 }
+
+func TestInfo(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	conn, err = grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	testutil.FatalOnErr("Failed to dial bufnet", err, t)
+	t.Cleanup(func() { conn.Close() })
+
+	savedReadBuildInfo, savedHostname := readBuildInfo, hostname
+	t.Cleanup(func() { readBuildInfo, hostname = savedReadBuildInfo, savedHostname })
+
+	sum := sha256.Sum256([]byte("package sansshell.authz"))
+	policyHash := hex.EncodeToString(sum[:])
+	services.SetPolicyHash(policyHash)
+
+	vcsTime := time.Date(2022, 4, 1, 10, 15, 0, 0, time.UTC)
+	buildInfo := &debug.BuildInfo{
+		GoVersion: "go1.18",
+		Main: debug.Module{
+			Path:    "github.com/Snowflake-Labs/sansshell",
+			Version: "(devel)",
+		},
+		Settings: []debug.BuildSetting{
+			{Key: "-compiler", Value: "gc"},
+			{Key: "vcs.revision", Value: "6cae8da"},
+			{Key: "vcs.time", Value: vcsTime.Format(time.RFC3339)},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	}
+	wantServices := []*pb.ServiceInfo{
+		{Name: "Sansshell.Admin", Methods: []string{"Info"}},
+		{Name: "Sansshell.Logging", Methods: []string{"GetVerbosity", "SetVerbosity"}},
+	}
+
+	client := pb.NewAdminClient(conn)
+	for _, tc := range []struct {
+		name      string
+		buildInfo *debug.BuildInfo
+		hostErr   error
+		wantErr   bool
+		want      *pb.InfoReply
+	}{
+		{
+			name:      "build info",
+			buildInfo: buildInfo,
+			want: &pb.InfoReply{
+				ModulePath:    "github.com/Snowflake-Labs/sansshell",
+				ModuleVersion: "(devel)",
+				GoVersion:     "go1.18",
+				VcsRevision:   "6cae8da",
+				VcsTime:       timestamppb.New(vcsTime),
+				VcsModified:   true,
+				StartTime:     timestamppb.New(startTime),
+				Hostname:      "host",
+				PolicySha256:  policyHash,
+				Services:      wantServices,
+			},
+		},
+		{
+			name: "no build info",
+			want: &pb.InfoReply{
+				StartTime:    timestamppb.New(startTime),
+				Hostname:     "host",
+				PolicySha256: policyHash,
+				Services:     wantServices,
+			},
+		},
+		{
+			name:    "hostname error",
+			hostErr: errors.New("no hostname"),
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			readBuildInfo = func() (*debug.BuildInfo, bool) {
+				return tc.buildInfo, tc.buildInfo != nil
+			}
+			hostname = func() (string, error) {
+				return "host", tc.hostErr
+			}
+			resp, err := client.Info(ctx, &emptypb.Empty{})
+			testutil.WantErr(tc.name, err, tc.wantErr, t)
+			if !tc.wantErr {
+				testutil.DiffErr(tc.name, resp, tc.want, t)
+			}
+		})
+	}
+}
@@ -24,8 +24,11 @@ import (
 	"io"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/google/subcommands"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/Snowflake-Labs/sansshell/client"
 	pb "github.com/Snowflake-Labs/sansshell/services/service"
@@ -46,6 +49,19 @@ func setup(f *flag.FlagSet) *subcommands.Commander {
 	c.Register(&actionCmd{action: pb.Action_ACTION_START}, "")
 	c.Register(&statusCmd{}, "")
 	c.Register(&actionCmd{action: pb.Action_ACTION_STOP}, "")
+	c.Register(&actionCmd{action: pb.Action_ACTION_RELOAD}, "")
+	c.Register(&actionCmd{action: pb.Action_ACTION_ENABLE}, "")
+	c.Register(&actionCmd{action: pb.Action_ACTION_DISABLE}, "")
+	c.Register(&actionCmd{action: pb.Action_ACTION_MASK}, "")
+	c.Register(&actionCmd{action: pb.Action_ACTION_UNMASK}, "")
+	c.Register(&actionCmd{action: pb.Action_ACTION_RESET_FAILED}, "")
+	c.Register(&daemonReloadCmd{}, "")
+	c.Register(&logsCmd{}, "")
+	c.Register(&getUnitCmd{}, "")
+	c.Register(&dependenciesCmd{}, "")
+	c.Register(&watchCmd{}, "")
+	c.Register(&listTimersCmd{}, "")
+	c.Register(&scheduleCmd{}, "")
 	return c
 }
 
@@ -98,12 +114,17 @@ func statusString(s pb.Status) string {
 }
 
 type actionCmd struct {
-	action     pb.Action
-	systemType string
+	action      pb.Action
+	systemType  string
+	wait        bool
+	waitStatus  string
+	settleTime  time.Duration
+	waitTimeout time.Duration
 }
 
 func (a *actionCmd) actionString() string {
-	return strings.ToLower(strings.TrimPrefix(a.action.String(), "ACTION_"))
+	// i.e. ACTION_RESET_FAILED -> reset-failed to match systemctl.
+	return strings.ReplaceAll(strings.ToLower(strings.TrimPrefix(a.action.String(), "ACTION_")), "_", "-")
 }
 
 func (a *actionCmd) Name() string { return a.actionString() }
@@ -114,12 +135,17 @@ func (a *actionCmd) Synopsis() string {
 
 func (a *actionCmd) Usage() string {
 	as := a.actionString()
-	return fmt.Sprintf(`%s [--system-type <type>] <service>:
-    %s the specified service`, as, as)
+	return fmt.Sprintf(`%s [--system-type <type>] [--wait [--wait-status=S] [--settle-time=D] [--wait-timeout=D]] <service>:
+    %s the specified service. With --wait this doesn't return until the service
+    reaches the expected status and stays there for the settle time.`, as, as)
 }
 
 func (a *actionCmd) SetFlags(f *flag.FlagSet) {
 	systemTypeFlag(f, &a.systemType)
+	f.BoolVar(&a.wait, "wait", false, "Wait for the service to reach the expected status before returning")
+	f.StringVar(&a.waitStatus, "wait-status", "", "The status to wait for (running or stopped). If unset this is derived from the action")
+	f.DurationVar(&a.settleTime, "settle-time", 0, "With --wait the time the service must stay in the expected status")
+	f.DurationVar(&a.waitTimeout, "wait-timeout", time.Minute, "With --wait the maximum time to wait (including settle time)")
 }
 
 func (a *actionCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
@@ -145,6 +171,23 @@ func (a *actionCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interf
 		ServiceName: serviceName,
 		Action:      a.action,
 	}
+	if a.wait {
+		waitStatus := pb.Status_STATUS_UNKNOWN
+		if a.waitStatus != "" {
+			i, ok := pb.Status_value[fmt.Sprintf("STATUS_%s", strings.ToUpper(a.waitStatus))]
+			if !ok {
+				fmt.Fprintf(errWriter, "no such status %s\n", a.waitStatus)
+				subcommands.DefaultCommander.ExplainCommand(errWriter, a)
+				return subcommands.ExitUsageError
+			}
+			waitStatus = pb.Status(i)
+		}
+		req.WaitFor = &pb.WaitFor{
+			Status:     waitStatus,
+			SettleTime: durationpb.New(a.settleTime),
+			Timeout:    durationpb.New(a.waitTimeout),
+		}
+	}
 
 	c := pb.NewServiceClientProxy(state.Conn)
 	respChan, err := c.ActionOneMany(ctx, req)
@@ -167,6 +210,9 @@ func (a *actionCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interf
 	for resp := range respChan {
 		out := state.Out[resp.Index]
 		output := fmt.Sprintf("[%s] %s %v: OK", systemTypeString(system), serviceName, as)
+		if st := resp.Resp.GetServiceStatus(); st != nil {
+			output = fmt.Sprintf("%s (%s)", output, statusString(st.GetStatus()))
+		}
 		if resp.Error != nil && err != io.EOF {
 			lastErr = fmt.Errorf("target %s (%d) returned error %w", resp.Target, resp.Index, resp.Error)
 			fmt.Fprint(state.Err[resp.Index], lastErr)
@@ -252,6 +298,11 @@ func (s *statusCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interf
 		if _, err := fmt.Fprintln(out, output); err != nil {
 			lastErr = fmt.Errorf("target %s [%d] write error: %w", resp.Target, resp.Index, err)
 			fmt.Fprint(state.Err[resp.Index], lastErr)
+			continue
+		}
+		if err := outputStatusDetails(out, resp.Resp.GetServiceStatus()); err != nil {
+			lastErr = fmt.Errorf("target %s [%d] write error: %w", resp.Target, resp.Index, err)
+			fmt.Fprint(state.Err[resp.Index], lastErr)
 		}
 	}
 	if lastErr != nil {
@@ -260,6 +311,40 @@ func (s *statusCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interf
 	return subcommands.ExitSuccess
 }
 
+// outputStatusDetails writes the additional unit state (if any) returned
+// for a service as indented lines below the one line summary.
+func outputStatusDetails(out io.Writer, st *pb.ServiceStatus) error {
+	var lines []string
+	if st.GetLoadState() != "" || st.GetActiveState() != "" || st.GetSubState() != "" {
+		lines = append(lines, fmt.Sprintf("State: %s/%s/%s (load/active/sub)", st.GetLoadState(), st.GetActiveState(), st.GetSubState()))
+	}
+	d := st.GetDetails()
+	if d.GetUnitFileState() != "" {
+		lines = append(lines, fmt.Sprintf("Unit file state: %s", d.GetUnitFileState()))
+	}
+	if d.GetMainPid() != 0 {
+		lines = append(lines, fmt.Sprintf("Main PID: %d", d.GetMainPid()))
+	}
+	if d != nil {
+		lines = append(lines, fmt.Sprintf("Restarts: %d", d.GetRestartCount()))
+	}
+	if d.GetActiveEnterTimestamp() != nil {
+		lines = append(lines, fmt.Sprintf("Active since: %s", d.GetActiveEnterTimestamp().AsTime().Local().Format(time.RFC1123)))
+	}
+	if d.GetMemoryCurrent() != 0 {
+		lines = append(lines, fmt.Sprintf("Memory: %d bytes", d.GetMemoryCurrent()))
+	}
+	if d.GetCpuUsageNsec() != 0 {
+		lines = append(lines, fmt.Sprintf("CPU: %s", time.Duration(d.GetCpuUsageNsec())))
+	}
+	for _, l := range lines {
+		if _, err := fmt.Fprintf(out, "    %s\n", l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type listCmd struct {
 	systemType string
 }
@@ -329,3 +414,678 @@ func (l *listCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interfac
 	}
 	return subcommands.ExitSuccess
 }
+
+type daemonReloadCmd struct {
+	systemType string
+}
+
+func (*daemonReloadCmd) Name() string     { return "daemon-reload" }
+func (*daemonReloadCmd) Synopsis() string { return "reload the service manager configuration" }
+func (*daemonReloadCmd) Usage() string {
+	return `daemon-reload [--system-type <type>]
+    reload the service manager configuration (i.e. after unit files have changed)
+  `
+}
+
+func (d *daemonReloadCmd) SetFlags(f *flag.FlagSet) {
+	systemTypeFlag(f, &d.systemType)
+}
+
+func (d *daemonReloadCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	state := args[0].(*util.ExecuteState)
+	errWriter := subcommands.DefaultCommander.Error
+
+	system, err := flagToSystemType(d.systemType)
+	if err != nil {
+		fmt.Fprintln(errWriter, err)
+		subcommands.DefaultCommander.ExplainCommand(errWriter, d)
+		return subcommands.ExitUsageError
+	}
+
+	req := &pb.DaemonReloadRequest{
+		SystemType: system,
+	}
+	c := pb.NewServiceClientProxy(state.Conn)
+
+	respChan, err := c.DaemonReloadOneMany(ctx, req)
+	if err != nil {
+		// Emit this to every error file as it's not specific to a given target.
+		for _, e := range state.Err {
+			fmt.Fprintf(e, "error executing 'daemon-reload': %v\n", err)
+		}
+		return subcommands.ExitFailure
+	}
+
+	// Error holding the last observed non-nil error, which will
+	// determine the exit status of the command.
+	// The contract with the proxy and 'many' functions requires
+	// that we completely drain the response channel, so we cannot
+	// return early here.
+	var lastErr error
+	for resp := range respChan {
+		if resp.Error != nil {
+			lastErr = fmt.Errorf("target %s (%d) error: %w", resp.Target, resp.Index, resp.Error)
+			fmt.Fprintln(state.Err[resp.Index], lastErr)
+			continue
+		}
+		if _, err := fmt.Fprintf(state.Out[resp.Index], "[%s] daemon-reload: OK\n", systemTypeString(resp.Resp.GetSystemType())); err != nil {
+			lastErr = fmt.Errorf("target %s (%d) writer error: %w", resp.Target, resp.Index, err)
+			fmt.Fprintln(state.Err[resp.Index], lastErr)
+		}
+	}
+	if lastErr != nil {
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
+
+type logsCmd struct {
+	systemType string
+	since      string
+	until      string
+	priority   string
+	grep       string
+	follow     bool
+}
+
+func (*logsCmd) Name() string     { return "logs" }
+func (*logsCmd) Synopsis() string { return "retrieve the logs of a service" }
+func (*logsCmd) Usage() string {
+	return `logs [--system-type <type>] [--since=T] [--until=T] [--priority=P] [--grep=RE] [--follow] <service>
+    return the log entries for the specified service. Times may be given
+    as RFC3339 timestamps or as durations relative to now (i.e. 1h).
+  `
+}
+
+func (l *logsCmd) SetFlags(f *flag.FlagSet) {
+	systemTypeFlag(f, &l.systemType)
+	f.StringVar(&l.since, "since", "", "Only return entries at or after this time")
+	f.StringVar(&l.until, "until", "", "Only return entries at or before this time")
+	f.StringVar(&l.priority, "priority", "", "Only return entries of this priority or more severe (one of: emerg, alert, crit, err, warning, notice, info, debug)")
+	f.StringVar(&l.grep, "grep", "", "Only return entries whose message matches this regular expression")
+	f.BoolVar(&l.follow, "follow", false, "Continue returning new entries until interrupted")
+}
+
+// parseLogTime parses either an RFC3339 timestamp or a duration which is
+// taken as relative to now (i.e. 1h means an hour ago).
+func parseLogTime(val string) (*timestamppb.Timestamp, error) {
+	if val == "" {
+		return nil, nil
+	}
+	if d, err := time.ParseDuration(val); err == nil {
+		return timestamppb.New(time.Now().Add(-d)), nil
+	}
+	t, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time %q: must be RFC3339 or a duration", val)
+	}
+	return timestamppb.New(t), nil
+}
+
+func priorityString(p pb.Priority) string {
+	return strings.ToLower(strings.TrimPrefix(p.String(), "PRIORITY_"))
+}
+
+func flagToPriority(val string) (pb.Priority, error) {
+	if val == "" {
+		return pb.Priority_PRIORITY_UNKNOWN, nil
+	}
+	i, ok := pb.Priority_value[fmt.Sprintf("PRIORITY_%s", strings.ToUpper(val))]
+	if !ok || pb.Priority(i) == pb.Priority_PRIORITY_UNKNOWN {
+		return pb.Priority_PRIORITY_UNKNOWN, fmt.Errorf("no such priority %s", val)
+	}
+	return pb.Priority(i), nil
+}
+
+func (l *logsCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	state := args[0].(*util.ExecuteState)
+	errWriter := subcommands.DefaultCommander.Error
+	if f.NArg() == 0 {
+		fmt.Fprintln(errWriter, "Please specify a service.")
+		subcommands.DefaultCommander.ExplainCommand(errWriter, l)
+		return subcommands.ExitUsageError
+	}
+	serviceName := f.Args()[0]
+
+	system, err := flagToSystemType(l.systemType)
+	if err != nil {
+		fmt.Fprintln(errWriter, err)
+		subcommands.DefaultCommander.ExplainCommand(errWriter, l)
+		return subcommands.ExitUsageError
+	}
+	since, err := parseLogTime(l.since)
+	if err != nil {
+		fmt.Fprintln(errWriter, err)
+		subcommands.DefaultCommander.ExplainCommand(errWriter, l)
+		return subcommands.ExitUsageError
+	}
+	until, err := parseLogTime(l.until)
+	if err != nil {
+		fmt.Fprintln(errWriter, err)
+		subcommands.DefaultCommander.ExplainCommand(errWriter, l)
+		return subcommands.ExitUsageError
+	}
+	priority, err := flagToPriority(l.priority)
+	if err != nil {
+		fmt.Fprintln(errWriter, err)
+		subcommands.DefaultCommander.ExplainCommand(errWriter, l)
+		return subcommands.ExitUsageError
+	}
+
+	req := &pb.LogsRequest{
+		SystemType:  system,
+		ServiceName: serviceName,
+		Since:       since,
+		Until:       until,
+		Priority:    priority,
+		Grep:        l.grep,
+		Follow:      l.follow,
+	}
+	c := pb.NewServiceClientProxy(state.Conn)
+
+	stream, err := c.LogsOneMany(ctx, req)
+	if err != nil {
+		// Emit this to every error file as it's not specific to a given target.
+		for _, e := range state.Err {
+			fmt.Fprintf(e, "error executing 'logs' for service %s: %v\n", serviceName, err)
+		}
+		return subcommands.ExitFailure
+	}
+
+	retCode := subcommands.ExitSuccess
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		// If the stream returns an error we're just done.
+		if err != nil {
+			// Emit this to every error file as it's not specific to a given target.
+			for _, e := range state.Err {
+				fmt.Fprintf(e, "Receive error: %v\n", err)
+			}
+			retCode = subcommands.ExitFailure
+			break
+		}
+		for _, r := range resp {
+			if r.Error != nil && r.Error != io.EOF {
+				fmt.Fprintf(state.Err[r.Index], "Error for target %s (%d): %v\n", r.Target, r.Index, r.Error)
+				retCode = subcommands.ExitFailure
+				continue
+			}
+			for _, e := range r.Resp.GetEntries() {
+				if err := outputLogEntry(state.Out[r.Index], e); err != nil {
+					fmt.Fprintf(state.Err[r.Index], "Error writing output for target %s (%d): %v\n", r.Target, r.Index, err)
+					retCode = subcommands.ExitFailure
+				}
+			}
+		}
+	}
+	return retCode
+}
+
+// outputLogEntry writes a log entry in a format similar to journalctl's default output.
+func outputLogEntry(out io.Writer, e *pb.LogEntry) error {
+	ident := e.GetIdentifier()
+	if e.GetPid() != 0 {
+		ident = fmt.Sprintf("%s[%d]", ident, e.GetPid())
+	}
+	_, err := fmt.Fprintf(out, "%s %s <%s>: %s\n", e.GetTimestamp().AsTime().Local().Format(time.RFC3339Nano), ident, priorityString(e.GetPriority()), e.GetMessage())
+	return err
+}
+
+type getUnitCmd struct {
+	systemType string
+}
+
+func (*getUnitCmd) Name() string     { return "get-unit" }
+func (*getUnitCmd) Synopsis() string { return "retrieve the configuration of a service" }
+func (*getUnitCmd) Usage() string {
+	return `get-unit [--system-type <type>] <service>
+    return the effective configuration of the specified service
+  `
+}
+
+func (g *getUnitCmd) SetFlags(f *flag.FlagSet) {
+	systemTypeFlag(f, &g.systemType)
+}
+
+func (g *getUnitCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	state := args[0].(*util.ExecuteState)
+	errWriter := subcommands.DefaultCommander.Error
+	if f.NArg() == 0 {
+		fmt.Fprintln(errWriter, "Please specify a service.")
+		subcommands.DefaultCommander.ExplainCommand(errWriter, g)
+		return subcommands.ExitUsageError
+	}
+	serviceName := f.Args()[0]
+
+	system, err := flagToSystemType(g.systemType)
+	if err != nil {
+		fmt.Fprintln(errWriter, err)
+		subcommands.DefaultCommander.ExplainCommand(errWriter, g)
+		return subcommands.ExitUsageError
+	}
+
+	req := &pb.GetUnitRequest{
+		SystemType:  system,
+		ServiceName: serviceName,
+	}
+	c := pb.NewServiceClientProxy(state.Conn)
+
+	respChan, err := c.GetUnitOneMany(ctx, req)
+	if err != nil {
+		// Emit this to every error file as it's not specific to a given target.
+		for _, e := range state.Err {
+			fmt.Fprintf(e, "error executing 'get-unit' for service %s: %v\n", serviceName, err)
+		}
+		return subcommands.ExitFailure
+	}
+
+	// Error holding the last observed non-nil error, which will
+	// determine the exit status of the command.
+	// The contract with the proxy and 'many' functions requires
+	// that we completely drain the response channel, so we cannot
+	// return early here.
+	// Note that this is only the last non-nil error, and previous
+	// error values may be lost.
+	var lastErr error
+	for resp := range respChan {
+		if resp.Error != nil {
+			lastErr = fmt.Errorf("target %s [%d] error: %w", resp.Target, resp.Index, resp.Error)
+			fmt.Fprintln(state.Err[resp.Index], lastErr)
+			continue
+		}
+		if err := outputUnitInfo(state.Out[resp.Index], resp.Resp.GetUnit()); err != nil {
+			lastErr = fmt.Errorf("target %s [%d] write error: %w", resp.Target, resp.Index, err)
+			fmt.Fprintln(state.Err[resp.Index], lastErr)
+		}
+	}
+	if lastErr != nil {
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
+
+// outputUnitInfo writes a unit's configuration as Key=value lines similar to systemctl show.
+func outputUnitInfo(out io.Writer, u *pb.UnitInfo) error {
+	lines := []string{
+		fmt.Sprintf("Description=%s", u.GetDescription()),
+		fmt.Sprintf("FragmentPath=%s", u.GetFragmentPath()),
+		fmt.Sprintf("DropInPaths=%s", strings.Join(u.GetDropInPaths(), " ")),
+		fmt.Sprintf("Type=%s", u.GetType()),
+	}
+	for _, e := range u.GetExecStart() {
+		prefix := ""
+		if e.GetIgnoreErrors() {
+			prefix = "-"
+		}
+		lines = append(lines, fmt.Sprintf("ExecStart=%s%s (%s)", prefix, e.GetPath(), strings.Join(e.GetArgs(), " ")))
+	}
+	lines = append(lines,
+		fmt.Sprintf("User=%s", u.GetUser()),
+		fmt.Sprintf("Group=%s", u.GetGroup()),
+		fmt.Sprintf("WorkingDirectory=%s", u.GetWorkingDirectory()),
+		fmt.Sprintf("Environment=%s", strings.Join(u.GetEnvironment(), " ")),
+		fmt.Sprintf("Restart=%s", u.GetRestart()),
+		fmt.Sprintf("RestartSec=%s", u.GetRestartSec().AsDuration()),
+	)
+	for _, l := range lines {
+		if _, err := fmt.Fprintln(out, l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type dependenciesCmd struct {
+	systemType string
+	recursive  bool
+}
+
+func (*dependenciesCmd) Name() string     { return "dependencies" }
+func (*dependenciesCmd) Synopsis() string { return "retrieve the dependencies of a service" }
+func (*dependenciesCmd) Usage() string {
+	return `dependencies [--system-type <type>] [--recursive] <service>
+    return the dependency edges of the specified service as: from type to
+  `
+}
+
+func (d *dependenciesCmd) SetFlags(f *flag.FlagSet) {
+	systemTypeFlag(f, &d.systemType)
+	f.BoolVar(&d.recursive, "recursive", false, "Also return the dependencies of required/wanted units")
+}
+
+func dependencyTypeString(t pb.DependencyType) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimPrefix(t.String(), "DEPENDENCY_TYPE_")), "_", "-")
+}
+
+func (d *dependenciesCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	state := args[0].(*util.ExecuteState)
+	errWriter := subcommands.DefaultCommander.Error
+	if f.NArg() == 0 {
+		fmt.Fprintln(errWriter, "Please specify a service.")
+		subcommands.DefaultCommander.ExplainCommand(errWriter, d)
+		return subcommands.ExitUsageError
+	}
+	serviceName := f.Args()[0]
+
+	system, err := flagToSystemType(d.systemType)
+	if err != nil {
+		fmt.Fprintln(errWriter, err)
+		subcommands.DefaultCommander.ExplainCommand(errWriter, d)
+		return subcommands.ExitUsageError
+	}
+
+	req := &pb.DependenciesRequest{
+		SystemType:  system,
+		ServiceName: serviceName,
+		Recursive:   d.recursive,
+	}
+	c := pb.NewServiceClientProxy(state.Conn)
+
+	respChan, err := c.DependenciesOneMany(ctx, req)
+	if err != nil {
+		// Emit this to every error file as it's not specific to a given target.
+		for _, e := range state.Err {
+			fmt.Fprintf(e, "error executing 'dependencies' for service %s: %v\n", serviceName, err)
+		}
+		return subcommands.ExitFailure
+	}
+
+	// Error holding the last observed non-nil error, which will
+	// determine the exit status of the command.
+	// The contract with the proxy and 'many' functions requires
+	// that we completely drain the response channel, so we cannot
+	// return early here.
+	// Note that this is only the last non-nil error, and previous
+	// error values may be lost.
+	var lastErr error
+	for resp := range respChan {
+		out := state.Out[resp.Index]
+		if resp.Error != nil {
+			lastErr = fmt.Errorf("target %s [%d] error: %w", resp.Target, resp.Index, resp.Error)
+			fmt.Fprintln(state.Err[resp.Index], lastErr)
+			continue
+		}
+		for _, dep := range resp.Resp.GetDependencies() {
+			if _, err := fmt.Fprintf(out, "%s %s %s\n", dep.GetFrom(), dependencyTypeString(dep.GetType()), dep.GetTo()); err != nil {
+				lastErr = fmt.Errorf("target %s [%d] write error: %w", resp.Target, resp.Index, err)
+				fmt.Fprintln(state.Err[resp.Index], lastErr)
+				break
+			}
+		}
+	}
+	if lastErr != nil {
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
+
+type watchCmd struct {
+	systemType string
+}
+
+func (*watchCmd) Name() string     { return "watch" }
+func (*watchCmd) Synopsis() string { return "stream service state changes" }
+func (*watchCmd) Usage() string {
+	return `watch [--system-type <type>] [service...]
+    stream the current state and then every state change of the specified
+    services (or all services if none are given) until interrupted
+  `
+}
+
+func (w *watchCmd) SetFlags(f *flag.FlagSet) {
+	systemTypeFlag(f, &w.systemType)
+}
+
+func (w *watchCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	state := args[0].(*util.ExecuteState)
+	errWriter := subcommands.DefaultCommander.Error
+
+	system, err := flagToSystemType(w.systemType)
+	if err != nil {
+		fmt.Fprintln(errWriter, err)
+		subcommands.DefaultCommander.ExplainCommand(errWriter, w)
+		return subcommands.ExitUsageError
+	}
+
+	req := &pb.WatchRequest{
+		SystemType:   system,
+		ServiceNames: f.Args(),
+	}
+	c := pb.NewServiceClientProxy(state.Conn)
+
+	stream, err := c.WatchOneMany(ctx, req)
+	if err != nil {
+		// Emit this to every error file as it's not specific to a given target.
+		for _, e := range state.Err {
+			fmt.Fprintf(e, "error executing 'watch': %v\n", err)
+		}
+		return subcommands.ExitFailure
+	}
+
+	retCode := subcommands.ExitSuccess
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		// If the stream returns an error we're just done.
+		if err != nil {
+			// Emit this to every error file as it's not specific to a given target.
+			for _, e := range state.Err {
+				fmt.Fprintf(e, "Receive error: %v\n", err)
+			}
+			retCode = subcommands.ExitFailure
+			break
+		}
+		for _, r := range resp {
+			if r.Error != nil && r.Error != io.EOF {
+				fmt.Fprintf(state.Err[r.Index], "Error for target %s (%d): %v\n", r.Target, r.Index, r.Error)
+				retCode = subcommands.ExitFailure
+				continue
+			}
+			if err := outputWatchEntry(state.Out[r.Index], r.Resp); err != nil {
+				fmt.Fprintf(state.Err[r.Index], "Error writing output for target %s (%d): %v\n", r.Target, r.Index, err)
+				retCode = subcommands.ExitFailure
+			}
+		}
+	}
+	return retCode
+}
+
+func serviceStateString(s *pb.ServiceStatus) string {
+	return fmt.Sprintf("%s (%s/%s/%s)", statusString(s.GetStatus()), s.GetLoadState(), s.GetActiveState(), s.GetSubState())
+}
+
+// outputWatchEntry writes a single state change as: time [system] service : [previous ->] current
+func outputWatchEntry(out io.Writer, r *pb.WatchReply) error {
+	transition := serviceStateString(r.GetCurrent())
+	if r.GetPrevious() != nil {
+		transition = fmt.Sprintf("%s -> %s", serviceStateString(r.GetPrevious()), transition)
+	}
+	_, err := fmt.Fprintf(out, "%s [%s] %s : %s\n", r.GetTimestamp().AsTime().Local().Format(time.RFC3339Nano), systemTypeString(r.GetSystemType()), r.GetCurrent().GetServiceName(), transition)
+	return err
+}
+
+type listTimersCmd struct {
+	systemType string
+}
+
+func (*listTimersCmd) Name() string     { return "list-timers" }
+func (*listTimersCmd) Synopsis() string { return "list timers (scheduled tasks)" }
+func (*listTimersCmd) Usage() string {
+	return `list-timers [--system-type <type>]
+    list the timers on the system with when they next and last elapsed
+  `
+}
+
+func (l *listTimersCmd) SetFlags(f *flag.FlagSet) {
+	systemTypeFlag(f, &l.systemType)
+}
+
+// timestampString formats an optional timestamp, using - if unset.
+func timestampString(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return "-"
+	}
+	return ts.AsTime().Local().Format(time.RFC3339)
+}
+
+func (l *listTimersCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	state := args[0].(*util.ExecuteState)
+	errWriter := subcommands.DefaultCommander.Error
+
+	system, err := flagToSystemType(l.systemType)
+	if err != nil {
+		fmt.Fprintln(errWriter, err)
+		subcommands.DefaultCommander.ExplainCommand(errWriter, l)
+		return subcommands.ExitUsageError
+	}
+
+	req := &pb.ListTimersRequest{
+		SystemType: system,
+	}
+	c := pb.NewServiceClientProxy(state.Conn)
+
+	respChan, err := c.ListTimersOneMany(ctx, req)
+	if err != nil {
+		// Emit this to every error file as it's not specific to a given target.
+		for _, e := range state.Err {
+			fmt.Fprintf(e, "error executing 'list-timers': %v\n", err)
+		}
+		return subcommands.ExitFailure
+	}
+
+	// Error holding the last observed non-nil error, which will
+	// determine the exit status of the command.
+	// The contract with the proxy and 'many' functions requires
+	// that we completely drain the response channel, so we cannot
+	// return early here.
+	// Note that this is only the last non-nil error, and previous
+	// error values may be lost.
+	var lastErr error
+	for resp := range respChan {
+		out := state.Out[resp.Index]
+		if resp.Error != nil {
+			lastErr = fmt.Errorf("target %s (%d) error: %w", resp.Target, resp.Index, resp.Error)
+			fmt.Fprintln(state.Err[resp.Index], lastErr)
+			continue
+		}
+		system := systemTypeString(resp.Resp.GetSystemType())
+		for _, t := range resp.Resp.GetTimers() {
+			if _, err := fmt.Fprintf(out, "[%s] %s -> %s : next %s last %s (%s)\n", system, t.GetTimerName(), t.GetUnit(), timestampString(t.GetNextElapse()), timestampString(t.GetLastTrigger()), strings.Join(t.GetSchedule(), ", ")); err != nil {
+				lastErr = fmt.Errorf("target %s [%d] writer error: %w", resp.Target, resp.Index, err)
+				fmt.Fprintln(state.Err[resp.Index], lastErr)
+				break
+			}
+		}
+	}
+	if lastErr != nil {
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
+
+type scheduleCmd struct {
+	systemType  string
+	name        string
+	description string
+	onCalendar  string
+	onActive    time.Duration
+	user        string
+}
+
+func (*scheduleCmd) Name() string     { return "schedule" }
+func (*scheduleCmd) Synopsis() string { return "schedule a command to run once later" }
+func (*scheduleCmd) Usage() string {
+	return `schedule [--system-type <type>] --name=N (--on-calendar=SPEC | --on-active=D) [--description=D] [--user=U] <command> [args...]
+    create a transient timer which runs the command (an absolute path) once
+    at the given time, similar to systemd-run
+  `
+}
+
+func (s *scheduleCmd) SetFlags(f *flag.FlagSet) {
+	systemTypeFlag(f, &s.systemType)
+	f.StringVar(&s.name, "name", "", "The name for the timer and service units (required)")
+	f.StringVar(&s.description, "description", "", "A description for the units")
+	f.StringVar(&s.onCalendar, "on-calendar", "", "When to run in systemd.time(7) calendar syntax (i.e. '2022-04-01 03:00')")
+	f.DurationVar(&s.onActive, "on-active", 0, "How long from now to run")
+	f.StringVar(&s.user, "user", "", "The user to run the command as (default root)")
+}
+
+func (s *scheduleCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	state := args[0].(*util.ExecuteState)
+	errWriter := subcommands.DefaultCommander.Error
+	if f.NArg() == 0 {
+		fmt.Fprintln(errWriter, "Please specify a command.")
+		subcommands.DefaultCommander.ExplainCommand(errWriter, s)
+		return subcommands.ExitUsageError
+	}
+	if s.name == "" {
+		fmt.Fprintln(errWriter, "--name must be set")
+		subcommands.DefaultCommander.ExplainCommand(errWriter, s)
+		return subcommands.ExitUsageError
+	}
+	if (s.onCalendar == "") == (s.onActive == 0) {
+		fmt.Fprintln(errWriter, "Exactly one of --on-calendar or --on-active must be set")
+		subcommands.DefaultCommander.ExplainCommand(errWriter, s)
+		return subcommands.ExitUsageError
+	}
+
+	system, err := flagToSystemType(s.systemType)
+	if err != nil {
+		fmt.Fprintln(errWriter, err)
+		subcommands.DefaultCommander.ExplainCommand(errWriter, s)
+		return subcommands.ExitUsageError
+	}
+
+	req := &pb.CreateTransientTimerRequest{
+		SystemType:  system,
+		Name:        s.name,
+		Description: s.description,
+		Command:     f.Args()[0],
+		Args:        f.Args()[1:],
+		User:        s.user,
+	}
+	if s.onCalendar != "" {
+		req.Schedule = &pb.CreateTransientTimerRequest_OnCalendar{OnCalendar: s.onCalendar}
+	} else {
+		req.Schedule = &pb.CreateTransientTimerRequest_OnActive{OnActive: durationpb.New(s.onActive)}
+	}
+	c := pb.NewServiceClientProxy(state.Conn)
+
+	respChan, err := c.CreateTransientTimerOneMany(ctx, req)
+	if err != nil {
+		// Emit this to every error file as it's not specific to a given target.
+		for _, e := range state.Err {
+			fmt.Fprintf(e, "error executing 'schedule': %v\n", err)
+		}
+		return subcommands.ExitFailure
+	}
+
+	// Error holding the last observed non-nil error, which will
+	// determine the exit status of the command.
+	// The contract with the proxy and 'many' functions requires
+	// that we completely drain the response channel, so we cannot
+	// return early here.
+	// Note that this is only the last non-nil error, and previous
+	// error values may be lost.
+	var lastErr error
+	for resp := range respChan {
+		if resp.Error != nil {
+			lastErr = fmt.Errorf("target %s (%d) error: %w", resp.Target, resp.Index, resp.Error)
+			fmt.Fprintln(state.Err[resp.Index], lastErr)
+			continue
+		}
+		if _, err := fmt.Fprintf(state.Out[resp.Index], "[%s] scheduled %s (%s)\n", systemTypeString(resp.Resp.GetSystemType()), resp.Resp.GetTimerName(), resp.Resp.GetServiceName()); err != nil {
+			lastErr = fmt.Errorf("target %s [%d] writer error: %w", resp.Target, resp.Index, err)
+			fmt.Fprintln(state.Err[resp.Index], lastErr)
+		}
+	}
+	if lastErr != nil {
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
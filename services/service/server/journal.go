@@ -0,0 +1,152 @@
+/* Copyright (c) 2019 Snowflake Inc. All rights reserved.
+
+   Licensed under the Apache License, Version 2.0 (the
+   "License"); you may not use this file except in compliance
+   with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing,
+   software distributed under the License is distributed on an
+   "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+   KIND, either express or implied.  See the License for the
+   specific language governing permissions and limitations
+   under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/Snowflake-Labs/sansshell/services/service"
+)
+
+// The journal export format is documented at
+// https://systemd.io/JOURNAL_EXPORT_FORMATS/
+//
+// Each entry is a series of fields terminated by an empty line.
+// Text fields are written as NAME=value followed by a newline.
+// Fields which may contain newlines or binary data are written as NAME
+// followed by a newline, a little endian 64 bit length, the data and a
+// trailing newline.
+
+// The journal fields used to build a pb.LogEntry.
+const (
+	journalFieldCursor     = "__CURSOR"
+	journalFieldRealtime   = "__REALTIME_TIMESTAMP"
+	journalFieldPriority   = "PRIORITY"
+	journalFieldIdentifier = "SYSLOG_IDENTIFIER"
+	journalFieldComm       = "_COMM"
+	journalFieldPID        = "_PID"
+	journalFieldSyslogPID  = "SYSLOG_PID"
+	journalFieldMessage    = "MESSAGE"
+)
+
+// maxJournalFieldSize bounds the size of a single binary field so a corrupt
+// stream can't cause an arbitrarily large allocation.
+const maxJournalFieldSize = 16 * 1024 * 1024
+
+// readJournalFields reads a single entry from a journal export stream and
+// returns its fields. If a field is repeated the last value wins.
+// io.EOF is returned only if the stream ends before any field is read.
+func readJournalFields(r *bufio.Reader) (map[string]string, error) {
+	fields := make(map[string]string)
+	for {
+		line, err := r.ReadString('\n')
+		if err == io.EOF && line == "" {
+			if len(fields) == 0 {
+				return nil, io.EOF
+			}
+			// Tolerate a missing final separator.
+			return fields, nil
+		}
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			if len(fields) == 0 {
+				// Skip any extra separators between entries.
+				continue
+			}
+			return fields, nil
+		}
+		if name, value, ok := strings.Cut(line, "="); ok {
+			fields[name] = value
+			continue
+		}
+		// Binary field.
+		var size uint64
+		if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+			return nil, fmt.Errorf("can't read size of field %s: %w", line, err)
+		}
+		if size > maxJournalFieldSize {
+			return nil, fmt.Errorf("field %s size %d is larger than max %d", line, size, maxJournalFieldSize)
+		}
+		data := make([]byte, size+1)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, fmt.Errorf("can't read field %s: %w", line, err)
+		}
+		if data[size] != '\n' {
+			return nil, fmt.Errorf("field %s not terminated by newline", line)
+		}
+		fields[line] = string(data[:size])
+	}
+}
+
+// journalFieldsToEntry converts the fields of a journal entry into a pb.LogEntry.
+func journalFieldsToEntry(fields map[string]string) (*pb.LogEntry, error) {
+	entry := &pb.LogEntry{
+		Cursor:     fields[journalFieldCursor],
+		Message:    fields[journalFieldMessage],
+		Identifier: fields[journalFieldIdentifier],
+	}
+	if entry.Identifier == "" {
+		entry.Identifier = fields[journalFieldComm]
+	}
+	if ts, ok := fields[journalFieldRealtime]; ok {
+		usec, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", journalFieldRealtime, ts, err)
+		}
+		entry.Timestamp = timestamppb.New(time.UnixMicro(usec))
+	}
+	if p, ok := fields[journalFieldPriority]; ok {
+		prio, err := strconv.ParseUint(p, 10, 8)
+		if err != nil || prio > 7 {
+			return nil, fmt.Errorf("invalid %s %q", journalFieldPriority, p)
+		}
+		// Syslog levels are offset by one from the enum to leave room for unknown.
+		entry.Priority = pb.Priority(prio + 1)
+	}
+	pid, ok := fields[journalFieldPID]
+	if !ok {
+		pid, ok = fields[journalFieldSyslogPID]
+	}
+	if ok {
+		var err error
+		if entry.Pid, err = strconv.ParseInt(pid, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid pid %q: %w", pid, err)
+		}
+	}
+	return entry, nil
+}
+
+// readJournalEntry reads the next entry from a journal export stream.
+// io.EOF is returned once the stream is exhausted.
+func readJournalEntry(r *bufio.Reader) (*pb.LogEntry, error) {
+	fields, err := readJournalFields(r)
+	if err != nil {
+		return nil, err
+	}
+	return journalFieldsToEntry(fields)
+}
@@ -0,0 +1,178 @@
+/* Copyright (c) 2019 Snowflake Inc. All rights reserved.
+
+   Licensed under the Apache License, Version 2.0 (the
+   "License"); you may not use this file except in compliance
+   with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing,
+   software distributed under the License is distributed on an
+   "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+   KIND, either express or implied.  See the License for the
+   specific language governing permissions and limitations
+   under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/Snowflake-Labs/sansshell/services/service"
+	"github.com/Snowflake-Labs/sansshell/testing/testutil"
+)
+
+var (
+	testdataJournal          = "./testdata/journal.export"
+	testdataJournalTruncated = "./testdata/journal_truncated.export"
+)
+
+// journalFixtureEntries are the entries recorded in testdataJournal.
+var journalFixtureEntries = []*pb.LogEntry{
+	{
+		Timestamp:  timestamppb.New(time.UnixMicro(1648816200000000)),
+		Priority:   pb.Priority_PRIORITY_INFO,
+		Identifier: "systemd",
+		Pid:        1,
+		Message:    "Started Foo Server.",
+		Cursor:     "s=3b1a;i=1a01;b=9f0c;m=2dc6c0;t=5db7a1e4c3a80;x=11",
+	},
+	{
+		Timestamp:  timestamppb.New(time.UnixMicro(1648816200500000)),
+		Priority:   pb.Priority_PRIORITY_INFO,
+		Identifier: "foo",
+		Pid:        1234,
+		Message:    "listening on :8080",
+		Cursor:     "s=3b1a;i=1a02;b=9f0c;m=2dc6c1;t=5db7a1e4c3a81;x=12",
+	},
+	{
+		Timestamp:  timestamppb.New(time.UnixMicro(1648816260000000)),
+		Priority:   pb.Priority_PRIORITY_ERR,
+		Identifier: "foo",
+		Pid:        1234,
+		Message:    "panic: config missing\n\ngoroutine 1 [running]:\nmain.main()",
+		Cursor:     "s=3b1a;i=1a03;b=9f0c;m=2dc6c2;t=5db7a1e4c3a82;x=13",
+	},
+	{
+		Timestamp:  timestamppb.New(time.UnixMicro(1648816260100000)),
+		Priority:   pb.Priority_PRIORITY_NOTICE,
+		Identifier: "systemd",
+		Pid:        1,
+		Message:    "foo.service: Main process exited, code=exited, status=2/INVALIDARGUMENT",
+		Cursor:     "s=3b1a;i=1a04;b=9f0c;m=2dc6c3;t=5db7a1e4c3a83;x=14",
+	},
+}
+
+func readAllJournalEntries(r io.Reader) ([]*pb.LogEntry, error) {
+	br := bufio.NewReader(r)
+	var entries []*pb.LogEntry
+	for {
+		e, err := readJournalEntry(br)
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return entries, err
+		}
+		entries = append(entries, e)
+	}
+}
+
+func TestReadJournalEntry(t *testing.T) {
+	f, err := os.Open(testdataJournal)
+	testutil.FatalOnErr("open fixture", err, t)
+	defer f.Close()
+	got, err := readAllJournalEntries(f)
+	testutil.FatalOnErr("read fixture", err, t)
+	testutil.DiffErr("fixture", got, journalFixtureEntries, t)
+
+	truncated, err := os.Open(testdataJournalTruncated)
+	testutil.FatalOnErr("open truncated fixture", err, t)
+	defer truncated.Close()
+	_, err = readAllJournalEntries(truncated)
+	testutil.WantErr("truncated", err, true, t)
+
+	for _, tc := range []struct {
+		name    string
+		input   string
+		want    []*pb.LogEntry
+		wantErr bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:  "no trailing separator",
+			input: "MESSAGE=hello\nPRIORITY=7",
+			want: []*pb.LogEntry{
+				{
+					Message:  "hello",
+					Priority: pb.Priority_PRIORITY_DEBUG,
+				},
+			},
+		},
+		{
+			name:  "extra separators",
+			input: "\n\nMESSAGE=one\n\n\nMESSAGE=two\n\n",
+			want: []*pb.LogEntry{
+				{Message: "one"},
+				{Message: "two"},
+			},
+		},
+		{
+			name:  "value containing equals",
+			input: "MESSAGE=a=b\n\n",
+			want: []*pb.LogEntry{
+				{Message: "a=b"},
+			},
+		},
+		{
+			name:    "bad priority",
+			input:   "PRIORITY=8\n\n",
+			wantErr: true,
+		},
+		{
+			name:    "bad timestamp",
+			input:   "__REALTIME_TIMESTAMP=yesterday\n\n",
+			wantErr: true,
+		},
+		{
+			name:    "bad pid",
+			input:   "_PID=-\n\n",
+			wantErr: true,
+		},
+		{
+			name:    "binary field missing size",
+			input:   "MESSAGE\n\x01\x00",
+			wantErr: true,
+		},
+		{
+			name:    "binary field missing newline",
+			input:   "MESSAGE\n\x02\x00\x00\x00\x00\x00\x00\x00hiX",
+			wantErr: true,
+		},
+		{
+			name:    "binary field too large",
+			input:   "MESSAGE\n\xff\xff\xff\xff\xff\xff\xff\xff",
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := readAllJournalEntries(strings.NewReader(tc.input))
+			testutil.WantErr(tc.name, err, tc.wantErr, t)
+			if tc.wantErr {
+				return
+			}
+			testutil.DiffErr(tc.name, got, tc.want, t)
+		})
+	}
+}
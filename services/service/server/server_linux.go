@@ -20,15 +20,34 @@
 package server
 
 import (
+	"bufio"
 	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os/exec"
+	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/coreos/go-systemd/v22/dbus"
+	godbus "github.com/godbus/dbus/v5"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/Snowflake-Labs/sansshell/services/healthcheck"
 	pb "github.com/Snowflake-Labs/sansshell/services/service"
+	"github.com/Snowflake-Labs/sansshell/services/util"
+)
+
+var (
+	journalctlBin = flag.String("journalctl-bin", "/usr/bin/journalctl", "Path to the journalctl binary")
+	systemdRunBin = flag.String("systemd-run-bin", "/usr/bin/systemd-run", "Path to the systemd-run binary")
 )
 
 // Systemd deals in 'units', which might be services, devices, sockets,
@@ -46,13 +65,18 @@ import (
 const (
 	// systemd has loaded the unit definition into memory
 	loadStateLoaded = "loaded"
+	// no unit definition exists for the unit
+	loadStateNotFound = "not-found"
 )
 
 // A unit's active state describes the administrative state of the unit
 // (e.g. whether it is enabled or disabled)
 // Only a subset of the possible values are defined here.
 const (
-	activeStateActive = "active"
+	activeStateActive       = "active"
+	activeStateFailed       = "failed"
+	activeStateActivating   = "activating"
+	activeStateDeactivating = "deactivating"
 )
 
 // A unit's sub-state provides more granular status of the unit
@@ -63,9 +87,10 @@ const (
 	substateRunning = "running"
 )
 
-// The suffix used for units of type 'service'
+// The suffixes used for units of type 'service' and 'timer'
 const (
 	unitSuffixService = ".service"
+	unitSuffixTimer   = ".timer"
 )
 
 // SystemD operations on units can take several 'modes', which
@@ -87,9 +112,23 @@ const (
 	operationResultDone = "done"
 )
 
+// The unit types used to query type specific properties.
+const (
+	unitTypeService = "Service"
+	unitTypeTimer   = "Timer"
+)
+
 // convert a dbus.UnitStatus to a servicepb.Status
 func unitStateToStatus(u dbus.UnitStatus) pb.Status {
 	switch {
+	// Failed and transitional states take precedence as the sub state
+	// will vary with the exact reason.
+	case u.ActiveState == activeStateFailed:
+		return pb.Status_STATUS_FAILED
+	case u.ActiveState == activeStateActivating:
+		return pb.Status_STATUS_ACTIVATING
+	case u.ActiveState == activeStateDeactivating:
+		return pb.Status_STATUS_DEACTIVATING
 	// A service is 'running' if it's loaded, active, and running.
 	case u.LoadState == loadStateLoaded && u.ActiveState == activeStateActive && u.SubState == substateRunning:
 		return pb.Status_STATUS_RUNNING
@@ -108,6 +147,17 @@ type systemdConnection interface {
 	StartUnitContext(ctx context.Context, name string, mode string, ch chan<- string) (int, error)
 	StopUnitContext(ctx context.Context, name string, mode string, ch chan<- string) (int, error)
 	RestartUnitContext(ctx context.Context, name string, mode string, ch chan<- string) (int, error)
+	ReloadUnitContext(ctx context.Context, name string, mode string, ch chan<- string) (int, error)
+	EnableUnitFilesContext(ctx context.Context, files []string, runtime bool, force bool) (bool, []dbus.EnableUnitFileChange, error)
+	DisableUnitFilesContext(ctx context.Context, files []string, runtime bool) ([]dbus.DisableUnitFileChange, error)
+	MaskUnitFilesContext(ctx context.Context, files []string, runtime bool, force bool) ([]dbus.MaskUnitFileChange, error)
+	UnmaskUnitFilesContext(ctx context.Context, files []string, runtime bool) ([]dbus.UnmaskUnitFileChange, error)
+	ResetFailedUnitContext(ctx context.Context, name string) error
+	ReloadContext(ctx context.Context) error
+	GetUnitPropertiesContext(ctx context.Context, unit string) (map[string]interface{}, error)
+	GetUnitTypePropertiesContext(ctx context.Context, unit string, unitType string) (map[string]interface{}, error)
+	Subscribe() error
+	SetPropertiesSubscriber(updateCh chan<- *dbus.PropertiesUpdate, errCh chan<- error)
 	Close()
 }
 
@@ -115,6 +165,13 @@ type systemdConnection interface {
 type server struct {
 	// dialSystemd is the function used to create connections to systemd.
 	dialSystemd func(context.Context) (systemdConnection, error)
+
+	// journal is the function used to run a journal query with the given
+	// journalctl arguments. It returns the export formatted output.
+	journal func(ctx context.Context, args []string) (io.ReadCloser, error)
+
+	// systemdRun is the function used to run systemd-run with the given arguments.
+	systemdRun func(ctx context.Context, args []string) error
 }
 
 func dialSystemd(ctx context.Context) (systemdConnection, error) {
@@ -125,8 +182,67 @@ func dialSystemd(ctx context.Context) (systemdConnection, error) {
 	return conn, nil
 }
 
+// journalReader is the output of a running journalctl command. Close waits
+// for the command to exit and returns any error it reported.
+type journalReader struct {
+	io.ReadCloser
+	cmd    *exec.Cmd
+	stderr *util.LimitedBuffer
+}
+
+func (j *journalReader) Close() error {
+	j.ReadCloser.Close()
+	if err := j.cmd.Wait(); err != nil {
+		return fmt.Errorf("%v: %s", err, j.stderr.String())
+	}
+	return nil
+}
+
+func runJournalctl(ctx context.Context, args []string) (io.ReadCloser, error) {
+	cmd := exec.CommandContext(ctx, *journalctlBin, args...)
+	stderr := util.NewLimitedBuffer(util.MaxBuf)
+	cmd.Stderr = stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &journalReader{ReadCloser: stdout, cmd: cmd, stderr: stderr}, nil
+}
+
+func runSystemdRun(ctx context.Context, args []string) error {
+	run, err := util.RunCommand(ctx, *systemdRunBin, args)
+	if err != nil {
+		return err
+	}
+	if err := run.Error; err != nil {
+		return fmt.Errorf("%v: %s", err, util.TrimString(run.Stderr.String()))
+	}
+	return nil
+}
+
+// checkSystemd is a health check which confirms systemd is reachable over dbus.
+func checkSystemd(ctx context.Context) error {
+	conn, err := dialSystemd(ctx)
+	if err != nil {
+		return fmt.Errorf("can't connect to systemd: %v", err)
+	}
+	conn.Close()
+	return nil
+}
+
+func init() {
+	healthcheck.RegisterCheck(pb.Service_ServiceDesc.ServiceName, "systemd-dbus", checkSystemd)
+}
+
 func createServer() pb.ServiceServer {
-	return &server{dialSystemd: dialSystemd}
+	return &server{
+		dialSystemd: dialSystemd,
+		journal:     runJournalctl,
+		systemdRun:  runSystemdRun,
+	}
 }
 
 // implement sort.Interface for UnitStatus slices, so that List can return
@@ -137,6 +253,177 @@ func (s byName) Len() int           { return len(s) }
 func (s byName) Less(i, j int) bool { return s[i].Name < s[j].Name }
 func (s byName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
 
+// unitToServiceStatus converts a dbus.UnitStatus for a service into a pb.ServiceStatus.
+func unitToServiceStatus(name string, u dbus.UnitStatus) *pb.ServiceStatus {
+	return &pb.ServiceStatus{
+		ServiceName: name,
+		Status:      unitStateToStatus(u),
+		LoadState:   u.LoadState,
+		ActiveState: u.ActiveState,
+		SubState:    u.SubState,
+	}
+}
+
+// Accessors for dbus properties which tolerate missing or unexpectedly
+// typed values (which vary across systemd versions) by returning zero values.
+func stringProperty(props map[string]interface{}, name string) string {
+	v, _ := props[name].(string)
+	return v
+}
+
+func uint32Property(props map[string]interface{}, name string) uint32 {
+	v, _ := props[name].(uint32)
+	return v
+}
+
+// systemd uses UINT64_MAX to indicate a value isn't set (i.e. accounting is disabled).
+func uint64Property(props map[string]interface{}, name string) uint64 {
+	v, _ := props[name].(uint64)
+	if v == math.MaxUint64 {
+		return 0
+	}
+	return v
+}
+
+// unitDetails fetches the extended details for a service unit from its dbus properties.
+func unitDetails(ctx context.Context, conn systemdConnection, unitName string) (*pb.ServiceDetails, error) {
+	unitProps, err := conn.GetUnitPropertiesContext(ctx, unitName)
+	if err != nil {
+		return nil, err
+	}
+	serviceProps, err := conn.GetUnitTypePropertiesContext(ctx, unitName, unitTypeService)
+	if err != nil {
+		return nil, err
+	}
+	details := &pb.ServiceDetails{
+		UnitFileState: stringProperty(unitProps, "UnitFileState"),
+		MainPid:       int64(uint32Property(serviceProps, "MainPID")),
+		RestartCount:  uint32Property(serviceProps, "NRestarts"),
+		MemoryCurrent: uint64Property(serviceProps, "MemoryCurrent"),
+		CpuUsageNsec:  uint64Property(serviceProps, "CPUUsageNSec"),
+	}
+	// Timestamps are in microseconds since the epoch with 0 meaning never.
+	if ts := uint64Property(unitProps, "ActiveEnterTimestamp"); ts != 0 {
+		details.ActiveEnterTimestamp = timestamppb.New(time.UnixMicro(int64(ts)))
+	}
+	return details, nil
+}
+
+func stringsProperty(props map[string]interface{}, name string) []string {
+	v, _ := props[name].([]string)
+	return v
+}
+
+// execProperty converts an ExecStart (or similar) property into ExecCommands.
+// Over dbus these are an array of structs of type (sasbttttuii) where the
+// first 3 fields are the path, arguments and whether errors are ignored.
+func execProperty(props map[string]interface{}, name string) []*pb.ExecCommand {
+	v, _ := props[name].([][]interface{})
+	var cmds []*pb.ExecCommand
+	for _, fields := range v {
+		if len(fields) < 3 {
+			continue
+		}
+		cmd := &pb.ExecCommand{}
+		cmd.Path, _ = fields[0].(string)
+		cmd.Args, _ = fields[1].([]string)
+		cmd.IgnoreErrors, _ = fields[2].(bool)
+		cmds = append(cmds, cmd)
+	}
+	return cmds
+}
+
+// unitInfo fetches the effective configuration for a service unit from its dbus properties.
+func unitInfo(ctx context.Context, conn systemdConnection, unitName string, serviceName string) (*pb.UnitInfo, error) {
+	unitProps, err := conn.GetUnitPropertiesContext(ctx, unitName)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "systemd properties error %v", err)
+	}
+	// systemd will happily return properties for units which don't exist.
+	if stringProperty(unitProps, "LoadState") == loadStateNotFound {
+		return nil, status.Errorf(codes.NotFound, "service %s was not found", serviceName)
+	}
+	serviceProps, err := conn.GetUnitTypePropertiesContext(ctx, unitName, unitTypeService)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "systemd properties error %v", err)
+	}
+	info := &pb.UnitInfo{
+		ServiceName:      serviceName,
+		Description:      stringProperty(unitProps, "Description"),
+		FragmentPath:     stringProperty(unitProps, "FragmentPath"),
+		DropInPaths:      stringsProperty(unitProps, "DropInPaths"),
+		Type:             stringProperty(serviceProps, "Type"),
+		ExecStart:        execProperty(serviceProps, "ExecStart"),
+		User:             stringProperty(serviceProps, "User"),
+		Group:            stringProperty(serviceProps, "Group"),
+		WorkingDirectory: stringProperty(serviceProps, "WorkingDirectory"),
+		Environment:      stringsProperty(serviceProps, "Environment"),
+		Restart:          stringProperty(serviceProps, "Restart"),
+	}
+	// Durations are in microseconds.
+	if _, ok := serviceProps["RestartUSec"]; ok {
+		info.RestartSec = durationpb.New(time.Duration(uint64Property(serviceProps, "RestartUSec")) * time.Microsecond)
+	}
+	return info, nil
+}
+
+// dependencyProperties maps dependency types to the unit property listing them.
+var dependencyProperties = []struct {
+	depType     pb.DependencyType
+	property    string
+	requirement bool
+}{
+	{pb.DependencyType_DEPENDENCY_TYPE_REQUIRES, "Requires", true},
+	{pb.DependencyType_DEPENDENCY_TYPE_REQUISITE, "Requisite", true},
+	{pb.DependencyType_DEPENDENCY_TYPE_WANTS, "Wants", true},
+	{pb.DependencyType_DEPENDENCY_TYPE_BINDS_TO, "BindsTo", true},
+	{pb.DependencyType_DEPENDENCY_TYPE_PART_OF, "PartOf", false},
+	{pb.DependencyType_DEPENDENCY_TYPE_CONFLICTS, "Conflicts", false},
+	{pb.DependencyType_DEPENDENCY_TYPE_BEFORE, "Before", false},
+	{pb.DependencyType_DEPENDENCY_TYPE_AFTER, "After", false},
+}
+
+// errUnitNotFound is returned by unitDependencies if the starting unit doesn't exist.
+var errUnitNotFound = errors.New("unit not found")
+
+// unitDependencies returns the dependency edges for a unit. If recursive
+// is set the requirement dependencies are followed (breadth first) and
+// their edges returned as well.
+func unitDependencies(ctx context.Context, conn systemdConnection, unitName string, recursive bool) ([]*pb.Dependency, error) {
+	var deps []*pb.Dependency
+	seen := map[string]bool{unitName: true}
+	queue := []string{unitName}
+	for len(queue) > 0 {
+		from := queue[0]
+		queue = queue[1:]
+		props, err := conn.GetUnitPropertiesContext(ctx, from)
+		if err != nil {
+			return nil, err
+		}
+		// Dependencies may legitimately name units which don't exist
+		// so only the starting unit must be found.
+		if from == unitName && stringProperty(props, "LoadState") == loadStateNotFound {
+			return nil, errUnitNotFound
+		}
+		for _, dp := range dependencyProperties {
+			to := append([]string(nil), stringsProperty(props, dp.property)...)
+			sort.Strings(to)
+			for _, t := range to {
+				deps = append(deps, &pb.Dependency{
+					From: from,
+					To:   t,
+					Type: dp.depType,
+				})
+				if recursive && dp.requirement && !seen[t] {
+					seen[t] = true
+					queue = append(queue, t)
+				}
+			}
+		}
+	}
+	return deps, nil
+}
+
 func checkSupportedSystem(t pb.SystemType) error {
 	switch t {
 	case pb.SystemType_SYSTEM_TYPE_UNKNOWN, pb.SystemType_SYSTEM_TYPE_SYSTEMD:
@@ -176,10 +463,7 @@ func (s *server) List(ctx context.Context, req *pb.ListRequest) (*pb.ListReply,
 		if !strings.HasSuffix(u.Name, unitSuffixService) {
 			continue
 		}
-		resp.Services = append(resp.Services, &pb.ServiceStatus{
-			ServiceName: strings.TrimSuffix(u.Name, unitSuffixService),
-			Status:      unitStateToStatus(u),
-		})
+		resp.Services = append(resp.Services, unitToServiceStatus(strings.TrimSuffix(u.Name, unitSuffixService), u))
 	}
 	return resp, nil
 }
@@ -206,25 +490,131 @@ func (s *server) Status(ctx context.Context, req *pb.StatusRequest) (*pb.StatusR
 	}
 	defer conn.Close()
 
+	u, found, err := findUnit(ctx, conn, unitName)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "systemd status error %v", err)
+	}
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "service %s was not found", req.GetServiceName())
+	}
+	ss := unitToServiceStatus(req.GetServiceName(), u)
+	if ss.Details, err = unitDetails(ctx, conn, unitName); err != nil {
+		return nil, status.Errorf(codes.Internal, "systemd properties error %v", err)
+	}
+	return &pb.StatusReply{
+		SystemType:    pb.SystemType_SYSTEM_TYPE_SYSTEMD,
+		ServiceStatus: ss,
+	}, nil
+}
+
+// findUnit returns the current state of the named unit and whether it
+// was found.
+func findUnit(ctx context.Context, conn systemdConnection, unitName string) (dbus.UnitStatus, bool, error) {
 	// NB: ideally we'd use ListUnitsByNamesContext, but older versions of systemd
 	// do not support this method, so the most failsafe method that works on all systemd
 	// versions is to retrieve the full list of units, and filter here.
 	units, err := conn.ListUnitsContext(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "systemd status error %v", err)
+		return dbus.UnitStatus{}, false, err
 	}
 	for _, u := range units {
 		if u.Name == unitName {
-			return &pb.StatusReply{
-				SystemType: pb.SystemType_SYSTEM_TYPE_SYSTEMD,
-				ServiceStatus: &pb.ServiceStatus{
-					ServiceName: req.GetServiceName(),
-					Status:      unitStateToStatus(u),
-				},
-			}, nil
+			return u, true, nil
+		}
+	}
+	return dbus.UnitStatus{}, false, nil
+}
+
+// waitPollInterval is how often the unit state is checked when waiting
+// for an action to take effect.
+var waitPollInterval = 250 * time.Millisecond
+
+// defaultWaitTimeout is used if WaitFor doesn't specify a timeout.
+const defaultWaitTimeout = time.Minute
+
+// waitParams validates a WaitFor for the given action and returns the
+// status to wait for along with the settle time and timeout.
+func waitParams(action pb.Action, w *pb.WaitFor) (pb.Status, time.Duration, time.Duration, error) {
+	target := w.GetStatus()
+	if target == pb.Status_STATUS_UNKNOWN {
+		switch action {
+		case pb.Action_ACTION_START, pb.Action_ACTION_RESTART, pb.Action_ACTION_RELOAD:
+			target = pb.Status_STATUS_RUNNING
+		case pb.Action_ACTION_STOP:
+			target = pb.Status_STATUS_STOPPED
+		default:
+			return 0, 0, 0, status.Errorf(codes.InvalidArgument, "wait_for requires a status for action %v", action)
+		}
+	}
+	if target != pb.Status_STATUS_RUNNING && target != pb.Status_STATUS_STOPPED {
+		return 0, 0, 0, status.Errorf(codes.InvalidArgument, "wait_for status %v is not supported", target)
+	}
+	var settle time.Duration
+	if w.GetSettleTime() != nil {
+		if err := w.GetSettleTime().CheckValid(); err != nil {
+			return 0, 0, 0, status.Errorf(codes.InvalidArgument, "invalid settle_time: %v", err)
+		}
+		settle = w.GetSettleTime().AsDuration()
+	}
+	timeout := defaultWaitTimeout
+	if w.GetTimeout() != nil {
+		if err := w.GetTimeout().CheckValid(); err != nil {
+			return 0, 0, 0, status.Errorf(codes.InvalidArgument, "invalid timeout: %v", err)
+		}
+		timeout = w.GetTimeout().AsDuration()
+	}
+	if settle < 0 || timeout <= 0 {
+		return 0, 0, 0, status.Error(codes.InvalidArgument, "wait_for durations must be positive")
+	}
+	if settle >= timeout {
+		return 0, 0, 0, status.Errorf(codes.InvalidArgument, "settle_time %v must be less than timeout %v", settle, timeout)
+	}
+	return target, settle, timeout, nil
+}
+
+// waitForStatus polls the unit until it has been in the target status for
+// the settle time. It returns the final observed status or an error if
+// the unit failed, left the target status while settling or the timeout
+// passed first.
+func waitForStatus(ctx context.Context, conn systemdConnection, unitName string, serviceName string, target pb.Status, settle time.Duration, timeout time.Duration) (*pb.ServiceStatus, error) {
+	deadline := time.Now().Add(timeout)
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+
+	var reached time.Time
+	for {
+		u, found, err := findUnit(ctx, conn, unitName)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "systemd status error %v", err)
+		}
+		ss := unitToServiceStatus(serviceName, u)
+		if !found {
+			// systemd unloads units which are inactive and not referenced.
+			ss.Status = pb.Status_STATUS_STOPPED
+		}
+		now := time.Now()
+		switch {
+		case ss.Status == target:
+			if reached.IsZero() {
+				reached = now
+			}
+			if now.Sub(reached) >= settle {
+				return ss, nil
+			}
+		case ss.Status == pb.Status_STATUS_FAILED:
+			return nil, status.Errorf(codes.FailedPrecondition, "service %s failed while waiting for %v (%s/%s/%s)", serviceName, target, ss.LoadState, ss.ActiveState, ss.SubState)
+		case !reached.IsZero():
+			return nil, status.Errorf(codes.FailedPrecondition, "service %s left %v after %v while settling: now %v (%s/%s/%s)", serviceName, target, now.Sub(reached), ss.Status, ss.LoadState, ss.ActiveState, ss.SubState)
+		}
+		if now.After(deadline) {
+			return nil, status.Errorf(codes.DeadlineExceeded, "timed out after %v waiting for service %s to be %v: last status %v (%s/%s/%s)", timeout, serviceName, target, ss.Status, ss.LoadState, ss.ActiveState, ss.SubState)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
 		}
 	}
-	return nil, status.Errorf(codes.NotFound, "service %s was not found", req.GetServiceName())
 }
 
 // See: pb.ServiceServer.Action
@@ -242,20 +632,53 @@ func (s *server) Action(ctx context.Context, req *pb.ActionRequest) (*pb.ActionR
 		unitName = unitName + unitSuffixService
 	}
 
+	var target pb.Status
+	var settle, timeout time.Duration
+	if req.WaitFor != nil {
+		var err error
+		if target, settle, timeout, err = waitParams(req.Action, req.WaitFor); err != nil {
+			return nil, err
+		}
+	}
+
 	conn, err := s.dialSystemd(ctx)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "error establishing systemd connection: %v", err)
 	}
 	defer conn.Close()
 
+	// Start/stop/etc are asynchronous jobs which report their result on resultChan.
+	// Changes to unit files (enable/disable/etc) are synchronous but require
+	// a daemon reload afterwards (as systemctl does) for systemd to notice.
 	resultChan := make(chan string)
+	isJob, unitFileChanged := false, false
 	switch req.Action {
 	case pb.Action_ACTION_START:
 		_, err = conn.StartUnitContext(ctx, unitName, modeReplace, resultChan)
+		isJob = true
 	case pb.Action_ACTION_RESTART:
 		_, err = conn.RestartUnitContext(ctx, unitName, modeReplace, resultChan)
+		isJob = true
 	case pb.Action_ACTION_STOP:
 		_, err = conn.StopUnitContext(ctx, unitName, modeReplace, resultChan)
+		isJob = true
+	case pb.Action_ACTION_RELOAD:
+		_, err = conn.ReloadUnitContext(ctx, unitName, modeReplace, resultChan)
+		isJob = true
+	case pb.Action_ACTION_ENABLE:
+		_, _, err = conn.EnableUnitFilesContext(ctx, []string{unitName}, false, false)
+		unitFileChanged = true
+	case pb.Action_ACTION_DISABLE:
+		_, err = conn.DisableUnitFilesContext(ctx, []string{unitName}, false)
+		unitFileChanged = true
+	case pb.Action_ACTION_MASK:
+		_, err = conn.MaskUnitFilesContext(ctx, []string{unitName}, false, false)
+		unitFileChanged = true
+	case pb.Action_ACTION_UNMASK:
+		_, err = conn.UnmaskUnitFilesContext(ctx, []string{unitName}, false)
+		unitFileChanged = true
+	case pb.Action_ACTION_RESET_FAILED:
+		err = conn.ResetFailedUnitContext(ctx, unitName)
 	default:
 		return nil, status.Errorf(codes.InvalidArgument, "invalid action type %v", req.Action)
 	}
@@ -263,15 +686,512 @@ func (s *server) Action(ctx context.Context, req *pb.ActionRequest) (*pb.ActionR
 		return nil, status.Errorf(codes.Internal, "error performing action %v: %v", req.Action, err)
 	}
 
-	// NB: delivery of a value on resultchan respects context cancellation, and will
-	// deliver a value of 'cancelled' if the ctx is cancelled by a client disconnect,
-	// so it's safe to do a simple recv.
-	result := <-resultChan
-	if result != operationResultDone {
-		return nil, status.Errorf(codes.Internal, "error performing action %v: %v", req.Action, result)
+	if isJob {
+		// NB: delivery of a value on resultchan respects context cancellation, and will
+		// deliver a value of 'cancelled' if the ctx is cancelled by a client disconnect,
+		// so it's safe to do a simple recv.
+		result := <-resultChan
+		if result != operationResultDone {
+			return nil, status.Errorf(codes.Internal, "error performing action %v: %v", req.Action, result)
+		}
+	}
+	if unitFileChanged {
+		if err := conn.ReloadContext(ctx); err != nil {
+			return nil, status.Errorf(codes.Internal, "error reloading systemd after action %v: %v", req.Action, err)
+		}
 	}
-	return &pb.ActionReply{
+	reply := &pb.ActionReply{
 		SystemType:  pb.SystemType_SYSTEM_TYPE_SYSTEMD,
 		ServiceName: req.GetServiceName(),
+	}
+	if req.WaitFor != nil {
+		// A job being done only means systemd executed it, not that the
+		// service is healthy so check it actually reaches the wanted state.
+		if reply.ServiceStatus, err = waitForStatus(ctx, conn, unitName, req.GetServiceName(), target, settle, timeout); err != nil {
+			return nil, err
+		}
+	}
+	return reply, nil
+}
+
+// See: pb.ServiceServer.DaemonReload
+func (s *server) DaemonReload(ctx context.Context, req *pb.DaemonReloadRequest) (*pb.DaemonReloadReply, error) {
+	if err := checkSupportedSystem(req.SystemType); err != nil {
+		return nil, err
+	}
+
+	conn, err := s.dialSystemd(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error establishing systemd connection: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.ReloadContext(ctx); err != nil {
+		return nil, status.Errorf(codes.Internal, "systemd reload error %v", err)
+	}
+	return &pb.DaemonReloadReply{
+		SystemType: pb.SystemType_SYSTEM_TYPE_SYSTEMD,
+	}, nil
+}
+
+// journalTimestamp formats a time for journalctl's --since/--until flags.
+func journalTimestamp(t time.Time) string {
+	return fmt.Sprintf("@%d.%06d", t.Unix(), t.Nanosecond()/1000)
+}
+
+// See: pb.ServiceServer.Logs
+func (s *server) Logs(req *pb.LogsRequest, stream pb.Service_LogsServer) error {
+	if err := checkSupportedSystem(req.SystemType); err != nil {
+		return err
+	}
+
+	unitName := req.GetServiceName()
+	if len(unitName) == 0 {
+		return status.Error(codes.InvalidArgument, "service name is required")
+	}
+	// journalctl expands glob patterns in --unit so a name like 'ssh*' would
+	// read logs for units the policy never saw.
+	if strings.ContainsAny(unitName, `*?[\`) || strings.HasPrefix(unitName, "-") {
+		return status.Errorf(codes.InvalidArgument, "invalid service name %q", unitName)
+	}
+
+	// Accept either 'foo' or 'foo.service'
+	if !strings.HasSuffix(unitName, unitSuffixService) {
+		unitName = unitName + unitSuffixService
+	}
+
+	args := []string{"--output=export", "--no-pager", "--unit=" + unitName}
+	if req.Since != nil {
+		if err := req.Since.CheckValid(); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid since: %v", err)
+		}
+		args = append(args, "--since="+journalTimestamp(req.Since.AsTime()))
+	}
+	if req.Until != nil {
+		if req.Follow {
+			return status.Error(codes.InvalidArgument, "until can't be combined with follow")
+		}
+		if err := req.Until.CheckValid(); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid until: %v", err)
+		}
+		if req.Since != nil && req.Until.AsTime().Before(req.Since.AsTime()) {
+			return status.Error(codes.InvalidArgument, "until must not be before since")
+		}
+		args = append(args, "--until="+journalTimestamp(req.Until.AsTime()))
+	}
+	switch {
+	case req.Priority == pb.Priority_PRIORITY_UNKNOWN:
+	case req.Priority > pb.Priority_PRIORITY_UNKNOWN && req.Priority <= pb.Priority_PRIORITY_DEBUG:
+		// The enum is offset by one from the syslog levels.
+		args = append(args, fmt.Sprintf("--priority=%d", req.Priority-1))
+	default:
+		return status.Errorf(codes.InvalidArgument, "invalid priority %d", req.Priority)
+	}
+	var re *regexp.Regexp
+	if req.Grep != "" {
+		var err error
+		if re, err = regexp.Compile(req.Grep); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid grep: %v", err)
+		}
+	}
+	if req.Follow {
+		args = append(args, "--follow")
+	}
+
+	ctx := stream.Context()
+	out, err := s.journal(ctx, args)
+	if err != nil {
+		return status.Errorf(codes.Internal, "error running journal query: %v", err)
+	}
+	r := bufio.NewReader(out)
+	for {
+		entry, err := readJournalEntry(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			out.Close()
+			// A cancelled follow will truncate the stream so this isn't an error.
+			if ctx.Err() != nil {
+				return nil
+			}
+			return status.Errorf(codes.Internal, "error parsing journal: %v", err)
+		}
+		if re != nil && !re.MatchString(entry.Message) {
+			continue
+		}
+		if err := stream.Send(&pb.LogsReply{
+			SystemType: pb.SystemType_SYSTEM_TYPE_SYSTEMD,
+			Entries:    []*pb.LogEntry{entry},
+		}); err != nil {
+			out.Close()
+			return status.Errorf(codes.Internal, "logs: send error %v", err)
+		}
+	}
+	if err := out.Close(); err != nil && ctx.Err() == nil {
+		return status.Errorf(codes.Internal, "error running journal query: %v", err)
+	}
+	return nil
+}
+
+// See: pb.ServiceServer.GetUnit
+func (s *server) GetUnit(ctx context.Context, req *pb.GetUnitRequest) (*pb.GetUnitReply, error) {
+	if err := checkSupportedSystem(req.SystemType); err != nil {
+		return nil, err
+	}
+
+	unitName := req.GetServiceName()
+	if len(unitName) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "service name is required")
+	}
+	// Accept either 'foo' or 'foo.service'
+	if !strings.HasSuffix(unitName, unitSuffixService) {
+		unitName = unitName + unitSuffixService
+	}
+
+	conn, err := s.dialSystemd(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error establishing systemd connection: %v", err)
+	}
+	defer conn.Close()
+
+	info, err := unitInfo(ctx, conn, unitName, req.GetServiceName())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetUnitReply{
+		SystemType: pb.SystemType_SYSTEM_TYPE_SYSTEMD,
+		Unit:       info,
+	}, nil
+}
+
+// See: pb.ServiceServer.Dependencies
+func (s *server) Dependencies(ctx context.Context, req *pb.DependenciesRequest) (*pb.DependenciesReply, error) {
+	if err := checkSupportedSystem(req.SystemType); err != nil {
+		return nil, err
+	}
+
+	unitName := req.GetServiceName()
+	if len(unitName) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "service name is required")
+	}
+	// Accept either 'foo' or 'foo.service'
+	if !strings.HasSuffix(unitName, unitSuffixService) {
+		unitName = unitName + unitSuffixService
+	}
+
+	conn, err := s.dialSystemd(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error establishing systemd connection: %v", err)
+	}
+	defer conn.Close()
+
+	deps, err := unitDependencies(ctx, conn, unitName, req.Recursive)
+	if errors.Is(err, errUnitNotFound) {
+		return nil, status.Errorf(codes.NotFound, "service %s was not found", req.GetServiceName())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "systemd properties error %v", err)
+	}
+	return &pb.DependenciesReply{
+		SystemType:   pb.SystemType_SYSTEM_TYPE_SYSTEMD,
+		Dependencies: deps,
+	}, nil
+}
+
+// watchBufferSize is the number of property changes which can be queued
+// for a Watch before changes are dropped (and the watch fails).
+const watchBufferSize = 1024
+
+// unitStatusFromProperties returns the state of a unit from its dbus
+// properties. Unlike ListUnitsContext this works for units which aren't loaded.
+func unitStatusFromProperties(ctx context.Context, conn systemdConnection, unitName string) (dbus.UnitStatus, error) {
+	props, err := conn.GetUnitPropertiesContext(ctx, unitName)
+	if err != nil {
+		return dbus.UnitStatus{}, err
+	}
+	return dbus.UnitStatus{
+		Name:        unitName,
+		LoadState:   stringProperty(props, "LoadState"),
+		ActiveState: stringProperty(props, "ActiveState"),
+		SubState:    stringProperty(props, "SubState"),
+	}, nil
+}
+
+// applyPropertiesUpdate returns the unit state with any changed states applied.
+func applyPropertiesUpdate(u dbus.UnitStatus, changed map[string]godbus.Variant) dbus.UnitStatus {
+	for name, dst := range map[string]*string{
+		"LoadState":   &u.LoadState,
+		"ActiveState": &u.ActiveState,
+		"SubState":    &u.SubState,
+	} {
+		if v, ok := changed[name]; ok {
+			if str, ok := v.Value().(string); ok {
+				*dst = str
+			}
+		}
+	}
+	return u
+}
+
+// See: pb.ServiceServer.Watch
+func (s *server) Watch(req *pb.WatchRequest, stream pb.Service_WatchServer) error {
+	if err := checkSupportedSystem(req.SystemType); err != nil {
+		return err
+	}
+
+	// If empty all services are watched.
+	watched := make(map[string]bool)
+	for _, name := range req.ServiceNames {
+		if len(name) == 0 {
+			return status.Error(codes.InvalidArgument, "service names must be non-empty")
+		}
+		// Accept either 'foo' or 'foo.service'
+		if !strings.HasSuffix(name, unitSuffixService) {
+			name = name + unitSuffixService
+		}
+		watched[name] = true
+	}
+
+	ctx := stream.Context()
+	conn, err := s.dialSystemd(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "error establishing systemd connection: %v", err)
+	}
+	defer conn.Close()
+
+	// Subscribe before reading the initial state so no changes are missed
+	// in between. Changes which don't alter the known state are dropped below.
+	updates := make(chan *dbus.PropertiesUpdate, watchBufferSize)
+	errs := make(chan error, 1)
+	conn.SetPropertiesSubscriber(updates, errs)
+	if err := conn.Subscribe(); err != nil {
+		return status.Errorf(codes.Internal, "systemd subscribe error %v", err)
+	}
+
+	known := make(map[string]dbus.UnitStatus)
+	if len(watched) == 0 {
+		units, err := conn.ListUnitsContext(ctx)
+		if err != nil {
+			return status.Errorf(codes.Internal, "systemd list error %v", err)
+		}
+		for _, u := range units {
+			if strings.HasSuffix(u.Name, unitSuffixService) {
+				known[u.Name] = u
+			}
+		}
+	} else {
+		for name := range watched {
+			u, err := unitStatusFromProperties(ctx, conn, name)
+			if err != nil {
+				return status.Errorf(codes.Internal, "systemd properties error %v", err)
+			}
+			known[name] = u
+		}
+	}
+
+	send := func(prev *dbus.UnitStatus, cur dbus.UnitStatus) error {
+		serviceName := strings.TrimSuffix(cur.Name, unitSuffixService)
+		reply := &pb.WatchReply{
+			SystemType: pb.SystemType_SYSTEM_TYPE_SYSTEMD,
+			Timestamp:  timestamppb.Now(),
+			Current:    unitToServiceStatus(serviceName, cur),
+		}
+		if prev != nil {
+			reply.Previous = unitToServiceStatus(serviceName, *prev)
+		}
+		if err := stream.Send(reply); err != nil {
+			return status.Errorf(codes.Internal, "watch: send error %v", err)
+		}
+		return nil
+	}
+
+	var names []string
+	for name := range known {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := send(nil, known[name]); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			// The only error reported is a full update channel which means
+			// transitions were lost so the caller's view is no longer accurate.
+			return status.Errorf(codes.Internal, "watch error (state changes were missed): %v", err)
+		case update := <-updates:
+			if !strings.HasSuffix(update.UnitName, unitSuffixService) {
+				continue
+			}
+			if len(watched) > 0 && !watched[update.UnitName] {
+				continue
+			}
+			prev, ok := known[update.UnitName]
+			var cur dbus.UnitStatus
+			if ok {
+				cur = applyPropertiesUpdate(prev, update.Changed)
+				if cur == prev {
+					continue
+				}
+			} else {
+				// A unit we haven't seen before (i.e. newly loaded) so get its full state.
+				if cur, err = unitStatusFromProperties(ctx, conn, update.UnitName); err != nil {
+					return status.Errorf(codes.Internal, "systemd properties error %v", err)
+				}
+			}
+			known[update.UnitName] = cur
+			var p *dbus.UnitStatus
+			if ok {
+				p = &prev
+			}
+			if err := send(p, cur); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// timerSchedule converts the TimersCalendar and TimersMonotonic properties
+// of a timer into unit file syntax. Over dbus these are arrays of (sst)
+// and (stt) structs where the first two fields are the setting and its
+// calendar spec or value in microseconds.
+func timerSchedule(props map[string]interface{}) []string {
+	var schedule []string
+	calendar, _ := props["TimersCalendar"].([][]interface{})
+	for _, fields := range calendar {
+		if len(fields) < 2 {
+			continue
+		}
+		base, _ := fields[0].(string)
+		spec, _ := fields[1].(string)
+		schedule = append(schedule, fmt.Sprintf("%s=%s", base, spec))
+	}
+	monotonic, _ := props["TimersMonotonic"].([][]interface{})
+	for _, fields := range monotonic {
+		if len(fields) < 2 {
+			continue
+		}
+		// i.e. OnUnitActiveUSec is set in unit files as OnUnitActiveSec.
+		base, _ := fields[0].(string)
+		usec, _ := fields[1].(uint64)
+		schedule = append(schedule, fmt.Sprintf("%s=%v", strings.Replace(base, "USec", "Sec", 1), time.Duration(usec)*time.Microsecond))
+	}
+	return schedule
+}
+
+// See: pb.ServiceServer.ListTimers
+func (s *server) ListTimers(ctx context.Context, req *pb.ListTimersRequest) (*pb.ListTimersReply, error) {
+	if err := checkSupportedSystem(req.SystemType); err != nil {
+		return nil, err
+	}
+
+	conn, err := s.dialSystemd(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error establishing systemd connection: %v", err)
+	}
+	defer conn.Close()
+
+	units, err := conn.ListUnitsContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "systemd list error %v", err)
+	}
+	sort.Sort(byName(units))
+
+	resp := &pb.ListTimersReply{
+		SystemType: pb.SystemType_SYSTEM_TYPE_SYSTEMD,
+	}
+	for _, u := range units {
+		if !strings.HasSuffix(u.Name, unitSuffixTimer) {
+			continue
+		}
+		props, err := conn.GetUnitTypePropertiesContext(ctx, u.Name, unitTypeTimer)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "systemd properties error %v", err)
+		}
+		timer := &pb.Timer{
+			TimerName:   u.Name,
+			Unit:        stringProperty(props, "Unit"),
+			LoadState:   u.LoadState,
+			ActiveState: u.ActiveState,
+			SubState:    u.SubState,
+			Schedule:    timerSchedule(props),
+		}
+		// Timestamps are in microseconds since the epoch with 0 meaning never.
+		if ts := uint64Property(props, "NextElapseUSecRealtime"); ts != 0 {
+			timer.NextElapse = timestamppb.New(time.UnixMicro(int64(ts)))
+		}
+		if ts := uint64Property(props, "LastTriggerUSec"); ts != 0 {
+			timer.LastTrigger = timestamppb.New(time.UnixMicro(int64(ts)))
+		}
+		resp.Timers = append(resp.Timers, timer)
+	}
+	return resp, nil
+}
+
+// transientNameRe is the set of names accepted for transient units. This is
+// stricter than systemd so names can't be confused with flags or templates.
+var transientNameRe = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.:-]*$`)
+
+// See: pb.ServiceServer.CreateTransientTimer
+func (s *server) CreateTransientTimer(ctx context.Context, req *pb.CreateTransientTimerRequest) (*pb.CreateTransientTimerReply, error) {
+	if err := checkSupportedSystem(req.SystemType); err != nil {
+		return nil, err
+	}
+	if !transientNameRe.MatchString(req.Name) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid name %q", req.Name)
+	}
+	if strings.HasSuffix(req.Name, unitSuffixService) || strings.HasSuffix(req.Name, unitSuffixTimer) {
+		return nil, status.Errorf(codes.InvalidArgument, "name %q must not include a unit suffix", req.Name)
+	}
+	if err := util.ValidPath(req.Command); err != nil {
+		return nil, err
+	}
+
+	args := []string{
+		"--unit=" + req.Name,
+		// The units should go away once done, even if the command fails.
+		"--collect",
+	}
+	if req.Description != "" {
+		args = append(args, "--description="+req.Description)
+	}
+	switch sched := req.Schedule.(type) {
+	case *pb.CreateTransientTimerRequest_OnCalendar:
+		if sched.OnCalendar == "" {
+			return nil, status.Error(codes.InvalidArgument, "on_calendar must be non-empty")
+		}
+		args = append(args, "--on-calendar="+sched.OnCalendar)
+	case *pb.CreateTransientTimerRequest_OnActive:
+		if err := sched.OnActive.CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid on_active: %v", err)
+		}
+		d := sched.OnActive.AsDuration()
+		if d < time.Microsecond {
+			return nil, status.Errorf(codes.InvalidArgument, "on_active %v must be positive", d)
+		}
+		args = append(args, fmt.Sprintf("--on-active=%dus", d.Microseconds()))
+	default:
+		return nil, status.Error(codes.InvalidArgument, "a schedule must be specified")
+	}
+	if req.User != "" {
+		args = append(args, "--uid="+req.User)
+	}
+	// Everything after -- is the command so it can't be interpreted as flags.
+	args = append(args, "--", req.Command)
+	args = append(args, req.Args...)
+
+	if err := s.systemdRun(ctx, args); err != nil {
+		return nil, status.Errorf(codes.Internal, "error creating transient timer %s: %v", req.Name, err)
+	}
+	return &pb.CreateTransientTimerReply{
+		SystemType:  pb.SystemType_SYSTEM_TYPE_SYSTEMD,
+		TimerName:   req.Name + unitSuffixTimer,
+		ServiceName: req.Name + unitSuffixService,
 	}, nil
 }
@@ -22,12 +22,22 @@ package server
 import (
 	"context"
 	"errors"
+	"io"
+	"math"
+	"os"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/coreos/go-systemd/v22/dbus"
+	godbus "github.com/godbus/dbus/v5"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/Snowflake-Labs/sansshell/services/service"
 	"github.com/Snowflake-Labs/sansshell/testing/testutil"
@@ -48,7 +58,38 @@ func (e errConn) StopUnitContext(context.Context, string, string, chan<- string)
 func (e errConn) RestartUnitContext(context.Context, string, string, chan<- string) (int, error) {
 	return 0, errors.New(string(e))
 }
-func (errConn) Close() {}
+func (e errConn) ReloadUnitContext(context.Context, string, string, chan<- string) (int, error) {
+	return 0, errors.New(string(e))
+}
+func (e errConn) EnableUnitFilesContext(context.Context, []string, bool, bool) (bool, []dbus.EnableUnitFileChange, error) {
+	return false, nil, errors.New(string(e))
+}
+func (e errConn) DisableUnitFilesContext(context.Context, []string, bool) ([]dbus.DisableUnitFileChange, error) {
+	return nil, errors.New(string(e))
+}
+func (e errConn) MaskUnitFilesContext(context.Context, []string, bool, bool) ([]dbus.MaskUnitFileChange, error) {
+	return nil, errors.New(string(e))
+}
+func (e errConn) UnmaskUnitFilesContext(context.Context, []string, bool) ([]dbus.UnmaskUnitFileChange, error) {
+	return nil, errors.New(string(e))
+}
+func (e errConn) ResetFailedUnitContext(context.Context, string) error {
+	return errors.New(string(e))
+}
+func (e errConn) ReloadContext(context.Context) error {
+	return errors.New(string(e))
+}
+func (e errConn) GetUnitPropertiesContext(context.Context, string) (map[string]interface{}, error) {
+	return nil, errors.New(string(e))
+}
+func (e errConn) GetUnitTypePropertiesContext(context.Context, string, string) (map[string]interface{}, error) {
+	return nil, errors.New(string(e))
+}
+func (e errConn) Subscribe() error {
+	return errors.New(string(e))
+}
+func (errConn) SetPropertiesSubscriber(chan<- *dbus.PropertiesUpdate, chan<- error) {}
+func (errConn) Close()                                                              {}
 
 func TestDialError(t *testing.T) {
 	sentinel := errors.New("dial error")
@@ -77,6 +118,27 @@ func TestDialError(t *testing.T) {
 			t.Errorf("err was %v, want internal error with message containing %v", err, sentinel)
 		}
 	})
+	t.Run("daemon-reload", func(t *testing.T) {
+		t.Parallel()
+		_, err := s.DaemonReload(context.Background(), &pb.DaemonReloadRequest{})
+		if status.Code(err) != codes.Internal || !strings.Contains(err.Error(), sentinel.Error()) {
+			t.Errorf("err was %v, want internal error with message containing %v", err, sentinel)
+		}
+	})
+	t.Run("get-unit", func(t *testing.T) {
+		t.Parallel()
+		_, err := s.GetUnit(context.Background(), &pb.GetUnitRequest{ServiceName: "foo"})
+		if status.Code(err) != codes.Internal || !strings.Contains(err.Error(), sentinel.Error()) {
+			t.Errorf("err was %v, want internal error with message containing %v", err, sentinel)
+		}
+	})
+	t.Run("dependencies", func(t *testing.T) {
+		t.Parallel()
+		_, err := s.Dependencies(context.Background(), &pb.DependenciesRequest{ServiceName: "foo"})
+		if status.Code(err) != codes.Internal || !strings.Contains(err.Error(), sentinel.Error()) {
+			t.Errorf("err was %v, want internal error with message containing %v", err, sentinel)
+		}
+	})
 }
 
 var (
@@ -97,7 +159,38 @@ func (l listConn) StopUnitContext(context.Context, string, string, chan<- string
 func (l listConn) RestartUnitContext(context.Context, string, string, chan<- string) (int, error) {
 	return 0, notImplementedError
 }
-func (listConn) Close() {}
+func (l listConn) ReloadUnitContext(context.Context, string, string, chan<- string) (int, error) {
+	return 0, notImplementedError
+}
+func (l listConn) EnableUnitFilesContext(context.Context, []string, bool, bool) (bool, []dbus.EnableUnitFileChange, error) {
+	return false, nil, notImplementedError
+}
+func (l listConn) DisableUnitFilesContext(context.Context, []string, bool) ([]dbus.DisableUnitFileChange, error) {
+	return nil, notImplementedError
+}
+func (l listConn) MaskUnitFilesContext(context.Context, []string, bool, bool) ([]dbus.MaskUnitFileChange, error) {
+	return nil, notImplementedError
+}
+func (l listConn) UnmaskUnitFilesContext(context.Context, []string, bool) ([]dbus.UnmaskUnitFileChange, error) {
+	return nil, notImplementedError
+}
+func (l listConn) ResetFailedUnitContext(context.Context, string) error {
+	return notImplementedError
+}
+func (l listConn) ReloadContext(context.Context) error {
+	return notImplementedError
+}
+
+// No properties are returned so all details are zero valued.
+func (l listConn) GetUnitPropertiesContext(context.Context, string) (map[string]interface{}, error) {
+	return map[string]interface{}{}, nil
+}
+func (l listConn) GetUnitTypePropertiesContext(context.Context, string, string) (map[string]interface{}, error) {
+	return map[string]interface{}{}, nil
+}
+func (listConn) Subscribe() error                                                    { return nil }
+func (listConn) SetPropertiesSubscriber(chan<- *dbus.PropertiesUpdate, chan<- error) {}
+func (listConn) Close()                                                              {}
 
 func wantStatusErr(code codes.Code, message string) func(string, error, *testing.T) {
 	return func(op string, e error, t *testing.T) {
@@ -170,10 +263,16 @@ func TestList(t *testing.T) {
 					{
 						ServiceName: "bar",
 						Status:      pb.Status_STATUS_RUNNING,
+						LoadState:   loadStateLoaded,
+						ActiveState: activeStateActive,
+						SubState:    substateRunning,
 					},
 					{
 						ServiceName: "foo",
 						Status:      pb.Status_STATUS_RUNNING,
+						LoadState:   loadStateLoaded,
+						ActiveState: activeStateActive,
+						SubState:    substateRunning,
 					},
 				},
 			},
@@ -261,6 +360,10 @@ func TestStatus(t *testing.T) {
 				ServiceStatus: &pb.ServiceStatus{
 					ServiceName: "foo.service",
 					Status:      pb.Status_STATUS_RUNNING,
+					LoadState:   loadStateLoaded,
+					ActiveState: activeStateActive,
+					SubState:    substateRunning,
+					Details:     &pb.ServiceDetails{},
 				},
 			},
 			errFunc: testutil.FatalOnErr,
@@ -289,6 +392,10 @@ func TestStatus(t *testing.T) {
 				ServiceStatus: &pb.ServiceStatus{
 					ServiceName: "foo",
 					Status:      pb.Status_STATUS_RUNNING,
+					LoadState:   loadStateLoaded,
+					ActiveState: activeStateActive,
+					SubState:    substateRunning,
+					Details:     &pb.ServiceDetails{},
 				},
 			},
 			errFunc: testutil.FatalOnErr,
@@ -317,6 +424,10 @@ func TestStatus(t *testing.T) {
 				ServiceStatus: &pb.ServiceStatus{
 					ServiceName: "foo",
 					Status:      pb.Status_STATUS_STOPPED,
+					LoadState:   loadStateLoaded,
+					ActiveState: activeStateActive,
+					SubState:    "dead",
+					Details:     &pb.ServiceDetails{},
 				},
 			},
 			errFunc: testutil.FatalOnErr,
@@ -362,7 +473,47 @@ func (a actionConn) RestartUnitContext(ctx context.Context, name string, mode st
 	}()
 	return 1, nil
 }
-func (actionConn) Close() {}
+func (a actionConn) ReloadUnitContext(ctx context.Context, name string, mode string, c chan<- string) (int, error) {
+	go func() {
+		c <- string(a)
+	}()
+	return 1, nil
+}
+
+// Unit file changes and resets are synchronous so they fail directly unless the result is done.
+func (a actionConn) err() error {
+	if string(a) != operationResultDone {
+		return errors.New(string(a))
+	}
+	return nil
+}
+func (a actionConn) EnableUnitFilesContext(context.Context, []string, bool, bool) (bool, []dbus.EnableUnitFileChange, error) {
+	return false, nil, a.err()
+}
+func (a actionConn) DisableUnitFilesContext(context.Context, []string, bool) ([]dbus.DisableUnitFileChange, error) {
+	return nil, a.err()
+}
+func (a actionConn) MaskUnitFilesContext(context.Context, []string, bool, bool) ([]dbus.MaskUnitFileChange, error) {
+	return nil, a.err()
+}
+func (a actionConn) UnmaskUnitFilesContext(context.Context, []string, bool) ([]dbus.UnmaskUnitFileChange, error) {
+	return nil, a.err()
+}
+func (a actionConn) ResetFailedUnitContext(context.Context, string) error {
+	return a.err()
+}
+func (a actionConn) ReloadContext(context.Context) error {
+	return a.err()
+}
+func (a actionConn) GetUnitPropertiesContext(context.Context, string) (map[string]interface{}, error) {
+	return nil, notImplementedError
+}
+func (a actionConn) GetUnitTypePropertiesContext(context.Context, string, string) (map[string]interface{}, error) {
+	return nil, notImplementedError
+}
+func (actionConn) Subscribe() error                                                    { return nil }
+func (actionConn) SetPropertiesSubscriber(chan<- *dbus.PropertiesUpdate, chan<- error) {}
+func (actionConn) Close()                                                              {}
 
 func TestAction(t *testing.T) {
 	for _, tc := range []struct {
@@ -414,7 +565,7 @@ func TestAction(t *testing.T) {
 			conn: errConn("not returned"),
 			req: &pb.ActionRequest{
 				ServiceName: "foo",
-				Action:      pb.Action(5),
+				Action:      pb.Action(100),
 			},
 			want:    nil,
 			errFunc: wantStatusErr(codes.InvalidArgument, "action"),
@@ -488,6 +639,144 @@ func TestAction(t *testing.T) {
 			},
 			errFunc: testutil.FatalOnErr,
 		},
+		{
+			name: "reload failed",
+			conn: actionConn("failed"),
+			req: &pb.ActionRequest{
+				ServiceName: "foo",
+				Action:      pb.Action_ACTION_RELOAD,
+			},
+			want:    nil,
+			errFunc: wantStatusErr(codes.Internal, "error performing action"),
+		},
+		{
+			name: "reload success",
+			conn: actionConn(operationResultDone),
+			req: &pb.ActionRequest{
+				ServiceName: "foo",
+				Action:      pb.Action_ACTION_RELOAD,
+			},
+			want: &pb.ActionReply{
+				SystemType:  pb.SystemType_SYSTEM_TYPE_SYSTEMD,
+				ServiceName: "foo",
+			},
+			errFunc: testutil.FatalOnErr,
+		},
+		{
+			name: "enable failed",
+			conn: actionConn("failed"),
+			req: &pb.ActionRequest{
+				ServiceName: "foo",
+				Action:      pb.Action_ACTION_ENABLE,
+			},
+			want:    nil,
+			errFunc: wantStatusErr(codes.Internal, "error performing action"),
+		},
+		{
+			name: "enable success",
+			conn: actionConn(operationResultDone),
+			req: &pb.ActionRequest{
+				ServiceName: "foo",
+				Action:      pb.Action_ACTION_ENABLE,
+			},
+			want: &pb.ActionReply{
+				SystemType:  pb.SystemType_SYSTEM_TYPE_SYSTEMD,
+				ServiceName: "foo",
+			},
+			errFunc: testutil.FatalOnErr,
+		},
+		{
+			name: "disable failed",
+			conn: actionConn("failed"),
+			req: &pb.ActionRequest{
+				ServiceName: "foo",
+				Action:      pb.Action_ACTION_DISABLE,
+			},
+			want:    nil,
+			errFunc: wantStatusErr(codes.Internal, "error performing action"),
+		},
+		{
+			name: "disable success",
+			conn: actionConn(operationResultDone),
+			req: &pb.ActionRequest{
+				ServiceName: "foo",
+				Action:      pb.Action_ACTION_DISABLE,
+			},
+			want: &pb.ActionReply{
+				SystemType:  pb.SystemType_SYSTEM_TYPE_SYSTEMD,
+				ServiceName: "foo",
+			},
+			errFunc: testutil.FatalOnErr,
+		},
+		{
+			name: "mask failed",
+			conn: actionConn("failed"),
+			req: &pb.ActionRequest{
+				ServiceName: "foo",
+				Action:      pb.Action_ACTION_MASK,
+			},
+			want:    nil,
+			errFunc: wantStatusErr(codes.Internal, "error performing action"),
+		},
+		{
+			name: "mask success",
+			conn: actionConn(operationResultDone),
+			req: &pb.ActionRequest{
+				ServiceName: "foo",
+				Action:      pb.Action_ACTION_MASK,
+			},
+			want: &pb.ActionReply{
+				SystemType:  pb.SystemType_SYSTEM_TYPE_SYSTEMD,
+				ServiceName: "foo",
+			},
+			errFunc: testutil.FatalOnErr,
+		},
+		{
+			name: "unmask failed",
+			conn: actionConn("failed"),
+			req: &pb.ActionRequest{
+				ServiceName: "foo",
+				Action:      pb.Action_ACTION_UNMASK,
+			},
+			want:    nil,
+			errFunc: wantStatusErr(codes.Internal, "error performing action"),
+		},
+		{
+			name: "unmask success",
+			conn: actionConn(operationResultDone),
+			req: &pb.ActionRequest{
+				ServiceName: "foo",
+				Action:      pb.Action_ACTION_UNMASK,
+			},
+			want: &pb.ActionReply{
+				SystemType:  pb.SystemType_SYSTEM_TYPE_SYSTEMD,
+				ServiceName: "foo",
+			},
+			errFunc: testutil.FatalOnErr,
+		},
+		{
+			name: "reset failed failed",
+			conn: actionConn("failed"),
+			req: &pb.ActionRequest{
+				ServiceName: "foo",
+				Action:      pb.Action_ACTION_RESET_FAILED,
+			},
+			want:    nil,
+			errFunc: wantStatusErr(codes.Internal, "error performing action"),
+		},
+		{
+			name: "reset failed success",
+			conn: actionConn(operationResultDone),
+			req: &pb.ActionRequest{
+				ServiceName: "foo",
+				Action:      pb.Action_ACTION_RESET_FAILED,
+			},
+			want: &pb.ActionReply{
+				SystemType:  pb.SystemType_SYSTEM_TYPE_SYSTEMD,
+				ServiceName: "foo",
+			},
+			errFunc: testutil.FatalOnErr,
+		},
 	} {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
@@ -503,3 +792,1430 @@ func TestAction(t *testing.T) {
 		})
 	}
 }
+
+// reloadConn records the calls made to it so the ordering of
+// unit file changes and daemon reloads can be checked.
+type reloadConn struct {
+	actionConn
+	calls []string
+}
+
+func (r *reloadConn) EnableUnitFilesContext(ctx context.Context, files []string, runtime bool, force bool) (bool, []dbus.EnableUnitFileChange, error) {
+	r.calls = append(r.calls, "enable "+strings.Join(files, ","))
+	return r.actionConn.EnableUnitFilesContext(ctx, files, runtime, force)
+}
+func (r *reloadConn) StartUnitContext(ctx context.Context, name string, mode string, c chan<- string) (int, error) {
+	r.calls = append(r.calls, "start "+name)
+	return r.actionConn.StartUnitContext(ctx, name, mode, c)
+}
+func (r *reloadConn) ReloadContext(ctx context.Context) error {
+	r.calls = append(r.calls, "daemon-reload")
+	return r.actionConn.ReloadContext(ctx)
+}
+
+func TestActionDaemonReload(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		action pb.Action
+		want   []string
+	}{
+		{
+			name:   "enable reloads",
+			action: pb.Action_ACTION_ENABLE,
+			want:   []string{"enable foo.service", "daemon-reload"},
+		},
+		{
+			name:   "start doesn't reload",
+			action: pb.Action_ACTION_START,
+			want:   []string{"start foo.service"},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			conn := &reloadConn{actionConn: actionConn(operationResultDone)}
+			s := &server{
+				dialSystemd: func(context.Context) (systemdConnection, error) {
+					return conn, nil
+				},
+			}
+			_, err := s.Action(context.Background(), &pb.ActionRequest{ServiceName: "foo", Action: tc.action})
+			testutil.FatalOnErr(tc.name, err, t)
+			testutil.DiffErr(tc.name, conn.calls, tc.want, t)
+		})
+	}
+}
+
+func TestDaemonReload(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		conn    systemdConnection
+		req     *pb.DaemonReloadRequest
+		want    *pb.DaemonReloadReply
+		errFunc func(string, error, *testing.T)
+	}{
+		{
+			name:    "reload error",
+			conn:    errConn("sentinel"),
+			req:     &pb.DaemonReloadRequest{},
+			want:    nil,
+			errFunc: wantStatusErr(codes.Internal, "sentinel"),
+		},
+		{
+			name: "bad system",
+			conn: errConn("not returned"),
+			req: &pb.DaemonReloadRequest{
+				SystemType: pb.SystemType(5),
+			},
+			want:    nil,
+			errFunc: wantStatusErr(codes.InvalidArgument, "system"),
+		},
+		{
+			name: "success",
+			conn: actionConn(operationResultDone),
+			req:  &pb.DaemonReloadRequest{},
+			want: &pb.DaemonReloadReply{
+				SystemType: pb.SystemType_SYSTEM_TYPE_SYSTEMD,
+			},
+			errFunc: testutil.FatalOnErr,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			s := &server{
+				dialSystemd: func(context.Context) (systemdConnection, error) {
+					return tc.conn, nil
+				},
+			}
+			got, err := s.DaemonReload(context.Background(), tc.req)
+			tc.errFunc("DaemonReload", err, t)
+			testutil.DiffErr(tc.name, got, tc.want, t)
+		})
+	}
+}
+
+// propsConn is a listConn which also returns canned unit properties.
+type propsConn struct {
+	listConn
+	unitProps    map[string]interface{}
+	serviceProps map[string]interface{}
+	propsErr     error
+}
+
+func (p propsConn) GetUnitPropertiesContext(context.Context, string) (map[string]interface{}, error) {
+	return p.unitProps, p.propsErr
+}
+func (p propsConn) GetUnitTypePropertiesContext(_ context.Context, _ string, unitType string) (map[string]interface{}, error) {
+	if p.propsErr != nil {
+		return nil, p.propsErr
+	}
+	if unitType != unitTypeService {
+		return nil, errors.New("unknown interface")
+	}
+	return p.serviceProps, nil
+}
+
+func TestStatusDetails(t *testing.T) {
+	activeEnter := time.Date(2022, 4, 1, 12, 30, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		name    string
+		conn    systemdConnection
+		want    *pb.ServiceStatus
+		errFunc func(string, error, *testing.T)
+	}{
+		{
+			name: "crash looping",
+			conn: propsConn{
+				listConn: listConn([]dbus.UnitStatus{
+					{
+						Name:        "foo.service",
+						LoadState:   loadStateLoaded,
+						ActiveState: activeStateActivating,
+						SubState:    "auto-restart",
+					},
+				}),
+				unitProps: map[string]interface{}{
+					"UnitFileState":        "enabled",
+					"ActiveEnterTimestamp": uint64(activeEnter.UnixMicro()),
+				},
+				serviceProps: map[string]interface{}{
+					"MainPID":       uint32(0),
+					"NRestarts":     uint32(42),
+					"MemoryCurrent": uint64(math.MaxUint64),
+					"CPUUsageNSec":  uint64(math.MaxUint64),
+				},
+			},
+			want: &pb.ServiceStatus{
+				ServiceName: "foo",
+				Status:      pb.Status_STATUS_ACTIVATING,
+				LoadState:   loadStateLoaded,
+				ActiveState: activeStateActivating,
+				SubState:    "auto-restart",
+				Details: &pb.ServiceDetails{
+					UnitFileState:        "enabled",
+					RestartCount:         42,
+					ActiveEnterTimestamp: timestamppb.New(activeEnter),
+				},
+			},
+			errFunc: testutil.FatalOnErr,
+		},
+		{
+			name: "failed",
+			conn: propsConn{
+				listConn: listConn([]dbus.UnitStatus{
+					{
+						Name:        "foo.service",
+						LoadState:   loadStateLoaded,
+						ActiveState: activeStateFailed,
+						SubState:    "failed",
+					},
+				}),
+				unitProps: map[string]interface{}{
+					"UnitFileState": "disabled",
+				},
+				serviceProps: map[string]interface{}{
+					"NRestarts": uint32(3),
+				},
+			},
+			want: &pb.ServiceStatus{
+				ServiceName: "foo",
+				Status:      pb.Status_STATUS_FAILED,
+				LoadState:   loadStateLoaded,
+				ActiveState: activeStateFailed,
+				SubState:    "failed",
+				Details: &pb.ServiceDetails{
+					UnitFileState: "disabled",
+					RestartCount:  3,
+				},
+			},
+			errFunc: testutil.FatalOnErr,
+		},
+		{
+			name: "running with accounting",
+			conn: propsConn{
+				listConn: listConn([]dbus.UnitStatus{
+					{
+						Name:        "foo.service",
+						LoadState:   loadStateLoaded,
+						ActiveState: activeStateActive,
+						SubState:    substateRunning,
+					},
+				}),
+				unitProps: map[string]interface{}{
+					"UnitFileState": "enabled",
+				},
+				serviceProps: map[string]interface{}{
+					"MainPID":       uint32(1234),
+					"MemoryCurrent": uint64(1 << 20),
+					"CPUUsageNSec":  uint64(5 * time.Second),
+				},
+			},
+			want: &pb.ServiceStatus{
+				ServiceName: "foo",
+				Status:      pb.Status_STATUS_RUNNING,
+				LoadState:   loadStateLoaded,
+				ActiveState: activeStateActive,
+				SubState:    substateRunning,
+				Details: &pb.ServiceDetails{
+					UnitFileState: "enabled",
+					MainPid:       1234,
+					MemoryCurrent: 1 << 20,
+					CpuUsageNsec:  uint64(5 * time.Second),
+				},
+			},
+			errFunc: testutil.FatalOnErr,
+		},
+		{
+			name: "stopping",
+			conn: propsConn{
+				listConn: listConn([]dbus.UnitStatus{
+					{
+						Name:        "foo.service",
+						LoadState:   loadStateLoaded,
+						ActiveState: activeStateDeactivating,
+						SubState:    "stop-sigterm",
+					},
+				}),
+			},
+			want: &pb.ServiceStatus{
+				ServiceName: "foo",
+				Status:      pb.Status_STATUS_DEACTIVATING,
+				LoadState:   loadStateLoaded,
+				ActiveState: activeStateDeactivating,
+				SubState:    "stop-sigterm",
+				Details:     &pb.ServiceDetails{},
+			},
+			errFunc: testutil.FatalOnErr,
+		},
+		{
+			name: "properties error",
+			conn: propsConn{
+				listConn: listConn([]dbus.UnitStatus{
+					{
+						Name:        "foo.service",
+						LoadState:   loadStateLoaded,
+						ActiveState: activeStateActive,
+						SubState:    substateRunning,
+					},
+				}),
+				propsErr: errors.New("sentinel"),
+			},
+			errFunc: wantStatusErr(codes.Internal, "properties"),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			s := &server{
+				dialSystemd: func(context.Context) (systemdConnection, error) {
+					return tc.conn, nil
+				},
+			}
+			got, err := s.Status(context.Background(), &pb.StatusRequest{ServiceName: "foo"})
+			tc.errFunc("Status", err, t)
+			testutil.DiffErr(tc.name, got.GetServiceStatus(), tc.want, t)
+		})
+	}
+}
+
+// logsStream is a pb.Service_LogsServer which records the replies sent.
+type logsStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	replies []*pb.LogsReply
+	sendErr error
+}
+
+func (l *logsStream) Context() context.Context { return l.ctx }
+func (l *logsStream) Send(r *pb.LogsReply) error {
+	if l.sendErr != nil {
+		return l.sendErr
+	}
+	l.replies = append(l.replies, r)
+	return nil
+}
+
+// errReader returns its data followed by an error.
+type errReader struct {
+	io.Reader
+}
+
+func (e errReader) Read(p []byte) (int, error) {
+	n, err := e.Reader.Read(p)
+	if err == io.EOF {
+		return n, errors.New("read error")
+	}
+	return n, err
+}
+
+func TestLogs(t *testing.T) {
+	since := time.UnixMicro(1648816200250000)
+	until := time.UnixMicro(1648816300000000)
+	fixture := func(context.Context) (io.ReadCloser, error) {
+		return os.Open(testdataJournal)
+	}
+	baseArgs := []string{"--output=export", "--no-pager", "--unit=foo.service"}
+	for _, tc := range []struct {
+		name     string
+		req      *pb.LogsRequest
+		journal  func(context.Context) (io.ReadCloser, error)
+		sendErr  error
+		wantArgs []string
+		want     []*pb.LogEntry
+		errFunc  func(string, error, *testing.T)
+	}{
+		{
+			name:     "all entries",
+			req:      &pb.LogsRequest{ServiceName: "foo"},
+			journal:  fixture,
+			wantArgs: baseArgs,
+			want:     journalFixtureEntries,
+			errFunc:  testutil.FatalOnErr,
+		},
+		{
+			name: "filters",
+			req: &pb.LogsRequest{
+				ServiceName: "foo.service",
+				Since:       timestamppb.New(since),
+				Until:       timestamppb.New(until),
+				Priority:    pb.Priority_PRIORITY_WARNING,
+			},
+			journal:  fixture,
+			wantArgs: append(baseArgs, "--since=@1648816200.250000", "--until=@1648816300.000000", "--priority=4"),
+			want:     journalFixtureEntries,
+			errFunc:  testutil.FatalOnErr,
+		},
+		{
+			name: "grep and follow",
+			req: &pb.LogsRequest{
+				ServiceName: "foo",
+				Grep:        "(?i)PANIC|exited",
+				Follow:      true,
+			},
+			journal:  fixture,
+			wantArgs: append(baseArgs, "--follow"),
+			want:     journalFixtureEntries[2:],
+			errFunc:  testutil.FatalOnErr,
+		},
+		{
+			name:    "bad system",
+			req:     &pb.LogsRequest{ServiceName: "foo", SystemType: pb.SystemType(100)},
+			errFunc: wantStatusErr(codes.InvalidArgument, "system"),
+		},
+		{
+			name:    "no service",
+			req:     &pb.LogsRequest{},
+			errFunc: wantStatusErr(codes.InvalidArgument, "service name"),
+		},
+		{
+			name:    "glob service",
+			req:     &pb.LogsRequest{ServiceName: "ssh*"},
+			errFunc: wantStatusErr(codes.InvalidArgument, "invalid service name"),
+		},
+		{
+			name:    "character class service",
+			req:     &pb.LogsRequest{ServiceName: "foo[12]"},
+			errFunc: wantStatusErr(codes.InvalidArgument, "invalid service name"),
+		},
+		{
+			name:    "escaped service",
+			req:     &pb.LogsRequest{ServiceName: `foo\x2a`},
+			errFunc: wantStatusErr(codes.InvalidArgument, "invalid service name"),
+		},
+		{
+			name:    "flag service",
+			req:     &pb.LogsRequest{ServiceName: "-b"},
+			errFunc: wantStatusErr(codes.InvalidArgument, "invalid service name"),
+		},
+		{
+			name: "until with follow",
+			req: &pb.LogsRequest{
+				ServiceName: "foo",
+				Until:       timestamppb.New(until),
+				Follow:      true,
+			},
+			errFunc: wantStatusErr(codes.InvalidArgument, "follow"),
+		},
+		{
+			name: "until before since",
+			req: &pb.LogsRequest{
+				ServiceName: "foo",
+				Since:       timestamppb.New(until),
+				Until:       timestamppb.New(since),
+			},
+			errFunc: wantStatusErr(codes.InvalidArgument, "until"),
+		},
+		{
+			name:    "bad priority",
+			req:     &pb.LogsRequest{ServiceName: "foo", Priority: pb.Priority(9)},
+			errFunc: wantStatusErr(codes.InvalidArgument, "priority"),
+		},
+		{
+			name:    "bad grep",
+			req:     &pb.LogsRequest{ServiceName: "foo", Grep: "("},
+			errFunc: wantStatusErr(codes.InvalidArgument, "grep"),
+		},
+		{
+			name: "journal error",
+			req:  &pb.LogsRequest{ServiceName: "foo"},
+			journal: func(context.Context) (io.ReadCloser, error) {
+				return nil, errors.New("sentinel")
+			},
+			wantArgs: baseArgs,
+			errFunc:  wantStatusErr(codes.Internal, "sentinel"),
+		},
+		{
+			name: "parse error",
+			req:  &pb.LogsRequest{ServiceName: "foo"},
+			journal: func(context.Context) (io.ReadCloser, error) {
+				return os.Open(testdataJournalTruncated)
+			},
+			wantArgs: baseArgs,
+			errFunc:  wantStatusErr(codes.Internal, "parsing"),
+		},
+		{
+			name: "read error",
+			req:  &pb.LogsRequest{ServiceName: "foo"},
+			journal: func(context.Context) (io.ReadCloser, error) {
+				return io.NopCloser(errReader{strings.NewReader("MESSAGE=hi\n")}), nil
+			},
+			wantArgs: baseArgs,
+			errFunc:  wantStatusErr(codes.Internal, "read error"),
+		},
+		{
+			name:     "send error",
+			req:      &pb.LogsRequest{ServiceName: "foo"},
+			journal:  fixture,
+			sendErr:  errors.New("sentinel"),
+			wantArgs: baseArgs,
+			errFunc:  wantStatusErr(codes.Internal, "sentinel"),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var gotArgs []string
+			s := &server{
+				journal: func(ctx context.Context, args []string) (io.ReadCloser, error) {
+					gotArgs = args
+					return tc.journal(ctx)
+				},
+			}
+			stream := &logsStream{ctx: context.Background(), sendErr: tc.sendErr}
+			err := s.Logs(tc.req, stream)
+			tc.errFunc(tc.name, err, t)
+			testutil.DiffErr(tc.name+" args", gotArgs, tc.wantArgs, t)
+			var got []*pb.LogEntry
+			for _, r := range stream.replies {
+				if r.SystemType != pb.SystemType_SYSTEM_TYPE_SYSTEMD {
+					t.Errorf("%s: got system type %v", tc.name, r.SystemType)
+				}
+				got = append(got, r.Entries...)
+			}
+			if err != nil {
+				return
+			}
+			testutil.DiffErr(tc.name, got, tc.want, t)
+		})
+	}
+}
+
+func TestLogsCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pr, pw := io.Pipe()
+	s := &server{
+		journal: func(context.Context, []string) (io.ReadCloser, error) {
+			return pr, nil
+		},
+	}
+	stream := &logsStream{ctx: ctx}
+	errCh := make(chan error)
+	go func() {
+		errCh <- s.Logs(&pb.LogsRequest{ServiceName: "foo", Follow: true}, stream)
+	}()
+	// Emulate a follow being killed mid entry by the context ending.
+	if _, err := pw.Write([]byte("MESSAGE=partial")); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	pw.CloseWithError(errors.New("signal: killed"))
+	if err := <-errCh; err != nil {
+		t.Fatalf("unexpected error from cancelled follow: %v", err)
+	}
+}
+
+// seqConn is an actionConn whose unit state changes on each call to
+// ListUnitsContext, repeating the last state once exhausted.
+// A state with an empty name means the unit isn't loaded.
+type seqConn struct {
+	actionConn
+	mu     sync.Mutex
+	states []dbus.UnitStatus
+}
+
+func (s *seqConn) ListUnitsContext(context.Context) ([]dbus.UnitStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u := s.states[0]
+	if len(s.states) > 1 {
+		s.states = s.states[1:]
+	}
+	if u.Name == "" {
+		return nil, nil
+	}
+	return []dbus.UnitStatus{u}, nil
+}
+
+func TestActionWaitFor(t *testing.T) {
+	savedInterval := waitPollInterval
+	waitPollInterval = 5 * time.Millisecond
+	t.Cleanup(func() {
+		waitPollInterval = savedInterval
+	})
+
+	unit := func(active, sub string) dbus.UnitStatus {
+		return dbus.UnitStatus{
+			Name:        "foo.service",
+			LoadState:   loadStateLoaded,
+			ActiveState: active,
+			SubState:    sub,
+		}
+	}
+	running := unit(activeStateActive, substateRunning)
+	activating := unit(activeStateActivating, "start")
+	autoRestart := unit(activeStateActivating, "auto-restart")
+	failed := unit(activeStateFailed, "failed")
+	inactive := unit("inactive", "dead")
+	settle := durationpb.New(20 * time.Millisecond)
+	timeout := durationpb.New(5 * time.Second)
+
+	for _, tc := range []struct {
+		name    string
+		action  pb.Action
+		waitFor *pb.WaitFor
+		states  []dbus.UnitStatus
+		want    *pb.ServiceStatus
+		errFunc func(string, error, *testing.T)
+	}{
+		{
+			name:    "restart running",
+			action:  pb.Action_ACTION_RESTART,
+			waitFor: &pb.WaitFor{},
+			states:  []dbus.UnitStatus{running},
+			want: &pb.ServiceStatus{
+				ServiceName: "foo",
+				Status:      pb.Status_STATUS_RUNNING,
+				LoadState:   loadStateLoaded,
+				ActiveState: activeStateActive,
+				SubState:    substateRunning,
+			},
+			errFunc: testutil.FatalOnErr,
+		},
+		{
+			name:   "start settles",
+			action: pb.Action_ACTION_START,
+			waitFor: &pb.WaitFor{
+				SettleTime: settle,
+				Timeout:    timeout,
+			},
+			states: []dbus.UnitStatus{activating, activating, running},
+			want: &pb.ServiceStatus{
+				ServiceName: "foo",
+				Status:      pb.Status_STATUS_RUNNING,
+				LoadState:   loadStateLoaded,
+				ActiveState: activeStateActive,
+				SubState:    substateRunning,
+			},
+			errFunc: testutil.FatalOnErr,
+		},
+		{
+			name:   "restart crashes while settling",
+			action: pb.Action_ACTION_RESTART,
+			waitFor: &pb.WaitFor{
+				SettleTime: durationpb.New(time.Second),
+				Timeout:    timeout,
+			},
+			states:  []dbus.UnitStatus{running, running, autoRestart},
+			errFunc: wantStatusErr(codes.FailedPrecondition, "settling"),
+		},
+		{
+			name:    "start fails",
+			action:  pb.Action_ACTION_START,
+			waitFor: &pb.WaitFor{Timeout: timeout},
+			states:  []dbus.UnitStatus{activating, failed},
+			errFunc: wantStatusErr(codes.FailedPrecondition, "failed"),
+		},
+		{
+			name:    "start times out",
+			action:  pb.Action_ACTION_START,
+			waitFor: &pb.WaitFor{Timeout: durationpb.New(20 * time.Millisecond)},
+			states:  []dbus.UnitStatus{autoRestart},
+			errFunc: wantStatusErr(codes.DeadlineExceeded, "auto-restart"),
+		},
+		{
+			name:    "stop unloads",
+			action:  pb.Action_ACTION_STOP,
+			waitFor: &pb.WaitFor{},
+			states:  []dbus.UnitStatus{unit(activeStateDeactivating, "stop-sigterm"), {}},
+			want: &pb.ServiceStatus{
+				ServiceName: "foo",
+				Status:      pb.Status_STATUS_STOPPED,
+			},
+			errFunc: testutil.FatalOnErr,
+		},
+		{
+			name:    "disable with explicit status",
+			action:  pb.Action_ACTION_DISABLE,
+			waitFor: &pb.WaitFor{Status: pb.Status_STATUS_STOPPED},
+			states:  []dbus.UnitStatus{inactive},
+			want: &pb.ServiceStatus{
+				ServiceName: "foo",
+				Status:      pb.Status_STATUS_STOPPED,
+				LoadState:   loadStateLoaded,
+				ActiveState: "inactive",
+				SubState:    "dead",
+			},
+			errFunc: testutil.FatalOnErr,
+		},
+		{
+			name:    "no status for action",
+			action:  pb.Action_ACTION_ENABLE,
+			waitFor: &pb.WaitFor{},
+			states:  []dbus.UnitStatus{inactive},
+			errFunc: wantStatusErr(codes.InvalidArgument, "requires a status"),
+		},
+		{
+			name:    "unsupported status",
+			action:  pb.Action_ACTION_START,
+			waitFor: &pb.WaitFor{Status: pb.Status_STATUS_FAILED},
+			states:  []dbus.UnitStatus{running},
+			errFunc: wantStatusErr(codes.InvalidArgument, "not supported"),
+		},
+		{
+			name:   "settle longer than timeout",
+			action: pb.Action_ACTION_START,
+			waitFor: &pb.WaitFor{
+				SettleTime: timeout,
+				Timeout:    settle,
+			},
+			states:  []dbus.UnitStatus{running},
+			errFunc: wantStatusErr(codes.InvalidArgument, "settle_time"),
+		},
+		{
+			name:    "negative settle",
+			action:  pb.Action_ACTION_START,
+			waitFor: &pb.WaitFor{SettleTime: durationpb.New(-time.Second)},
+			states:  []dbus.UnitStatus{running},
+			errFunc: wantStatusErr(codes.InvalidArgument, "positive"),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			conn := &seqConn{actionConn: actionConn(operationResultDone), states: tc.states}
+			s := &server{
+				dialSystemd: func(context.Context) (systemdConnection, error) {
+					return conn, nil
+				},
+			}
+			req := &pb.ActionRequest{
+				ServiceName: "foo",
+				Action:      tc.action,
+				WaitFor:     tc.waitFor,
+			}
+			got, err := s.Action(context.Background(), req)
+			tc.errFunc(tc.name, err, t)
+			testutil.DiffErr(tc.name, got.GetServiceStatus(), tc.want, t)
+		})
+	}
+}
+
+func TestActionWaitForCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	conn := &seqConn{
+		actionConn: actionConn(operationResultDone),
+		states:     []dbus.UnitStatus{{Name: "foo.service", LoadState: loadStateLoaded, ActiveState: activeStateActivating}},
+	}
+	s := &server{
+		dialSystemd: func(context.Context) (systemdConnection, error) {
+			return conn, nil
+		},
+	}
+	_, err := s.Action(ctx, &pb.ActionRequest{
+		ServiceName: "foo",
+		Action:      pb.Action_ACTION_START,
+		WaitFor:     &pb.WaitFor{},
+	})
+	wantStatusErr(codes.DeadlineExceeded, "deadline")("cancel", err, t)
+}
+
+// unitsConn is a listConn which returns per unit properties. As with
+// systemd, unknown units have a LoadState of not-found.
+type unitsConn struct {
+	listConn
+	units    map[string]map[string]interface{}
+	services map[string]map[string]interface{}
+}
+
+func (u unitsConn) GetUnitPropertiesContext(_ context.Context, unit string) (map[string]interface{}, error) {
+	if p, ok := u.units[unit]; ok {
+		return p, nil
+	}
+	return map[string]interface{}{"LoadState": loadStateNotFound}, nil
+}
+func (u unitsConn) GetUnitTypePropertiesContext(_ context.Context, unit string, unitType string) (map[string]interface{}, error) {
+	if unitType != unitTypeService {
+		return nil, errors.New("unknown interface")
+	}
+	if p, ok := u.services[unit]; ok {
+		return p, nil
+	}
+	return map[string]interface{}{}, nil
+}
+
+func TestGetUnit(t *testing.T) {
+	conn := unitsConn{
+		units: map[string]map[string]interface{}{
+			"foo.service": {
+				"LoadState":    loadStateLoaded,
+				"Description":  "Foo Server",
+				"FragmentPath": "/usr/lib/systemd/system/foo.service",
+				"DropInPaths":  []string{"/etc/systemd/system/foo.service.d/10-limits.conf", "/etc/systemd/system/foo.service.d/20-env.conf"},
+			},
+			"bar.service": {
+				"LoadState": loadStateLoaded,
+			},
+		},
+		services: map[string]map[string]interface{}{
+			"foo.service": {
+				"Type": "notify",
+				"ExecStart": [][]interface{}{
+					{"/usr/bin/foo", []string{"/usr/bin/foo", "--port", "8080"}, false, uint64(0), uint64(0), uint64(0), uint64(0), uint32(0), int32(0), int32(0)},
+					{"/usr/bin/foo-hook", []string{"foo-hook"}, true, uint64(0), uint64(0), uint64(0), uint64(0), uint32(0), int32(0), int32(0)},
+					// Malformed entries are skipped.
+					{"/usr/bin/short"},
+				},
+				"User":             "foo",
+				"Group":            "daemon",
+				"WorkingDirectory": "/var/lib/foo",
+				"Environment":      []string{"LANG=C", "FOO_MODE=prod"},
+				"Restart":          "on-failure",
+				"RestartUSec":      uint64(5000000),
+			},
+		},
+	}
+	for _, tc := range []struct {
+		name    string
+		conn    systemdConnection
+		req     *pb.GetUnitRequest
+		want    *pb.GetUnitReply
+		errFunc func(string, error, *testing.T)
+	}{
+		{
+			name: "full unit",
+			conn: conn,
+			req:  &pb.GetUnitRequest{ServiceName: "foo"},
+			want: &pb.GetUnitReply{
+				SystemType: pb.SystemType_SYSTEM_TYPE_SYSTEMD,
+				Unit: &pb.UnitInfo{
+					ServiceName:  "foo",
+					Description:  "Foo Server",
+					FragmentPath: "/usr/lib/systemd/system/foo.service",
+					DropInPaths:  []string{"/etc/systemd/system/foo.service.d/10-limits.conf", "/etc/systemd/system/foo.service.d/20-env.conf"},
+					Type:         "notify",
+					ExecStart: []*pb.ExecCommand{
+						{
+							Path: "/usr/bin/foo",
+							Args: []string{"/usr/bin/foo", "--port", "8080"},
+						},
+						{
+							Path:         "/usr/bin/foo-hook",
+							Args:         []string{"foo-hook"},
+							IgnoreErrors: true,
+						},
+					},
+					User:             "foo",
+					Group:            "daemon",
+					WorkingDirectory: "/var/lib/foo",
+					Environment:      []string{"LANG=C", "FOO_MODE=prod"},
+					Restart:          "on-failure",
+					RestartSec:       durationpb.New(5 * time.Second),
+				},
+			},
+			errFunc: testutil.FatalOnErr,
+		},
+		{
+			name: "minimal unit",
+			conn: conn,
+			req:  &pb.GetUnitRequest{ServiceName: "bar.service"},
+			want: &pb.GetUnitReply{
+				SystemType: pb.SystemType_SYSTEM_TYPE_SYSTEMD,
+				Unit: &pb.UnitInfo{
+					ServiceName: "bar.service",
+				},
+			},
+			errFunc: testutil.FatalOnErr,
+		},
+		{
+			name:    "not found",
+			conn:    conn,
+			req:     &pb.GetUnitRequest{ServiceName: "baz"},
+			errFunc: wantStatusErr(codes.NotFound, "baz"),
+		},
+		{
+			name:    "no service",
+			conn:    conn,
+			req:     &pb.GetUnitRequest{},
+			errFunc: wantStatusErr(codes.InvalidArgument, "service name"),
+		},
+		{
+			name:    "bad system",
+			conn:    conn,
+			req:     &pb.GetUnitRequest{ServiceName: "foo", SystemType: pb.SystemType(100)},
+			errFunc: wantStatusErr(codes.InvalidArgument, "system"),
+		},
+		{
+			name:    "properties error",
+			conn:    errConn("sentinel"),
+			req:     &pb.GetUnitRequest{ServiceName: "foo"},
+			errFunc: wantStatusErr(codes.Internal, "sentinel"),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			s := &server{
+				dialSystemd: func(context.Context) (systemdConnection, error) {
+					return tc.conn, nil
+				},
+			}
+			got, err := s.GetUnit(context.Background(), tc.req)
+			tc.errFunc(tc.name, err, t)
+			testutil.DiffErr(tc.name, got, tc.want, t)
+		})
+	}
+}
+
+func TestDependencies(t *testing.T) {
+	conn := unitsConn{
+		units: map[string]map[string]interface{}{
+			"foo.service": {
+				"LoadState": loadStateLoaded,
+				// Deliberately unsorted.
+				"Requires":  []string{"sysinit.target", "foo.socket"},
+				"Wants":     []string{"network-online.target"},
+				"Conflicts": []string{"shutdown.target"},
+				"Before":    []string{"shutdown.target"},
+				"After":     []string{"network-online.target", "foo.socket"},
+			},
+			"foo.socket": {
+				"LoadState": loadStateLoaded,
+				"Requires":  []string{"sysinit.target"},
+				"PartOf":    []string{"foo.service"},
+			},
+			"sysinit.target": {
+				"LoadState": loadStateLoaded,
+				// A cycle back to foo.service shouldn't loop forever.
+				"Wants": []string{"foo.service", "missing.service"},
+			},
+		},
+	}
+	edge := func(from, to string, depType pb.DependencyType) *pb.Dependency {
+		return &pb.Dependency{From: from, To: to, Type: depType}
+	}
+	direct := []*pb.Dependency{
+		edge("foo.service", "foo.socket", pb.DependencyType_DEPENDENCY_TYPE_REQUIRES),
+		edge("foo.service", "sysinit.target", pb.DependencyType_DEPENDENCY_TYPE_REQUIRES),
+		edge("foo.service", "network-online.target", pb.DependencyType_DEPENDENCY_TYPE_WANTS),
+		edge("foo.service", "shutdown.target", pb.DependencyType_DEPENDENCY_TYPE_CONFLICTS),
+		edge("foo.service", "shutdown.target", pb.DependencyType_DEPENDENCY_TYPE_BEFORE),
+		edge("foo.service", "foo.socket", pb.DependencyType_DEPENDENCY_TYPE_AFTER),
+		edge("foo.service", "network-online.target", pb.DependencyType_DEPENDENCY_TYPE_AFTER),
+	}
+	recursive := append(append([]*pb.Dependency{}, direct...),
+		edge("foo.socket", "sysinit.target", pb.DependencyType_DEPENDENCY_TYPE_REQUIRES),
+		edge("foo.socket", "foo.service", pb.DependencyType_DEPENDENCY_TYPE_PART_OF),
+		edge("sysinit.target", "foo.service", pb.DependencyType_DEPENDENCY_TYPE_WANTS),
+		edge("sysinit.target", "missing.service", pb.DependencyType_DEPENDENCY_TYPE_WANTS),
+	)
+	for _, tc := range []struct {
+		name    string
+		conn    systemdConnection
+		req     *pb.DependenciesRequest
+		want    []*pb.Dependency
+		errFunc func(string, error, *testing.T)
+	}{
+		{
+			name:    "direct",
+			conn:    conn,
+			req:     &pb.DependenciesRequest{ServiceName: "foo"},
+			want:    direct,
+			errFunc: testutil.FatalOnErr,
+		},
+		{
+			name:    "recursive",
+			conn:    conn,
+			req:     &pb.DependenciesRequest{ServiceName: "foo.service", Recursive: true},
+			want:    recursive,
+			errFunc: testutil.FatalOnErr,
+		},
+		{
+			name:    "not found",
+			conn:    conn,
+			req:     &pb.DependenciesRequest{ServiceName: "baz"},
+			errFunc: wantStatusErr(codes.NotFound, "baz"),
+		},
+		{
+			name:    "no service",
+			conn:    conn,
+			req:     &pb.DependenciesRequest{},
+			errFunc: wantStatusErr(codes.InvalidArgument, "service name"),
+		},
+		{
+			name:    "bad system",
+			conn:    conn,
+			req:     &pb.DependenciesRequest{ServiceName: "foo", SystemType: pb.SystemType(100)},
+			errFunc: wantStatusErr(codes.InvalidArgument, "system"),
+		},
+		{
+			name:    "properties error",
+			conn:    errConn("sentinel"),
+			req:     &pb.DependenciesRequest{ServiceName: "foo"},
+			errFunc: wantStatusErr(codes.Internal, "sentinel"),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			s := &server{
+				dialSystemd: func(context.Context) (systemdConnection, error) {
+					return tc.conn, nil
+				},
+			}
+			got, err := s.Dependencies(context.Background(), tc.req)
+			tc.errFunc(tc.name, err, t)
+			testutil.DiffErr(tc.name, got.GetDependencies(), tc.want, t)
+		})
+	}
+}
+
+// watchConn is a unitsConn which delivers canned property updates once
+// subscribed, followed by subErr (if set) on the error channel.
+type watchConn struct {
+	unitsConn
+	updates  []*dbus.PropertiesUpdate
+	subErr   error
+	updateCh chan<- *dbus.PropertiesUpdate
+	errCh    chan<- error
+}
+
+func (w *watchConn) SetPropertiesSubscriber(updateCh chan<- *dbus.PropertiesUpdate, errCh chan<- error) {
+	w.updateCh, w.errCh = updateCh, errCh
+}
+func (w *watchConn) Subscribe() error {
+	go func() {
+		for _, u := range w.updates {
+			w.updateCh <- u
+		}
+		if w.subErr != nil {
+			w.errCh <- w.subErr
+		}
+	}()
+	return nil
+}
+
+// watchStream is a pb.Service_WatchServer which records the replies sent
+// and cancels its context once want replies have been sent.
+type watchStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	cancel  context.CancelFunc
+	want    int
+	replies []*pb.WatchReply
+	sendErr error
+}
+
+func (w *watchStream) Context() context.Context { return w.ctx }
+func (w *watchStream) Send(r *pb.WatchReply) error {
+	if w.sendErr != nil {
+		return w.sendErr
+	}
+	w.replies = append(w.replies, r)
+	if len(w.replies) == w.want {
+		w.cancel()
+	}
+	return nil
+}
+
+func TestWatch(t *testing.T) {
+	state := func(name, active, sub string) *pb.ServiceStatus {
+		u := dbus.UnitStatus{LoadState: loadStateLoaded, ActiveState: active, SubState: sub}
+		return unitToServiceStatus(name, u)
+	}
+	change := func(unit string, props map[string]string) *dbus.PropertiesUpdate {
+		changed := make(map[string]godbus.Variant)
+		for k, v := range props {
+			changed[k] = godbus.MakeVariant(v)
+		}
+		return &dbus.PropertiesUpdate{UnitName: unit, Changed: changed}
+	}
+	fooRunning := state("foo", activeStateActive, substateRunning)
+	fooStopping := state("foo", activeStateDeactivating, "stop-sigterm")
+	fooDead := state("foo", "inactive", "dead")
+	fooFailed := state("foo", activeStateFailed, "failed")
+	barFailed := state("bar", activeStateFailed, "failed")
+	quxStarting := state("qux", activeStateActivating, "start")
+	units := map[string]map[string]interface{}{
+		"foo.service": {
+			"LoadState":   loadStateLoaded,
+			"ActiveState": activeStateActive,
+			"SubState":    substateRunning,
+		},
+		"qux.service": {
+			"LoadState":   loadStateLoaded,
+			"ActiveState": activeStateActivating,
+			"SubState":    "start",
+		},
+	}
+
+	for _, tc := range []struct {
+		name    string
+		conn    systemdConnection
+		req     *pb.WatchRequest
+		sendErr error
+		want    []*pb.WatchReply
+		// If set the watch isn't cancelled after the wanted replies.
+		keepOpen bool
+		errFunc  func(string, error, *testing.T)
+	}{
+		{
+			name: "all services",
+			conn: &watchConn{
+				unitsConn: unitsConn{
+					listConn: listConn([]dbus.UnitStatus{
+						{Name: "foo.service", LoadState: loadStateLoaded, ActiveState: activeStateActive, SubState: substateRunning},
+						{Name: "bar.service", LoadState: loadStateLoaded, ActiveState: activeStateFailed, SubState: "failed"},
+						{Name: "baz.socket", LoadState: loadStateLoaded, ActiveState: activeStateActive, SubState: "listening"},
+					}),
+					units: units,
+				},
+				updates: []*dbus.PropertiesUpdate{
+					change("foo.service", map[string]string{"ActiveState": activeStateDeactivating, "SubState": "stop-sigterm"}),
+					change("baz.socket", map[string]string{"ActiveState": "inactive", "SubState": "dead"}),
+					change("foo.service", map[string]string{"Description": "not a state change"}),
+					change("foo.service", map[string]string{"ActiveState": "inactive", "SubState": "dead"}),
+					change("qux.service", map[string]string{"ActiveState": activeStateActivating}),
+				},
+			},
+			req: &pb.WatchRequest{},
+			want: []*pb.WatchReply{
+				{Current: barFailed},
+				{Current: fooRunning},
+				{Previous: fooRunning, Current: fooStopping},
+				{Previous: fooStopping, Current: fooDead},
+				{Current: quxStarting},
+			},
+			errFunc: testutil.FatalOnErr,
+		},
+		{
+			name: "named service",
+			conn: &watchConn{
+				unitsConn: unitsConn{units: units},
+				updates: []*dbus.PropertiesUpdate{
+					change("qux.service", map[string]string{"ActiveState": activeStateActive}),
+					change("foo.service", map[string]string{"ActiveState": activeStateFailed, "SubState": "failed"}),
+				},
+			},
+			req: &pb.WatchRequest{ServiceNames: []string{"foo"}},
+			want: []*pb.WatchReply{
+				{Current: fooRunning},
+				{Previous: fooRunning, Current: fooFailed},
+			},
+			errFunc: testutil.FatalOnErr,
+		},
+		{
+			name: "missed changes",
+			conn: &watchConn{
+				unitsConn: unitsConn{units: units},
+				subErr:    errors.New("update channel is full"),
+			},
+			req: &pb.WatchRequest{ServiceNames: []string{"foo.service"}},
+			want: []*pb.WatchReply{
+				{Current: fooRunning},
+			},
+			keepOpen: true,
+			errFunc:  wantStatusErr(codes.Internal, "missed"),
+		},
+		{
+			name:    "subscribe error",
+			conn:    errConn("sentinel"),
+			req:     &pb.WatchRequest{},
+			errFunc: wantStatusErr(codes.Internal, "sentinel"),
+		},
+		{
+			name:    "properties error",
+			conn:    propsConn{propsErr: errors.New("sentinel")},
+			req:     &pb.WatchRequest{ServiceNames: []string{"foo"}},
+			errFunc: wantStatusErr(codes.Internal, "sentinel"),
+		},
+		{
+			name:    "send error",
+			conn:    &watchConn{unitsConn: unitsConn{units: units}},
+			req:     &pb.WatchRequest{ServiceNames: []string{"foo"}},
+			sendErr: errors.New("sentinel"),
+			errFunc: wantStatusErr(codes.Internal, "sentinel"),
+		},
+		{
+			name:    "empty name",
+			conn:    &watchConn{},
+			req:     &pb.WatchRequest{ServiceNames: []string{""}},
+			errFunc: wantStatusErr(codes.InvalidArgument, "non-empty"),
+		},
+		{
+			name:    "bad system",
+			conn:    &watchConn{},
+			req:     &pb.WatchRequest{SystemType: pb.SystemType(100)},
+			errFunc: wantStatusErr(codes.InvalidArgument, "system"),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			s := &server{
+				dialSystemd: func(context.Context) (systemdConnection, error) {
+					return tc.conn, nil
+				},
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			stream := &watchStream{ctx: ctx, cancel: cancel, want: len(tc.want), sendErr: tc.sendErr}
+			if tc.keepOpen {
+				stream.want = -1
+			}
+			err := s.Watch(tc.req, stream)
+			tc.errFunc(tc.name, err, t)
+			for _, r := range stream.replies {
+				if r.SystemType != pb.SystemType_SYSTEM_TYPE_SYSTEMD || r.Timestamp == nil {
+					t.Errorf("%s: bad reply %v", tc.name, r)
+				}
+			}
+			testutil.DiffErr(tc.name, stream.replies, tc.want, t, protocmp.IgnoreFields(&pb.WatchReply{}, "system_type", "timestamp"))
+		})
+	}
+}
+
+// timersConn is a listConn which returns per unit timer properties.
+type timersConn struct {
+	listConn
+	timers map[string]map[string]interface{}
+}
+
+func (tc timersConn) GetUnitTypePropertiesContext(_ context.Context, unit string, unitType string) (map[string]interface{}, error) {
+	if unitType != unitTypeTimer {
+		return nil, errors.New("unknown interface")
+	}
+	if p, ok := tc.timers[unit]; ok {
+		return p, nil
+	}
+	return nil, errors.New("unknown unit")
+}
+
+func TestListTimers(t *testing.T) {
+	next := time.Date(2022, 4, 2, 0, 0, 0, 0, time.UTC)
+	last := time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)
+	units := listConn([]dbus.UnitStatus{
+		{Name: "logrotate.timer", LoadState: loadStateLoaded, ActiveState: activeStateActive, SubState: "waiting"},
+		{Name: "logrotate.service", LoadState: loadStateLoaded, ActiveState: "inactive", SubState: "dead"},
+		{Name: "cleanup.timer", LoadState: loadStateLoaded, ActiveState: activeStateActive, SubState: "elapsed"},
+	})
+	for _, tc := range []struct {
+		name    string
+		conn    systemdConnection
+		req     *pb.ListTimersRequest
+		want    *pb.ListTimersReply
+		errFunc func(string, error, *testing.T)
+	}{
+		{
+			name: "timers",
+			conn: timersConn{
+				listConn: units,
+				timers: map[string]map[string]interface{}{
+					"logrotate.timer": {
+						"Unit":                   "logrotate.service",
+						"NextElapseUSecRealtime": uint64(next.UnixMicro()),
+						"LastTriggerUSec":        uint64(last.UnixMicro()),
+						"TimersCalendar": [][]interface{}{
+							{"OnCalendar", "*-*-* 00:00:00", uint64(next.UnixMicro())},
+						},
+						"TimersMonotonic": [][]interface{}{
+							{"OnBootUSec", uint64(15 * time.Minute / time.Microsecond), uint64(0)},
+						},
+					},
+					"cleanup.timer": {
+						"Unit": "cleanup.service",
+						// Never elapsing again is reported as 0 or UINT64_MAX.
+						"NextElapseUSecRealtime": uint64(math.MaxUint64),
+						"LastTriggerUSec":        uint64(0),
+						"TimersMonotonic": [][]interface{}{
+							{"OnActiveUSec", uint64(30 * time.Second / time.Microsecond), uint64(0)},
+						},
+					},
+				},
+			},
+			req: &pb.ListTimersRequest{},
+			want: &pb.ListTimersReply{
+				SystemType: pb.SystemType_SYSTEM_TYPE_SYSTEMD,
+				Timers: []*pb.Timer{
+					{
+						TimerName:   "cleanup.timer",
+						Unit:        "cleanup.service",
+						LoadState:   loadStateLoaded,
+						ActiveState: activeStateActive,
+						SubState:    "elapsed",
+						Schedule:    []string{"OnActiveSec=30s"},
+					},
+					{
+						TimerName:   "logrotate.timer",
+						Unit:        "logrotate.service",
+						LoadState:   loadStateLoaded,
+						ActiveState: activeStateActive,
+						SubState:    "waiting",
+						NextElapse:  timestamppb.New(next),
+						LastTrigger: timestamppb.New(last),
+						Schedule:    []string{"OnCalendar=*-*-* 00:00:00", "OnBootSec=15m0s"},
+					},
+				},
+			},
+			errFunc: testutil.FatalOnErr,
+		},
+		{
+			name:    "list error",
+			conn:    errConn("sentinel"),
+			req:     &pb.ListTimersRequest{},
+			errFunc: wantStatusErr(codes.Internal, "sentinel"),
+		},
+		{
+			name:    "properties error",
+			conn:    timersConn{listConn: units},
+			req:     &pb.ListTimersRequest{},
+			errFunc: wantStatusErr(codes.Internal, "unknown unit"),
+		},
+		{
+			name:    "bad system",
+			conn:    timersConn{listConn: units},
+			req:     &pb.ListTimersRequest{SystemType: pb.SystemType(100)},
+			errFunc: wantStatusErr(codes.InvalidArgument, "system"),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			s := &server{
+				dialSystemd: func(context.Context) (systemdConnection, error) {
+					return tc.conn, nil
+				},
+			}
+			got, err := s.ListTimers(context.Background(), tc.req)
+			tc.errFunc(tc.name, err, t)
+			testutil.DiffErr(tc.name, got, tc.want, t)
+		})
+	}
+}
+
+func TestCreateTransientTimer(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		req      *pb.CreateTransientTimerRequest
+		runErr   error
+		wantArgs []string
+		want     *pb.CreateTransientTimerReply
+		errFunc  func(string, error, *testing.T)
+	}{
+		{
+			name: "on calendar",
+			req: &pb.CreateTransientTimerRequest{
+				Name:        "reindex",
+				Description: "one off reindex",
+				Schedule:    &pb.CreateTransientTimerRequest_OnCalendar{OnCalendar: "2022-04-02 03:00"},
+				Command:     "/usr/bin/reindex",
+				Args:        []string{"--all", "--verbose"},
+				User:        "app",
+			},
+			wantArgs: []string{"--unit=reindex", "--collect", "--description=one off reindex", "--on-calendar=2022-04-02 03:00", "--uid=app", "--", "/usr/bin/reindex", "--all", "--verbose"},
+			want: &pb.CreateTransientTimerReply{
+				SystemType:  pb.SystemType_SYSTEM_TYPE_SYSTEMD,
+				TimerName:   "reindex.timer",
+				ServiceName: "reindex.service",
+			},
+			errFunc: testutil.FatalOnErr,
+		},
+		{
+			name: "on active",
+			req: &pb.CreateTransientTimerRequest{
+				Name:     "restart-foo",
+				Schedule: &pb.CreateTransientTimerRequest_OnActive{OnActive: durationpb.New(90 * time.Minute)},
+				Command:  "/usr/bin/systemctl",
+				Args:     []string{"restart", "foo"},
+			},
+			wantArgs: []string{"--unit=restart-foo", "--collect", "--on-active=5400000000us", "--", "/usr/bin/systemctl", "restart", "foo"},
+			want: &pb.CreateTransientTimerReply{
+				SystemType:  pb.SystemType_SYSTEM_TYPE_SYSTEMD,
+				TimerName:   "restart-foo.timer",
+				ServiceName: "restart-foo.service",
+			},
+			errFunc: testutil.FatalOnErr,
+		},
+		{
+			name: "run error",
+			req: &pb.CreateTransientTimerRequest{
+				Name:     "dup",
+				Schedule: &pb.CreateTransientTimerRequest_OnActive{OnActive: durationpb.New(time.Minute)},
+				Command:  "/bin/true",
+			},
+			runErr:   errors.New("Unit dup.service already exists"),
+			wantArgs: []string{"--unit=dup", "--collect", "--on-active=60000000us", "--", "/bin/true"},
+			errFunc:  wantStatusErr(codes.Internal, "already exists"),
+		},
+		{
+			name: "bad name",
+			req: &pb.CreateTransientTimerRequest{
+				Name:     "--foo",
+				Schedule: &pb.CreateTransientTimerRequest_OnActive{OnActive: durationpb.New(time.Minute)},
+				Command:  "/bin/true",
+			},
+			errFunc: wantStatusErr(codes.InvalidArgument, "name"),
+		},
+		{
+			name: "name with suffix",
+			req: &pb.CreateTransientTimerRequest{
+				Name:     "foo.timer",
+				Schedule: &pb.CreateTransientTimerRequest_OnActive{OnActive: durationpb.New(time.Minute)},
+				Command:  "/bin/true",
+			},
+			errFunc: wantStatusErr(codes.InvalidArgument, "suffix"),
+		},
+		{
+			name: "relative command",
+			req: &pb.CreateTransientTimerRequest{
+				Name:     "foo",
+				Schedule: &pb.CreateTransientTimerRequest_OnActive{OnActive: durationpb.New(time.Minute)},
+				Command:  "true",
+			},
+			errFunc: wantStatusErr(codes.InvalidArgument, "absolute"),
+		},
+		{
+			name: "no schedule",
+			req: &pb.CreateTransientTimerRequest{
+				Name:    "foo",
+				Command: "/bin/true",
+			},
+			errFunc: wantStatusErr(codes.InvalidArgument, "schedule"),
+		},
+		{
+			name: "empty calendar",
+			req: &pb.CreateTransientTimerRequest{
+				Name:     "foo",
+				Schedule: &pb.CreateTransientTimerRequest_OnCalendar{},
+				Command:  "/bin/true",
+			},
+			errFunc: wantStatusErr(codes.InvalidArgument, "on_calendar"),
+		},
+		{
+			name: "negative delay",
+			req: &pb.CreateTransientTimerRequest{
+				Name:     "foo",
+				Schedule: &pb.CreateTransientTimerRequest_OnActive{OnActive: durationpb.New(-time.Minute)},
+				Command:  "/bin/true",
+			},
+			errFunc: wantStatusErr(codes.InvalidArgument, "positive"),
+		},
+		{
+			name: "bad system",
+			req: &pb.CreateTransientTimerRequest{
+				SystemType: pb.SystemType(100),
+				Name:       "foo",
+				Schedule:   &pb.CreateTransientTimerRequest_OnActive{OnActive: durationpb.New(time.Minute)},
+				Command:    "/bin/true",
+			},
+			errFunc: wantStatusErr(codes.InvalidArgument, "system"),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var gotArgs []string
+			s := &server{
+				systemdRun: func(_ context.Context, args []string) error {
+					gotArgs = args
+					return tc.runErr
+				},
+			}
+			got, err := s.CreateTransientTimer(context.Background(), tc.req)
+			tc.errFunc(tc.name, err, t)
+			testutil.DiffErr(tc.name+" args", gotArgs, tc.wantArgs, t)
+			testutil.DiffErr(tc.name, got, tc.want, t)
+		})
+	}
+}
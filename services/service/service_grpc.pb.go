@@ -24,6 +24,32 @@ type ServiceClient interface {
 	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusReply, error)
 	// Action alters the status of a single service.
 	Action(ctx context.Context, in *ActionRequest, opts ...grpc.CallOption) (*ActionReply, error)
+	// DaemonReload asks the service management system to reload its
+	// configuration (i.e. after unit files have changed on disk).
+	DaemonReload(ctx context.Context, in *DaemonReloadRequest, opts ...grpc.CallOption) (*DaemonReloadReply, error)
+	// Logs streams the log entries (i.e. from the systemd journal) for a
+	// single service. If follow is set the stream continues returning new
+	// entries until the client cancels.
+	// NOTE: Log messages can contain sensitive data.
+	Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (Service_LogsClient, error)
+	// GetUnit returns how a single service is configured (i.e. where its
+	// definition lives and the effective settings after drop-ins).
+	// NOTE: The environment can contain sensitive data.
+	GetUnit(ctx context.Context, in *GetUnitRequest, opts ...grpc.CallOption) (*GetUnitReply, error)
+	// Dependencies returns the dependency edges of a single service.
+	Dependencies(ctx context.Context, in *DependenciesRequest, opts ...grpc.CallOption) (*DependenciesReply, error)
+	// Watch streams state changes for services as they happen. The current
+	// state of each matching service is sent first followed by every
+	// transition until the client cancels.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Service_WatchClient, error)
+	// ListTimers returns the timers (scheduled tasks) configured on the system.
+	ListTimers(ctx context.Context, in *ListTimersRequest, opts ...grpc.CallOption) (*ListTimersReply, error)
+	// CreateTransientTimer schedules a command to run once at a later time
+	// (as systemd-run does) using a transient timer and service which
+	// are removed once they complete.
+	// NOTE: This runs an arbitrary command (as root by default) so policy
+	// should restrict the command, args and user allowed.
+	CreateTransientTimer(ctx context.Context, in *CreateTransientTimerRequest, opts ...grpc.CallOption) (*CreateTransientTimerReply, error)
 }
 
 type serviceClient struct {
@@ -61,6 +87,115 @@ func (c *serviceClient) Action(ctx context.Context, in *ActionRequest, opts ...g
 	return out, nil
 }
 
+func (c *serviceClient) DaemonReload(ctx context.Context, in *DaemonReloadRequest, opts ...grpc.CallOption) (*DaemonReloadReply, error) {
+	out := new(DaemonReloadReply)
+	err := c.cc.Invoke(ctx, "/Service.Service/DaemonReload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (Service_LogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[0], "/Service.Service/Logs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_LogsClient interface {
+	Recv() (*LogsReply, error)
+	grpc.ClientStream
+}
+
+type serviceLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceLogsClient) Recv() (*LogsReply, error) {
+	m := new(LogsReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serviceClient) GetUnit(ctx context.Context, in *GetUnitRequest, opts ...grpc.CallOption) (*GetUnitReply, error) {
+	out := new(GetUnitReply)
+	err := c.cc.Invoke(ctx, "/Service.Service/GetUnit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) Dependencies(ctx context.Context, in *DependenciesRequest, opts ...grpc.CallOption) (*DependenciesReply, error) {
+	out := new(DependenciesReply)
+	err := c.cc.Invoke(ctx, "/Service.Service/Dependencies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Service_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[1], "/Service.Service/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_WatchClient interface {
+	Recv() (*WatchReply, error)
+	grpc.ClientStream
+}
+
+type serviceWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceWatchClient) Recv() (*WatchReply, error) {
+	m := new(WatchReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serviceClient) ListTimers(ctx context.Context, in *ListTimersRequest, opts ...grpc.CallOption) (*ListTimersReply, error) {
+	out := new(ListTimersReply)
+	err := c.cc.Invoke(ctx, "/Service.Service/ListTimers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) CreateTransientTimer(ctx context.Context, in *CreateTransientTimerRequest, opts ...grpc.CallOption) (*CreateTransientTimerReply, error) {
+	out := new(CreateTransientTimerReply)
+	err := c.cc.Invoke(ctx, "/Service.Service/CreateTransientTimer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServiceServer is the server API for Service service.
 // All implementations should embed UnimplementedServiceServer
 // for forward compatibility
@@ -71,6 +206,32 @@ type ServiceServer interface {
 	Status(context.Context, *StatusRequest) (*StatusReply, error)
 	// Action alters the status of a single service.
 	Action(context.Context, *ActionRequest) (*ActionReply, error)
+	// DaemonReload asks the service management system to reload its
+	// configuration (i.e. after unit files have changed on disk).
+	DaemonReload(context.Context, *DaemonReloadRequest) (*DaemonReloadReply, error)
+	// Logs streams the log entries (i.e. from the systemd journal) for a
+	// single service. If follow is set the stream continues returning new
+	// entries until the client cancels.
+	// NOTE: Log messages can contain sensitive data.
+	Logs(*LogsRequest, Service_LogsServer) error
+	// GetUnit returns how a single service is configured (i.e. where its
+	// definition lives and the effective settings after drop-ins).
+	// NOTE: The environment can contain sensitive data.
+	GetUnit(context.Context, *GetUnitRequest) (*GetUnitReply, error)
+	// Dependencies returns the dependency edges of a single service.
+	Dependencies(context.Context, *DependenciesRequest) (*DependenciesReply, error)
+	// Watch streams state changes for services as they happen. The current
+	// state of each matching service is sent first followed by every
+	// transition until the client cancels.
+	Watch(*WatchRequest, Service_WatchServer) error
+	// ListTimers returns the timers (scheduled tasks) configured on the system.
+	ListTimers(context.Context, *ListTimersRequest) (*ListTimersReply, error)
+	// CreateTransientTimer schedules a command to run once at a later time
+	// (as systemd-run does) using a transient timer and service which
+	// are removed once they complete.
+	// NOTE: This runs an arbitrary command (as root by default) so policy
+	// should restrict the command, args and user allowed.
+	CreateTransientTimer(context.Context, *CreateTransientTimerRequest) (*CreateTransientTimerReply, error)
 }
 
 // UnimplementedServiceServer should be embedded to have forward compatible implementations.
@@ -86,6 +247,27 @@ func (UnimplementedServiceServer) Status(context.Context, *StatusRequest) (*Stat
 func (UnimplementedServiceServer) Action(context.Context, *ActionRequest) (*ActionReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Action not implemented")
 }
+func (UnimplementedServiceServer) DaemonReload(context.Context, *DaemonReloadRequest) (*DaemonReloadReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DaemonReload not implemented")
+}
+func (UnimplementedServiceServer) Logs(*LogsRequest, Service_LogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method Logs not implemented")
+}
+func (UnimplementedServiceServer) GetUnit(context.Context, *GetUnitRequest) (*GetUnitReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUnit not implemented")
+}
+func (UnimplementedServiceServer) Dependencies(context.Context, *DependenciesRequest) (*DependenciesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Dependencies not implemented")
+}
+func (UnimplementedServiceServer) Watch(*WatchRequest, Service_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedServiceServer) ListTimers(context.Context, *ListTimersRequest) (*ListTimersReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTimers not implemented")
+}
+func (UnimplementedServiceServer) CreateTransientTimer(context.Context, *CreateTransientTimerRequest) (*CreateTransientTimerReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTransientTimer not implemented")
+}
 
 // UnsafeServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to ServiceServer will
@@ -152,6 +334,138 @@ func _Service_Action_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_DaemonReload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DaemonReloadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).DaemonReload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Service.Service/DaemonReload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).DaemonReload(ctx, req.(*DaemonReloadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_Logs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).Logs(m, &serviceLogsServer{stream})
+}
+
+type Service_LogsServer interface {
+	Send(*LogsReply) error
+	grpc.ServerStream
+}
+
+type serviceLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceLogsServer) Send(m *LogsReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Service_GetUnit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUnitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetUnit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Service.Service/GetUnit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetUnit(ctx, req.(*GetUnitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_Dependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).Dependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Service.Service/Dependencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).Dependencies(ctx, req.(*DependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).Watch(m, &serviceWatchServer{stream})
+}
+
+type Service_WatchServer interface {
+	Send(*WatchReply) error
+	grpc.ServerStream
+}
+
+type serviceWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceWatchServer) Send(m *WatchReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Service_ListTimers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTimersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ListTimers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Service.Service/ListTimers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ListTimers(ctx, req.(*ListTimersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_CreateTransientTimer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTransientTimerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).CreateTransientTimer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Service.Service/CreateTransientTimer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).CreateTransientTimer(ctx, req.(*CreateTransientTimerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Service_ServiceDesc is the grpc.ServiceDesc for Service service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -171,7 +485,38 @@ var Service_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Action",
 			Handler:    _Service_Action_Handler,
 		},
+		{
+			MethodName: "DaemonReload",
+			Handler:    _Service_DaemonReload_Handler,
+		},
+		{
+			MethodName: "GetUnit",
+			Handler:    _Service_GetUnit_Handler,
+		},
+		{
+			MethodName: "Dependencies",
+			Handler:    _Service_Dependencies_Handler,
+		},
+		{
+			MethodName: "ListTimers",
+			Handler:    _Service_ListTimers_Handler,
+		},
+		{
+			MethodName: "CreateTransientTimer",
+			Handler:    _Service_CreateTransientTimer_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Logs",
+			Handler:       _Service_Logs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Watch",
+			Handler:       _Service_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "service.proto",
 }
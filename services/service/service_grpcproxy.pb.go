@@ -13,6 +13,7 @@ import (
 
 import (
 	"fmt"
+	"io"
 )
 
 // ServiceClientProxy is the superset of ServiceClient which additionally includes the OneMany proxy methods
@@ -21,6 +22,13 @@ type ServiceClientProxy interface {
 	ListOneMany(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (<-chan *ListManyResponse, error)
 	StatusOneMany(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (<-chan *StatusManyResponse, error)
 	ActionOneMany(ctx context.Context, in *ActionRequest, opts ...grpc.CallOption) (<-chan *ActionManyResponse, error)
+	DaemonReloadOneMany(ctx context.Context, in *DaemonReloadRequest, opts ...grpc.CallOption) (<-chan *DaemonReloadManyResponse, error)
+	LogsOneMany(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (Service_LogsClientProxy, error)
+	GetUnitOneMany(ctx context.Context, in *GetUnitRequest, opts ...grpc.CallOption) (<-chan *GetUnitManyResponse, error)
+	DependenciesOneMany(ctx context.Context, in *DependenciesRequest, opts ...grpc.CallOption) (<-chan *DependenciesManyResponse, error)
+	WatchOneMany(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Service_WatchClientProxy, error)
+	ListTimersOneMany(ctx context.Context, in *ListTimersRequest, opts ...grpc.CallOption) (<-chan *ListTimersManyResponse, error)
+	CreateTransientTimerOneMany(ctx context.Context, in *CreateTransientTimerRequest, opts ...grpc.CallOption) (<-chan *CreateTransientTimerManyResponse, error)
 }
 
 // Embed the original client inside of this so we get the other generated methods automatically.
@@ -234,3 +242,514 @@ func (c *serviceClientProxy) ActionOneMany(ctx context.Context, in *ActionReques
 
 	return ret, nil
 }
+
+// DaemonReloadManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type DaemonReloadManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *DaemonReloadReply
+	Error error
+}
+
+// DaemonReloadOneMany provides the same API as DaemonReload but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *serviceClientProxy) DaemonReloadOneMany(ctx context.Context, in *DaemonReloadRequest, opts ...grpc.CallOption) (<-chan *DaemonReloadManyResponse, error) {
+	conn := c.cc.(*proxy.Conn)
+	ret := make(chan *DaemonReloadManyResponse)
+	// If this is a single case we can just use Invoke and marshal it onto the channel once and be done.
+	if len(conn.Targets) == 1 {
+		go func() {
+			out := &DaemonReloadManyResponse{
+				Target: conn.Targets[0],
+				Index:  0,
+				Resp:   &DaemonReloadReply{},
+			}
+			err := conn.Invoke(ctx, "/Service.Service/DaemonReload", in, out.Resp, opts...)
+			if err != nil {
+				out.Error = err
+			}
+			// Send and close.
+			ret <- out
+			close(ret)
+		}()
+		return ret, nil
+	}
+	manyRet, err := conn.InvokeOneMany(ctx, "/Service.Service/DaemonReload", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// A goroutine to retrive untyped responses and convert them to typed ones.
+	go func() {
+		for {
+			typedResp := &DaemonReloadManyResponse{
+				Resp: &DaemonReloadReply{},
+			}
+
+			resp, ok := <-manyRet
+			if !ok {
+				// All done so we can shut down.
+				close(ret)
+				return
+			}
+			typedResp.Target = resp.Target
+			typedResp.Index = resp.Index
+			typedResp.Error = resp.Error
+			if resp.Error == nil {
+				if err := resp.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+					typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, resp.Error)
+				}
+			}
+			ret <- typedResp
+		}
+	}()
+
+	return ret, nil
+}
+
+// LogsManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type LogsManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *LogsReply
+	Error error
+}
+
+type Service_LogsClientProxy interface {
+	Recv() ([]*LogsManyResponse, error)
+	grpc.ClientStream
+}
+
+type serviceClientLogsClientProxy struct {
+	cc         *proxy.Conn
+	directDone bool
+	grpc.ClientStream
+}
+
+func (x *serviceClientLogsClientProxy) Recv() ([]*LogsManyResponse, error) {
+	var ret []*LogsManyResponse
+	// If this is a direct connection the RecvMsg call is to a standard grpc.ClientStream
+	// and not our proxy based one. This means we need to receive a typed response and
+	// convert it into a single slice entry return. This ensures the OneMany style calls
+	// can be used by proxy with 1:N targets and non proxy with 1 target without client changes.
+	if x.cc.Direct() {
+		// Check if we're done. Just return EOF now. Any real error was already sent inside
+		// of a ManyResponse.
+		if x.directDone {
+			return nil, io.EOF
+		}
+		m := &LogsReply{}
+		err := x.ClientStream.RecvMsg(m)
+		ret = append(ret, &LogsManyResponse{
+			Resp:   m,
+			Error:  err,
+			Target: x.cc.Targets[0],
+			Index:  0,
+		})
+		// An error means we're done so set things so a later call now gets an EOF.
+		if err != nil {
+			x.directDone = true
+		}
+		return ret, nil
+	}
+
+	m := []*proxy.Ret{}
+	if err := x.ClientStream.RecvMsg(&m); err != nil {
+		return nil, err
+	}
+	for _, r := range m {
+		typedResp := &LogsManyResponse{
+			Resp: &LogsReply{},
+		}
+		typedResp.Target = r.Target
+		typedResp.Index = r.Index
+		typedResp.Error = r.Error
+		if r.Error == nil {
+			if err := r.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+				typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, r.Error)
+			}
+		}
+		ret = append(ret, typedResp)
+	}
+	return ret, nil
+}
+
+// LogsOneMany provides the same API as Logs but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *serviceClientProxy) LogsOneMany(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (Service_LogsClientProxy, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[0], "/Service.Service/Logs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceClientLogsClientProxy{c.cc.(*proxy.Conn), false, stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// GetUnitManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type GetUnitManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *GetUnitReply
+	Error error
+}
+
+// GetUnitOneMany provides the same API as GetUnit but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *serviceClientProxy) GetUnitOneMany(ctx context.Context, in *GetUnitRequest, opts ...grpc.CallOption) (<-chan *GetUnitManyResponse, error) {
+	conn := c.cc.(*proxy.Conn)
+	ret := make(chan *GetUnitManyResponse)
+	// If this is a single case we can just use Invoke and marshal it onto the channel once and be done.
+	if len(conn.Targets) == 1 {
+		go func() {
+			out := &GetUnitManyResponse{
+				Target: conn.Targets[0],
+				Index:  0,
+				Resp:   &GetUnitReply{},
+			}
+			err := conn.Invoke(ctx, "/Service.Service/GetUnit", in, out.Resp, opts...)
+			if err != nil {
+				out.Error = err
+			}
+			// Send and close.
+			ret <- out
+			close(ret)
+		}()
+		return ret, nil
+	}
+	manyRet, err := conn.InvokeOneMany(ctx, "/Service.Service/GetUnit", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// A goroutine to retrive untyped responses and convert them to typed ones.
+	go func() {
+		for {
+			typedResp := &GetUnitManyResponse{
+				Resp: &GetUnitReply{},
+			}
+
+			resp, ok := <-manyRet
+			if !ok {
+				// All done so we can shut down.
+				close(ret)
+				return
+			}
+			typedResp.Target = resp.Target
+			typedResp.Index = resp.Index
+			typedResp.Error = resp.Error
+			if resp.Error == nil {
+				if err := resp.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+					typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, resp.Error)
+				}
+			}
+			ret <- typedResp
+		}
+	}()
+
+	return ret, nil
+}
+
+// DependenciesManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type DependenciesManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *DependenciesReply
+	Error error
+}
+
+// DependenciesOneMany provides the same API as Dependencies but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *serviceClientProxy) DependenciesOneMany(ctx context.Context, in *DependenciesRequest, opts ...grpc.CallOption) (<-chan *DependenciesManyResponse, error) {
+	conn := c.cc.(*proxy.Conn)
+	ret := make(chan *DependenciesManyResponse)
+	// If this is a single case we can just use Invoke and marshal it onto the channel once and be done.
+	if len(conn.Targets) == 1 {
+		go func() {
+			out := &DependenciesManyResponse{
+				Target: conn.Targets[0],
+				Index:  0,
+				Resp:   &DependenciesReply{},
+			}
+			err := conn.Invoke(ctx, "/Service.Service/Dependencies", in, out.Resp, opts...)
+			if err != nil {
+				out.Error = err
+			}
+			// Send and close.
+			ret <- out
+			close(ret)
+		}()
+		return ret, nil
+	}
+	manyRet, err := conn.InvokeOneMany(ctx, "/Service.Service/Dependencies", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// A goroutine to retrive untyped responses and convert them to typed ones.
+	go func() {
+		for {
+			typedResp := &DependenciesManyResponse{
+				Resp: &DependenciesReply{},
+			}
+
+			resp, ok := <-manyRet
+			if !ok {
+				// All done so we can shut down.
+				close(ret)
+				return
+			}
+			typedResp.Target = resp.Target
+			typedResp.Index = resp.Index
+			typedResp.Error = resp.Error
+			if resp.Error == nil {
+				if err := resp.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+					typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, resp.Error)
+				}
+			}
+			ret <- typedResp
+		}
+	}()
+
+	return ret, nil
+}
+
+// WatchManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type WatchManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *WatchReply
+	Error error
+}
+
+type Service_WatchClientProxy interface {
+	Recv() ([]*WatchManyResponse, error)
+	grpc.ClientStream
+}
+
+type serviceClientWatchClientProxy struct {
+	cc         *proxy.Conn
+	directDone bool
+	grpc.ClientStream
+}
+
+func (x *serviceClientWatchClientProxy) Recv() ([]*WatchManyResponse, error) {
+	var ret []*WatchManyResponse
+	// If this is a direct connection the RecvMsg call is to a standard grpc.ClientStream
+	// and not our proxy based one. This means we need to receive a typed response and
+	// convert it into a single slice entry return. This ensures the OneMany style calls
+	// can be used by proxy with 1:N targets and non proxy with 1 target without client changes.
+	if x.cc.Direct() {
+		// Check if we're done. Just return EOF now. Any real error was already sent inside
+		// of a ManyResponse.
+		if x.directDone {
+			return nil, io.EOF
+		}
+		m := &WatchReply{}
+		err := x.ClientStream.RecvMsg(m)
+		ret = append(ret, &WatchManyResponse{
+			Resp:   m,
+			Error:  err,
+			Target: x.cc.Targets[0],
+			Index:  0,
+		})
+		// An error means we're done so set things so a later call now gets an EOF.
+		if err != nil {
+			x.directDone = true
+		}
+		return ret, nil
+	}
+
+	m := []*proxy.Ret{}
+	if err := x.ClientStream.RecvMsg(&m); err != nil {
+		return nil, err
+	}
+	for _, r := range m {
+		typedResp := &WatchManyResponse{
+			Resp: &WatchReply{},
+		}
+		typedResp.Target = r.Target
+		typedResp.Index = r.Index
+		typedResp.Error = r.Error
+		if r.Error == nil {
+			if err := r.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+				typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, r.Error)
+			}
+		}
+		ret = append(ret, typedResp)
+	}
+	return ret, nil
+}
+
+// WatchOneMany provides the same API as Watch but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *serviceClientProxy) WatchOneMany(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Service_WatchClientProxy, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[1], "/Service.Service/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceClientWatchClientProxy{c.cc.(*proxy.Conn), false, stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// ListTimersManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type ListTimersManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *ListTimersReply
+	Error error
+}
+
+// ListTimersOneMany provides the same API as ListTimers but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *serviceClientProxy) ListTimersOneMany(ctx context.Context, in *ListTimersRequest, opts ...grpc.CallOption) (<-chan *ListTimersManyResponse, error) {
+	conn := c.cc.(*proxy.Conn)
+	ret := make(chan *ListTimersManyResponse)
+	// If this is a single case we can just use Invoke and marshal it onto the channel once and be done.
+	if len(conn.Targets) == 1 {
+		go func() {
+			out := &ListTimersManyResponse{
+				Target: conn.Targets[0],
+				Index:  0,
+				Resp:   &ListTimersReply{},
+			}
+			err := conn.Invoke(ctx, "/Service.Service/ListTimers", in, out.Resp, opts...)
+			if err != nil {
+				out.Error = err
+			}
+			// Send and close.
+			ret <- out
+			close(ret)
+		}()
+		return ret, nil
+	}
+	manyRet, err := conn.InvokeOneMany(ctx, "/Service.Service/ListTimers", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// A goroutine to retrive untyped responses and convert them to typed ones.
+	go func() {
+		for {
+			typedResp := &ListTimersManyResponse{
+				Resp: &ListTimersReply{},
+			}
+
+			resp, ok := <-manyRet
+			if !ok {
+				// All done so we can shut down.
+				close(ret)
+				return
+			}
+			typedResp.Target = resp.Target
+			typedResp.Index = resp.Index
+			typedResp.Error = resp.Error
+			if resp.Error == nil {
+				if err := resp.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+					typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, resp.Error)
+				}
+			}
+			ret <- typedResp
+		}
+	}()
+
+	return ret, nil
+}
+
+// CreateTransientTimerManyResponse encapsulates a proxy data packet.
+// It includes the target, index, response and possible error returned.
+type CreateTransientTimerManyResponse struct {
+	Target string
+	// As targets can be duplicated this is the index into the slice passed to proxy.Conn.
+	Index int
+	Resp  *CreateTransientTimerReply
+	Error error
+}
+
+// CreateTransientTimerOneMany provides the same API as CreateTransientTimer but sends the same request to N destinations at once.
+// N can be a single destination.
+//
+// NOTE: The returned channel must be read until it closes in order to avoid leaking goroutines.
+func (c *serviceClientProxy) CreateTransientTimerOneMany(ctx context.Context, in *CreateTransientTimerRequest, opts ...grpc.CallOption) (<-chan *CreateTransientTimerManyResponse, error) {
+	conn := c.cc.(*proxy.Conn)
+	ret := make(chan *CreateTransientTimerManyResponse)
+	// If this is a single case we can just use Invoke and marshal it onto the channel once and be done.
+	if len(conn.Targets) == 1 {
+		go func() {
+			out := &CreateTransientTimerManyResponse{
+				Target: conn.Targets[0],
+				Index:  0,
+				Resp:   &CreateTransientTimerReply{},
+			}
+			err := conn.Invoke(ctx, "/Service.Service/CreateTransientTimer", in, out.Resp, opts...)
+			if err != nil {
+				out.Error = err
+			}
+			// Send and close.
+			ret <- out
+			close(ret)
+		}()
+		return ret, nil
+	}
+	manyRet, err := conn.InvokeOneMany(ctx, "/Service.Service/CreateTransientTimer", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// A goroutine to retrive untyped responses and convert them to typed ones.
+	go func() {
+		for {
+			typedResp := &CreateTransientTimerManyResponse{
+				Resp: &CreateTransientTimerReply{},
+			}
+
+			resp, ok := <-manyRet
+			if !ok {
+				// All done so we can shut down.
+				close(ret)
+				return
+			}
+			typedResp.Target = resp.Target
+			typedResp.Index = resp.Index
+			typedResp.Error = resp.Error
+			if resp.Error == nil {
+				if err := resp.Resp.UnmarshalTo(typedResp.Resp); err != nil {
+					typedResp.Error = fmt.Errorf("can't decode any response - %v. Original Error - %v", err, resp.Error)
+				}
+			}
+			ret <- typedResp
+		}
+	}()
+
+	return ret, nil
+}
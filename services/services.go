@@ -27,6 +27,7 @@ import (
 var (
 	mu          sync.RWMutex
 	rpcServices []SansShellRPCService
+	policyHash  string
 )
 
 // SansShellRPCService provides an interface for services to implement
@@ -49,3 +50,21 @@ func ListServices() []SansShellRPCService {
 	defer mu.RUnlock()
 	return rpcServices
 }
+
+// SetPolicyHash records the hash of the OPA policy the server is enforcing
+// so services can report on it without needing the policy itself. hash is
+// normally opa.AuthzPolicy.Hash() and is set again whenever the policy is
+// reloaded.
+func SetPolicyHash(hash string) {
+	mu.Lock()
+	defer mu.Unlock()
+	policyHash = hash
+}
+
+// PolicyHash returns the hash last passed to SetPolicyHash or an empty
+// string if it was never called.
+func PolicyHash() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return policyHash
+}
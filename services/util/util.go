@@ -65,6 +65,7 @@ type cmdOptions struct {
 	env          []string
 	uid          uint32
 	gid          uint32
+	stdout       io.Writer
 }
 
 // Option will run the apply operation to change required checking/state
@@ -129,6 +130,15 @@ func EnvVar(evar string) Option {
 	})
 }
 
+// StdoutWriter is an option where stdout is also written to w as the command runs
+// rather than only being available once it completes. If w returns an error the
+// command's output is no longer read and it will be an error from the run.
+func StdoutWriter(w io.Writer) Option {
+	return optionfunc(func(o *cmdOptions) {
+		o.stdout = w
+	})
+}
+
 // DefRunBufLimit is the default limit we'll buffer for stdout/stderr from RunCommand exec'ing
 // a process.
 const DefRunBufLimit = 10 * 1024 * 1024
@@ -230,6 +240,9 @@ func RunCommand(ctx context.Context, bin string, args []string, opts ...Option)
 	// These probably should be streaming through a go-routine to rate limit what we
 	// can buffer. In practice output tends to be in the low K range size wise.
 	cmd.Stdout = run.Stdout
+	if options.stdout != nil {
+		cmd.Stdout = io.MultiWriter(run.Stdout, options.stdout)
+	}
 	cmd.Stderr = run.Stderr
 	cmd.Stdin = nil
 	// Set to an empty slice to get an empty environment. Nil means inherit.
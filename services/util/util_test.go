@@ -37,6 +37,7 @@ func TestRunCommand(t *testing.T) {
 		stderr            string
 		stderrIsError     bool
 		env               []string
+		teeStdout         bool
 	}{
 		{
 			name:    "Not absolute path",
@@ -69,6 +70,14 @@ func TestRunCommand(t *testing.T) {
 			stderrIsError: true,
 			wantErr:       true,
 		},
+		{
+			name:      "Command with stdout also written as it runs",
+			bin:       testutil.ResolvePath(t, "sh"),
+			args:      []string{"-c", "echo foo >&2 && echo bar && echo baz"},
+			stdout:    "bar\nbaz\n",
+			stderr:    "foo\n",
+			teeStdout: true,
+		},
 		{
 			name:   "Verify clean environment",
 			bin:    testutil.ResolvePath(t, "env"),
@@ -116,6 +125,10 @@ func TestRunCommand(t *testing.T) {
 			if tc.gid != 0 {
 				opts = append(opts, CommandGroup(tc.gid))
 			}
+			tee := &bytes.Buffer{}
+			if tc.teeStdout {
+				opts = append(opts, StdoutWriter(tee))
+			}
 			run, err := RunCommand(context.Background(), tc.bin, tc.args, opts...)
 			t.Logf("%s: response: %+v", tc.name, run)
 			t.Logf("%s: error: %v", tc.name, err)
@@ -131,6 +144,9 @@ func TestRunCommand(t *testing.T) {
 			if got, want := run.Stderr.String(), tc.stderr; got != want {
 				t.Fatalf("%s: Stderr differs. Want %q Got %q", tc.name, want, got)
 			}
+			if got, want := tee.String(), tc.stdout; tc.teeStdout && got != want {
+				t.Fatalf("%s: Stdout writer differs. Want %q Got %q", tc.name, want, got)
+			}
 			if tc.returnCodeNonZero && run.ExitCode == 0 {
 				t.Fatalf("%s: Asked for non-zero return code and got 0", tc.name)
 			}
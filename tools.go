@@ -37,6 +37,7 @@ import (
 
 //go:generate go generate ./proxy/testdata
 //go:generate go generate ./services/ansible
+//go:generate go generate ./services/approval
 //go:generate go generate ./services/exec
 //go:generate go generate ./services/healthcheck
 //go:generate go generate ./services/localfile